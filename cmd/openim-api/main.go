@@ -35,6 +35,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/cmd"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
 )
 
 func main() {
@@ -64,6 +65,10 @@ func run(port int) error {
 	if err != nil {
 		return err
 	}
+	mongo, err := unrelation.NewMongo()
+	if err != nil {
+		return err
+	}
 	fmt.Println("api start init discov client")
 	var client discoveryregistry.SvcDiscoveryRegistry
 	client, err = openKeeper.NewClient(config.Config.Zookeeper.ZkAddr, config.Config.Zookeeper.Schema,
@@ -82,7 +87,7 @@ func run(port int) error {
 		return err
 	}
 	fmt.Println("api register public config to discov success")
-	router := api.NewGinRouter(client, rdb)
+	router := api.NewGinRouter(client, rdb, mongo.GetDatabase())
 	fmt.Println("api init router success")
 	var address string
 	if config.Config.Api.ListenIP != "" {
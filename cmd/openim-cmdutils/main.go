@@ -25,15 +25,22 @@ func main() {
 	clearCmd := cmd.NewClearCmd()
 	seqCmd := cmd.NewSeqCmd()
 	msgCmd := cmd.NewMsgCmd()
-	getCmd.AddCommand(seqCmd.GetSeqCmd(), msgCmd.GetMsgCmd())
+	broadcastCmd := cmd.NewBroadcastCmd()
+	cancelCmd := cmd.NewCancelCmd()
+	exportCmd := cmd.NewExportCmd()
+	userCmd := cmd.NewUserCmd()
+	getCmd.AddCommand(seqCmd.GetSeqCmd(), msgCmd.GetMsgCmd(), broadcastCmd.GetBroadcastCmd())
 	getCmd.AddSuperGroupIDFlag()
 	getCmd.AddUserIDFlag()
 	getCmd.AddBeginSeqFlag()
 	getCmd.AddLimitFlag()
+	getCmd.AddJobIDFlag()
 	// openIM get seq --userID=xxx
 	// openIM get seq --superGroupID=xxx
 	// openIM get msg --userID=xxx --beginSeq=100 --limit=10
 	// openIM get msg --superGroupID=xxx --beginSeq=100 --limit=10
+	// openIM get broadcast --jobID=xxx
+	// openIM get broadcast
 
 	fixCmd.AddCommand(seqCmd.FixSeqCmd())
 	fixCmd.AddSuperGroupIDFlag()
@@ -52,7 +59,16 @@ func main() {
 	// openIM clear msg --userID=xxx --beginSeq=100 --limit=10
 	// openIM clear msg --superGroupID=xxx --beginSeq=100 --limit=10
 	// openIM clear msg --clearAll
-	msgUtilsCmd.AddCommand(&getCmd.Command, &fixCmd.Command, &clearCmd.Command)
+
+	cancelCmd.AddCommand(broadcastCmd.CancelBroadcastCmd())
+	cancelCmd.AddJobIDFlag()
+	// openIM cancel broadcast --jobID=xxx
+
+	exportCmd.AddCommand(userCmd.ExportUserCmd())
+	exportCmd.AddUserIDFlag()
+	exportCmd.AddDirFlag()
+	// openIM export user --userID=xxx --dir=xxx
+	msgUtilsCmd.AddCommand(&getCmd.Command, &fixCmd.Command, &clearCmd.Command, &cancelCmd.Command, &exportCmd.Command)
 	if err := msgUtilsCmd.Execute(); err != nil {
 		panic(err)
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/OpenIMSDK/protocol/conversation"
 	"github.com/OpenIMSDK/tools/a2r"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/conversationext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -44,3 +45,15 @@ func (o *ConversationApi) GetConversations(c *gin.Context) {
 func (o *ConversationApi) SetConversations(c *gin.Context) {
 	a2r.Call(conversation.ConversationClient.SetConversations, o.Client, c)
 }
+
+func (o *ConversationApi) GetIncrementalConversations(c *gin.Context) {
+	a2r.Call(conversationext.ConversationExtClient.GetIncrementalConversations, o.ExtClient, c)
+}
+
+func (o *ConversationApi) SetConversationMuteUntil(c *gin.Context) {
+	a2r.Call(conversationext.ConversationExtClient.SetConversationMuteUntil, o.ExtClient, c)
+}
+
+func (o *ConversationApi) GetConversationsMuteUntil(c *gin.Context) {
+	a2r.Call(conversationext.ConversationExtClient.GetConversationsMuteUntil, o.ExtClient, c)
+}
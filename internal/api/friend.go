@@ -18,6 +18,7 @@ import (
 	"github.com/OpenIMSDK/protocol/friend"
 	"github.com/OpenIMSDK/tools/a2r"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 
 	"github.com/gin-gonic/gin"
@@ -88,3 +89,55 @@ func (o *FriendApi) IsFriend(c *gin.Context) {
 func (o *FriendApi) GetFriendIDs(c *gin.Context) {
 	a2r.Call(friend.FriendClient.GetFriendIDs, o.Client, c)
 }
+
+func (o *FriendApi) CreateFriendLabel(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.CreateFriendLabel, o.ExtClient, c)
+}
+
+func (o *FriendApi) UpdateFriendLabel(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.UpdateFriendLabel, o.ExtClient, c)
+}
+
+func (o *FriendApi) DeleteFriendLabel(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.DeleteFriendLabel, o.ExtClient, c)
+}
+
+func (o *FriendApi) SortFriendLabels(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.SortFriendLabels, o.ExtClient, c)
+}
+
+func (o *FriendApi) GetFriendLabels(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.GetFriendLabels, o.ExtClient, c)
+}
+
+func (o *FriendApi) AddFriendLabelMembers(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.AddFriendLabelMembers, o.ExtClient, c)
+}
+
+func (o *FriendApi) RemoveFriendLabelMembers(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.RemoveFriendLabelMembers, o.ExtClient, c)
+}
+
+func (o *FriendApi) SetFriendLabels(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.SetFriendLabels, o.ExtClient, c)
+}
+
+func (o *FriendApi) GetLabelFriends(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.GetLabelFriends, o.ExtClient, c)
+}
+
+func (o *FriendApi) GetFriendSuggestions(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.GetFriendSuggestions, o.ExtClient, c)
+}
+
+func (o *FriendApi) SetAddFriendPolicy(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.SetAddFriendPolicy, o.ExtClient, c)
+}
+
+func (o *FriendApi) GetAddFriendPolicy(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.GetAddFriendPolicy, o.ExtClient, c)
+}
+
+func (o *FriendApi) GetIncrementalFriends(c *gin.Context) {
+	a2r.Call(friendext.FriendExtClient.GetIncrementalFriends, o.ExtClient, c)
+}
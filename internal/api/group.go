@@ -18,6 +18,7 @@ import (
 	"github.com/OpenIMSDK/protocol/group"
 	"github.com/OpenIMSDK/tools/a2r"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 
 	"github.com/gin-gonic/gin"
@@ -144,3 +145,155 @@ func (o *GroupApi) GetGroups(c *gin.Context) {
 func (o *GroupApi) GetGroupMemberUserIDs(c *gin.Context) {
 	a2r.Call(group.GroupClient.GetGroupMemberUserIDs, o.Client, c)
 }
+
+func (o *GroupApi) CreateGroupRole(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.CreateGroupRole, o.ExtClient, c)
+}
+
+func (o *GroupApi) SetGroupRole(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.SetGroupRole, o.ExtClient, c)
+}
+
+func (o *GroupApi) DeleteGroupRole(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.DeleteGroupRole, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupRoles(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupRoles, o.ExtClient, c)
+}
+
+func (o *GroupApi) SetGroupMembersRole(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.SetGroupMembersRole, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupMembersExtInfo(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupMembersExtInfo, o.ExtClient, c)
+}
+
+func (o *GroupApi) CreateGroupInviteLink(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.CreateGroupInviteLink, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupInviteLinks(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupInviteLinks, o.ExtClient, c)
+}
+
+func (o *GroupApi) RevokeGroupInviteLinks(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.RevokeGroupInviteLinks, o.ExtClient, c)
+}
+
+func (o *GroupApi) JoinGroupByInviteLink(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.JoinGroupByInviteLink, o.ExtClient, c)
+}
+
+func (o *GroupApi) SetGroupJoinPolicy(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.SetGroupJoinPolicy, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupJoinPolicy(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupJoinPolicy, o.ExtClient, c)
+}
+
+func (o *GroupApi) JoinGroupWithAnswers(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.JoinGroupWithAnswers, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupJoinAnswers(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupJoinAnswers, o.ExtClient, c)
+}
+
+func (o *GroupApi) SearchGroupMembers(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.SearchGroupMembers, o.ExtClient, c)
+}
+
+func (o *GroupApi) CreateChannel(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.CreateChannel, o.ExtClient, c)
+}
+
+func (o *GroupApi) SetChannelPublic(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.SetChannelPublic, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetChannelsInfo(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetChannelsInfo, o.ExtClient, c)
+}
+
+func (o *GroupApi) SearchChannels(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.SearchChannels, o.ExtClient, c)
+}
+
+func (o *GroupApi) SubscribeChannel(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.SubscribeChannel, o.ExtClient, c)
+}
+
+func (o *GroupApi) UnsubscribeChannel(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.UnsubscribeChannel, o.ExtClient, c)
+}
+
+func (o *GroupApi) OpenChannel(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.OpenChannel, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetSubscribedChannels(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetSubscribedChannels, o.ExtClient, c)
+}
+
+func (o *GroupApi) SetGroupSlowMode(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.SetGroupSlowMode, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupSlowMode(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupSlowMode, o.ExtClient, c)
+}
+
+func (o *GroupApi) BanGroupMembers(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.BanGroupMembers, o.ExtClient, c)
+}
+
+func (o *GroupApi) UnbanGroupMembers(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.UnbanGroupMembers, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupBans(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupBans, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupAuditLogs(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupAuditLogs, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetGroupAnnouncements(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetGroupAnnouncements, o.ExtClient, c)
+}
+
+func (o *GroupApi) ReassignGroupOwner(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.ReassignGroupOwner, o.ExtClient, c)
+}
+
+func (o *GroupApi) CreateCommunity(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.CreateCommunity, o.ExtClient, c)
+}
+
+func (o *GroupApi) AttachCommunityGroups(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.AttachCommunityGroups, o.ExtClient, c)
+}
+
+func (o *GroupApi) DetachCommunityGroups(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.DetachCommunityGroups, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetCommunityGroups(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetCommunityGroups, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetJoinedCommunities(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetJoinedCommunities, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetIncrementalJoinGroups(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetIncrementalJoinGroups, o.ExtClient, c)
+}
+
+func (o *GroupApi) GetIncrementalGroupMembers(c *gin.Context) {
+	a2r.Call(groupext.GroupExtClient.GetIncrementalGroupMembers, o.ExtClient, c)
+}
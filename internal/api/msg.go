@@ -15,6 +15,10 @@
 package api
 
 import (
+	"bufio"
+	"encoding/json"
+	"strings"
+
 	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -34,6 +38,8 @@ import (
 	"github.com/OpenIMSDK/tools/utils"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/apistruct"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -344,6 +350,106 @@ func (m *MessageApi) BatchSendMsg(c *gin.Context) {
 	apiresp.GinSuccess(c, resp)
 }
 
+func (m *MessageApi) CreateBroadcast(c *gin.Context) {
+	var req apistruct.CreateBroadcastReq
+	if err := c.BindJSON(&req); err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	log.ZInfo(c, "CreateBroadcast", "req", req)
+	m.createBroadcast(c, &req)
+}
+
+// CreateBroadcastByFile the multipart form carries the request json in "req"
+// and the target user ids in "file", one user id per line.
+func (m *MessageApi) CreateBroadcastByFile(c *gin.Context) {
+	var req apistruct.CreateBroadcastReq
+	if err := json.Unmarshal([]byte(c.PostForm("req")), &req); err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	defer file.Close()
+	var userIDs []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if userID := strings.TrimSpace(scanner.Text()); userID != "" {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	req.Segment = &msgext.BroadcastSegment{Type: unrelation.BroadcastSegmentUserIDs, UserIDs: userIDs}
+	log.ZInfo(c, "CreateBroadcastByFile", "userIDs", len(userIDs))
+	m.createBroadcast(c, &req)
+}
+
+func (m *MessageApi) createBroadcast(c *gin.Context, req *apistruct.CreateBroadcastReq) {
+	if err := authverify.CheckAdmin(c); err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	sendMsgReq, err := m.getSendMsgReq(c, req.SendMsg)
+	if err != nil {
+		log.ZError(c, "decodeData failed", err)
+		apiresp.GinError(c, err)
+		return
+	}
+	resp, err := m.ExtClient.CreateBroadcast(c, &msgext.CreateBroadcastReq{MsgData: sendMsgReq.MsgData, Segment: req.Segment, Rate: req.Rate})
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	apiresp.GinSuccess(c, resp)
+}
+
+func (m *MessageApi) GetBroadcast(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetBroadcast, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetBroadcasts(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetBroadcasts, m.ExtClient, c)
+}
+
+func (m *MessageApi) CancelBroadcast(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.CancelBroadcast, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetMentions(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetMentions, m.ExtClient, c)
+}
+
+func (m *MessageApi) PinMsg(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.PinMsg, m.ExtClient, c)
+}
+
+func (m *MessageApi) UnpinMsg(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.UnpinMsg, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetPinnedMsgs(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetPinnedMsgs, m.ExtClient, c)
+}
+
+func (m *MessageApi) GetQuotas(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.GetQuotas, m.ExtClient, c)
+}
+
+func (m *MessageApi) SetQuota(c *gin.Context) {
+	a2r.Call(msgext.MsgExtClient.SetQuota, m.ExtClient, c)
+}
+
 func (m *MessageApi) CheckMsgIsSendSuccess(c *gin.Context) {
 	a2r.Call(msg.MsgClient.GetSendMsgStatus, m.Client, c)
 }
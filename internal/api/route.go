@@ -26,11 +26,13 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -43,7 +45,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
-func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.UniversalClient) *gin.Engine {
+func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.UniversalClient, database *mongo.Database) *gin.Engine {
 	discov.AddOption(mw.GrpcClient(), grpc.WithTransportCredentials(insecure.NewCredentials())) // 默认RPC中间件
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
@@ -70,7 +72,7 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		r.Use(prome.PrometheusMiddleware)
 		r.GET("/metrics", prome.PrometheusHandler())
 	}
-	ParseToken := GinParseToken(rdb)
+	ParseToken := GinParseToken(rdb, database)
 	userRouterGroup := r.Group("/user")
 	{
 		userRouterGroup.POST("/user_register", u.UserRegister)
@@ -85,6 +87,23 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		userRouterGroup.POST("/subscribe_users_status", ParseToken, u.UnSubscriberStatus)
 		userRouterGroup.POST("/unsubscribe_users_status", ParseToken, u.UnSubscriberStatus)
 		userRouterGroup.POST("/get_users_status", ParseToken, u.GetUserStatus)
+		userRouterGroup.POST("/deactivate", ParseToken, u.DeactivateUser)
+		userRouterGroup.POST("/reactivate", ParseToken, u.ReactivateUser)
+		userRouterGroup.POST("/get_users_account_status", ParseToken, u.GetUsersAccountStatus)
+		userRouterGroup.POST("/erase", ParseToken, u.EraseUser)
+		userRouterGroup.POST("/get_erase_job", ParseToken, u.GetUserEraseJob)
+		userRouterGroup.POST("/get_erase_jobs", ParseToken, u.GetUserEraseJobs)
+		userRouterGroup.POST("/export", ParseToken, u.ExportUser)
+		userRouterGroup.POST("/get_export_job", ParseToken, u.GetUserExportJob)
+		userRouterGroup.POST("/get_export_jobs", ParseToken, u.GetUserExportJobs)
+		userRouterGroup.POST("/search", ParseToken, u.SearchUsers)
+		userRouterGroup.POST("/set_search_hidden", ParseToken, u.SetUserSearchHidden)
+		userRouterGroup.POST("/get_search_hidden", ParseToken, u.GetUserSearchHidden)
+		userRouterGroup.POST("/suspend", ParseToken, u.SuspendUser)
+		userRouterGroup.POST("/lift_suspension", ParseToken, u.LiftUserSuspension)
+		userRouterGroup.POST("/get_suspensions", ParseToken, u.GetUserSuspensions)
+		userRouterGroup.POST("/set_do_not_disturb", ParseToken, u.SetDoNotDisturbSchedule)
+		userRouterGroup.POST("/get_do_not_disturb", ParseToken, u.GetDoNotDisturbSchedule)
 	}
 	// friend routing group
 	friendRouterGroup := r.Group("/friend", ParseToken)
@@ -105,6 +124,19 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		friendRouterGroup.POST("/import_friend", f.ImportFriends)
 		friendRouterGroup.POST("/is_friend", f.IsFriend)
 		friendRouterGroup.POST("/get_friend_id", f.GetFriendIDs)
+		friendRouterGroup.POST("/label/create", f.CreateFriendLabel)
+		friendRouterGroup.POST("/label/update", f.UpdateFriendLabel)
+		friendRouterGroup.POST("/label/delete", f.DeleteFriendLabel)
+		friendRouterGroup.POST("/label/sort", f.SortFriendLabels)
+		friendRouterGroup.POST("/label/list", f.GetFriendLabels)
+		friendRouterGroup.POST("/label/add_friends", f.AddFriendLabelMembers)
+		friendRouterGroup.POST("/label/remove_friends", f.RemoveFriendLabelMembers)
+		friendRouterGroup.POST("/label/set_friend_labels", f.SetFriendLabels)
+		friendRouterGroup.POST("/label/get_friends", f.GetLabelFriends)
+		friendRouterGroup.POST("/get_suggestions", f.GetFriendSuggestions)
+		friendRouterGroup.POST("/set_add_friend_policy", f.SetAddFriendPolicy)
+		friendRouterGroup.POST("/get_add_friend_policy", f.GetAddFriendPolicy)
+		friendRouterGroup.POST("/get_incremental_friends", f.GetIncrementalFriends)
 	}
 	g := NewGroupApi(*groupRpc)
 	groupRouterGroup := r.Group("/group", ParseToken)
@@ -133,12 +165,56 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		groupRouterGroup.POST("/get_group_abstract_info", g.GetGroupAbstractInfo)
 		groupRouterGroup.POST("/get_groups", g.GetGroups)
 		groupRouterGroup.POST("/get_group_member_user_id", g.GetGroupMemberUserIDs)
+		groupRouterGroup.POST("/create_group_role", g.CreateGroupRole)
+		groupRouterGroup.POST("/set_group_role", g.SetGroupRole)
+		groupRouterGroup.POST("/delete_group_role", g.DeleteGroupRole)
+		groupRouterGroup.POST("/get_group_roles", g.GetGroupRoles)
+		groupRouterGroup.POST("/set_group_members_role", g.SetGroupMembersRole)
+		groupRouterGroup.POST("/get_group_members_ext_info", g.GetGroupMembersExtInfo)
+		groupRouterGroup.POST("/create_invite_link", g.CreateGroupInviteLink)
+		groupRouterGroup.POST("/get_invite_links", g.GetGroupInviteLinks)
+		groupRouterGroup.POST("/revoke_invite_links", g.RevokeGroupInviteLinks)
+		groupRouterGroup.POST("/join_by_invite", g.JoinGroupByInviteLink)
+		groupRouterGroup.POST("/set_join_policy", g.SetGroupJoinPolicy)
+		groupRouterGroup.POST("/get_join_policy", g.GetGroupJoinPolicy)
+		groupRouterGroup.POST("/join_group_with_answers", g.JoinGroupWithAnswers)
+		groupRouterGroup.POST("/get_join_answers", g.GetGroupJoinAnswers)
+		groupRouterGroup.POST("/search_group_members", g.SearchGroupMembers)
+		groupRouterGroup.POST("/set_slow_mode", g.SetGroupSlowMode)
+		groupRouterGroup.POST("/get_slow_mode", g.GetGroupSlowMode)
+		groupRouterGroup.POST("/ban_group_members", g.BanGroupMembers)
+		groupRouterGroup.POST("/unban_group_members", g.UnbanGroupMembers)
+		groupRouterGroup.POST("/get_group_bans", g.GetGroupBans)
+		groupRouterGroup.POST("/get_group_audit_logs", g.GetGroupAuditLogs)
+		groupRouterGroup.POST("/get_group_announcements", g.GetGroupAnnouncements)
+		groupRouterGroup.POST("/reassign_group_owner", g.ReassignGroupOwner)
+		groupRouterGroup.POST("/get_incremental_join_groups", g.GetIncrementalJoinGroups)
+		groupRouterGroup.POST("/get_incremental_group_members", g.GetIncrementalGroupMembers)
 	}
 	superGroupRouterGroup := r.Group("/super_group", ParseToken)
 	{
 		superGroupRouterGroup.POST("/get_joined_group_list", g.GetJoinedSuperGroupList)
 		superGroupRouterGroup.POST("/get_groups_info", g.GetSuperGroupsInfo)
 	}
+	channelRouterGroup := r.Group("/channel", ParseToken)
+	{
+		channelRouterGroup.POST("/create_channel", g.CreateChannel)
+		channelRouterGroup.POST("/set_channel_public", g.SetChannelPublic)
+		channelRouterGroup.POST("/get_channels_info", g.GetChannelsInfo)
+		channelRouterGroup.POST("/search_channels", g.SearchChannels)
+		channelRouterGroup.POST("/subscribe", g.SubscribeChannel)
+		channelRouterGroup.POST("/unsubscribe", g.UnsubscribeChannel)
+		channelRouterGroup.POST("/open_channel", g.OpenChannel)
+		channelRouterGroup.POST("/get_subscribed_channels", g.GetSubscribedChannels)
+	}
+	communityRouterGroup := r.Group("/community", ParseToken)
+	{
+		communityRouterGroup.POST("/create_community", g.CreateCommunity)
+		communityRouterGroup.POST("/attach_groups", g.AttachCommunityGroups)
+		communityRouterGroup.POST("/detach_groups", g.DetachCommunityGroups)
+		communityRouterGroup.POST("/get_community_groups", g.GetCommunityGroups)
+		communityRouterGroup.POST("/get_joined_communities", g.GetJoinedCommunities)
+	}
 	// certificate
 	authRouterGroup := r.Group("/auth")
 	{
@@ -153,6 +229,13 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		t := NewThirdApi(*thirdRpc)
 		thirdGroup.POST("/fcm_update_token", t.FcmUpdateToken)
 		thirdGroup.POST("/set_app_badge", t.SetAppBadge)
+		thirdGroup.POST("/e2ee/publish_key_bundle", t.PublishKeyBundle)
+		thirdGroup.POST("/e2ee/upload_one_time_pre_keys", t.UploadOneTimePreKeys)
+		thirdGroup.POST("/e2ee/get_one_time_pre_key_count", t.GetOneTimePreKeyCount)
+		thirdGroup.POST("/e2ee/fetch_key_bundles", t.FetchKeyBundles)
+		thirdGroup.POST("/e2ee/delete_key_bundle", t.DeleteKeyBundle)
+		thirdGroup.POST("/e2ee/set_sender_key_distribution", t.SetSenderKeyDistribution)
+		thirdGroup.POST("/e2ee/get_sender_key_distributions", t.GetSenderKeyDistributions)
 
 		objectGroup := r.Group("/object", ParseToken)
 
@@ -175,6 +258,10 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		msgGroup.POST("/revoke_msg", m.RevokeMsg)
 		msgGroup.POST("/mark_msgs_as_read", m.MarkMsgsAsRead)
 		msgGroup.POST("/mark_conversation_as_read", m.MarkConversationAsRead)
+		msgGroup.POST("/get_mentions", m.GetMentions)
+		msgGroup.POST("/pin_msg", m.PinMsg)
+		msgGroup.POST("/unpin_msg", m.UnpinMsg)
+		msgGroup.POST("/get_pinned_msgs", m.GetPinnedMsgs)
 		msgGroup.POST("/get_conversations_has_read_and_max_seq", m.GetConversationsHasReadAndMaxSeq)
 		msgGroup.POST("/set_conversation_has_read_seq", m.SetConversationHasReadSeq)
 
@@ -186,6 +273,14 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 
 		msgGroup.POST("/batch_send_msg", m.BatchSendMsg)
 		msgGroup.POST("/check_msg_is_send_success", m.CheckMsgIsSendSuccess)
+
+		msgGroup.POST("/broadcast/create", m.CreateBroadcast)
+		msgGroup.POST("/broadcast/create_by_file", m.CreateBroadcastByFile)
+		msgGroup.POST("/broadcast/get", m.GetBroadcast)
+		msgGroup.POST("/broadcast/list", m.GetBroadcasts)
+		msgGroup.POST("/broadcast/cancel", m.CancelBroadcast)
+		msgGroup.POST("/quota/get", m.GetQuotas)
+		msgGroup.POST("/quota/set", m.SetQuota)
 	}
 	// Conversation
 	conversationGroup := r.Group("/conversation", ParseToken)
@@ -195,6 +290,9 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 		conversationGroup.POST("/get_conversation", c.GetConversation)
 		conversationGroup.POST("/get_conversations", c.GetConversations)
 		conversationGroup.POST("/set_conversations", c.SetConversations)
+		conversationGroup.POST("/get_incremental_conversations", c.GetIncrementalConversations)
+		conversationGroup.POST("/set_conversation_mute_until", c.SetConversationMuteUntil)
+		conversationGroup.POST("/get_conversations_mute_until", c.GetConversationsMuteUntil)
 	}
 
 	statisticsGroup := r.Group("/statistics", ParseToken)
@@ -207,12 +305,13 @@ func NewGinRouter(discov discoveryregistry.SvcDiscoveryRegistry, rdb redis.Unive
 	return r
 }
 
-func GinParseToken(rdb redis.UniversalClient) gin.HandlerFunc {
+func GinParseToken(rdb redis.UniversalClient, database *mongo.Database) gin.HandlerFunc {
 	dataBase := controller.NewAuthDatabase(
 		cache.NewMsgCacheModel(rdb),
 		config.Config.Secret,
 		config.Config.TokenPolicy.Expire,
 	)
+	suspension := controller.InitUserSuspensionChecker(rdb, database)
 	return func(c *gin.Context) {
 		switch c.Request.Method {
 		case http.MethodPost:
@@ -262,6 +361,12 @@ func GinParseToken(rdb redis.UniversalClient) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
+			if err := suspension.CheckUserSuspension(c, claims.UserID, unRelationTb.UserSuspensionScopeLogin); err != nil {
+				log.ZWarn(c, "user login suspended", err, "userID", claims.UserID)
+				apiresp.GinError(c, err)
+				c.Abort()
+				return
+			}
 			c.Set(constant.OpUserPlatform, constant.PlatformIDToName(claims.PlatformID))
 			c.Set(constant.OpUserID, claims.UserID)
 			c.Next()
@@ -26,6 +26,7 @@ import (
 	"github.com/OpenIMSDK/tools/errs"
 	"github.com/OpenIMSDK/tools/mcontext"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/thirdext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -105,3 +106,31 @@ func (o *ThirdApi) ObjectRedirect(c *gin.Context) {
 	}
 	c.Redirect(http.StatusFound, resp.Url)
 }
+
+func (o *ThirdApi) PublishKeyBundle(c *gin.Context) {
+	a2r.Call(thirdext.ThirdExtClient.PublishKeyBundle, o.ExtClient, c)
+}
+
+func (o *ThirdApi) UploadOneTimePreKeys(c *gin.Context) {
+	a2r.Call(thirdext.ThirdExtClient.UploadOneTimePreKeys, o.ExtClient, c)
+}
+
+func (o *ThirdApi) GetOneTimePreKeyCount(c *gin.Context) {
+	a2r.Call(thirdext.ThirdExtClient.GetOneTimePreKeyCount, o.ExtClient, c)
+}
+
+func (o *ThirdApi) FetchKeyBundles(c *gin.Context) {
+	a2r.Call(thirdext.ThirdExtClient.FetchKeyBundles, o.ExtClient, c)
+}
+
+func (o *ThirdApi) DeleteKeyBundle(c *gin.Context) {
+	a2r.Call(thirdext.ThirdExtClient.DeleteKeyBundle, o.ExtClient, c)
+}
+
+func (o *ThirdApi) SetSenderKeyDistribution(c *gin.Context) {
+	a2r.Call(thirdext.ThirdExtClient.SetSenderKeyDistribution, o.ExtClient, c)
+}
+
+func (o *ThirdApi) GetSenderKeyDistributions(c *gin.Context) {
+	a2r.Call(thirdext.ThirdExtClient.GetSenderKeyDistributions, o.ExtClient, c)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/OpenIMSDK/tools/log"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -199,3 +200,71 @@ func (u *UserApi) UnSubscriberStatus(c *gin.Context) {
 func (u *UserApi) GetUserStatus(c *gin.Context) {
 	a2r.Call(user.UserClient.GetUserStatus, u.Client, c)
 }
+
+func (u *UserApi) DeactivateUser(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.DeactivateUser, u.ExtClient, c)
+}
+
+func (u *UserApi) ReactivateUser(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.ReactivateUser, u.ExtClient, c)
+}
+
+func (u *UserApi) GetUsersAccountStatus(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.GetUsersAccountStatus, u.ExtClient, c)
+}
+
+func (u *UserApi) EraseUser(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.EraseUser, u.ExtClient, c)
+}
+
+func (u *UserApi) GetUserEraseJob(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.GetUserEraseJob, u.ExtClient, c)
+}
+
+func (u *UserApi) GetUserEraseJobs(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.GetUserEraseJobs, u.ExtClient, c)
+}
+
+func (u *UserApi) ExportUser(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.ExportUser, u.ExtClient, c)
+}
+
+func (u *UserApi) GetUserExportJob(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.GetUserExportJob, u.ExtClient, c)
+}
+
+func (u *UserApi) GetUserExportJobs(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.GetUserExportJobs, u.ExtClient, c)
+}
+
+func (u *UserApi) SearchUsers(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.SearchUsers, u.ExtClient, c)
+}
+
+func (u *UserApi) SetUserSearchHidden(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.SetUserSearchHidden, u.ExtClient, c)
+}
+
+func (u *UserApi) GetUserSearchHidden(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.GetUserSearchHidden, u.ExtClient, c)
+}
+
+func (u *UserApi) SuspendUser(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.SuspendUser, u.ExtClient, c)
+}
+
+func (u *UserApi) LiftUserSuspension(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.LiftUserSuspension, u.ExtClient, c)
+}
+
+func (u *UserApi) GetUserSuspensions(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.GetUserSuspensions, u.ExtClient, c)
+}
+
+func (u *UserApi) SetDoNotDisturbSchedule(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.SetDoNotDisturbSchedule, u.ExtClient, c)
+}
+
+func (u *UserApi) GetDoNotDisturbSchedule(c *gin.Context) {
+	a2r.Call(userext.UserExtClient.GetDoNotDisturbSchedule, u.ExtClient, c)
+}
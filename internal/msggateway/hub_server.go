@@ -23,6 +23,8 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
 
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/msggateway"
@@ -40,9 +42,14 @@ func (s *Server) InitServer(client discoveryregistry.SvcDiscoveryRegistry, serve
 	if err != nil {
 		return err
 	}
+	mongo, err := unrelation.NewMongo()
+	if err != nil {
+		return err
+	}
 	msgModel := cache.NewMsgCacheModel(rdb)
 	s.LongConnServer.SetDiscoveryRegistry(client)
 	s.LongConnServer.SetCacheHandler(msgModel)
+	s.LongConnServer.SetUserSuspensionChecker(controller.InitUserSuspensionChecker(rdb, mongo.GetDatabase()))
 	msggateway.RegisterMsgGatewayServer(server, s)
 	return nil
 }
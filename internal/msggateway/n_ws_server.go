@@ -30,6 +30,8 @@ import (
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
 
 	"github.com/redis/go-redis/v9"
 
@@ -49,6 +51,7 @@ type LongConnServer interface {
 	GetUserPlatformCons(userID string, platform int) ([]*Client, bool, bool)
 	Validate(s interface{}) error
 	SetCacheHandler(cache cache.MsgModel)
+	SetUserSuspensionChecker(suspension controller.UserSuspensionChecker)
 	SetDiscoveryRegistry(client discoveryregistry.SvcDiscoveryRegistry)
 	KickUserConn(client *Client) error
 	UnRegister(c *Client)
@@ -77,6 +80,7 @@ type WsServer struct {
 	hubServer         *Server
 	validate          *validator.Validate
 	cache             cache.MsgModel
+	suspension        controller.UserSuspensionChecker
 	userClient        *rpcclient.UserRpcClient
 	Compressor
 	Encoder
@@ -117,6 +121,10 @@ func (ws *WsServer) SetCacheHandler(cache cache.MsgModel) {
 	ws.cache = cache
 }
 
+func (ws *WsServer) SetUserSuspensionChecker(suspension controller.UserSuspensionChecker) {
+	ws.suspension = suspension
+}
+
 func (ws *WsServer) UnRegister(c *Client) {
 	ws.unregisterChan <- c
 }
@@ -381,6 +389,11 @@ func (ws *WsServer) wsHandler(w http.ResponseWriter, r *http.Request) {
 		httpError(connContext, errs.ErrTokenNotExist.Wrap())
 		return
 	}
+	err = ws.suspension.CheckUserSuspension(context.Background(), userID, unRelationTb.UserSuspensionScopeLogin)
+	if err != nil {
+		httpError(connContext, err)
+		return
+	}
 	wsLongConn := newGWebSocket(WebSocket, ws.handshakeTimeout)
 	err = wsLongConn.GenerateLongConn(w, r)
 	if err != nil {
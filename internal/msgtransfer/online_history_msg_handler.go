@@ -79,17 +79,20 @@ type OnlineHistoryRedisConsumerHandler struct {
 	singleMsgFailedCountMutex  sync.Mutex
 
 	msgDatabase           controller.CommonMsgDatabase
+	mentionDatabase       controller.MentionDatabase
 	conversationRpcClient *rpcclient.ConversationRpcClient
 	groupRpcClient        *rpcclient.GroupRpcClient
 }
 
 func NewOnlineHistoryRedisConsumerHandler(
 	database controller.CommonMsgDatabase,
+	mentionDatabase controller.MentionDatabase,
 	conversationRpcClient *rpcclient.ConversationRpcClient,
 	groupRpcClient *rpcclient.GroupRpcClient,
 ) *OnlineHistoryRedisConsumerHandler {
 	var och OnlineHistoryRedisConsumerHandler
 	och.msgDatabase = database
+	och.mentionDatabase = mentionDatabase
 	och.msgDistributionCh = make(chan Cmd2Value) // no buffer channel
 	go och.MessagesDistributionHandle()
 	for i := 0; i < ChannelNum; i++ {
@@ -285,6 +288,7 @@ func (och *OnlineHistoryRedisConsumerHandler) handleMsg(
 		if isNewConversation {
 			if storageList[0].SessionType == constant.SuperGroupChatType {
 				log.ZInfo(ctx, "group chat first create conversation", "conversationID", conversationID)
+				// 频道订阅者不是群成员, 只为成员创建会话, 订阅者打开频道时再创建
 				userIDs, err := och.groupRpcClient.GetGroupMemberIDs(ctx, storageList[0].GroupID)
 				if err != nil {
 					log.ZWarn(ctx, "get group member ids error", err, "conversationID", conversationID)
@@ -300,6 +304,12 @@ func (och *OnlineHistoryRedisConsumerHandler) handleMsg(
 			}
 		}
 
+		if err := och.msgDatabase.ScheduleMsgsDestruct(ctx, conversationID, storageList); err != nil {
+			log.ZError(ctx, "schedule msgs destruct error", err, "conversationID", conversationID)
+		}
+		if err := och.mentionDatabase.AddMsgsMentions(ctx, conversationID, storageList); err != nil {
+			log.ZError(ctx, "add msgs mentions error", err, "conversationID", conversationID)
+		}
 		log.ZDebug(ctx, "success incr to next topic")
 		och.singleMsgSuccessCountMutex.Lock()
 		och.singleMsgSuccessCount += uint64(len(storageList))
@@ -46,6 +46,7 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	groupRpcClient := rpcclient.NewGroupRpcClient(client)
 	conversationRpcClient := rpcclient.NewConversationRpcClient(client)
 	msgRpcClient := rpcclient.NewMessageRpcClient(client)
+	userRpcClient := rpcclient.NewUserRpcClient(client)
 	pusher := NewPusher(
 		client,
 		offlinePusher,
@@ -55,6 +56,7 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 		&conversationRpcClient,
 		&groupRpcClient,
 		&msgRpcClient,
+		&userRpcClient,
 	)
 	var wg sync.WaitGroup
 	wg.Add(2)
@@ -37,10 +37,14 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/localcache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
+// channelPushBatchSize 频道消息每批推送的订阅者数量.
+const channelPushBatchSize = 1000
+
 type Pusher struct {
 	database               controller.PushDatabase
 	discov                 discoveryregistry.SvcDiscoveryRegistry
@@ -50,6 +54,7 @@ type Pusher struct {
 	msgRpcClient           *rpcclient.MessageRpcClient
 	conversationRpcClient  *rpcclient.ConversationRpcClient
 	groupRpcClient         *rpcclient.GroupRpcClient
+	userRpcClient          *rpcclient.UserRpcClient
 	successCount           int
 }
 
@@ -58,6 +63,7 @@ var errNoOfflinePusher = errors.New("no offlinePusher is configured")
 func NewPusher(discov discoveryregistry.SvcDiscoveryRegistry, offlinePusher offlinepush.OfflinePusher, database controller.PushDatabase,
 	groupLocalCache *localcache.GroupLocalCache, conversationLocalCache *localcache.ConversationLocalCache,
 	conversationRpcClient *rpcclient.ConversationRpcClient, groupRpcClient *rpcclient.GroupRpcClient, msgRpcClient *rpcclient.MessageRpcClient,
+	userRpcClient *rpcclient.UserRpcClient,
 ) *Pusher {
 	return &Pusher{
 		discov:                 discov,
@@ -68,6 +74,7 @@ func NewPusher(discov discoveryregistry.SvcDiscoveryRegistry, offlinePusher offl
 		msgRpcClient:           msgRpcClient,
 		conversationRpcClient:  conversationRpcClient,
 		groupRpcClient:         groupRpcClient,
+		userRpcClient:          userRpcClient,
 	}
 }
 
@@ -107,21 +114,36 @@ func (p *Pusher) Push2User(ctx context.Context, userIDs []string, msg *sdkws.Msg
 	isOfflinePush := utils.GetSwitchFromOptions(msg.Options, constant.IsOfflinePush)
 	log.ZDebug(ctx, "push_result", "ws push result", wsResults, "sendData", msg, "isOfflinePush", isOfflinePush, "push_to_userID", userIDs)
 	p.successCount++
-	for _, userID := range userIDs {
-		if isOfflinePush && userID != msg.SendID {
-			// save invitation info for offline push
-			for _, v := range wsResults {
-				if v.OnlinePush {
-					return nil
-				}
-			}
-			if err := callbackOfflinePush(ctx, userIDs, msg, &[]string{}); err != nil {
-				return err
-			}
-			err = p.offlinePushMsg(ctx, userID, msg, userIDs)
-			if err != nil {
-				return err
-			}
+	if !isOfflinePush {
+		return nil
+	}
+	// save invitation info for offline push
+	for _, v := range wsResults {
+		if v.OnlinePush {
+			return nil
+		}
+	}
+	offlineUserIDs := utils.SliceSub(userIDs, []string{msg.SendID})
+	if len(offlineUserIDs) == 0 {
+		return nil
+	}
+	if msg.ContentType != constant.SignalingNotification {
+		// 会话静音及免打扰时段只影响离线推送, 只对需要离线推送的用户查询
+		mutedUserIDs, err := p.conversationRpcClient.GetMutedUserIDs(ctx, msgprocessor.GetConversationIDByMsg(msg), offlineUserIDs)
+		if err != nil {
+			return err
+		}
+		offlineUserIDs, err = p.removeDoNotDisturbUserIDs(ctx, utils.SliceSub(offlineUserIDs, mutedUserIDs))
+		if err != nil {
+			return err
+		}
+	}
+	for _, userID := range offlineUserIDs {
+		if err := callbackOfflinePush(ctx, offlineUserIDs, msg, &[]string{}); err != nil {
+			return err
+		}
+		if err := p.offlinePushMsg(ctx, userID, msg, offlineUserIDs); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -139,6 +161,15 @@ func (p *Pusher) UnmarshalNotificationElem(bytes []byte, t interface{}) error {
 
 func (p *Pusher) Push2SuperGroup(ctx context.Context, groupID string, msg *sdkws.MsgData) (err error) {
 	log.ZDebug(ctx, "Get super group msg from msg_transfer and push msg", "msg", msg.String(), "groupID", groupID)
+	if msg.ContentType < constant.NotificationBegin || msg.ContentType > constant.NotificationEnd {
+		groupType, err := p.groupLocalCache.GetGroupType(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if groupType == relation.ChannelGroup {
+			return p.Push2Channel(ctx, groupID, msg)
+		}
+	}
 	var pushToUserIDs []string
 	if err := callbackBeforeSuperGroupOnlinePush(ctx, groupID, msg, &pushToUserIDs); err != nil {
 		return err
@@ -226,6 +257,10 @@ func (p *Pusher) Push2SuperGroup(ctx context.Context, groupID string, msg *sdkws
 				return err
 			}
 			needOfflinePushUserIDs = utils.DifferenceString(notNotificationUserIDs, needOfflinePushUserIDs)
+			needOfflinePushUserIDs, err = p.removeDoNotDisturbUserIDs(ctx, needOfflinePushUserIDs)
+			if err != nil {
+				return err
+			}
 		}
 		// Use offline push messaging
 		if len(needOfflinePushUserIDs) > 0 {
@@ -252,6 +287,88 @@ func (p *Pusher) Push2SuperGroup(ctx context.Context, groupID string, msg *sdkws
 	return nil
 }
 
+// Push2Channel 频道消息推送, 先推送给频道成员(管理员), 再按批次推送给订阅者, 避免一次加载全部订阅者.
+func (p *Pusher) Push2Channel(ctx context.Context, groupID string, msg *sdkws.MsgData) error {
+	memberIDs, err := p.groupLocalCache.GetGroupMemberIDs(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if err := p.pushChannelBatch(ctx, groupID, msg, memberIDs); err != nil {
+		return err
+	}
+	var cursor string
+	for {
+		userIDs, err := p.groupRpcClient.GetChannelSubscriberIDs(ctx, groupID, cursor, channelPushBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+		if err := p.pushChannelBatch(ctx, groupID, msg, userIDs); err != nil {
+			log.ZError(ctx, "push channel batch failed", err, "groupID", groupID, "cursor", cursor)
+		}
+		if len(userIDs) < channelPushBatchSize {
+			return nil
+		}
+		cursor = userIDs[len(userIDs)-1]
+	}
+}
+
+// pushChannelBatch 在线推送一批用户, 不在线且未设置免打扰的用户走离线推送.
+func (p *Pusher) pushChannelBatch(ctx context.Context, groupID string, msg *sdkws.MsgData, userIDs []string) error {
+	wsResults, err := p.GetConnsAndOnlinePush(ctx, msg, userIDs)
+	if err != nil {
+		return err
+	}
+	p.successCount++
+	if !utils.GetSwitchFromOptions(msg.Options, constant.IsOfflinePush) {
+		return nil
+	}
+	onlineUserIDs := []string{msg.SendID}
+	for _, v := range wsResults {
+		if v.OnlinePush {
+			onlineUserIDs = append(onlineUserIDs, v.UserID)
+		}
+	}
+	offlineUserIDs := utils.DifferenceString(onlineUserIDs, userIDs)
+	notNotifyUserIDs, err := p.conversationLocalCache.GetRecvMsgNotNotifyUserIDs(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	offlineUserIDs = utils.DifferenceString(notNotifyUserIDs, offlineUserIDs)
+	offlineUserIDs, err = p.removeDoNotDisturbUserIDs(ctx, offlineUserIDs)
+	if err != nil {
+		return err
+	}
+	if len(offlineUserIDs) == 0 {
+		return nil
+	}
+	var offlinePushUserIDs []string
+	if err := callbackOfflinePush(ctx, offlineUserIDs, msg, &offlinePushUserIDs); err != nil {
+		return err
+	}
+	if len(offlinePushUserIDs) > 0 {
+		offlineUserIDs = offlinePushUserIDs
+	}
+	return p.offlinePushMsg(ctx, groupID, msg, offlineUserIDs)
+}
+
+// removeDoNotDisturbUserIDs 去掉当前处于免打扰时段的用户, 时段结束后自动恢复离线推送.
+func (p *Pusher) removeDoNotDisturbUserIDs(ctx context.Context, userIDs []string) ([]string, error) {
+	if len(userIDs) == 0 {
+		return userIDs, nil
+	}
+	dndUserIDs, err := p.userRpcClient.GetDoNotDisturbUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(dndUserIDs) == 0 {
+		return userIDs, nil
+	}
+	return utils.DifferenceString(dndUserIDs, userIDs), nil
+}
+
 func (p *Pusher) GetConnsAndOnlinePush(ctx context.Context, msg *sdkws.MsgData, pushToUserIDs []string) (wsResults []*msggateway.SingleMsgToUserResults, err error) {
 	conns, err := p.discov.GetConns(ctx, config.Config.RpcRegisterName.OpenImMessageGatewayName)
 	log.ZDebug(ctx, "get gateway conn", "conn length", len(conns))
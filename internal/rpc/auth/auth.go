@@ -34,11 +34,15 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
 type authServer struct {
 	authDatabase   controller.AuthDatabase
+	suspension     controller.UserSuspensionChecker
+	userActivity   controller.UserActivityDatabase
 	userRpcClient  *rpcclient.UserRpcClient
 	RegisterCenter discoveryregistry.SvcDiscoveryRegistry
 }
@@ -48,10 +52,19 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	if err != nil {
 		return err
 	}
+	mongo, err := unrelation.NewMongo()
+	if err != nil {
+		return err
+	}
+	if err := mongo.CreateUserActivityIndex(); err != nil {
+		return err
+	}
 	userRpcClient := rpcclient.NewUserRpcClient(client)
 	pbAuth.RegisterAuthServer(server, &authServer{
 		userRpcClient:  &userRpcClient,
 		RegisterCenter: client,
+		suspension:     controller.InitUserSuspensionChecker(rdb, mongo.GetDatabase()),
+		userActivity:   controller.InitUserActivityDatabase(rdb, mongo.GetDatabase()),
 		authDatabase: controller.NewAuthDatabase(
 			cache.NewMsgCacheModel(rdb),
 			config.Config.Secret,
@@ -66,13 +79,19 @@ func (s *authServer) UserToken(ctx context.Context, req *pbAuth.UserTokenReq) (*
 	if req.Secret != config.Config.Secret {
 		return nil, errs.ErrNoPermission.Wrap("secret invalid")
 	}
-	if _, err := s.userRpcClient.GetUserInfo(ctx, req.UserID); err != nil {
+	if err := s.userRpcClient.CheckUserActive(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := s.suspension.CheckUserSuspension(ctx, req.UserID, unRelationTb.UserSuspensionScopeLogin); err != nil {
 		return nil, err
 	}
 	token, err := s.authDatabase.CreateToken(ctx, req.UserID, int(req.PlatformID))
 	if err != nil {
 		return nil, err
 	}
+	if err := s.userActivity.Touch(ctx, req.UserID); err != nil {
+		log.ZWarn(ctx, "touch user activity failed", err, "userID", req.UserID)
+	}
 	resp.Token = token
 	resp.ExpireTimeSeconds = config.Config.TokenPolicy.Expire * 24 * 60 * 60
 	return &resp, nil
@@ -34,6 +34,8 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/relation"
 	tableRelation "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/conversationext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient/notification"
 )
@@ -42,6 +44,7 @@ type conversationServer struct {
 	groupRpcClient                 *rpcclient.GroupRpcClient
 	conversationDatabase           controller.ConversationDatabase
 	conversationNotificationSender *notification.ConversationNotificationSender
+	versionLogDatabase             controller.VersionLogDatabase
 }
 
 func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) error {
@@ -56,14 +59,25 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	if err != nil {
 		return err
 	}
+	mongo, err := unrelation.NewMongo()
+	if err != nil {
+		return err
+	}
+	if err := mongo.CreateVersionLogIndex(); err != nil {
+		return err
+	}
 	conversationDB := relation.NewConversationGorm(db)
+	versionLogDB := unrelation.NewVersionLogMongoDriver(mongo.GetDatabase())
 	groupRpcClient := rpcclient.NewGroupRpcClient(client)
 	msgRpcClient := rpcclient.NewMessageRpcClient(client)
-	pbConversation.RegisterConversationServer(server, &conversationServer{
+	srv := &conversationServer{
 		conversationNotificationSender: notification.NewConversationNotificationSender(&msgRpcClient),
 		groupRpcClient:                 &groupRpcClient,
-		conversationDatabase:           controller.NewConversationDatabase(conversationDB, cache.NewConversationRedis(rdb, cache.GetDefaultOpt(), conversationDB), tx.NewGorm(db)),
-	})
+		conversationDatabase:           controller.NewConversationDatabase(conversationDB, cache.NewConversationRedis(rdb, cache.GetDefaultOpt(), conversationDB), tx.NewGorm(db), versionLogDB),
+		versionLogDatabase:             controller.NewVersionLogDatabase(versionLogDB),
+	}
+	pbConversation.RegisterConversationServer(server, srv)
+	conversationext.RegisterConversationExtServer(server, srv)
 	return nil
 }
 
@@ -188,12 +202,17 @@ func (c *conversationServer) SetConversations(ctx context.Context, req *pbConver
 	return &pbConversation.SetConversationsResp{}, nil
 }
 
-// 获取超级大群开启免打扰的用户ID.
+// 获取超级大群开启免打扰或会话静音未到期的用户ID.
 func (c *conversationServer) GetRecvMsgNotNotifyUserIDs(ctx context.Context, req *pbConversation.GetRecvMsgNotNotifyUserIDsReq) (*pbConversation.GetRecvMsgNotNotifyUserIDsResp, error) {
 	userIDs, err := c.conversationDatabase.FindRecvMsgNotNotifyUserIDs(ctx, req.GroupID)
 	if err != nil {
 		return nil, err
 	}
+	mutedUserIDs, err := c.conversationDatabase.FindMutedUserIDs(ctx, msgprocessor.GetConversationIDBySessionType(constant.SuperGroupChatType, req.GroupID), nil)
+	if err != nil {
+		return nil, err
+	}
+	userIDs = utils.Distinct(append(userIDs, mutedUserIDs...))
 	return &pbConversation.GetRecvMsgNotNotifyUserIDsResp{UserIDs: userIDs}, nil
 }
 
@@ -0,0 +1,67 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversation
+
+import (
+	"context"
+
+	pbConversation "github.com/OpenIMSDK/protocol/conversation"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/conversationext"
+)
+
+// GetIncrementalConversations 获取version之后会话列表的增删改, 用于替代GetUserConversationIDsHash比对后全量拉取.
+func (c *conversationServer) GetIncrementalConversations(ctx context.Context, req *conversationext.GetIncrementalConversationsReq) (*conversationext.GetIncrementalConversationsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	changes, err := c.versionLogDatabase.GetChanges(ctx, unRelationTb.VersionDomainConversation, req.OwnerUserID, req.Version, config.Config.VersionLog.SyncLimit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &conversationext.GetIncrementalConversationsResp{
+		Version:  changes.Version,
+		FullSync: changes.FullSync,
+		More:     changes.More,
+		Insert:   []*pbConversation.Conversation{},
+		Update:   []*pbConversation.Conversation{},
+		Delete:   changes.Delete,
+	}
+	if changes.FullSync {
+		return resp, nil
+	}
+	if len(changes.Insert) > 0 {
+		conversations, err := c.conversationDatabase.FindConversations(ctx, req.OwnerUserID, changes.Insert)
+		if err != nil {
+			return nil, err
+		}
+		resp.Insert = convert.ConversationsDB2Pb(conversations)
+	}
+	if len(changes.Update) > 0 {
+		conversations, err := c.conversationDatabase.FindConversations(ctx, req.OwnerUserID, changes.Update)
+		if err != nil {
+			return nil, err
+		}
+		resp.Update = convert.ConversationsDB2Pb(conversations)
+	}
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversation
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/conversationext"
+)
+
+// SetConversationMuteUntil 设置会话静音截止时间, 到期后自动恢复离线推送, 不需要客户端再次设置.
+func (c *conversationServer) SetConversationMuteUntil(ctx context.Context, req *conversationext.SetConversationMuteUntilReq) (*conversationext.SetConversationMuteUntilResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	if err := c.conversationDatabase.SetConversationMuteUntil(ctx, req.OwnerUserID, req.ConversationID, req.MuteUntil); err != nil {
+		return nil, err
+	}
+	if err := c.conversationNotificationSender.ConversationChangeNotification(ctx, req.OwnerUserID, []string{req.ConversationID}); err != nil {
+		log.ZWarn(ctx, "ConversationChangeNotification failed", err, "ownerUserID", req.OwnerUserID, "conversationID", req.ConversationID)
+	}
+	return &conversationext.SetConversationMuteUntilResp{}, nil
+}
+
+func (c *conversationServer) GetConversationsMuteUntil(ctx context.Context, req *conversationext.GetConversationsMuteUntilReq) (*conversationext.GetConversationsMuteUntilResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	conversations, err := c.conversationDatabase.FindConversations(ctx, req.OwnerUserID, req.ConversationIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	resp := &conversationext.GetConversationsMuteUntilResp{MuteUntil: make(map[string]int64)}
+	for _, conversation := range conversations {
+		if conversation.MuteUntil > now {
+			resp.MuteUntil[conversation.ConversationID] = conversation.MuteUntil
+		}
+	}
+	return resp, nil
+}
+
+func (c *conversationServer) GetMutedUserIDs(ctx context.Context, req *conversationext.GetMutedUserIDsReq) (*conversationext.GetMutedUserIDsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	userIDs, err := c.conversationDatabase.FindMutedUserIDs(ctx, req.ConversationID, req.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &conversationext.GetMutedUserIDsResp{UserIDs: userIDs}, nil
+}
@@ -37,13 +37,22 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/relation"
 	tablerelation "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient/notification"
 )
 
 type friendServer struct {
 	friendDatabase     controller.FriendDatabase
 	blackDatabase      controller.BlackDatabase
+	labelDatabase      controller.FriendLabelDatabase
+	suggestionDatabase controller.FriendSuggestionDatabase
+	addPolicyDatabase  controller.AddFriendPolicyDatabase
+	quotaDatabase      controller.QuotaDatabase
+	versionLogDatabase controller.VersionLogDatabase
 	userRpcClient      *rpcclient.UserRpcClient
+	groupRpcClient     *rpcclient.GroupRpcClient
 	notificationSender *notification.FriendNotificationSender
 	RegisterCenter     registry.SvcDiscoveryRegistry
 }
@@ -53,36 +62,59 @@ func Start(client registry.SvcDiscoveryRegistry, server *grpc.Server) error {
 	if err != nil {
 		return err
 	}
-	if err := db.AutoMigrate(&tablerelation.FriendModel{}, &tablerelation.FriendRequestModel{}, &tablerelation.BlackModel{}); err != nil {
+	if err := db.AutoMigrate(&tablerelation.FriendModel{}, &tablerelation.FriendRequestModel{}, &tablerelation.BlackModel{}, &tablerelation.FriendLabelModel{}, &tablerelation.FriendLabelMemberModel{}, &tablerelation.AddFriendPolicyModel{}); err != nil {
 		return err
 	}
 	rdb, err := cache.NewRedis()
 	if err != nil {
 		return err
 	}
+	mongo, err := unrelation.NewMongo()
+	if err != nil {
+		return err
+	}
+	if err := mongo.CreateVersionLogIndex(); err != nil {
+		return err
+	}
+	quotaDB := unrelation.NewQuotaMongoDriver(mongo.GetDatabase())
+	versionLogDB := unrelation.NewVersionLogMongoDriver(mongo.GetDatabase())
 	blackDB := relation.NewBlackGorm(db)
 	friendDB := relation.NewFriendGorm(db)
 	userRpcClient := rpcclient.NewUserRpcClient(client)
 	msgRpcClient := rpcclient.NewMessageRpcClient(client)
+	groupRpcClient := rpcclient.NewGroupRpcClient(client)
 	notificationSender := notification.NewFriendNotificationSender(
 		&msgRpcClient,
 		notification.WithRpcFunc(userRpcClient.GetUsersInfo),
 	)
-	pbfriend.RegisterFriendServer(server, &friendServer{
+	srv := &friendServer{
 		friendDatabase: controller.NewFriendDatabase(
 			friendDB,
 			relation.NewFriendRequestGorm(db),
 			cache.NewFriendCacheRedis(rdb, friendDB, cache.GetDefaultOpt()),
 			tx.NewGorm(db),
+			versionLogDB,
 		),
 		blackDatabase: controller.NewBlackDatabase(
 			blackDB,
 			cache.NewBlackCacheRedis(rdb, blackDB, cache.GetDefaultOpt()),
 		),
+		labelDatabase: controller.NewFriendLabelDatabase(
+			relation.NewFriendLabelDB(db),
+			relation.NewFriendLabelMemberDB(db),
+			tx.NewGorm(db),
+		),
+		suggestionDatabase: controller.NewFriendSuggestionDatabase(cache.NewFriendSuggestionCacheRedis(rdb)),
+		addPolicyDatabase:  controller.NewAddFriendPolicyDatabase(relation.NewAddFriendPolicyDB(db)),
+		quotaDatabase:      controller.NewQuotaDatabase(quotaDB, cache.NewQuotaCacheRedis(rdb, quotaDB, cache.GetDefaultOpt())),
+		versionLogDatabase: controller.NewVersionLogDatabase(versionLogDB),
 		userRpcClient:      &userRpcClient,
+		groupRpcClient:     &groupRpcClient,
 		notificationSender: notificationSender,
 		RegisterCenter:     client,
-	})
+	}
+	pbfriend.RegisterFriendServer(server, srv)
+	friendext.RegisterFriendExtServer(server, srv)
 	return nil
 }
 
@@ -112,7 +144,26 @@ func (s *friendServer) ApplyToAddFriend(
 	if in1 && in2 {
 		return nil, errs.ErrRelationshipAlready.Wrap()
 	}
+	// 管理员不受加好友权限, 拒绝冷却和申请配额限制
+	var quotaKey string
+	if !authverify.IsAppManagerUid(ctx) {
+		if !in2 {
+			if err := s.checkAddFriendPolicy(ctx, req.FromUserID, req.ToUserID); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkRefuseCooldown(ctx, req.FromUserID, req.ToUserID); err != nil {
+			return nil, err
+		}
+		if quotaKey, err = s.quotaDatabase.ConsumeQuota(ctx, unRelationTb.QuotaKindUserFriendRequest, req.FromUserID, 1); err != nil {
+			return nil, err
+		}
+	}
 	if err = s.friendDatabase.AddFriendRequest(ctx, req.FromUserID, req.ToUserID, req.ReqMsg, req.Ex); err != nil {
+		// 申请未写入时退还配额
+		if err := s.quotaDatabase.RefundQuota(ctx, quotaKey, 1); err != nil {
+			log.ZWarn(ctx, "refund friend request quota failed", err, "key", quotaKey)
+		}
 		return nil, err
 	}
 	s.notificationSender.FriendApplicationAddNotification(ctx, req)
@@ -125,6 +176,7 @@ func (s *friendServer) ImportFriends(
 	req *pbfriend.ImportFriendReq,
 ) (resp *pbfriend.ImportFriendResp, err error) {
 	defer log.ZInfo(ctx, utils.GetFuncName()+" Return")
+	// 只有管理员可以导入, 不受加好友权限限制
 	if err := authverify.CheckAdmin(ctx); err != nil {
 		return nil, err
 	}
@@ -155,6 +207,9 @@ func (s *friendServer) RespondFriendApply(
 	if err := authverify.CheckAccessV3(ctx, req.ToUserID); err != nil {
 		return nil, err
 	}
+	if err := s.checkFriendRequestExpired(ctx, req.FromUserID, req.ToUserID); err != nil {
+		return nil, err
+	}
 
 	friendRequest := tablerelation.FriendRequestModel{
 		FromUserID:   req.FromUserID,
@@ -198,6 +253,13 @@ func (s *friendServer) DeleteFriend(
 	if err := s.friendDatabase.Delete(ctx, req.OwnerUserID, []string{req.FriendUserID}); err != nil {
 		return nil, err
 	}
+	if err := s.labelDatabase.RemoveFriends(ctx, req.OwnerUserID, []string{req.FriendUserID}); err != nil {
+		log.ZError(ctx, "remove friend from labels failed", err, "ownerUserID", req.OwnerUserID, "friendUserID", req.FriendUserID)
+	}
+	// 删除后可以重新推荐
+	if err := s.suggestionDatabase.DelFriendSuggestions(ctx, req.OwnerUserID, req.FriendUserID); err != nil {
+		log.ZError(ctx, "delete friend suggestions failed", err, "ownerUserID", req.OwnerUserID, "friendUserID", req.FriendUserID)
+	}
 	s.notificationSender.FriendDeletedNotification(ctx, req)
 	return resp, nil
 }
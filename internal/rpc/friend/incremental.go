@@ -0,0 +1,70 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friend
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/protocol/sdkws"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
+)
+
+// GetIncrementalFriends 获取version之后好友列表的增删改, 用于客户端重连后的增量同步.
+func (s *friendServer) GetIncrementalFriends(ctx context.Context, req *friendext.GetIncrementalFriendsReq) (*friendext.GetIncrementalFriendsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	changes, err := s.versionLogDatabase.GetChanges(ctx, unRelationTb.VersionDomainFriend, req.UserID, req.Version, config.Config.VersionLog.SyncLimit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &friendext.GetIncrementalFriendsResp{
+		Version:  changes.Version,
+		FullSync: changes.FullSync,
+		More:     changes.More,
+		Insert:   []*sdkws.FriendInfo{},
+		Update:   []*sdkws.FriendInfo{},
+		Delete:   changes.Delete,
+	}
+	if changes.FullSync {
+		return resp, nil
+	}
+	if resp.Insert, err = s.findFriendsInfo(ctx, req.UserID, changes.Insert); err != nil {
+		return nil, err
+	}
+	if resp.Update, err = s.findFriendsInfo(ctx, req.UserID, changes.Update); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// findFriendsInfo 变更之后又被删除的好友会在之后的版本中返回删除, 这里直接忽略.
+func (s *friendServer) findFriendsInfo(ctx context.Context, ownerUserID string, friendUserIDs []string) ([]*sdkws.FriendInfo, error) {
+	if len(friendUserIDs) == 0 {
+		return []*sdkws.FriendInfo{}, nil
+	}
+	friends, err := s.friendDatabase.FindFriends(ctx, ownerUserID, friendUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	return convert.FriendsDB2Pb(ctx, friends, s.userRpcClient.GetUsersInfoMap)
+}
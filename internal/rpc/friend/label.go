@@ -0,0 +1,307 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friend
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
+)
+
+func (s *friendServer) labelMembers(ownerUserID string, labelID string, friendUserIDs []string) []*relation.FriendLabelMemberModel {
+	now := time.Now()
+	return utils.Slice(friendUserIDs, func(friendUserID string) *relation.FriendLabelMemberModel {
+		return &relation.FriendLabelMemberModel{
+			OwnerUserID:  ownerUserID,
+			LabelID:      labelID,
+			FriendUserID: friendUserID,
+			CreateTime:   now,
+		}
+	})
+}
+
+// checkLabelName 同一用户的分组名称不能重复.
+func (s *friendServer) checkLabelName(labels []*relation.FriendLabelModel, labelID string, name string) error {
+	for _, label := range labels {
+		if label.LabelID != labelID && label.Name == name {
+			return errs.ErrDuplicateKey.Wrap("label name already exists")
+		}
+	}
+	return nil
+}
+
+func (s *friendServer) CreateFriendLabel(ctx context.Context, req *friendext.CreateFriendLabelReq) (*friendext.CreateFriendLabelResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	labels, err := s.labelDatabase.FindLabels(ctx, req.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) >= friendext.MaxFriendLabels {
+		return nil, errs.ErrArgs.Wrap("too many friend labels")
+	}
+	if err := s.checkLabelName(labels, "", req.Name); err != nil {
+		return nil, err
+	}
+	if len(req.FriendUserIDs) > 0 {
+		if _, err := s.friendDatabase.FindFriendsWithError(ctx, req.OwnerUserID, req.FriendUserIDs); err != nil {
+			return nil, err
+		}
+	}
+	label := &relation.FriendLabelModel{
+		OwnerUserID: req.OwnerUserID,
+		LabelID:     utils.OperationIDGenerator(),
+		Name:        req.Name,
+		Sort:        int32(len(labels)),
+		CreateTime:  time.Now(),
+	}
+	if err := s.labelDatabase.CreateLabel(ctx, label, s.labelMembers(req.OwnerUserID, label.LabelID, req.FriendUserIDs)); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendLabelsUpdatedNotification(ctx, req.OwnerUserID, []string{label.LabelID})
+	return &friendext.CreateFriendLabelResp{Label: &friendext.FriendLabel{
+		LabelID:       label.LabelID,
+		Name:          label.Name,
+		Sort:          label.Sort,
+		CreateTime:    label.CreateTime.UnixMilli(),
+		FriendUserIDs: req.FriendUserIDs,
+	}}, nil
+}
+
+func (s *friendServer) UpdateFriendLabel(ctx context.Context, req *friendext.UpdateFriendLabelReq) (*friendext.UpdateFriendLabelResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	labels, err := s.labelDatabase.FindLabels(ctx, req.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	label := utils.SliceToMap(labels, func(e *relation.FriendLabelModel) string { return e.LabelID })[req.LabelID]
+	if label == nil {
+		return nil, errs.ErrRecordNotFound.Wrap("friend label not found")
+	}
+	if label.Name == req.Name {
+		return &friendext.UpdateFriendLabelResp{}, nil
+	}
+	if err := s.checkLabelName(labels, req.LabelID, req.Name); err != nil {
+		return nil, err
+	}
+	if err := s.labelDatabase.UpdateLabel(ctx, req.OwnerUserID, req.LabelID, map[string]any{"name": req.Name}); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendLabelsUpdatedNotification(ctx, req.OwnerUserID, []string{req.LabelID})
+	return &friendext.UpdateFriendLabelResp{}, nil
+}
+
+func (s *friendServer) DeleteFriendLabel(ctx context.Context, req *friendext.DeleteFriendLabelReq) (*friendext.DeleteFriendLabelResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.labelDatabase.TakeLabel(ctx, req.OwnerUserID, req.LabelID); err != nil {
+		return nil, err
+	}
+	if err := s.labelDatabase.DeleteLabel(ctx, req.OwnerUserID, req.LabelID); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendLabelsUpdatedNotification(ctx, req.OwnerUserID, []string{req.LabelID})
+	return &friendext.DeleteFriendLabelResp{}, nil
+}
+
+func (s *friendServer) SortFriendLabels(ctx context.Context, req *friendext.SortFriendLabelsReq) (*friendext.SortFriendLabelsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	labels, err := s.labelDatabase.FindLabels(ctx, req.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs := utils.Slice(labels, func(e *relation.FriendLabelModel) string { return e.LabelID })
+	if len(labelIDs) != len(req.LabelIDs) || len(utils.Single(labelIDs, req.LabelIDs)) > 0 {
+		return nil, errs.ErrArgs.Wrap("labelIDs must contain all the friend labels")
+	}
+	if err := s.labelDatabase.SortLabels(ctx, req.OwnerUserID, req.LabelIDs); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendLabelsUpdatedNotification(ctx, req.OwnerUserID, req.LabelIDs)
+	return &friendext.SortFriendLabelsResp{}, nil
+}
+
+func (s *friendServer) GetFriendLabels(ctx context.Context, req *friendext.GetFriendLabelsReq) (*friendext.GetFriendLabelsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	labels, err := s.labelDatabase.FindLabels(ctx, req.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.labelDatabase.FindLabelMembers(ctx, req.OwnerUserID, nil)
+	if err != nil {
+		return nil, err
+	}
+	friendUserIDs := make(map[string][]string)
+	for _, member := range members {
+		friendUserIDs[member.LabelID] = append(friendUserIDs[member.LabelID], member.FriendUserID)
+	}
+	return &friendext.GetFriendLabelsResp{
+		Labels: utils.Slice(labels, func(e *relation.FriendLabelModel) *friendext.FriendLabel {
+			label := &friendext.FriendLabel{
+				LabelID:       e.LabelID,
+				Name:          e.Name,
+				Sort:          e.Sort,
+				CreateTime:    e.CreateTime.UnixMilli(),
+				FriendUserIDs: friendUserIDs[e.LabelID],
+			}
+			if label.FriendUserIDs == nil {
+				label.FriendUserIDs = []string{}
+			}
+			return label
+		}),
+	}, nil
+}
+
+func (s *friendServer) AddFriendLabelMembers(ctx context.Context, req *friendext.AddFriendLabelMembersReq) (*friendext.AddFriendLabelMembersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.labelDatabase.TakeLabel(ctx, req.OwnerUserID, req.LabelID); err != nil {
+		return nil, err
+	}
+	if _, err := s.friendDatabase.FindFriendsWithError(ctx, req.OwnerUserID, req.FriendUserIDs); err != nil {
+		return nil, err
+	}
+	if err := s.labelDatabase.AddLabelMembers(ctx, s.labelMembers(req.OwnerUserID, req.LabelID, req.FriendUserIDs)); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendLabelsUpdatedNotification(ctx, req.OwnerUserID, []string{req.LabelID})
+	return &friendext.AddFriendLabelMembersResp{}, nil
+}
+
+func (s *friendServer) RemoveFriendLabelMembers(ctx context.Context, req *friendext.RemoveFriendLabelMembersReq) (*friendext.RemoveFriendLabelMembersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.labelDatabase.TakeLabel(ctx, req.OwnerUserID, req.LabelID); err != nil {
+		return nil, err
+	}
+	if err := s.labelDatabase.RemoveLabelMembers(ctx, req.OwnerUserID, req.LabelID, req.FriendUserIDs); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendLabelsUpdatedNotification(ctx, req.OwnerUserID, []string{req.LabelID})
+	return &friendext.RemoveFriendLabelMembersResp{}, nil
+}
+
+func (s *friendServer) SetFriendLabels(ctx context.Context, req *friendext.SetFriendLabelsReq) (*friendext.SetFriendLabelsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.friendDatabase.FindFriendsWithError(ctx, req.OwnerUserID, []string{req.FriendUserID}); err != nil {
+		return nil, err
+	}
+	labels, err := s.labelDatabase.FindLabels(ctx, req.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs := utils.SliceSet(utils.Slice(labels, func(e *relation.FriendLabelModel) string { return e.LabelID }))
+	for _, labelID := range req.LabelIDs {
+		if _, ok := labelIDs[labelID]; !ok {
+			return nil, errs.ErrRecordNotFound.Wrap("friend label not found " + labelID)
+		}
+	}
+	oldMembers, err := s.labelDatabase.FindLabelMembers(ctx, req.OwnerUserID, []string{req.FriendUserID})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	members := utils.Slice(req.LabelIDs, func(labelID string) *relation.FriendLabelMemberModel {
+		return &relation.FriendLabelMemberModel{
+			OwnerUserID:  req.OwnerUserID,
+			LabelID:      labelID,
+			FriendUserID: req.FriendUserID,
+			CreateTime:   now,
+		}
+	})
+	if err := s.labelDatabase.SetFriendLabels(ctx, req.OwnerUserID, req.FriendUserID, members); err != nil {
+		return nil, err
+	}
+	changed := append(utils.Slice(oldMembers, func(e *relation.FriendLabelMemberModel) string { return e.LabelID }), req.LabelIDs...)
+	s.notificationSender.FriendLabelsUpdatedNotification(ctx, req.OwnerUserID, utils.Distinct(changed))
+	return &friendext.SetFriendLabelsResp{}, nil
+}
+
+func (s *friendServer) GetLabelFriends(ctx context.Context, req *friendext.GetLabelFriendsReq) (*friendext.GetLabelFriendsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.labelDatabase.TakeLabel(ctx, req.OwnerUserID, req.LabelID); err != nil {
+		return nil, err
+	}
+	total, friendUserIDs, err := s.labelDatabase.PageLabelFriendUserIDs(ctx, req.OwnerUserID, req.LabelID, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	resp := &friendext.GetLabelFriendsResp{Total: total, Friends: []*sdkws.FriendInfo{}}
+	if len(friendUserIDs) == 0 {
+		return resp, nil
+	}
+	// 分组中可能残留已不是好友的用户, 跳过即可
+	friends, err := s.friendDatabase.FindFriends(ctx, req.OwnerUserID, friendUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	friendMap := utils.SliceToMap(friends, func(e *relation.FriendModel) string { return e.FriendUserID })
+	friends = utils.Filter(friendUserIDs, func(friendUserID string) (*relation.FriendModel, bool) {
+		friend, ok := friendMap[friendUserID]
+		return friend, ok
+	})
+	if resp.Friends, err = convert.FriendsDB2Pb(ctx, friends, s.userRpcClient.GetUsersInfoMap); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,126 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friend
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	tablerelation "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
+)
+
+// checkAddFriendPolicy 检查toUserID的加好友权限是否允许fromUserID申请, 已在toUserID好友列表中的不检查.
+func (s *friendServer) checkAddFriendPolicy(ctx context.Context, fromUserID, toUserID string) error {
+	policy, err := s.addPolicyDatabase.GetAddFriendPolicy(ctx, toUserID)
+	if err != nil {
+		return err
+	}
+	switch policy {
+	case tablerelation.AddFriendPolicyAnyone:
+		return nil
+	case tablerelation.AddFriendPolicyFriendsOfFriends:
+		fromFriendIDs, err := s.friendDatabase.FindFriendUserIDs(ctx, fromUserID)
+		if err != nil {
+			return err
+		}
+		toFriendIDs, err := s.friendDatabase.FindFriendUserIDs(ctx, toUserID)
+		if err != nil {
+			return err
+		}
+		if len(utils.IntersectString(fromFriendIDs, toFriendIDs)) > 0 {
+			return nil
+		}
+	case tablerelation.AddFriendPolicyGroupCoMembers:
+		groupIDs, err := s.groupRpcClient.GetSharedGroupIDs(ctx, fromUserID, toUserID)
+		if err != nil {
+			return err
+		}
+		if len(groupIDs) > 0 {
+			return nil
+		}
+	}
+	return errcode.ErrAddFriendNotAllowed.Wrap()
+}
+
+// checkRefuseCooldown fromUserID发给toUserID的申请被拒绝后, refuseCooldown内不能再次申请.
+func (s *friendServer) checkRefuseCooldown(ctx context.Context, fromUserID, toUserID string) error {
+	cooldown := time.Duration(config.Config.FriendRequest.RefuseCooldown) * time.Second
+	if cooldown <= 0 {
+		return nil
+	}
+	friendRequest, err := s.friendDatabase.TakeFriendRequest(ctx, fromUserID, toUserID)
+	if err != nil {
+		if errs.Unwrap(err) == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+	if friendRequest.HandleResult == constant.FriendResponseRefuse && time.Since(friendRequest.HandleTime) < cooldown {
+		return errcode.ErrFriendRequestCooldown.Wrap()
+	}
+	return nil
+}
+
+// checkFriendRequestExpired 未处理的申请超过expire不能再处理.
+func (s *friendServer) checkFriendRequestExpired(ctx context.Context, fromUserID, toUserID string) error {
+	expire := time.Duration(config.Config.FriendRequest.Expire) * time.Second
+	if expire <= 0 {
+		return nil
+	}
+	friendRequest, err := s.friendDatabase.TakeFriendRequest(ctx, fromUserID, toUserID)
+	if err != nil {
+		return err
+	}
+	if friendRequest.HandleResult == constant.FriendResponseNotHandle && time.Since(friendRequest.CreateTime) > expire {
+		return errcode.ErrFriendRequestExpired.Wrap()
+	}
+	return nil
+}
+
+func (s *friendServer) SetAddFriendPolicy(ctx context.Context, req *friendext.SetAddFriendPolicyReq) (*friendext.SetAddFriendPolicyResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := s.addPolicyDatabase.SetAddFriendPolicy(ctx, req.UserID, req.Policy); err != nil {
+		return nil, err
+	}
+	return &friendext.SetAddFriendPolicyResp{}, nil
+}
+
+func (s *friendServer) GetAddFriendPolicy(ctx context.Context, req *friendext.GetAddFriendPolicyReq) (*friendext.GetAddFriendPolicyResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	policy, err := s.addPolicyDatabase.GetAddFriendPolicy(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &friendext.GetAddFriendPolicyResp{Policy: policy}, nil
+}
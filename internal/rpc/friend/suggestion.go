@@ -0,0 +1,207 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friend
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
+)
+
+// 每次查询好友关系的用户数.
+const suggestionFriendBatch = 500
+
+// suggestionExcluded 不能推荐给userID的用户: 自己, 好友, 黑名单中的用户, 和userID之间有未处理好友申请的用户.
+func (s *friendServer) suggestionExcluded(ctx context.Context, userID string) (map[string]struct{}, error) {
+	excluded := map[string]struct{}{userID: {}}
+	friendIDs, err := s.friendDatabase.FindFriendUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	blackIDs, err := s.blackDatabase.FindBlackIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range append(friendIDs, blackIDs...) {
+		excluded[id] = struct{}{}
+	}
+	requests, err := s.friendDatabase.FindUnhandledFriendRequests(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		excluded[request.FromUserID] = struct{}{}
+		excluded[request.ToUserID] = struct{}{}
+	}
+	return excluded, nil
+}
+
+// computeFriendSuggestions 按共同好友数, 其次共同群数排序, 最多保留config.Config.FriendSuggestion.MaxCount个.
+func (s *friendServer) computeFriendSuggestions(ctx context.Context, userID string) ([]*cache.FriendSuggestion, error) {
+	excluded, err := s.suggestionExcluded(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	friendIDs, err := s.friendDatabase.FindFriendUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make(map[string]*cache.FriendSuggestion)
+	candidate := func(id string) *cache.FriendSuggestion {
+		c, ok := candidates[id]
+		if !ok {
+			c = &cache.FriendSuggestion{UserID: id}
+			candidates[id] = c
+		}
+		return c
+	}
+	for i := 0; i < len(friendIDs); i += suggestionFriendBatch {
+		friends, err := s.friendDatabase.FindOwnersFriends(ctx, friendIDs[i:utils.Min(i+suggestionFriendBatch, len(friendIDs))])
+		if err != nil {
+			return nil, err
+		}
+		for _, friend := range friends {
+			if _, ok := excluded[friend.FriendUserID]; !ok {
+				candidate(friend.FriendUserID).MutualFriendCount++
+			}
+		}
+	}
+	counts, err := s.groupRpcClient.GetSharedGroupCounts(ctx, userID, int32(config.Config.FriendSuggestion.MaxGroupMemberCount))
+	if err != nil {
+		return nil, err
+	}
+	for id, count := range counts {
+		if _, ok := excluded[id]; !ok {
+			candidate(id).SharedGroupCount = count
+		}
+	}
+	sorted := make([]*cache.FriendSuggestion, 0, len(candidates))
+	for _, c := range candidates {
+		sorted = append(sorted, c)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].MutualFriendCount != sorted[j].MutualFriendCount {
+			return sorted[i].MutualFriendCount > sorted[j].MutualFriendCount
+		}
+		if sorted[i].SharedGroupCount != sorted[j].SharedGroupCount {
+			return sorted[i].SharedGroupCount > sorted[j].SharedGroupCount
+		}
+		return sorted[i].UserID < sorted[j].UserID
+	})
+	maxCount := config.Config.FriendSuggestion.MaxCount
+	suggestions := make([]*cache.FriendSuggestion, 0, utils.Min(len(sorted), maxCount))
+	for _, c := range sorted {
+		if maxCount > 0 && len(suggestions) >= maxCount {
+			break
+		}
+		// 对方把userID拉黑了
+		blocked, _, err := s.blackDatabase.CheckIn(ctx, c.UserID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !blocked {
+			suggestions = append(suggestions, c)
+		}
+	}
+	return suggestions, nil
+}
+
+// refreshFriendSuggestions 重新计算并保存, expireHours为0时不保存.
+func (s *friendServer) refreshFriendSuggestions(ctx context.Context, userID string) ([]*cache.FriendSuggestion, error) {
+	suggestions, err := s.computeFriendSuggestions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if expire := config.Config.FriendSuggestion.ExpireHours; expire > 0 {
+		data := &cache.FriendSuggestions{Suggestions: suggestions, UpdateTime: time.Now().UnixMilli()}
+		if err := s.suggestionDatabase.SetFriendSuggestions(ctx, userID, data, time.Duration(expire)*time.Hour); err != nil {
+			return nil, err
+		}
+	}
+	return suggestions, nil
+}
+
+func (s *friendServer) GetFriendSuggestions(ctx context.Context, req *friendext.GetFriendSuggestionsReq) (*friendext.GetFriendSuggestionsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.userRpcClient.Access(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	precomputed, err := s.suggestionDatabase.GetFriendSuggestions(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var suggestions []*cache.FriendSuggestion
+	if precomputed == nil {
+		if suggestions, err = s.refreshFriendSuggestions(ctx, req.UserID); err != nil {
+			return nil, err
+		}
+	} else {
+		// 计算之后可能已经成为好友, 拉黑或发出了好友申请
+		excluded, err := s.suggestionExcluded(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		suggestions = utils.Filter(precomputed.Suggestions, func(e *cache.FriendSuggestion) (*cache.FriendSuggestion, bool) {
+			_, ok := excluded[e.UserID]
+			return e, !ok
+		})
+	}
+	resp := &friendext.GetFriendSuggestionsResp{Total: int64(len(suggestions)), Suggestions: []*friendext.FriendSuggestion{}}
+	suggestions = utils.Paginate(suggestions, int(req.Pagination.PageNumber), int(req.Pagination.ShowNumber))
+	if len(suggestions) == 0 {
+		return resp, nil
+	}
+	users, err := s.userRpcClient.GetPublicUserInfoMap(ctx, utils.Slice(suggestions, func(e *cache.FriendSuggestion) string { return e.UserID }), false)
+	if err != nil {
+		return nil, err
+	}
+	for _, suggestion := range suggestions {
+		user, ok := users[suggestion.UserID]
+		if !ok {
+			continue
+		}
+		resp.Suggestions = append(resp.Suggestions, &friendext.FriendSuggestion{
+			User:              user,
+			MutualFriendCount: suggestion.MutualFriendCount,
+			SharedGroupCount:  suggestion.SharedGroupCount,
+		})
+	}
+	return resp, nil
+}
+
+func (s *friendServer) RefreshFriendSuggestions(ctx context.Context, req *friendext.RefreshFriendSuggestionsReq) (*friendext.RefreshFriendSuggestionsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	for _, userID := range utils.Distinct(req.UserIDs) {
+		if _, err := s.refreshFriendSuggestions(ctx, userID); err != nil {
+			log.ZError(ctx, "refresh friend suggestions failed", err, "userID", userID)
+		}
+	}
+	return &friendext.RefreshFriendSuggestionsResp{}, nil
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+// addAnnouncement 记录群公告历史, 失败不影响群公告的修改.
+func (s *groupServer) addAnnouncement(ctx context.Context, groupID string, notification string) {
+	announcement := &unRelationTb.GroupAnnouncementModel{
+		GroupID:      groupID,
+		Notification: notification,
+		UserID:       mcontext.GetOpUserID(ctx),
+		CreateTime:   time.Now(),
+	}
+	if err := s.announcementDatabase.AddAnnouncement(ctx, announcement); err != nil {
+		log.ZError(ctx, "add group announcement failed", err, "groupID", groupID)
+	}
+}
+
+func (s *groupServer) GetGroupAnnouncements(ctx context.Context, req *groupext.GetGroupAnnouncementsReq) (*groupext.GetGroupAnnouncementsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if !authverify.IsAppManagerUid(ctx) {
+		if _, err := s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	total, announcements, err := s.announcementDatabase.PageAnnouncements(ctx, req.GroupID, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupAnnouncementsResp{
+		Total: total,
+		Announcements: utils.Slice(announcements, func(e *unRelationTb.GroupAnnouncementModel) *groupext.GroupAnnouncement {
+			return &groupext.GroupAnnouncement{
+				GroupID:      e.GroupID,
+				Notification: e.Notification,
+				UserID:       e.UserID,
+				CreateTime:   e.CreateTime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
@@ -0,0 +1,152 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+// auditJson before/after为nil时记录为空字符串.
+func auditJson(v any) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// audit 记录一条群管理操作, 写入失败只打日志, 不影响操作本身.
+func (s *groupServer) audit(ctx context.Context, groupID string, action string, targetUserIDs []string, before, after any) {
+	auditLog := &unRelationTb.GroupAuditLogModel{
+		GroupID:        groupID,
+		Action:         action,
+		OperatorUserID: mcontext.GetOpUserID(ctx),
+		TargetUserIDs:  targetUserIDs,
+		Before:         auditJson(before),
+		After:          auditJson(after),
+		CreateTime:     time.Now(),
+	}
+	if err := s.auditLogDatabase.AddLogs(ctx, auditLog); err != nil {
+		log.ZError(ctx, "add group audit log failed", err, "groupID", groupID, "action", action)
+	}
+}
+
+// groupAuditValues 取出data中要修改的群字段的当前值.
+func groupAuditValues(group *relationTb.GroupModel, data map[string]any) map[string]any {
+	values := make(map[string]any)
+	for key := range data {
+		switch key {
+		case "name":
+			values[key] = group.GroupName
+		case "notification":
+			values[key] = group.Notification
+		case "introduction":
+			values[key] = group.Introduction
+		case "face_url":
+			values[key] = group.FaceURL
+		case "need_verification":
+			values[key] = group.NeedVerification
+		case "look_member_info":
+			values[key] = group.LookMemberInfo
+		case "apply_member_friend":
+			values[key] = group.ApplyMemberFriend
+		case "status":
+			values[key] = group.Status
+		case "slow_mode":
+			values[key] = group.SlowMode
+		}
+	}
+	return values
+}
+
+// groupMemberAuditValues 取出data中要修改的群成员字段的当前值.
+func groupMemberAuditValues(member *relationTb.GroupMemberModel, data map[string]any) map[string]any {
+	values := make(map[string]any)
+	for key := range data {
+		switch key {
+		case "nickname":
+			values[key] = member.Nickname
+		case "user_group_face_url":
+			values[key] = member.FaceURL
+		case "role_level":
+			values[key] = member.RoleLevel
+		case "ex":
+			values[key] = member.Ex
+		case "mute_end_time":
+			values[key] = member.MuteEndTime
+		case "role_id":
+			values[key] = member.RoleID
+		}
+	}
+	return values
+}
+
+func (s *groupServer) GetGroupAuditLogs(ctx context.Context, req *groupext.GetGroupAuditLogsReq) (*groupext.GetGroupAuditLogsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if req.GroupID == "" {
+		if !authverify.IsAppManagerUid(ctx) {
+			return nil, errs.ErrNoPermission.Wrap("only app manager can query all groups")
+		}
+	} else if err := s.CheckGroupAdmin(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	filter := &unRelationTb.GroupAuditLogFilter{
+		GroupID:        req.GroupID,
+		OperatorUserID: req.OperatorUserID,
+		TargetUserID:   req.TargetUserID,
+		Actions:        req.Actions,
+	}
+	if req.StartTime > 0 {
+		filter.StartTime = time.UnixMilli(req.StartTime)
+	}
+	if req.EndTime > 0 {
+		filter.EndTime = time.UnixMilli(req.EndTime)
+	}
+	total, logs, err := s.auditLogDatabase.PageLogs(ctx, filter, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupAuditLogsResp{
+		Total: total,
+		Logs: utils.Slice(logs, func(e *unRelationTb.GroupAuditLogModel) *groupext.GroupAuditLog {
+			return &groupext.GroupAuditLog{
+				GroupID:        e.GroupID,
+				Action:         e.Action,
+				OperatorUserID: e.OperatorUserID,
+				TargetUserIDs:  e.TargetUserIDs,
+				Before:         e.Before,
+				After:          e.After,
+				CreateTime:     e.CreateTime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
@@ -0,0 +1,187 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	pbGroup "github.com/OpenIMSDK/protocol/group"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+)
+
+// checkGroupBanned 有用户仍在封禁期内时返回errcode.ErrGroupMemberBanned.
+func (s *groupServer) checkGroupBanned(ctx context.Context, groupID string, userIDs ...string) error {
+	bans, err := s.GroupDatabase.FindActiveGroupBans(ctx, groupID, userIDs)
+	if err != nil {
+		return err
+	}
+	if len(bans) > 0 {
+		return errcode.ErrGroupMemberBanned.Wrap("user " + bans[0].UserID + " is banned from group")
+	}
+	return nil
+}
+
+func (s *groupServer) BanGroupMembers(ctx context.Context, req *groupext.BanGroupMembersReq) (*groupext.BanGroupMembersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, errs.ErrDismissedAlready.Wrap()
+	}
+	opUserID := mcontext.GetOpUserID(ctx)
+	if utils.Contain(opUserID, req.UserIDs...) {
+		return nil, errs.ErrArgs.Wrap("can not ban yourself")
+	}
+	opMember, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionKick)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.User.GetPublicUserInfos(ctx, req.UserIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != len(req.UserIDs) {
+		return nil, errs.ErrUserIDNotFound.Wrap("user not found")
+	}
+	members, err := s.FindGroupMember(ctx, []string{req.GroupID}, req.UserIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if err := checkManageMember(opMember, member); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	expireTime := time.Unix(0, 0)
+	if req.Duration > 0 {
+		expireTime = now.Add(time.Duration(req.Duration) * time.Second)
+	}
+	bans := utils.Slice(req.UserIDs, func(userID string) *relationTb.GroupBanModel {
+		return &relationTb.GroupBanModel{
+			GroupID:        req.GroupID,
+			UserID:         userID,
+			Reason:         req.Reason,
+			OperatorUserID: opUserID,
+			ExpireTime:     expireTime,
+			CreateTime:     now,
+		}
+	})
+	if err := s.GroupDatabase.BanGroupMembers(ctx, bans); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditBanMember, req.UserIDs, nil, req)
+	// 封禁已生效, 后续步骤逐个执行, 失败的返回给调用方重试
+	resp := &groupext.BanGroupMembersResp{}
+	fail := func(step string, err error, userIDs ...string) {
+		log.ZError(ctx, "ban group members step failed", err, "groupID", req.GroupID, "step", step, "userIDs", userIDs)
+		for _, userID := range userIDs {
+			resp.Failures = append(resp.Failures, &groupext.BanGroupMemberFailure{UserID: userID, Step: step, ErrMsg: err.Error()})
+		}
+	}
+	if req.DeleteMsgsDuration > 0 {
+		since := now.Add(-time.Duration(req.DeleteMsgsDuration) * time.Second).UnixMilli()
+		for _, userID := range req.UserIDs {
+			if _, err := s.msgRpcClient.ExtClient.DeleteUserGroupMsgs(ctx, &msgext.DeleteUserGroupMsgsReq{
+				GroupID: req.GroupID,
+				UserID:  userID,
+				Since:   since,
+			}); err != nil {
+				fail(groupext.BanStepDeleteMsgs, err, userID)
+			}
+		}
+	}
+	if req.Kick {
+		if len(members) > 0 {
+			kickedUserIDs := utils.Slice(members, func(e *relationTb.GroupMemberModel) string { return e.UserID })
+			if _, err := s.KickGroupMember(ctx, &pbGroup.KickGroupMemberReq{
+				GroupID:       req.GroupID,
+				KickedUserIDs: kickedUserIDs,
+				Reason:        req.Reason,
+			}); err != nil {
+				fail(groupext.BanStepKick, err, kickedUserIDs...)
+			}
+		}
+		if group.GroupType == relationTb.ChannelGroup {
+			if err := s.GroupDatabase.UnsubscribeChannel(ctx, req.GroupID, req.UserIDs); err != nil {
+				fail(groupext.BanStepUnsubscribe, err, req.UserIDs...)
+			}
+		}
+	}
+	var expireTimeMilli int64
+	if req.Duration > 0 {
+		expireTimeMilli = expireTime.UnixMilli()
+	}
+	s.Notification.GroupMemberBannedNotification(ctx, req.GroupID, req.UserIDs, req.Reason, expireTimeMilli)
+	return resp, nil
+}
+
+func (s *groupServer) UnbanGroupMembers(ctx context.Context, req *groupext.UnbanGroupMembersReq) (*groupext.UnbanGroupMembersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionKick); err != nil {
+		return nil, err
+	}
+	if err := s.GroupDatabase.UnbanGroupMembers(ctx, req.GroupID, req.UserIDs); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditUnbanMember, req.UserIDs, nil, nil)
+	return &groupext.UnbanGroupMembersResp{}, nil
+}
+
+func (s *groupServer) GetGroupBans(ctx context.Context, req *groupext.GetGroupBansReq) (*groupext.GetGroupBansResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionKick); err != nil {
+		return nil, err
+	}
+	total, bans, err := s.GroupDatabase.PageGroupBans(ctx, req.GroupID, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupBansResp{
+		Total: total,
+		Bans: utils.Slice(bans, func(e *relationTb.GroupBanModel) *groupext.GroupBan {
+			ban := &groupext.GroupBan{
+				GroupID:        e.GroupID,
+				UserID:         e.UserID,
+				Reason:         e.Reason,
+				OperatorUserID: e.OperatorUserID,
+				CreateTime:     e.CreateTime.UnixMilli(),
+			}
+			if e.ExpireTime.After(time.Unix(0, 0)) {
+				ban.ExpireTime = e.ExpireTime.UnixMilli()
+			}
+			return ban
+		}),
+	}, nil
+}
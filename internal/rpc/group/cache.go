@@ -20,6 +20,7 @@ import (
 	pbGroup "github.com/OpenIMSDK/protocol/group"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
 )
 
 func (s *groupServer) GetGroupInfoCache(
@@ -45,3 +46,17 @@ func (s *groupServer) GetGroupMemberCache(
 	resp = &pbGroup.GetGroupMemberCacheResp{Member: convert.Db2PbGroupMember(members)}
 	return resp, nil
 }
+
+func (s *groupServer) GetGroupExtInfoCache(ctx context.Context, req *groupext.GetGroupExtInfoCacheReq) (*groupext.GetGroupExtInfoCacheResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupExtInfoCacheResp{Group: &groupext.GroupExtInfo{
+		GroupInfo: convert.Db2PbGroupInfo(group, "", 0),
+		SlowMode:  group.SlowMode,
+	}}, nil
+}
@@ -0,0 +1,364 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+// takeChannel 获取未解散的频道.
+func (s *groupServer) takeChannel(ctx context.Context, groupID string) (*relationTb.GroupModel, *relationTb.ChannelModel, error) {
+	group, err := s.GroupDatabase.TakeGroup(ctx, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if group.GroupType != relationTb.ChannelGroup {
+		return nil, nil, errs.ErrGroupTypeNotSupport.Wrap("not a channel")
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, nil, errs.ErrDismissedAlready.Wrap()
+	}
+	channel, err := s.GroupDatabase.TakeChannel(ctx, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, channel, nil
+}
+
+// channelInfos 组装频道信息, userID不为空时填充是否已订阅.
+func (s *groupServer) channelInfos(ctx context.Context, channels []*relationTb.ChannelModel, userID string) ([]*groupext.ChannelInfo, error) {
+	if len(channels) == 0 {
+		return []*groupext.ChannelInfo{}, nil
+	}
+	groupIDs := utils.Slice(channels, func(e *relationTb.ChannelModel) string { return e.GroupID })
+	groups, err := s.GroupDatabase.FindGroup(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	groupMap := utils.SliceToMap(groups, func(e *relationTb.GroupModel) string { return e.GroupID })
+	owners, err := s.FindGroupMember(ctx, groupIDs, nil, []int32{constant.GroupOwner})
+	if err != nil {
+		return nil, err
+	}
+	ownerMap := utils.SliceToMap(owners, func(e *relationTb.GroupMemberModel) string { return e.GroupID })
+	infos := make([]*groupext.ChannelInfo, 0, len(channels))
+	for _, channel := range channels {
+		group, ok := groupMap[channel.GroupID]
+		if !ok {
+			continue
+		}
+		var ownerUserID string
+		if owner, ok := ownerMap[channel.GroupID]; ok {
+			ownerUserID = owner.UserID
+		}
+		info := &groupext.ChannelInfo{
+			// 频道只展示订阅数, 不展示成员数
+			GroupInfo:       convert.Db2PbGroupInfo(group, ownerUserID, 0),
+			IsPublic:        channel.IsPublic,
+			SubscriberCount: channel.SubscriberCount,
+		}
+		if userID != "" {
+			if _, err := s.GroupDatabase.TakeChannelSubscriber(ctx, channel.GroupID, userID); err == nil {
+				info.Subscribed = true
+			} else if !s.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (s *groupServer) CreateChannel(ctx context.Context, req *groupext.CreateChannelReq) (*groupext.CreateChannelResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	if err := s.checkCreateSuspension(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	userIDs := append([]string{req.OwnerUserID}, req.AdminUserIDs...)
+	if utils.Duplicate(userIDs) {
+		return nil, errs.ErrArgs.Wrap("channel admin repeated")
+	}
+	userMap, err := s.User.GetUsersInfoMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(userMap) != len(userIDs) {
+		return nil, errs.ErrUserIDNotFound.Wrap("user not found")
+	}
+	now := time.Now()
+	group := &relationTb.GroupModel{
+		GroupID:                req.GroupID,
+		GroupName:              req.GroupName,
+		Introduction:           req.Introduction,
+		FaceURL:                req.FaceURL,
+		Ex:                     req.Ex,
+		CreateTime:             now,
+		Status:                 constant.GroupOk,
+		CreatorUserID:          mcontext.GetOpUserID(ctx),
+		GroupType:              relationTb.ChannelGroup,
+		NeedVerification:       constant.Directly,
+		NotificationUpdateTime: time.UnixMilli(0),
+	}
+	if err := s.GenGroupID(ctx, &group.GroupID); err != nil {
+		return nil, err
+	}
+	members := make([]*relationTb.GroupMemberModel, 0, len(userIDs))
+	for _, userID := range userIDs {
+		member := convert.Pb2DbGroupMember(userMap[userID])
+		member.Nickname = ""
+		member.GroupID = group.GroupID
+		member.RoleLevel = constant.GroupAdmin
+		if userID == req.OwnerUserID {
+			member.RoleLevel = constant.GroupOwner
+		}
+		member.OperatorUserID = mcontext.GetOpUserID(ctx)
+		member.JoinSource = constant.JoinByInvitation
+		member.InviterUserID = mcontext.GetOpUserID(ctx)
+		member.JoinTime = now
+		member.MuteEndTime = time.Unix(0, 0)
+		members = append(members, member)
+	}
+	channel := &relationTb.ChannelModel{
+		GroupID:    group.GroupID,
+		IsPublic:   req.IsPublic,
+		CreateTime: now,
+	}
+	if err := s.GroupDatabase.CreateChannel(ctx, group, members, channel); err != nil {
+		return nil, err
+	}
+	if err := s.conversationRpcClient.GroupChatFirstCreateConversation(ctx, group.GroupID, userIDs); err != nil {
+		return nil, err
+	}
+	return &groupext.CreateChannelResp{
+		Channel: &groupext.ChannelInfo{
+			GroupInfo: convert.Db2PbGroupInfo(group, req.OwnerUserID, 0),
+			IsPublic:  channel.IsPublic,
+		},
+	}, nil
+}
+
+func (s *groupServer) SetChannelPublic(ctx context.Context, req *groupext.SetChannelPublicReq) (*groupext.SetChannelPublicResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	_, channel, err := s.takeChannel(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionEditInfo); err != nil {
+		return nil, err
+	}
+	if err := s.GroupDatabase.UpdateChannelPublic(ctx, req.GroupID, req.IsPublic); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditSetChannelPublic, nil,
+		map[string]any{"is_public": channel.IsPublic},
+		map[string]any{"is_public": req.IsPublic},
+	)
+	return &groupext.SetChannelPublicResp{}, nil
+}
+
+func (s *groupServer) GetChannelsInfo(ctx context.Context, req *groupext.GetChannelsInfoReq) (*groupext.GetChannelsInfoResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	channels, err := s.GroupDatabase.FindChannels(ctx, utils.Distinct(req.GroupIDs))
+	if err != nil {
+		return nil, err
+	}
+	infos, err := s.channelInfos(ctx, channels, mcontext.GetOpUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetChannelsInfoResp{Channels: infos}, nil
+}
+
+func (s *groupServer) SearchChannels(ctx context.Context, req *groupext.SearchChannelsReq) (*groupext.SearchChannelsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	total, channels, err := s.GroupDatabase.SearchPublicChannels(ctx, req.Keyword, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := s.channelInfos(ctx, channels, mcontext.GetOpUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.SearchChannelsResp{Total: total, Channels: infos}, nil
+}
+
+func (s *groupServer) SubscribeChannel(ctx context.Context, req *groupext.SubscribeChannelReq) (*groupext.SubscribeChannelResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	_, channel, err := s.takeChannel(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	// 公开频道和社区公开频道可自行订阅, 私有频道或替他人订阅需要邀请权限
+	selfSubscribe := req.UserID == mcontext.GetOpUserID(ctx)
+	if selfSubscribe && !channel.IsPublic {
+		if selfSubscribe, err = s.isCommunityMemberOfPublicGroup(ctx, req.GroupID, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+	if !selfSubscribe {
+		if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionInvite); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.User.GetUserInfo(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupBanned(ctx, req.GroupID, req.UserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, req.UserID); err == nil {
+		return nil, errs.ErrArgs.Wrap("already in channel")
+	} else if !s.IsNotFound(err) {
+		return nil, err
+	}
+	if _, err := s.GroupDatabase.TakeChannelSubscriber(ctx, req.GroupID, req.UserID); err == nil {
+		return nil, errs.ErrArgs.Wrap("already subscribed")
+	} else if !s.IsNotFound(err) {
+		return nil, err
+	}
+	subscriber := &relationTb.ChannelSubscriberModel{
+		GroupID:       req.GroupID,
+		UserID:        req.UserID,
+		SubscribeTime: time.Now(),
+	}
+	if err := s.GroupDatabase.SubscribeChannel(ctx, subscriber); err != nil {
+		return nil, err
+	}
+	return &groupext.SubscribeChannelResp{}, nil
+}
+
+func (s *groupServer) UnsubscribeChannel(ctx context.Context, req *groupext.UnsubscribeChannelReq) (*groupext.UnsubscribeChannelResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, _, err := s.takeChannel(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	if req.UserID != mcontext.GetOpUserID(ctx) {
+		if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionKick); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.GroupDatabase.UnsubscribeChannel(ctx, req.GroupID, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	return &groupext.UnsubscribeChannelResp{}, nil
+}
+
+func (s *groupServer) OpenChannel(ctx context.Context, req *groupext.OpenChannelReq) (*groupext.OpenChannelResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if _, _, err := s.takeChannel(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	subscriber, err := s.GroupDatabase.TakeChannelSubscriber(ctx, req.GroupID, req.UserID)
+	if err != nil {
+		if s.IsNotFound(err) {
+			return nil, errs.ErrNoPermission.Wrap("not subscribed")
+		}
+		return nil, err
+	}
+	if subscriber.Opened {
+		return &groupext.OpenChannelResp{}, nil
+	}
+	if err := s.conversationRpcClient.GroupChatFirstCreateConversation(ctx, req.GroupID, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	if err := s.GroupDatabase.SetChannelSubscriberOpened(ctx, req.GroupID, req.UserID); err != nil {
+		return nil, err
+	}
+	return &groupext.OpenChannelResp{}, nil
+}
+
+func (s *groupServer) GetSubscribedChannels(ctx context.Context, req *groupext.GetSubscribedChannelsReq) (*groupext.GetSubscribedChannelsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	total, subscribers, err := s.GroupDatabase.PageUserSubscribedChannels(ctx, req.UserID, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := utils.Slice(subscribers, func(e *relationTb.ChannelSubscriberModel) string { return e.GroupID })
+	channels, err := s.GroupDatabase.FindChannels(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	// 保持订阅时间倒序
+	channels = utils.Order(groupIDs, channels, func(e *relationTb.ChannelModel) string { return e.GroupID })
+	infos, err := s.channelInfos(ctx, channels, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		info.Subscribed = true
+	}
+	return &groupext.GetSubscribedChannelsResp{Total: total, Channels: infos}, nil
+}
+
+func (s *groupServer) IsChannelSubscriber(ctx context.Context, req *groupext.IsChannelSubscriberReq) (*groupext.IsChannelSubscriberResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := s.GroupDatabase.TakeChannelSubscriber(ctx, req.GroupID, req.UserID); err != nil {
+		if s.IsNotFound(err) {
+			return &groupext.IsChannelSubscriberResp{}, nil
+		}
+		return nil, err
+	}
+	return &groupext.IsChannelSubscriberResp{Subscribed: true}, nil
+}
+
+func (s *groupServer) GetChannelSubscriberIDs(ctx context.Context, req *groupext.GetChannelSubscriberIDsReq) (*groupext.GetChannelSubscriberIDsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	userIDs, err := s.GroupDatabase.FindChannelSubscriberIDs(ctx, req.GroupID, req.Cursor, int(req.Count))
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetChannelSubscriberIDsResp{UserIDs: userIDs}, nil
+}
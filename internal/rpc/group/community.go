@@ -0,0 +1,529 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+func (s *groupServer) takeCommunity(ctx context.Context, communityID string) (*relationTb.GroupModel, error) {
+	group, err := s.GroupDatabase.TakeGroup(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if group.GroupType != relationTb.CommunityGroup {
+		return nil, errs.ErrGroupTypeNotSupport.Wrap("not a community")
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, errs.ErrDismissedAlready.Wrap()
+	}
+	return group, nil
+}
+
+// groupInfoMap 获取群信息, 填充群主和成员数.
+func (s *groupServer) groupInfoMap(ctx context.Context, groups []*relationTb.GroupModel) (map[string]*sdkws.GroupInfo, error) {
+	groupIDs := utils.Slice(groups, func(e *relationTb.GroupModel) string { return e.GroupID })
+	owners, err := s.FindGroupMember(ctx, groupIDs, nil, []int32{constant.GroupOwner})
+	if err != nil {
+		return nil, err
+	}
+	ownerMap := utils.SliceToMap(owners, func(e *relationTb.GroupMemberModel) string { return e.GroupID })
+	memberNumMap, err := s.GroupDatabase.MapGroupMemberNum(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	infoMap := make(map[string]*sdkws.GroupInfo, len(groups))
+	for _, group := range groups {
+		var ownerUserID string
+		if owner, ok := ownerMap[group.GroupID]; ok {
+			ownerUserID = owner.UserID
+		}
+		infoMap[group.GroupID] = convert.Db2PbGroupInfo(group, ownerUserID, memberNumMap[group.GroupID])
+	}
+	return infoMap, nil
+}
+
+// isCommunityMemberOfPublicGroup 群属于某个社区且公开, 并且用户是该社区成员.
+func (s *groupServer) isCommunityMemberOfPublicGroup(ctx context.Context, groupID string, userID string) (bool, error) {
+	communityGroups, err := s.GroupDatabase.FindGroupsCommunity(ctx, []string{groupID})
+	if err != nil {
+		return false, err
+	}
+	if len(communityGroups) == 0 || !communityGroups[0].IsPublic {
+		return false, nil
+	}
+	if _, err := s.GroupDatabase.TakeGroupMember(ctx, communityGroups[0].CommunityID, userID); err != nil {
+		if s.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// communityMembersEntered 用户加入社区后, 自动加入社区的公开群并订阅公告频道和公开频道.
+// 社区成员已经加入, 这里的失败只打日志.
+func (s *groupServer) communityMembersEntered(ctx context.Context, communityID string, userIDs []string) {
+	communityGroups, err := s.GroupDatabase.FindCommunityGroups(ctx, communityID)
+	if err != nil {
+		log.ZError(ctx, "FindCommunityGroups failed", err, "communityID", communityID)
+		return
+	}
+	for _, communityGroup := range communityGroups {
+		if !(communityGroup.IsPublic || communityGroup.IsAnnouncement) {
+			continue
+		}
+		if err := s.enterCommunityGroup(ctx, communityGroup.GroupID, userIDs); err != nil {
+			log.ZError(ctx, "enterCommunityGroup failed", err, "communityID", communityID, "groupID", communityGroup.GroupID, "userIDs", userIDs)
+		}
+	}
+}
+
+func (s *groupServer) enterCommunityGroup(ctx context.Context, groupID string, userIDs []string) error {
+	group, err := s.GroupDatabase.TakeGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil
+	}
+	members, err := s.GroupDatabase.FindGroupMember(ctx, []string{groupID}, userIDs, nil)
+	if err != nil {
+		return err
+	}
+	bans, err := s.GroupDatabase.FindActiveGroupBans(ctx, groupID, userIDs)
+	if err != nil {
+		return err
+	}
+	skip := make(map[string]struct{})
+	for _, member := range members {
+		skip[member.UserID] = struct{}{}
+	}
+	for _, ban := range bans {
+		skip[ban.UserID] = struct{}{}
+	}
+	enterUserIDs := utils.Filter(userIDs, func(userID string) (string, bool) {
+		_, ok := skip[userID]
+		return userID, !ok
+	})
+	if len(enterUserIDs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	if group.GroupType == relationTb.ChannelGroup {
+		for _, userID := range enterUserIDs {
+			if _, err := s.GroupDatabase.TakeChannelSubscriber(ctx, groupID, userID); err == nil {
+				continue
+			} else if !s.IsNotFound(err) {
+				return err
+			}
+			subscriber := &relationTb.ChannelSubscriberModel{
+				GroupID:       groupID,
+				UserID:        userID,
+				SubscribeTime: now,
+			}
+			if err := s.GroupDatabase.SubscribeChannel(ctx, subscriber); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	userMap, err := s.User.GetUsersInfoMap(ctx, enterUserIDs)
+	if err != nil {
+		return err
+	}
+	opUserID := mcontext.GetOpUserID(ctx)
+	groupMembers := make([]*relationTb.GroupMemberModel, 0, len(enterUserIDs))
+	for _, userID := range enterUserIDs {
+		user, ok := userMap[userID]
+		if !ok {
+			continue
+		}
+		member := convert.Pb2DbGroupMember(user)
+		member.Nickname = ""
+		member.GroupID = groupID
+		member.RoleLevel = constant.GroupOrdinaryUsers
+		member.OperatorUserID = opUserID
+		member.JoinSource = relationTb.JoinByCommunity
+		member.JoinTime = now
+		member.MuteEndTime = time.Unix(0, 0)
+		if err := CallbackBeforeMemberJoinGroup(ctx, member, group.Ex); err != nil {
+			log.ZWarn(ctx, "CallbackBeforeMemberJoinGroup rejected", err, "groupID", groupID, "userID", userID)
+			continue
+		}
+		groupMembers = append(groupMembers, member)
+	}
+	if len(groupMembers) == 0 {
+		return nil
+	}
+	if err := s.GroupDatabase.CreateGroup(ctx, nil, groupMembers); err != nil {
+		return err
+	}
+	enterUserIDs = utils.Slice(groupMembers, func(e *relationTb.GroupMemberModel) string { return e.UserID })
+	if err := s.conversationRpcClient.GroupChatFirstCreateConversation(ctx, groupID, enterUserIDs); err != nil {
+		return err
+	}
+	for _, userID := range enterUserIDs {
+		s.Notification.MemberEnterNotification(ctx, groupID, userID)
+	}
+	return nil
+}
+
+// communityMembersLeft 用户退出社区后, 退出社区下的所有群并取消订阅所有频道, 群主不会被移出.
+func (s *groupServer) communityMembersLeft(ctx context.Context, communityID string, userIDs []string) {
+	communityGroups, err := s.GroupDatabase.FindCommunityGroups(ctx, communityID)
+	if err != nil {
+		log.ZError(ctx, "FindCommunityGroups failed", err, "communityID", communityID)
+		return
+	}
+	for _, communityGroup := range communityGroups {
+		if err := s.leaveCommunityGroup(ctx, communityGroup.GroupID, userIDs); err != nil {
+			log.ZError(ctx, "leaveCommunityGroup failed", err, "communityID", communityID, "groupID", communityGroup.GroupID, "userIDs", userIDs)
+		}
+	}
+}
+
+func (s *groupServer) leaveCommunityGroup(ctx context.Context, groupID string, userIDs []string) error {
+	group, err := s.GroupDatabase.TakeGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if group.GroupType == relationTb.ChannelGroup {
+		if err := s.GroupDatabase.UnsubscribeChannel(ctx, groupID, userIDs); err != nil {
+			return err
+		}
+	}
+	members, err := s.GroupDatabase.FindGroupMember(ctx, []string{groupID}, userIDs, nil)
+	if err != nil {
+		return err
+	}
+	members = utils.Filter(members, func(e *relationTb.GroupMemberModel) (*relationTb.GroupMemberModel, bool) {
+		return e, e.RoleLevel != constant.GroupOwner
+	})
+	if len(members) == 0 {
+		return nil
+	}
+	leftUserIDs := utils.Slice(members, func(e *relationTb.GroupMemberModel) string { return e.UserID })
+	if err := s.GroupDatabase.DeleteGroupMember(ctx, groupID, leftUserIDs); err != nil {
+		return err
+	}
+	for _, member := range members {
+		s.Notification.MemberQuitNotification(ctx, s.groupMemberDB2PB(member, 0))
+	}
+	return s.deleteMemberAndSetConversationSeq(ctx, groupID, leftUserIDs)
+}
+
+func (s *groupServer) CreateCommunity(ctx context.Context, req *groupext.CreateCommunityReq) (*groupext.CreateCommunityResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	if err := s.checkCreateSuspension(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
+	managerIDs := append([]string{req.OwnerUserID}, req.AdminUserIDs...)
+	userIDs := append(append([]string{}, managerIDs...), req.MemberUserIDs...)
+	if utils.Duplicate(userIDs) {
+		return nil, errs.ErrArgs.Wrap("community member repeated")
+	}
+	userMap, err := s.User.GetUsersInfoMap(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(userMap) != len(userIDs) {
+		return nil, errs.ErrUserIDNotFound.Wrap("user not found")
+	}
+	now := time.Now()
+	opUserID := mcontext.GetOpUserID(ctx)
+	community := &relationTb.GroupModel{
+		GroupID:                req.GroupID,
+		GroupName:              req.GroupName,
+		Introduction:           req.Introduction,
+		FaceURL:                req.FaceURL,
+		Ex:                     req.Ex,
+		CreateTime:             now,
+		Status:                 constant.GroupOk,
+		CreatorUserID:          opUserID,
+		GroupType:              relationTb.CommunityGroup,
+		NeedVerification:       req.NeedVerification,
+		NotificationUpdateTime: time.UnixMilli(0),
+	}
+	if err := s.GenGroupID(ctx, &community.GroupID); err != nil {
+		return nil, err
+	}
+	announcement := &relationTb.GroupModel{
+		GroupName:              req.GroupName,
+		FaceURL:                req.FaceURL,
+		CreateTime:             now,
+		Status:                 constant.GroupOk,
+		CreatorUserID:          opUserID,
+		GroupType:              relationTb.ChannelGroup,
+		NeedVerification:       constant.Directly,
+		NotificationUpdateTime: time.UnixMilli(0),
+	}
+	if err := s.GenGroupID(ctx, &announcement.GroupID); err != nil {
+		return nil, err
+	}
+	newMember := func(groupID string, userID string, roleLevel int32) *relationTb.GroupMemberModel {
+		member := convert.Pb2DbGroupMember(userMap[userID])
+		member.Nickname = ""
+		member.GroupID = groupID
+		member.RoleLevel = roleLevel
+		member.OperatorUserID = opUserID
+		member.JoinSource = constant.JoinByInvitation
+		member.InviterUserID = opUserID
+		member.JoinTime = now
+		member.MuteEndTime = time.Unix(0, 0)
+		return member
+	}
+	var members []*relationTb.GroupMemberModel
+	for _, groupID := range []string{community.GroupID, announcement.GroupID} {
+		members = append(members, newMember(groupID, req.OwnerUserID, constant.GroupOwner))
+		for _, userID := range req.AdminUserIDs {
+			members = append(members, newMember(groupID, userID, constant.GroupAdmin))
+		}
+	}
+	for _, userID := range req.MemberUserIDs {
+		members = append(members, newMember(community.GroupID, userID, constant.GroupOrdinaryUsers))
+	}
+	channel := &relationTb.ChannelModel{
+		GroupID:    announcement.GroupID,
+		CreateTime: now,
+	}
+	communityGroup := &relationTb.CommunityGroupModel{
+		CommunityID:    community.GroupID,
+		GroupID:        announcement.GroupID,
+		IsAnnouncement: true,
+		CreateTime:     now,
+	}
+	if err := s.GroupDatabase.CreateCommunity(
+		ctx,
+		[]*relationTb.GroupModel{community, announcement},
+		members,
+		channel,
+		[]*relationTb.CommunityGroupModel{communityGroup},
+	); err != nil {
+		return nil, err
+	}
+	if err := s.conversationRpcClient.GroupChatFirstCreateConversation(ctx, announcement.GroupID, managerIDs); err != nil {
+		return nil, err
+	}
+	if len(req.MemberUserIDs) > 0 {
+		s.communityMembersEntered(ctx, community.GroupID, req.MemberUserIDs)
+	}
+	return &groupext.CreateCommunityResp{
+		Community: &groupext.CommunityInfo{
+			GroupInfo:           convert.Db2PbGroupInfo(community, req.OwnerUserID, uint32(len(userIDs))),
+			AnnouncementGroupID: announcement.GroupID,
+		},
+	}, nil
+}
+
+func (s *groupServer) AttachCommunityGroups(ctx context.Context, req *groupext.AttachCommunityGroupsReq) (*groupext.AttachCommunityGroupsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := s.takeCommunity(ctx, req.CommunityID); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkPermission(ctx, req.CommunityID, relationTb.GroupPermissionEditInfo); err != nil {
+		return nil, err
+	}
+	groups, err := s.GroupDatabase.FindNotDismissedGroup(ctx, req.GroupIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) != len(req.GroupIDs) {
+		return nil, errs.ErrGroupIDNotFound.Wrap("group not found or dismissed")
+	}
+	for _, group := range groups {
+		switch group.GroupType {
+		case constant.WorkingGroup, relationTb.ChannelGroup:
+		default:
+			return nil, errs.ErrGroupTypeNotSupport.Wrap("group " + group.GroupID + " can not be attached to community")
+		}
+	}
+	attached, err := s.GroupDatabase.FindGroupsCommunity(ctx, req.GroupIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(attached) > 0 {
+		return nil, errs.ErrArgs.Wrap("group " + attached[0].GroupID + " already in community " + attached[0].CommunityID)
+	}
+	if !authverify.IsAppManagerUid(ctx) {
+		owners, err := s.GroupDatabase.FindGroupMember(ctx, req.GroupIDs, []string{mcontext.GetOpUserID(ctx)}, []int32{constant.GroupOwner})
+		if err != nil {
+			return nil, err
+		}
+		if len(owners) != len(req.GroupIDs) {
+			return nil, errs.ErrNoPermission.Wrap("only the group owner can attach the group to community")
+		}
+	}
+	now := time.Now()
+	communityGroups := utils.Slice(req.GroupIDs, func(groupID string) *relationTb.CommunityGroupModel {
+		return &relationTb.CommunityGroupModel{
+			CommunityID: req.CommunityID,
+			GroupID:     groupID,
+			IsPublic:    req.IsPublic,
+			CreateTime:  now,
+		}
+	})
+	if err := s.GroupDatabase.AttachCommunityGroups(ctx, communityGroups); err != nil {
+		return nil, err
+	}
+	return &groupext.AttachCommunityGroupsResp{}, nil
+}
+
+func (s *groupServer) DetachCommunityGroups(ctx context.Context, req *groupext.DetachCommunityGroupsReq) (*groupext.DetachCommunityGroupsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := s.takeCommunity(ctx, req.CommunityID); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkPermission(ctx, req.CommunityID, relationTb.GroupPermissionEditInfo); err != nil {
+		return nil, err
+	}
+	communityGroups, err := s.GroupDatabase.FindCommunityGroups(ctx, req.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	communityGroupMap := utils.SliceToMap(communityGroups, func(e *relationTb.CommunityGroupModel) string { return e.GroupID })
+	for _, groupID := range req.GroupIDs {
+		communityGroup, ok := communityGroupMap[groupID]
+		if !ok {
+			return nil, errs.ErrArgs.Wrap("group " + groupID + " not in community")
+		}
+		if communityGroup.IsAnnouncement {
+			return nil, errs.ErrArgs.Wrap("announcement channel can not be detached")
+		}
+	}
+	if err := s.GroupDatabase.DetachCommunityGroups(ctx, req.CommunityID, req.GroupIDs); err != nil {
+		return nil, err
+	}
+	return &groupext.DetachCommunityGroupsResp{}, nil
+}
+
+func (s *groupServer) GetCommunityGroups(ctx context.Context, req *groupext.GetCommunityGroupsReq) (*groupext.GetCommunityGroupsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := s.takeCommunity(ctx, req.CommunityID); err != nil {
+		return nil, err
+	}
+	communityGroups, err := s.GroupDatabase.FindCommunityGroups(ctx, req.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if !authverify.IsAppManagerUid(ctx) {
+		opUserID := mcontext.GetOpUserID(ctx)
+		opMember, err := s.GroupDatabase.TakeGroupMember(ctx, req.CommunityID, opUserID)
+		if err != nil {
+			return nil, err
+		}
+		if opMember.RoleLevel == constant.GroupOrdinaryUsers {
+			groupIDs := utils.Slice(communityGroups, func(e *relationTb.CommunityGroupModel) string { return e.GroupID })
+			joined, err := s.GroupDatabase.FindGroupMember(ctx, groupIDs, []string{opUserID}, nil)
+			if err != nil {
+				return nil, err
+			}
+			joinedMap := utils.SliceToMap(joined, func(e *relationTb.GroupMemberModel) string { return e.GroupID })
+			communityGroups = utils.Filter(communityGroups, func(e *relationTb.CommunityGroupModel) (*relationTb.CommunityGroupModel, bool) {
+				_, ok := joinedMap[e.GroupID]
+				return e, ok || e.IsPublic || e.IsAnnouncement
+			})
+		}
+	}
+	groups, err := s.GroupDatabase.FindNotDismissedGroup(ctx, utils.Slice(communityGroups, func(e *relationTb.CommunityGroupModel) string { return e.GroupID }))
+	if err != nil {
+		return nil, err
+	}
+	infoMap, err := s.groupInfoMap(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.GetCommunityGroupsResp{Groups: make([]*groupext.CommunityGroup, 0, len(communityGroups))}
+	for _, communityGroup := range communityGroups {
+		info, ok := infoMap[communityGroup.GroupID]
+		if !ok {
+			continue
+		}
+		resp.Groups = append(resp.Groups, &groupext.CommunityGroup{
+			GroupInfo:      info,
+			IsPublic:       communityGroup.IsPublic,
+			IsAnnouncement: communityGroup.IsAnnouncement,
+		})
+	}
+	return resp, nil
+}
+
+func (s *groupServer) GetJoinedCommunities(ctx context.Context, req *groupext.GetJoinedCommunitiesReq) (*groupext.GetJoinedCommunitiesResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	groupIDs, err := s.GroupDatabase.FindJoinedGroupIDs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.GroupDatabase.FindNotDismissedGroup(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	communities := utils.Filter(groups, func(e *relationTb.GroupModel) (*relationTb.GroupModel, bool) {
+		return e, e.GroupType == relationTb.CommunityGroup
+	})
+	resp := &groupext.GetJoinedCommunitiesResp{Total: int64(len(communities)), Communities: []*groupext.CommunityInfo{}}
+	communities = utils.Paginate(communities, int(req.Pagination.PageNumber), int(req.Pagination.ShowNumber))
+	if len(communities) == 0 {
+		return resp, nil
+	}
+	infoMap, err := s.groupInfoMap(ctx, communities)
+	if err != nil {
+		return nil, err
+	}
+	for _, community := range communities {
+		info := &groupext.CommunityInfo{GroupInfo: infoMap[community.GroupID]}
+		communityGroups, err := s.GroupDatabase.FindCommunityGroups(ctx, community.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, communityGroup := range communityGroups {
+			if communityGroup.IsAnnouncement {
+				info.AnnouncementGroupID = communityGroup.GroupID
+				break
+			}
+		}
+		resp.Communities = append(resp.Communities, info)
+	}
+	return resp, nil
+}
@@ -138,3 +138,33 @@ func (s *groupServer) PageGetGroupMember(
 	}
 	return total, members, nil
 }
+
+// fillGroupMembers 群昵称或头像为空时使用用户的昵称和头像.
+func (s *groupServer) fillGroupMembers(ctx context.Context, members []*relationTb.GroupMemberModel) error {
+	emptyUserIDs := make(map[string]struct{})
+	for _, member := range members {
+		if member.Nickname == "" || member.FaceURL == "" {
+			emptyUserIDs[member.UserID] = struct{}{}
+		}
+	}
+	if len(emptyUserIDs) == 0 {
+		return nil
+	}
+	users, err := s.User.GetPublicUserInfoMap(ctx, utils.Keys(emptyUserIDs), true)
+	if err != nil {
+		return err
+	}
+	for i, member := range members {
+		user, ok := users[member.UserID]
+		if !ok {
+			continue
+		}
+		if member.Nickname == "" {
+			members[i].Nickname = user.Nickname
+		}
+		if member.FaceURL == "" {
+			members[i].FaceURL = user.FaceURL
+		}
+	}
+	return nil
+}
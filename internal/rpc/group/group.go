@@ -51,7 +51,10 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/relation"
 	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
 )
 
 func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) error {
@@ -59,7 +62,7 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	if err != nil {
 		return err
 	}
-	if err := db.AutoMigrate(&relationTb.GroupModel{}, &relationTb.GroupMemberModel{}, &relationTb.GroupRequestModel{}); err != nil {
+	if err := db.AutoMigrate(&relationTb.GroupModel{}, &relationTb.GroupMemberModel{}, &relationTb.GroupRequestModel{}, &relationTb.GroupRoleModel{}, &relationTb.GroupInviteLinkModel{}, &relationTb.ChannelModel{}, &relationTb.ChannelSubscriberModel{}, &relationTb.GroupBanModel{}, &relationTb.CommunityGroupModel{}); err != nil {
 		return err
 	}
 	mongo, err := unrelation.NewMongo()
@@ -70,11 +73,23 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	if err != nil {
 		return err
 	}
+	if err := mongo.CreateGroupJoinPolicyIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateGroupAuditLogIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateGroupAnnouncementIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateVersionLogIndex(); err != nil {
+		return err
+	}
 	userRpcClient := rpcclient.NewUserRpcClient(client)
 	msgRpcClient := rpcclient.NewMessageRpcClient(client)
 	conversationRpcClient := rpcclient.NewConversationRpcClient(client)
 	database := controller.InitGroupDatabase(db, rdb, mongo.GetDatabase())
-	pbGroup.RegisterGroupServer(server, &groupServer{
+	srv := &groupServer{
 		GroupDatabase: database,
 		User:          userRpcClient,
 		Notification: notification.NewGroupNotificationSender(database, &msgRpcClient, &userRpcClient, func(ctx context.Context, userIDs []string) ([]notification.CommonUser, error) {
@@ -86,7 +101,19 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 		}),
 		conversationRpcClient: conversationRpcClient,
 		msgRpcClient:          msgRpcClient,
-	})
+		friendRpcClient:       rpcclient.NewFriendRpcClient(client),
+		joinPolicyDatabase: controller.NewGroupJoinPolicyDatabase(
+			unrelation.NewGroupJoinPolicyMongoDriver(mongo.GetDatabase()),
+			unrelation.NewGroupJoinAnswerMongoDriver(mongo.GetDatabase()),
+			relation.NewGroupRequest(db),
+		),
+		auditLogDatabase:     controller.NewGroupAuditLogDatabase(unrelation.NewGroupAuditLogMongoDriver(mongo.GetDatabase())),
+		announcementDatabase: controller.NewGroupAnnouncementDatabase(unrelation.NewGroupAnnouncementMongoDriver(mongo.GetDatabase())),
+		versionLogDatabase:   controller.NewVersionLogDatabase(unrelation.NewVersionLogMongoDriver(mongo.GetDatabase())),
+		userSuspension:       controller.InitUserSuspensionChecker(rdb, mongo.GetDatabase()),
+	}
+	pbGroup.RegisterGroupServer(server, srv)
+	groupext.RegisterGroupExtServer(server, srv)
 	return nil
 }
 
@@ -96,6 +123,12 @@ type groupServer struct {
 	Notification          *notification.GroupNotificationSender
 	conversationRpcClient rpcclient.ConversationRpcClient
 	msgRpcClient          rpcclient.MessageRpcClient
+	friendRpcClient       rpcclient.FriendRpcClient
+	joinPolicyDatabase    controller.GroupJoinPolicyDatabase
+	auditLogDatabase      controller.GroupAuditLogDatabase
+	announcementDatabase  controller.GroupAnnouncementDatabase
+	versionLogDatabase    controller.VersionLogDatabase
+	userSuspension        controller.UserSuspensionChecker
 }
 
 func (s *groupServer) CheckGroupAdmin(ctx context.Context, groupID string) error {
@@ -111,6 +144,14 @@ func (s *groupServer) CheckGroupAdmin(ctx context.Context, groupID string) error
 	return nil
 }
 
+// checkCreateSuspension 被封禁建群的用户不能创建群、频道和社区, 管理员代为创建时不校验.
+func (s *groupServer) checkCreateSuspension(ctx context.Context, ownerUserID string) error {
+	if authverify.IsAppManagerUid(ctx) {
+		return nil
+	}
+	return s.userSuspension.CheckUserSuspension(ctx, ownerUserID, unRelationTb.UserSuspensionScopeCreateGroup)
+}
+
 func (s *groupServer) GetUsernameMap(ctx context.Context, userIDs []string, complete bool) (map[string]string, error) {
 	if len(userIDs) == 0 {
 		return map[string]string{}, nil
@@ -167,6 +208,9 @@ func (s *groupServer) CreateGroup(ctx context.Context, req *pbGroup.CreateGroupR
 	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID); err != nil {
 		return nil, err
 	}
+	if err := s.checkCreateSuspension(ctx, req.OwnerUserID); err != nil {
+		return nil, err
+	}
 	userIDs := append(append(req.MemberUserIDs, req.AdminUserIDs...), req.OwnerUserID)
 	opUserID := mcontext.GetOpUserID(ctx)
 	if !utils.Contain(opUserID, userIDs...) {
@@ -321,51 +365,50 @@ func (s *groupServer) InviteUserToGroup(ctx context.Context, req *pbGroup.Invite
 	if group.Status == constant.GroupStatusDismissed {
 		return nil, errs.ErrDismissedAlready.Wrap()
 	}
+	if err := s.checkGroupBanned(ctx, req.GroupID, req.InvitedUserIDs...); err != nil {
+		return nil, err
+	}
 	userMap, err := s.User.GetUsersInfoMap(ctx, req.InvitedUserIDs)
 	if err != nil {
 		return nil, err
 	}
-	var groupMember *relationTb.GroupMemberModel
 	var opUserID string
+	permissions := relationTb.GroupPermissionAll
 	if !authverify.IsAppManagerUid(ctx) {
 		opUserID = mcontext.GetOpUserID(ctx)
-		groupMembers, err := s.FindGroupMember(ctx, []string{req.GroupID}, []string{opUserID}, nil)
+		groupMember, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionInvite)
 		if err != nil {
 			return nil, err
 		}
-		if len(groupMembers) <= 0 {
-			return nil, errs.ErrNoPermission.Wrap("not in group")
+		permissions, err = s.getMemberPermissions(ctx, groupMember)
+		if err != nil {
+			return nil, err
 		}
-		groupMember = groupMembers[0]
 	}
-	if group.NeedVerification == constant.AllNeedVerification {
-		if !authverify.IsAppManagerUid(ctx) {
-			if !(groupMember.RoleLevel == constant.GroupOwner || groupMember.RoleLevel == constant.GroupAdmin) {
-				var requests []*relationTb.GroupRequestModel
-				for _, userID := range req.InvitedUserIDs {
-					requests = append(requests, &relationTb.GroupRequestModel{
-						UserID:        userID,
-						GroupID:       req.GroupID,
-						JoinSource:    constant.JoinByInvitation,
-						InviterUserID: opUserID,
-						ReqTime:       time.Now(),
-						HandledTime:   time.Unix(0, 0),
-					})
-				}
-				if err := s.GroupDatabase.CreateGroupRequest(ctx, requests); err != nil {
-					return nil, err
-				}
-				for _, request := range requests {
-					s.Notification.JoinGroupApplicationNotification(ctx, &pbGroup.JoinGroupReq{
-						GroupID:       request.GroupID,
-						ReqMessage:    request.ReqMsg,
-						JoinSource:    request.JoinSource,
-						InviterUserID: request.InviterUserID,
-					})
-				}
-				return resp, nil
-			}
+	if group.NeedVerification == constant.AllNeedVerification && permissions&relationTb.GroupPermissionApproveJoin == 0 {
+		var requests []*relationTb.GroupRequestModel
+		for _, userID := range req.InvitedUserIDs {
+			requests = append(requests, &relationTb.GroupRequestModel{
+				UserID:        userID,
+				GroupID:       req.GroupID,
+				JoinSource:    constant.JoinByInvitation,
+				InviterUserID: opUserID,
+				ReqTime:       time.Now(),
+				HandledTime:   time.Unix(0, 0),
+			})
+		}
+		if err := s.GroupDatabase.CreateGroupRequest(ctx, requests); err != nil {
+			return nil, err
 		}
+		for _, request := range requests {
+			s.Notification.JoinGroupApplicationNotification(ctx, &pbGroup.JoinGroupReq{
+				GroupID:       request.GroupID,
+				ReqMessage:    request.ReqMsg,
+				JoinSource:    request.JoinSource,
+				InviterUserID: request.InviterUserID,
+			})
+		}
+		return resp, nil
 	}
 
 	if group.GroupType == constant.SuperGroup {
@@ -403,6 +446,9 @@ func (s *groupServer) InviteUserToGroup(ctx context.Context, req *pbGroup.Invite
 			return nil, err
 		}
 		s.Notification.MemberInvitedNotification(ctx, req.GroupID, req.Reason, req.InvitedUserIDs)
+		if group.GroupType == relationTb.CommunityGroup {
+			s.communityMembersEntered(ctx, group.GroupID, req.InvitedUserIDs)
+		}
 	}
 	return resp, nil
 }
@@ -482,28 +528,23 @@ func (s *groupServer) KickGroupMember(ctx context.Context, req *pbGroup.KickGrou
 		for i, member := range members {
 			memberMap[member.UserID] = members[i]
 		}
-		isAppManagerUid := authverify.IsAppManagerUid(ctx)
-		opMember := memberMap[opUserID]
+		var opMember *relationTb.GroupMemberModel
+		if !authverify.IsAppManagerUid(ctx) {
+			opMember = memberMap[opUserID]
+			if opMember == nil {
+				return nil, errs.ErrNoPermission.Wrap("opUserID no in group")
+			}
+			if err := s.checkMemberPermission(ctx, opMember, relationTb.GroupPermissionKick); err != nil {
+				return nil, err
+			}
+		}
 		for _, userID := range req.KickedUserIDs {
 			member, ok := memberMap[userID]
 			if !ok {
 				return nil, errs.ErrUserIDNotFound.Wrap(userID)
 			}
-			if !isAppManagerUid {
-				if opMember == nil {
-					return nil, errs.ErrNoPermission.Wrap("opUserID no in group")
-				}
-				switch opMember.RoleLevel {
-				case constant.GroupOwner:
-				case constant.GroupAdmin:
-					if member.RoleLevel == constant.GroupOwner || member.RoleLevel == constant.GroupAdmin {
-						return nil, errs.ErrNoPermission.Wrap("group admins cannot remove the group owner and other admins")
-					}
-				case constant.GroupOrdinaryUsers:
-					return nil, errs.ErrNoPermission.Wrap("opUserID no permission")
-				default:
-					return nil, errs.ErrNoPermission.Wrap("opUserID roleLevel unknown")
-				}
+			if err := checkManageMember(opMember, member); err != nil {
+				return nil, err
 			}
 		}
 		num, err := s.GroupDatabase.FindGroupMemberNum(ctx, req.GroupID)
@@ -553,6 +594,10 @@ func (s *groupServer) KickGroupMember(ctx context.Context, req *pbGroup.KickGrou
 	if err := s.deleteMemberAndSetConversationSeq(ctx, req.GroupID, req.KickedUserIDs); err != nil {
 		return nil, err
 	}
+	if group.GroupType == relationTb.CommunityGroup {
+		s.communityMembersLeft(ctx, group.GroupID, req.KickedUserIDs)
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditKickMember, req.KickedUserIDs, nil, map[string]any{"reason": req.Reason})
 	return resp, nil
 }
 
@@ -672,14 +717,8 @@ func (s *groupServer) GroupApplicationResponse(ctx context.Context, req *pbGroup
 	if !utils.Contain(req.HandleResult, constant.GroupResponseAgree, constant.GroupResponseRefuse) {
 		return nil, errs.ErrArgs.Wrap("HandleResult unknown")
 	}
-	if !authverify.IsAppManagerUid(ctx) {
-		groupMember, err := s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-		if !(groupMember.RoleLevel == constant.GroupOwner || groupMember.RoleLevel == constant.GroupAdmin) {
-			return nil, errs.ErrNoPermission.Wrap("no group owner or admin")
-		}
+	if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionApproveJoin); err != nil {
+		return nil, err
 	}
 	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
 	if err != nil {
@@ -692,6 +731,9 @@ func (s *groupServer) GroupApplicationResponse(ctx context.Context, req *pbGroup
 	if groupRequest.HandleResult != 0 {
 		return nil, errs.ErrGroupRequestHandled.Wrap("group request already processed")
 	}
+	if err := s.checkGroupRequestExpired(ctx, groupRequest); err != nil {
+		return nil, err
+	}
 	var inGroup bool
 	if _, err := s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, req.FromUserID); err == nil {
 		inGroup = true // 已经在群里了
@@ -701,6 +743,11 @@ func (s *groupServer) GroupApplicationResponse(ctx context.Context, req *pbGroup
 	if _, err := s.User.GetPublicUserInfo(ctx, req.FromUserID); err != nil {
 		return nil, err
 	}
+	if (!inGroup) && req.HandleResult == constant.GroupResponseAgree {
+		if err := s.checkGroupBanned(ctx, req.GroupID, req.FromUserID); err != nil {
+			return nil, err
+		}
+	}
 	var member *relationTb.GroupMemberModel
 	if (!inGroup) && req.HandleResult == constant.GroupResponseAgree {
 		member = &relationTb.GroupMemberModel{
@@ -716,6 +763,15 @@ func (s *groupServer) GroupApplicationResponse(ctx context.Context, req *pbGroup
 			OperatorUserID: mcontext.GetOpUserID(ctx),
 			Ex:             groupRequest.Ex,
 		}
+		if groupRequest.InviteCode != "" {
+			if link, err := s.GroupDatabase.TakeGroupInviteLink(ctx, groupRequest.InviteCode); err == nil {
+				if member.RoleID, err = s.inviteLinkRoleID(ctx, link); err != nil {
+					return nil, err
+				}
+			} else if !s.IsNotFound(err) {
+				return nil, err
+			}
+		}
 		if err = CallbackBeforeMemberJoinGroup(ctx, member, group.Ex); err != nil {
 			return nil, err
 		}
@@ -724,6 +780,22 @@ func (s *groupServer) GroupApplicationResponse(ctx context.Context, req *pbGroup
 	if err := s.GroupDatabase.HandlerGroupRequest(ctx, req.GroupID, req.FromUserID, req.HandledMsg, req.HandleResult, member); err != nil {
 		return nil, err
 	}
+	auditAction := unRelationTb.GroupAuditAcceptApplication
+	if req.HandleResult == constant.GroupResponseRefuse {
+		auditAction = unRelationTb.GroupAuditRefuseApplication
+	}
+	s.audit(ctx, req.GroupID, auditAction, []string{req.FromUserID},
+		map[string]any{"handle_result": groupRequest.HandleResult},
+		map[string]any{"handle_result": req.HandleResult, "handled_msg": req.HandledMsg},
+	)
+	if err := s.joinPolicyDatabase.DeleteAnswers(ctx, req.GroupID, []string{req.FromUserID}); err != nil {
+		log.ZError(ctx, "DeleteAnswers failed", err, "groupID", req.GroupID, "userID", req.FromUserID)
+	}
+	if member != nil && groupRequest.InviteCode != "" {
+		if err := s.GroupDatabase.IncrGroupInviteLinkJoinCount(ctx, groupRequest.InviteCode); err != nil {
+			log.ZError(ctx, "IncrGroupInviteLinkJoinCount failed", err, "code", groupRequest.InviteCode)
+		}
+	}
 	switch req.HandleResult {
 	case constant.GroupResponseAgree:
 		if err := s.conversationRpcClient.GroupChatFirstCreateConversation(ctx, req.GroupID, []string{req.FromUserID}); err != nil {
@@ -734,6 +806,9 @@ func (s *groupServer) GroupApplicationResponse(ctx context.Context, req *pbGroup
 			log.ZDebug(ctx, "GroupApplicationResponse", "member is nil")
 		} else {
 			s.Notification.MemberEnterNotification(ctx, req.GroupID, req.FromUserID)
+			if group.GroupType == relationTb.CommunityGroup {
+				s.communityMembersEntered(ctx, group.GroupID, []string{req.FromUserID})
+			}
 		}
 	case constant.GroupResponseRefuse:
 		s.Notification.GroupApplicationRejectedNotification(ctx, req)
@@ -743,48 +818,90 @@ func (s *groupServer) GroupApplicationResponse(ctx context.Context, req *pbGroup
 
 func (s *groupServer) JoinGroup(ctx context.Context, req *pbGroup.JoinGroupReq) (resp *pbGroup.JoinGroupResp, err error) {
 	defer log.ZInfo(ctx, "JoinGroup.Return")
+	if _, err := s.joinGroup(ctx, req, nil); err != nil {
+		return nil, err
+	}
+	return &pbGroup.JoinGroupResp{}, nil
+}
+
+// joinGroup 按入群规则直接入群、自动拒绝或提交入群申请, 返回是否已直接入群.
+func (s *groupServer) joinGroup(ctx context.Context, req *pbGroup.JoinGroupReq, answers []*unRelationTb.GroupEntryAnswerModel) (bool, error) {
 	user, err := s.User.GetUserInfo(ctx, req.InviterUserID)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 	if group.Status == constant.GroupStatusDismissed {
-		return nil, errs.ErrDismissedAlready.Wrap()
+		return false, errs.ErrDismissedAlready.Wrap()
+	}
+	if group.GroupType == relationTb.ChannelGroup {
+		return false, errs.ErrGroupTypeNotSupport.Wrap("subscribe to join a channel")
+	}
+	if err := s.checkGroupBanned(ctx, req.GroupID, req.InviterUserID); err != nil {
+		return false, err
 	}
 	_, err = s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, req.InviterUserID)
 	if err == nil {
-		return nil, errs.ErrArgs.Wrap("already in group")
+		return false, errs.ErrArgs.Wrap("already in group")
 	} else if !s.IsNotFound(err) && utils.Unwrap(err) != errs.ErrRecordNotFound {
-		return nil, err
+		return false, err
 	}
 	log.ZInfo(ctx, "JoinGroup.groupInfo", "group", group, "eq", group.NeedVerification == constant.Directly)
-	resp = &pbGroup.JoinGroupResp{}
-	if group.NeedVerification == constant.Directly {
+	// 社区成员可以直接加入社区的公开群
+	communityMember, err := s.isCommunityMemberOfPublicGroup(ctx, req.GroupID, req.InviterUserID)
+	if err != nil {
+		return false, err
+	}
+	var policy *unRelationTb.GroupJoinPolicyModel
+	joinSource := int32(constant.JoinByInvitation)
+	if communityMember {
+		joinSource = relationTb.JoinByCommunity
+	}
+	handleResult := int32(constant.GroupResponseAgree)
+	if group.NeedVerification != constant.Directly && !communityMember {
+		policy, err = s.joinPolicyDatabase.TakePolicy(ctx, req.GroupID)
+		if err != nil {
+			return false, err
+		}
+		joinSource = req.JoinSource
+		handleResult, err = s.evaluateJoinPolicy(ctx, policy, user, answers)
+		if err != nil {
+			return false, err
+		}
+		log.ZInfo(ctx, "JoinGroup.evaluateJoinPolicy", "handleResult", handleResult)
+	}
+	switch handleResult {
+	case constant.GroupResponseRefuse:
+		return false, errcode.ErrGroupJoinRejected.Wrap()
+	case constant.GroupResponseAgree:
 		if group.GroupType == constant.SuperGroup {
-			return nil, errs.ErrGroupTypeNotSupport.Wrap()
+			return false, errs.ErrGroupTypeNotSupport.Wrap()
 		}
 		groupMember := convert.Pb2DbGroupMember(user)
 		groupMember.GroupID = group.GroupID
 		groupMember.RoleLevel = constant.GroupOrdinaryUsers
 		groupMember.OperatorUserID = mcontext.GetOpUserID(ctx)
-		groupMember.JoinSource = constant.JoinByInvitation
+		groupMember.JoinSource = joinSource
 		groupMember.InviterUserID = req.InviterUserID
 		groupMember.JoinTime = time.Now()
 		groupMember.MuteEndTime = time.Unix(0, 0)
 		if err := CallbackBeforeMemberJoinGroup(ctx, groupMember, group.Ex); err != nil {
-			return nil, err
+			return false, err
 		}
 		if err := s.GroupDatabase.CreateGroup(ctx, nil, []*relationTb.GroupMemberModel{groupMember}); err != nil {
-			return nil, err
+			return false, err
 		}
 		if err := s.conversationRpcClient.GroupChatFirstCreateConversation(ctx, req.GroupID, []string{req.InviterUserID}); err != nil {
-			return nil, err
+			return false, err
 		}
 		s.Notification.MemberEnterNotification(ctx, req.GroupID, req.InviterUserID)
-		return resp, nil
+		if group.GroupType == relationTb.CommunityGroup {
+			s.communityMembersEntered(ctx, group.GroupID, []string{req.InviterUserID})
+		}
+		return true, nil
 	}
 	groupRequest := relationTb.GroupRequestModel{
 		UserID:      req.InviterUserID,
@@ -795,10 +912,21 @@ func (s *groupServer) JoinGroup(ctx context.Context, req *pbGroup.JoinGroupReq)
 		HandledTime: time.Unix(0, 0),
 	}
 	if err := s.GroupDatabase.CreateGroupRequest(ctx, []*relationTb.GroupRequestModel{&groupRequest}); err != nil {
-		return nil, err
+		return false, err
+	}
+	if policy != nil && len(policy.Questions) > 0 {
+		answer := &unRelationTb.GroupJoinAnswerModel{
+			GroupID:    req.GroupID,
+			UserID:     req.InviterUserID,
+			Answers:    answers,
+			CreateTime: groupRequest.ReqTime,
+		}
+		if err := s.joinPolicyDatabase.SetAnswer(ctx, answer); err != nil {
+			return false, err
+		}
 	}
 	s.Notification.JoinGroupApplicationNotification(ctx, req)
-	return resp, nil
+	return false, nil
 }
 
 func (s *groupServer) QuitGroup(ctx context.Context, req *pbGroup.QuitGroupReq) (*pbGroup.QuitGroupResp, error) {
@@ -829,6 +957,9 @@ func (s *groupServer) QuitGroup(ctx context.Context, req *pbGroup.QuitGroupReq)
 	if err := s.deleteMemberAndSetConversationSeq(ctx, req.GroupID, []string{mcontext.GetOpUserID(ctx)}); err != nil {
 		return nil, err
 	}
+	if group.GroupType == relationTb.CommunityGroup {
+		s.communityMembersLeft(ctx, group.GroupID, []string{mcontext.GetOpUserID(ctx)})
+	}
 	return resp, nil
 }
 
@@ -842,16 +973,9 @@ func (s *groupServer) deleteMemberAndSetConversationSeq(ctx context.Context, gro
 }
 
 func (s *groupServer) SetGroupInfo(ctx context.Context, req *pbGroup.SetGroupInfoReq) (*pbGroup.SetGroupInfoResp, error) {
-	var opMember *relationTb.GroupMemberModel
-	if !authverify.IsAppManagerUid(ctx) {
-		var err error
-		opMember, err = s.TakeGroupMember(ctx, req.GroupInfoForSet.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-		if !(opMember.RoleLevel == constant.GroupOwner || opMember.RoleLevel == constant.GroupAdmin) {
-			return nil, errs.ErrNoPermission.Wrap("no group owner or admin")
-		}
+	opMember, err := s.checkPermission(ctx, req.GroupInfoForSet.GroupID, relationTb.GroupPermissionEditInfo)
+	if err != nil {
+		return nil, err
 	}
 	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupInfoForSet.GroupID)
 	if err != nil {
@@ -876,6 +1000,10 @@ func (s *groupServer) SetGroupInfo(ctx context.Context, req *pbGroup.SetGroupInf
 	if err := s.GroupDatabase.UpdateGroup(ctx, group.GroupID, data); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, group.GroupID, unRelationTb.GroupAuditSetInfo, nil, groupAuditValues(group, data), data)
+	if req.GroupInfoForSet.Notification != "" {
+		s.addAnnouncement(ctx, group.GroupID, req.GroupInfoForSet.Notification)
+	}
 	group, err = s.GroupDatabase.TakeGroup(ctx, req.GroupInfoForSet.GroupID)
 	if err != nil {
 		return nil, err
@@ -960,6 +1088,10 @@ func (s *groupServer) TransferGroupOwner(ctx context.Context, req *pbGroup.Trans
 	if err := s.GroupDatabase.TransferGroupOwner(ctx, req.GroupID, req.OldOwnerUserID, req.NewOwnerUserID, newOwner.RoleLevel); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditTransferOwner, []string{req.OldOwnerUserID, req.NewOwnerUserID},
+		map[string]any{"owner_user_id": req.OldOwnerUserID},
+		map[string]any{"owner_user_id": req.NewOwnerUserID},
+	)
 	s.Notification.GroupOwnerTransferredNotification(ctx, req)
 	return resp, nil
 }
@@ -1102,6 +1234,10 @@ func (s *groupServer) DismissGroup(ctx context.Context, req *pbGroup.DismissGrou
 	if err := s.GroupDatabase.DismissGroup(ctx, req.GroupID, req.DeleteMember); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditDismiss, nil,
+		map[string]any{"status": group.Status},
+		map[string]any{"status": constant.GroupStatusDismissed, "delete_member": req.DeleteMember},
+	)
 	if group.GroupType == constant.SuperGroup {
 		if err := s.GroupDatabase.DeleteSuperGroup(ctx, group.GroupID); err != nil {
 			return nil, err
@@ -1135,28 +1271,18 @@ func (s *groupServer) MuteGroupMember(ctx context.Context, req *pbGroup.MuteGrou
 	if err != nil {
 		return nil, err
 	}
-	if !authverify.IsAppManagerUid(ctx) {
-		opMember, err := s.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-		switch member.RoleLevel {
-		case constant.GroupOwner:
-			return nil, errs.ErrNoPermission.Wrap("set group owner mute")
-		case constant.GroupAdmin:
-			if opMember.RoleLevel != constant.GroupOwner {
-				return nil, errs.ErrNoPermission.Wrap("set group admin mute")
-			}
-		case constant.GroupOrdinaryUsers:
-			if !(opMember.RoleLevel == constant.GroupAdmin || opMember.RoleLevel == constant.GroupOwner) {
-				return nil, errs.ErrNoPermission.Wrap("set group ordinary users mute")
-			}
-		}
+	opMember, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionMute)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManageMember(opMember, member); err != nil {
+		return nil, err
 	}
 	data := UpdateGroupMemberMutedTimeMap(time.Now().Add(time.Second * time.Duration(req.MutedSeconds)))
 	if err := s.GroupDatabase.UpdateGroupMember(ctx, member.GroupID, member.UserID, data); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditMuteMember, []string{req.UserID}, groupMemberAuditValues(member, data), data)
 	s.Notification.GroupMemberMutedNotification(ctx, req.GroupID, req.UserID, req.MutedSeconds)
 	return resp, nil
 }
@@ -1183,52 +1309,54 @@ func (s *groupServer) CancelMuteGroupMember(ctx context.Context, req *pbGroup.Ca
 	if err != nil {
 		return nil, err
 	}
-	if !authverify.IsAppManagerUid(ctx) {
-		opMember, err := s.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-		switch member.RoleLevel {
-		case constant.GroupOwner:
-			return nil, errs.ErrNoPermission.Wrap("set group owner mute")
-		case constant.GroupAdmin:
-			if opMember.RoleLevel != constant.GroupOwner {
-				return nil, errs.ErrNoPermission.Wrap("set group admin mute")
-			}
-		case constant.GroupOrdinaryUsers:
-			if !(opMember.RoleLevel == constant.GroupAdmin || opMember.RoleLevel == constant.GroupOwner) {
-				return nil, errs.ErrNoPermission.Wrap("set group ordinary users mute")
-			}
-		}
+	opMember, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionMute)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManageMember(opMember, member); err != nil {
+		return nil, err
 	}
 	data := UpdateGroupMemberMutedTimeMap(time.Unix(0, 0))
 	if err := s.GroupDatabase.UpdateGroupMember(ctx, member.GroupID, member.UserID, data); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditCancelMuteMember, []string{req.UserID}, groupMemberAuditValues(member, data), data)
 	s.Notification.GroupMemberCancelMutedNotification(ctx, req.GroupID, req.UserID)
 	return resp, nil
 }
 
 func (s *groupServer) MuteGroup(ctx context.Context, req *pbGroup.MuteGroupReq) (*pbGroup.MuteGroupResp, error) {
 	resp := &pbGroup.MuteGroupResp{}
-	if err := s.CheckGroupAdmin(ctx, req.GroupID); err != nil {
+	if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionMute); err != nil {
 		return nil, err
 	}
-	if err := s.GroupDatabase.UpdateGroup(ctx, req.GroupID, UpdateGroupStatusMap(constant.GroupStatusMuted)); err != nil {
+	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
+	if err != nil {
 		return nil, err
 	}
+	data := UpdateGroupStatusMap(constant.GroupStatusMuted)
+	if err := s.GroupDatabase.UpdateGroup(ctx, req.GroupID, data); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditMuteGroup, nil, groupAuditValues(group, data), data)
 	s.Notification.GroupMutedNotification(ctx, req.GroupID)
 	return resp, nil
 }
 
 func (s *groupServer) CancelMuteGroup(ctx context.Context, req *pbGroup.CancelMuteGroupReq) (*pbGroup.CancelMuteGroupResp, error) {
 	resp := &pbGroup.CancelMuteGroupResp{}
-	if err := s.CheckGroupAdmin(ctx, req.GroupID); err != nil {
+	if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionMute); err != nil {
+		return nil, err
+	}
+	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
+	if err != nil {
 		return nil, err
 	}
-	if err := s.GroupDatabase.UpdateGroup(ctx, req.GroupID, UpdateGroupStatusMap(constant.GroupOk)); err != nil {
+	data := UpdateGroupStatusMap(constant.GroupOk)
+	if err := s.GroupDatabase.UpdateGroup(ctx, req.GroupID, data); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditCancelMuteGroup, nil, groupAuditValues(group, data), data)
 	s.Notification.GroupCancelMutedNotification(ctx, req.GroupID)
 	return resp, nil
 }
@@ -1343,6 +1471,17 @@ func (s *groupServer) SetGroupMemberInfo(ctx context.Context, req *pbGroup.SetGr
 		return nil, err
 	}
 	for _, member := range req.Members {
+		// 修改自己的群昵称等不是管理操作, 不记录
+		if member.UserID != mcontext.GetOpUserID(ctx) {
+			data := UpdateGroupMemberMap(member)
+			auditAction := unRelationTb.GroupAuditSetMemberInfo
+			if member.RoleLevel != nil {
+				auditAction = unRelationTb.GroupAuditSetMemberRole
+			}
+			s.audit(ctx, member.GroupID, auditAction, []string{member.UserID},
+				groupMemberAuditValues(memberMap[[...]string{member.GroupID, member.UserID}], data), data,
+			)
+		}
 		if member.RoleLevel != nil {
 			switch member.RoleLevel.Value {
 			case constant.GroupAdmin:
@@ -1397,7 +1536,7 @@ func (s *groupServer) GetUserInGroupMembers(ctx context.Context, req *pbGroup.Ge
 	if len(req.GroupIDs) == 0 {
 		return nil, errs.ErrArgs.Wrap("groupIDs empty")
 	}
-	members, err := s.FindGroupMember(ctx, []string{req.UserID}, req.GroupIDs, nil)
+	members, err := s.FindGroupMember(ctx, req.GroupIDs, []string{req.UserID}, nil)
 	if err != nil {
 		return nil, err
 	}
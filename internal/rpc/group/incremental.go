@@ -0,0 +1,147 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+// GetIncrementalJoinGroups 获取version之后已加入群列表的增删改, 群信息变更也记为更新.
+func (s *groupServer) GetIncrementalJoinGroups(ctx context.Context, req *groupext.GetIncrementalJoinGroupsReq) (*groupext.GetIncrementalJoinGroupsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	changes, err := s.versionLogDatabase.GetChanges(ctx, unRelationTb.VersionDomainJoinedGroup, req.UserID, req.Version, config.Config.VersionLog.SyncLimit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.GetIncrementalJoinGroupsResp{
+		Version:  changes.Version,
+		FullSync: changes.FullSync,
+		More:     changes.More,
+		Insert:   []*sdkws.GroupInfo{},
+		Update:   []*sdkws.GroupInfo{},
+		Delete:   changes.Delete,
+	}
+	if changes.FullSync || len(changes.Insert)+len(changes.Update) == 0 {
+		return resp, nil
+	}
+	groups, err := s.GroupDatabase.FindGroup(ctx, append(changes.Insert, changes.Update...))
+	if err != nil {
+		return nil, err
+	}
+	infoMap, err := s.groupInfoMap(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+	resp.Insert = utils.Filter(changes.Insert, func(groupID string) (*sdkws.GroupInfo, bool) {
+		info, ok := infoMap[groupID]
+		return info, ok
+	})
+	resp.Update = utils.Filter(changes.Update, func(groupID string) (*sdkws.GroupInfo, bool) {
+		info, ok := infoMap[groupID]
+		return info, ok
+	})
+	return resp, nil
+}
+
+// GetIncrementalGroupMembers 批量获取群成员列表的增删改, 版本号未变的群不返回.
+func (s *groupServer) GetIncrementalGroupMembers(ctx context.Context, req *groupext.GetIncrementalGroupMembersReq) (*groupext.GetIncrementalGroupMembersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	groupIDs := utils.Slice(req.Groups, func(e *groupext.GroupVersion) string { return e.GroupID })
+	// 已退出的群通过已加入群列表的删除同步, 这里忽略
+	members, err := s.GroupDatabase.FindGroupMember(ctx, groupIDs, []string{req.UserID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	joined := utils.SliceSetAny(members, func(e *relationTb.GroupMemberModel) string { return e.GroupID })
+	versions, err := s.versionLogDatabase.GetVersions(ctx, unRelationTb.VersionDomainGroupMember, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.GetIncrementalGroupMembersResp{Groups: []*groupext.GroupMemberChanges{}}
+	for _, group := range req.Groups {
+		if _, ok := joined[group.GroupID]; !ok {
+			continue
+		}
+		if version, ok := versions[group.GroupID]; ok && version == group.Version {
+			continue
+		}
+		changes, err := s.versionLogDatabase.GetChanges(ctx, unRelationTb.VersionDomainGroupMember, group.GroupID, group.Version, config.Config.VersionLog.SyncLimit)
+		if err != nil {
+			return nil, err
+		}
+		memberChanges := &groupext.GroupMemberChanges{
+			GroupID:  group.GroupID,
+			Version:  changes.Version,
+			FullSync: changes.FullSync,
+			More:     changes.More,
+			Insert:   []*sdkws.GroupMemberFullInfo{},
+			Update:   []*sdkws.GroupMemberFullInfo{},
+			Delete:   changes.Delete,
+		}
+		if !changes.FullSync {
+			if memberChanges.Insert, err = s.groupMembersInfo(ctx, group.GroupID, changes.Insert); err != nil {
+				return nil, err
+			}
+			if memberChanges.Update, err = s.groupMembersInfo(ctx, group.GroupID, changes.Update); err != nil {
+				return nil, err
+			}
+		}
+		resp.Groups = append(resp.Groups, memberChanges)
+	}
+	return resp, nil
+}
+
+// groupMembersInfo 获取群成员信息, 没有群昵称的使用用户昵称, 已不在群中的忽略.
+func (s *groupServer) groupMembersInfo(ctx context.Context, groupID string, userIDs []string) ([]*sdkws.GroupMemberFullInfo, error) {
+	if len(userIDs) == 0 {
+		return []*sdkws.GroupMemberFullInfo{}, nil
+	}
+	members, err := s.FindGroupMember(ctx, []string{groupID}, userIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	nameMap, err := s.GetUsernameMap(ctx, utils.Filter(members, func(e *relationTb.GroupMemberModel) (string, bool) {
+		return e.UserID, e.Nickname == ""
+	}), true)
+	if err != nil {
+		return nil, err
+	}
+	return utils.Slice(members, func(e *relationTb.GroupMemberModel) *sdkws.GroupMemberFullInfo {
+		if e.Nickname == "" {
+			e.Nickname = nameMap[e.UserID]
+		}
+		return convert.Db2PbGroupMember(e)
+	}), nil
+}
@@ -0,0 +1,293 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	pbGroup "github.com/OpenIMSDK/protocol/group"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+func genInviteCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", errs.Wrap(err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func groupInviteLinkDB2Ext(link *relationTb.GroupInviteLinkModel) *groupext.GroupInviteLink {
+	var expireTime int64
+	if link.ExpireTime.After(time.Unix(0, 0)) {
+		expireTime = link.ExpireTime.UnixMilli()
+	}
+	return &groupext.GroupInviteLink{
+		Code:          link.Code,
+		GroupID:       link.GroupID,
+		CreatorUserID: link.CreatorUserID,
+		MaxUses:       link.MaxUses,
+		UseCount:      link.UseCount,
+		JoinCount:     link.JoinCount,
+		ExpireTime:    expireTime,
+		AutoApprove:   link.AutoApprove,
+		RoleID:        link.RoleID,
+		Revoked:       link.Revoked,
+		CreateTime:    link.CreateTime.UnixMilli(),
+		Ex:            link.Ex,
+	}
+}
+
+// inviteLinkRoleID 链接的目标角色被删除后不再分配.
+func (s *groupServer) inviteLinkRoleID(ctx context.Context, link *relationTb.GroupInviteLinkModel) (string, error) {
+	if link.RoleID == "" {
+		return "", nil
+	}
+	roles, err := s.GroupDatabase.FindGroupRoles(ctx, link.GroupID)
+	if err != nil {
+		return "", err
+	}
+	for _, role := range roles {
+		if role.RoleID == link.RoleID {
+			return link.RoleID, nil
+		}
+	}
+	return "", nil
+}
+
+func (s *groupServer) CreateGroupInviteLink(ctx context.Context, req *groupext.CreateGroupInviteLinkReq) (*groupext.CreateGroupInviteLinkResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, errs.ErrDismissedAlready.Wrap()
+	}
+	opMember, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionInvite)
+	if err != nil {
+		return nil, err
+	}
+	if req.AutoApprove && group.NeedVerification != constant.Directly && opMember != nil {
+		if err := s.checkMemberPermission(ctx, opMember, relationTb.GroupPermissionApproveJoin); err != nil {
+			return nil, err
+		}
+	}
+	if req.RoleID != "" {
+		if err := s.checkGroupOwner(ctx, req.GroupID); err != nil {
+			return nil, err
+		}
+		if _, err := s.GroupDatabase.TakeGroupRole(ctx, req.GroupID, req.RoleID); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	expireTime := time.Unix(0, 0)
+	if req.ExpireTime > 0 {
+		expireTime = time.UnixMilli(req.ExpireTime)
+		if !expireTime.After(now) {
+			return nil, errs.ErrArgs.Wrap("expireTime is earlier than now")
+		}
+	}
+	code, err := genInviteCode()
+	if err != nil {
+		return nil, err
+	}
+	link := &relationTb.GroupInviteLinkModel{
+		Code:          code,
+		GroupID:       req.GroupID,
+		CreatorUserID: mcontext.GetOpUserID(ctx),
+		MaxUses:       req.MaxUses,
+		ExpireTime:    expireTime,
+		AutoApprove:   req.AutoApprove,
+		RoleID:        req.RoleID,
+		CreateTime:    now,
+		Ex:            req.Ex,
+	}
+	if err := s.GroupDatabase.CreateGroupInviteLink(ctx, link); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditCreateInviteLink, nil, nil, groupInviteLinkDB2Ext(link))
+	return &groupext.CreateGroupInviteLinkResp{Link: groupInviteLinkDB2Ext(link)}, nil
+}
+
+func (s *groupServer) GetGroupInviteLinks(ctx context.Context, req *groupext.GetGroupInviteLinksReq) (*groupext.GetGroupInviteLinksResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	opMember, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionInvite)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.GroupDatabase.FindGroupInviteLinks(ctx, req.GroupID, req.IncludeRevoked)
+	if err != nil {
+		return nil, err
+	}
+	// 没有审批权限的成员只能看到自己创建的链接
+	if opMember != nil && s.checkMemberPermission(ctx, opMember, relationTb.GroupPermissionApproveJoin) != nil {
+		links = utils.Filter(links, func(e *relationTb.GroupInviteLinkModel) (*relationTb.GroupInviteLinkModel, bool) {
+			return e, e.CreatorUserID == opMember.UserID
+		})
+	}
+	resp := &groupext.GetGroupInviteLinksResp{Links: utils.Slice(links, groupInviteLinkDB2Ext)}
+	for _, link := range links {
+		resp.TotalJoinCount += int64(link.JoinCount)
+	}
+	return resp, nil
+}
+
+func (s *groupServer) RevokeGroupInviteLinks(ctx context.Context, req *groupext.RevokeGroupInviteLinksReq) (*groupext.RevokeGroupInviteLinksResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	opMember, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionInvite)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.GroupDatabase.FindGroupInviteLinks(ctx, req.GroupID, true)
+	if err != nil {
+		return nil, err
+	}
+	linkMap := utils.SliceToMap(links, func(e *relationTb.GroupInviteLinkModel) string {
+		return e.Code
+	})
+	approver := opMember == nil || s.checkMemberPermission(ctx, opMember, relationTb.GroupPermissionApproveJoin) == nil
+	for _, code := range req.Codes {
+		link, ok := linkMap[code]
+		if !ok {
+			return nil, errs.ErrRecordNotFound.Wrap("invite link not found " + code)
+		}
+		if !approver && link.CreatorUserID != opMember.UserID {
+			return nil, errs.ErrNoPermission.Wrap("only the creator can revoke the invite link")
+		}
+	}
+	if err := s.GroupDatabase.RevokeGroupInviteLinks(ctx, req.GroupID, req.Codes); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditRevokeInviteLinks, nil, nil, map[string]any{"codes": req.Codes})
+	return &groupext.RevokeGroupInviteLinksResp{}, nil
+}
+
+func (s *groupServer) JoinGroupByInviteLink(ctx context.Context, req *groupext.JoinGroupByInviteLinkReq) (*groupext.JoinGroupByInviteLinkResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	link, err := s.GroupDatabase.TakeGroupInviteLink(ctx, req.Code)
+	if err != nil {
+		if s.IsNotFound(err) {
+			return nil, errcode.ErrGroupInviteLinkInvalid.Wrap("invite link not found")
+		}
+		return nil, err
+	}
+	if link.Revoked || link.Expired(time.Now()) || link.Exhausted() {
+		return nil, errcode.ErrGroupInviteLinkInvalid.Wrap()
+	}
+	group, err := s.GroupDatabase.TakeGroup(ctx, link.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, errs.ErrDismissedAlready.Wrap()
+	}
+	if group.GroupType == relationTb.ChannelGroup {
+		return nil, errs.ErrGroupTypeNotSupport.Wrap("subscribe to join a channel")
+	}
+	userID := mcontext.GetOpUserID(ctx)
+	if err := s.checkGroupBanned(ctx, link.GroupID, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GroupDatabase.TakeGroupMember(ctx, link.GroupID, userID); err == nil {
+		return nil, errs.ErrArgs.Wrap("already in group")
+	} else if !s.IsNotFound(err) {
+		return nil, err
+	}
+	user, err := s.User.GetUserInfo(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.JoinGroupByInviteLinkResp{GroupID: link.GroupID}
+	if link.AutoApprove || group.NeedVerification == constant.Directly {
+		member := convert.Pb2DbGroupMember(user)
+		member.Nickname = ""
+		member.GroupID = link.GroupID
+		member.RoleLevel = constant.GroupOrdinaryUsers
+		member.OperatorUserID = userID
+		member.JoinSource = relationTb.JoinByInviteLink
+		member.InviterUserID = link.CreatorUserID
+		member.JoinTime = time.Now()
+		member.MuteEndTime = time.Unix(0, 0)
+		member.RoleID, err = s.inviteLinkRoleID(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		if err := CallbackBeforeMemberJoinGroup(ctx, member, group.Ex); err != nil {
+			return nil, err
+		}
+		ok, err := s.GroupDatabase.JoinGroupByInviteLink(ctx, link.Code, member, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errcode.ErrGroupInviteLinkInvalid.Wrap()
+		}
+		if err := s.conversationRpcClient.GroupChatFirstCreateConversation(ctx, link.GroupID, []string{userID}); err != nil {
+			return nil, err
+		}
+		s.Notification.MemberEnterNotification(ctx, link.GroupID, userID)
+		if group.GroupType == relationTb.CommunityGroup {
+			s.communityMembersEntered(ctx, group.GroupID, []string{userID})
+		}
+		resp.Joined = true
+		return resp, nil
+	}
+	request := &relationTb.GroupRequestModel{
+		UserID:        userID,
+		GroupID:       link.GroupID,
+		ReqMsg:        req.ReqMessage,
+		JoinSource:    relationTb.JoinByInviteLink,
+		InviterUserID: link.CreatorUserID,
+		InviteCode:    link.Code,
+		ReqTime:       time.Now(),
+		HandledTime:   time.Unix(0, 0),
+	}
+	ok, err := s.GroupDatabase.JoinGroupByInviteLink(ctx, link.Code, nil, request)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errcode.ErrGroupInviteLinkInvalid.Wrap()
+	}
+	s.Notification.JoinGroupApplicationNotification(ctx, &pbGroup.JoinGroupReq{
+		GroupID:       link.GroupID,
+		ReqMessage:    req.ReqMessage,
+		JoinSource:    relationTb.JoinByInviteLink,
+		InviterUserID: userID,
+	})
+	return resp, nil
+}
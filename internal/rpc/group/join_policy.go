@@ -0,0 +1,249 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	pbGroup "github.com/OpenIMSDK/protocol/group"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+// checkGroupRequestExpired 未处理的入群申请超过群设置的requestExpire不能再处理.
+func (s *groupServer) checkGroupRequestExpired(ctx context.Context, groupRequest *relationTb.GroupRequestModel) error {
+	policy, err := s.joinPolicyDatabase.TakePolicy(ctx, groupRequest.GroupID)
+	if err != nil {
+		return err
+	}
+	if policy == nil || policy.RequestExpire <= 0 {
+		return nil
+	}
+	if time.Since(groupRequest.ReqTime) > time.Duration(policy.RequestExpire)*time.Second {
+		return errcode.ErrGroupRequestExpired.Wrap()
+	}
+	return nil
+}
+
+// evaluateJoinPolicy 按顺序匹配入群规则, 返回第一个命中规则的动作, 都不命中返回0.
+func (s *groupServer) evaluateJoinPolicy(
+	ctx context.Context,
+	policy *unRelationTb.GroupJoinPolicyModel,
+	user *sdkws.UserInfo,
+	answers []*unRelationTb.GroupEntryAnswerModel,
+) (int32, error) {
+	if policy == nil {
+		return 0, nil
+	}
+	for _, rule := range policy.Rules {
+		var matched bool
+		switch rule.Type {
+		case unRelationTb.GroupJoinRuleFriendOfAdmin:
+			var err error
+			matched, err = s.isFriendOfAdmin(ctx, policy.GroupID, user.UserID)
+			if err != nil {
+				return 0, err
+			}
+		case unRelationTb.GroupJoinRuleExMatch:
+			matched = matchUserEx(user.Ex, rule.Key, rule.Value)
+		case unRelationTb.GroupJoinRuleAnswerCorrect:
+			matched = len(policy.Questions) > 0 && !hasWrongAnswer(policy.Questions, answers)
+		case unRelationTb.GroupJoinRuleAnswerWrong:
+			matched = hasWrongAnswer(policy.Questions, answers)
+		}
+		if matched {
+			return rule.Action, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *groupServer) isFriendOfAdmin(ctx context.Context, groupID string, userID string) (bool, error) {
+	admins, err := s.GroupDatabase.FindGroupMember(ctx, []string{groupID}, nil, []int32{constant.GroupOwner, constant.GroupAdmin})
+	if err != nil {
+		return false, err
+	}
+	friendIDs, err := s.friendRpcClient.GetFriendIDs(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, admin := range admins {
+		if utils.IsContain(admin.UserID, friendIDs) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matchUserEx(ex string, key string, value string) bool {
+	var m map[string]any
+	if err := json.Unmarshal([]byte(ex), &m); err != nil {
+		return false
+	}
+	v, ok := m[key]
+	return ok && fmt.Sprint(v) == value
+}
+
+// hasWrongAnswer 未回答或回答不是期望的答案之一都视为回答错误.
+func hasWrongAnswer(questions []*unRelationTb.GroupEntryQuestionModel, answers []*unRelationTb.GroupEntryAnswerModel) bool {
+	answerMap := make(map[string]string)
+	for _, answer := range answers {
+		answerMap[answer.QuestionID] = strings.TrimSpace(answer.Answer)
+	}
+	for _, question := range questions {
+		answer := answerMap[question.QuestionID]
+		if answer == "" {
+			return true
+		}
+		if len(question.Answers) == 0 {
+			continue
+		}
+		var correct bool
+		for _, expected := range question.Answers {
+			if strings.EqualFold(strings.TrimSpace(expected), answer) {
+				correct = true
+				break
+			}
+		}
+		if !correct {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *groupServer) SetGroupJoinPolicy(ctx context.Context, req *groupext.SetGroupJoinPolicyReq) (*groupext.SetGroupJoinPolicyResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionApproveJoin); err != nil {
+		return nil, err
+	}
+	if len(req.Questions) == 0 && len(req.Rules) == 0 && req.RequestExpire == 0 {
+		if err := s.joinPolicyDatabase.DeletePolicy(ctx, req.GroupID); err != nil {
+			return nil, err
+		}
+		s.audit(ctx, req.GroupID, unRelationTb.GroupAuditSetJoinPolicy, nil, nil, nil)
+		return &groupext.SetGroupJoinPolicyResp{}, nil
+	}
+	policy := &unRelationTb.GroupJoinPolicyModel{
+		GroupID: req.GroupID,
+		Questions: utils.Slice(req.Questions, func(e *groupext.GroupEntryQuestion) *unRelationTb.GroupEntryQuestionModel {
+			return &unRelationTb.GroupEntryQuestionModel{QuestionID: e.QuestionID, Question: e.Question, Answers: e.Answers}
+		}),
+		Rules: utils.Slice(req.Rules, func(e *groupext.GroupJoinRule) *unRelationTb.GroupJoinRuleModel {
+			return &unRelationTb.GroupJoinRuleModel{Type: e.Type, Action: e.Action, Key: e.Key, Value: e.Value}
+		}),
+		RequestExpire: req.RequestExpire,
+		OpUserID:      mcontext.GetOpUserID(ctx),
+		UpdateTime:    time.Now(),
+	}
+	if err := s.joinPolicyDatabase.SetPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditSetJoinPolicy, nil, nil, req)
+	return &groupext.SetGroupJoinPolicyResp{}, nil
+}
+
+func (s *groupServer) GetGroupJoinPolicy(ctx context.Context, req *groupext.GetGroupJoinPolicyReq) (*groupext.GetGroupJoinPolicyResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	policy, err := s.joinPolicyDatabase.TakePolicy(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return &groupext.GetGroupJoinPolicyResp{}, nil
+	}
+	// 申请人只能看到问题
+	_, err = s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionApproveJoin)
+	approver := err == nil
+	resp := &groupext.GetGroupJoinPolicyResp{Policy: &groupext.GroupJoinPolicy{
+		GroupID: policy.GroupID,
+		Questions: utils.Slice(policy.Questions, func(e *unRelationTb.GroupEntryQuestionModel) *groupext.GroupEntryQuestion {
+			question := &groupext.GroupEntryQuestion{QuestionID: e.QuestionID, Question: e.Question}
+			if approver {
+				question.Answers = e.Answers
+			}
+			return question
+		}),
+		RequestExpire: policy.RequestExpire,
+		UpdateTime:    policy.UpdateTime.UnixMilli(),
+	}}
+	if approver {
+		resp.Policy.Rules = utils.Slice(policy.Rules, func(e *unRelationTb.GroupJoinRuleModel) *groupext.GroupJoinRule {
+			return &groupext.GroupJoinRule{Type: e.Type, Action: e.Action, Key: e.Key, Value: e.Value}
+		})
+		resp.Policy.OpUserID = policy.OpUserID
+	}
+	return resp, nil
+}
+
+func (s *groupServer) JoinGroupWithAnswers(ctx context.Context, req *groupext.JoinGroupWithAnswersReq) (*groupext.JoinGroupWithAnswersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	joined, err := s.joinGroup(ctx, &pbGroup.JoinGroupReq{
+		GroupID:       req.GroupID,
+		ReqMessage:    req.ReqMessage,
+		JoinSource:    req.JoinSource,
+		InviterUserID: mcontext.GetOpUserID(ctx),
+	}, utils.Slice(req.Answers, func(e *groupext.GroupEntryAnswer) *unRelationTb.GroupEntryAnswerModel {
+		return &unRelationTb.GroupEntryAnswerModel{QuestionID: e.QuestionID, Answer: e.Answer}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.JoinGroupWithAnswersResp{Joined: joined}, nil
+}
+
+func (s *groupServer) GetGroupJoinAnswers(ctx context.Context, req *groupext.GetGroupJoinAnswersReq) (*groupext.GetGroupJoinAnswersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	// 申请人可以查看自己的回答
+	if len(req.UserIDs) != 1 || req.UserIDs[0] != mcontext.GetOpUserID(ctx) {
+		if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionApproveJoin); err != nil {
+			return nil, err
+		}
+	}
+	answers, err := s.joinPolicyDatabase.FindAnswers(ctx, req.GroupID, req.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupJoinAnswersResp{
+		Answers: utils.Slice(answers, func(e *unRelationTb.GroupJoinAnswerModel) *groupext.GroupJoinAnswer {
+			return &groupext.GroupJoinAnswer{
+				UserID: e.UserID,
+				Answers: utils.Slice(e.Answers, func(a *unRelationTb.GroupEntryAnswerModel) *groupext.GroupEntryAnswer {
+					return &groupext.GroupEntryAnswer{QuestionID: a.QuestionID, Answer: a.Answer}
+				}),
+				CreateTime: e.CreateTime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
@@ -0,0 +1,109 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+func encodeMemberCursor(member *relationTb.GroupMemberModel) string {
+	data, _ := json.Marshal(&relationTb.GroupMemberCursor{
+		RoleLevel: member.RoleLevel,
+		JoinTime:  member.JoinTime,
+		UserID:    member.UserID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeMemberCursor(cursor string) (*relationTb.GroupMemberCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errs.ErrArgs.Wrap("cursor is invalid")
+	}
+	var c relationTb.GroupMemberCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errs.ErrArgs.Wrap("cursor is invalid")
+	}
+	return &c, nil
+}
+
+func (s *groupServer) SearchGroupMembers(ctx context.Context, req *groupext.SearchGroupMembersReq) (*groupext.SearchGroupMembersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if !authverify.IsAppManagerUid(ctx) {
+		if _, err := s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	cursor, err := decodeMemberCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	filter := &relationTb.GroupMemberSearchFilter{
+		Keyword:       req.Keyword,
+		KeywordPrefix: req.KeywordPrefix,
+		RoleLevels:    req.RoleLevels,
+		Muted:         req.Muted,
+		JoinSources:   req.JoinSources,
+		Sort:          req.Sort,
+	}
+	if req.JoinTimeBegin > 0 {
+		filter.JoinTimeBegin = time.UnixMilli(req.JoinTimeBegin)
+	}
+	if req.JoinTimeEnd > 0 {
+		filter.JoinTimeEnd = time.UnixMilli(req.JoinTimeEnd)
+	}
+	// 多查一个判断是否还有下一页
+	total, members, err := s.GroupDatabase.SearchGroupMemberByCursor(ctx, req.GroupID, filter, cursor, int(req.Count)+1)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.SearchGroupMembersResp{Total: total}
+	if len(members) > int(req.Count) {
+		members = members[:req.Count]
+		resp.NextCursor = encodeMemberCursor(members[len(members)-1])
+	}
+	if err := s.fillGroupMembers(ctx, members); err != nil {
+		return nil, err
+	}
+	roles, err := s.GroupDatabase.FindGroupRoles(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Members = utils.Slice(members, func(e *relationTb.GroupMemberModel) *groupext.GroupMemberInfo {
+		return &groupext.GroupMemberInfo{
+			GroupMemberFullInfo: convert.Db2PbGroupMember(e),
+			RoleID:              e.RoleID,
+			Permissions:         memberPermissions(e, roles),
+		}
+	})
+	return resp, nil
+}
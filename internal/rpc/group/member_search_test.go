@@ -0,0 +1,68 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+func Test_MemberCursor(t *testing.T) {
+	joinTime := time.UnixMilli(1685600000123).UTC()
+	tests := []struct {
+		name   string
+		member *relationTb.GroupMemberModel
+	}{
+		{"owner", &relationTb.GroupMemberModel{RoleLevel: 100, JoinTime: joinTime, UserID: "owner"}},
+		{"ordinary", &relationTb.GroupMemberModel{RoleLevel: 20, JoinTime: joinTime, UserID: "user_1"}},
+		{"special user id", &relationTb.GroupMemberModel{RoleLevel: 20, JoinTime: joinTime, UserID: "a+b/c=?%"}},
+		{"zero join time", &relationTb.GroupMemberModel{RoleLevel: 60, UserID: "admin"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cursor, err := decodeMemberCursor(encodeMemberCursor(test.member))
+			assert.Nil(t, err)
+			assert.Equal(t, test.member.RoleLevel, cursor.RoleLevel)
+			assert.True(t, test.member.JoinTime.Equal(cursor.JoinTime))
+			assert.Equal(t, test.member.UserID, cursor.UserID)
+		})
+	}
+}
+
+func Test_DecodeMemberCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+		isNil  bool
+		hasErr bool
+	}{
+		{"first page", "", true, false},
+		{"not base64", "!!!", true, true},
+		{"not json", "bm90IGpzb24", true, true},
+		{"std base64 padding", "e30=", true, true},
+		{"empty object", "e30", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cursor, err := decodeMemberCursor(test.cursor)
+			assert.Equal(t, test.hasErr, err != nil)
+			assert.Equal(t, test.isNil, cursor == nil)
+		})
+	}
+}
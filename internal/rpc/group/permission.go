@@ -0,0 +1,97 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/mcontext"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+// getMemberPermissions 获取成员生效的权限.
+func (s *groupServer) getMemberPermissions(ctx context.Context, member *relationTb.GroupMemberModel) (int64, error) {
+	if member.RoleLevel == constant.GroupOwner || member.RoleID == "" {
+		return relationTb.DefaultGroupPermissions(member.RoleLevel), nil
+	}
+	roles, err := s.GroupDatabase.FindGroupRoles(ctx, member.GroupID)
+	if err != nil {
+		return 0, err
+	}
+	return memberPermissions(member, roles), nil
+}
+
+// memberPermissions 群主始终拥有全部权限, 未设置角色的成员使用默认权限.
+func memberPermissions(member *relationTb.GroupMemberModel, roles []*relationTb.GroupRoleModel) int64 {
+	if member.RoleLevel != constant.GroupOwner && member.RoleID != "" {
+		for _, role := range roles {
+			if role.RoleID == member.RoleID {
+				return role.Permissions
+			}
+		}
+	}
+	return relationTb.DefaultGroupPermissions(member.RoleLevel)
+}
+
+func (s *groupServer) checkMemberPermission(ctx context.Context, member *relationTb.GroupMemberModel, permission int64) error {
+	permissions, err := s.getMemberPermissions(ctx, member)
+	if err != nil {
+		return err
+	}
+	if permissions&permission != permission {
+		return errs.ErrNoPermission.Wrap(fmt.Sprintf("group permission %d required", permission))
+	}
+	return nil
+}
+
+// checkPermission 校验操作者在群内拥有指定权限, app管理员返回nil.
+func (s *groupServer) checkPermission(ctx context.Context, groupID string, permission int64) (*relationTb.GroupMemberModel, error) {
+	if authverify.IsAppManagerUid(ctx) {
+		return nil, nil
+	}
+	opMember, err := s.TakeGroupMember(ctx, groupID, mcontext.GetOpUserID(ctx))
+	if err != nil {
+		if s.IsNotFound(err) {
+			return nil, errs.ErrNoPermission.Wrap("not in group")
+		}
+		return nil, err
+	}
+	if err := s.checkMemberPermission(ctx, opMember, permission); err != nil {
+		return nil, err
+	}
+	return opMember, nil
+}
+
+// checkManageMember 群主不能被管理, 非群主只能管理其他普通成员. opMember为nil表示app管理员.
+func checkManageMember(opMember *relationTb.GroupMemberModel, member *relationTb.GroupMemberModel) error {
+	if opMember == nil {
+		return nil
+	}
+	switch {
+	case member.RoleLevel == constant.GroupOwner:
+		return errs.ErrNoPermission.Wrap("group owner cannot be managed")
+	case opMember.RoleLevel == constant.GroupOwner:
+		return nil
+	case member.RoleLevel == constant.GroupOrdinaryUsers && member.UserID != opMember.UserID:
+		return nil
+	default:
+		return errs.ErrNoPermission.Wrap("only ordinary members can be managed")
+	}
+}
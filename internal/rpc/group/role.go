@@ -0,0 +1,211 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+func groupRoleDB2Ext(role *relationTb.GroupRoleModel) *groupext.GroupRole {
+	return &groupext.GroupRole{
+		GroupID:     role.GroupID,
+		RoleID:      role.RoleID,
+		Name:        role.Name,
+		Permissions: role.Permissions,
+		CreateTime:  role.CreateTime.UnixMilli(),
+		Ex:          role.Ex,
+	}
+}
+
+// checkGroupOwner 只有群主和app管理员可以管理群角色.
+func (s *groupServer) checkGroupOwner(ctx context.Context, groupID string) error {
+	if authverify.IsAppManagerUid(ctx) {
+		return nil
+	}
+	owner, err := s.GroupDatabase.TakeGroupOwner(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if owner.UserID != mcontext.GetOpUserID(ctx) {
+		return errs.ErrNoPermission.Wrap("only group owner can manage roles")
+	}
+	return nil
+}
+
+func (s *groupServer) CreateGroupRole(ctx context.Context, req *groupext.CreateGroupRoleReq) (*groupext.CreateGroupRoleResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupOwner(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	role := &relationTb.GroupRoleModel{
+		GroupID:     req.GroupID,
+		RoleID:      utils.OperationIDGenerator(),
+		Name:        req.Name,
+		Permissions: req.Permissions,
+		CreateTime:  time.Now(),
+		Ex:          req.Ex,
+	}
+	if err := s.GroupDatabase.CreateGroupRole(ctx, role); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditCreateRole, nil, nil, groupRoleDB2Ext(role))
+	return &groupext.CreateGroupRoleResp{RoleID: role.RoleID}, nil
+}
+
+func (s *groupServer) SetGroupRole(ctx context.Context, req *groupext.SetGroupRoleReq) (*groupext.SetGroupRoleResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupOwner(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	role, err := s.GroupDatabase.TakeGroupRole(ctx, req.GroupID, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]any)
+	if req.Name != nil {
+		data["name"] = *req.Name
+	}
+	if req.Permissions != nil {
+		data["permissions"] = *req.Permissions
+	}
+	if req.Ex != nil {
+		data["ex"] = *req.Ex
+	}
+	if len(data) > 0 {
+		if err := s.GroupDatabase.UpdateGroupRole(ctx, req.GroupID, req.RoleID, data); err != nil {
+			return nil, err
+		}
+		s.audit(ctx, req.GroupID, unRelationTb.GroupAuditSetRole, nil, groupRoleDB2Ext(role), data)
+	}
+	return &groupext.SetGroupRoleResp{}, nil
+}
+
+func (s *groupServer) DeleteGroupRole(ctx context.Context, req *groupext.DeleteGroupRoleReq) (*groupext.DeleteGroupRoleResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupOwner(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	role, err := s.GroupDatabase.TakeGroupRole(ctx, req.GroupID, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.GroupDatabase.DeleteGroupRole(ctx, req.GroupID, req.RoleID); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditDeleteRole, nil, groupRoleDB2Ext(role), nil)
+	return &groupext.DeleteGroupRoleResp{}, nil
+}
+
+func (s *groupServer) GetGroupRoles(ctx context.Context, req *groupext.GetGroupRolesReq) (*groupext.GetGroupRolesResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if !authverify.IsAppManagerUid(ctx) {
+		if _, err := s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	roles, err := s.GroupDatabase.FindGroupRoles(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupRolesResp{Roles: utils.Slice(roles, groupRoleDB2Ext)}, nil
+}
+
+func (s *groupServer) SetGroupMembersRole(ctx context.Context, req *groupext.SetGroupMembersRoleReq) (*groupext.SetGroupMembersRoleResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if utils.Duplicate(req.UserIDs) {
+		return nil, errs.ErrArgs.Wrap("userIDs duplicate")
+	}
+	if err := s.checkGroupOwner(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	if req.RoleID != "" {
+		if _, err := s.GroupDatabase.TakeGroupRole(ctx, req.GroupID, req.RoleID); err != nil {
+			return nil, err
+		}
+	}
+	members, err := s.GroupDatabase.FindGroupMember(ctx, []string{req.GroupID}, req.UserIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) != len(req.UserIDs) {
+		return nil, errs.ErrNotInGroupYet.Wrap("user not in group")
+	}
+	for _, member := range members {
+		if member.RoleLevel == constant.GroupOwner {
+			return nil, errs.ErrNoPermission.Wrap("cannot set role of group owner")
+		}
+	}
+	if err := s.GroupDatabase.SetGroupMembersRole(ctx, req.GroupID, req.UserIDs, req.RoleID); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditSetMembersRoleID, req.UserIDs,
+		utils.SliceToMapAny(members, func(e *relationTb.GroupMemberModel) (string, string) { return e.UserID, e.RoleID }),
+		map[string]any{"role_id": req.RoleID},
+	)
+	for _, userID := range req.UserIDs {
+		s.Notification.GroupMemberInfoSetNotification(ctx, req.GroupID, userID)
+	}
+	return &groupext.SetGroupMembersRoleResp{}, nil
+}
+
+func (s *groupServer) GetGroupMembersExtInfo(ctx context.Context, req *groupext.GetGroupMembersExtInfoReq) (*groupext.GetGroupMembersExtInfoResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if !authverify.IsAppManagerUid(ctx) {
+		if _, err := s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	members, err := s.GroupDatabase.FindGroupMember(ctx, []string{req.GroupID}, req.UserIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.GroupDatabase.FindGroupRoles(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupMembersExtInfoResp{
+		Members: utils.Slice(members, func(e *relationTb.GroupMemberModel) *groupext.GroupMemberInfo {
+			return &groupext.GroupMemberInfo{
+				GroupMemberFullInfo: convert.Db2PbGroupMember(e),
+				RoleID:              e.RoleID,
+				Permissions:         memberPermissions(e, roles),
+			}
+		}),
+	}, nil
+}
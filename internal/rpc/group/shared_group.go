@@ -0,0 +1,101 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+// GetSharedGroupCounts 统计和userID同在一个未解散群的用户, 用于好友推荐.
+func (s *groupServer) GetSharedGroupCounts(ctx context.Context, req *groupext.GetSharedGroupCountsReq) (*groupext.GetSharedGroupCountsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	groupIDs, err := s.GroupDatabase.FindJoinedGroupIDs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.GetSharedGroupCountsResp{Counts: make(map[string]int32)}
+	if len(groupIDs) == 0 {
+		return resp, nil
+	}
+	groups, err := s.GroupDatabase.FindNotDismissedGroup(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if req.MaxMemberCount > 0 {
+			num, err := s.GroupDatabase.FindGroupMemberNum(ctx, group.GroupID)
+			if err != nil {
+				return nil, err
+			}
+			if num > uint32(req.MaxMemberCount) {
+				continue
+			}
+		}
+		userIDs, err := s.GroupDatabase.FindGroupMemberUserID(ctx, group.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range userIDs {
+			if userID != req.UserID {
+				resp.Counts[userID]++
+			}
+		}
+	}
+	return resp, nil
+}
+
+// GetSharedGroupIDs 取两个用户已加入群的交集, 只返回未解散的群.
+func (s *groupServer) GetSharedGroupIDs(ctx context.Context, req *groupext.GetSharedGroupIDsReq) (*groupext.GetSharedGroupIDsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	groupIDs, err := s.GroupDatabase.FindJoinedGroupIDs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.GetSharedGroupIDsResp{GroupIDs: []string{}}
+	if len(groupIDs) == 0 {
+		return resp, nil
+	}
+	otherGroupIDs, err := s.GroupDatabase.FindJoinedGroupIDs(ctx, req.OtherUserID)
+	if err != nil {
+		return nil, err
+	}
+	sharedGroupIDs := utils.IntersectString(groupIDs, otherGroupIDs)
+	if len(sharedGroupIDs) == 0 {
+		return resp, nil
+	}
+	groups, err := s.GroupDatabase.FindNotDismissedGroup(ctx, sharedGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		resp.GroupIDs = append(resp.GroupIDs, group.GroupID)
+	}
+	return resp, nil
+}
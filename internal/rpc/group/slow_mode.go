@@ -0,0 +1,57 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+func (s *groupServer) SetGroupSlowMode(ctx context.Context, req *groupext.SetGroupSlowModeReq) (*groupext.SetGroupSlowModeResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkPermission(ctx, req.GroupID, relationTb.GroupPermissionEditInfo); err != nil {
+		return nil, err
+	}
+	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.SlowMode == req.Seconds {
+		return &groupext.SetGroupSlowModeResp{}, nil
+	}
+	data := map[string]any{"slow_mode": req.Seconds}
+	if err := s.GroupDatabase.UpdateGroup(ctx, req.GroupID, data); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, req.GroupID, unRelationTb.GroupAuditSetSlowMode, nil, groupAuditValues(group, data), data)
+	s.Notification.GroupSlowModeSetNotification(ctx, req.GroupID, req.Seconds)
+	return &groupext.SetGroupSlowModeResp{}, nil
+}
+
+func (s *groupServer) GetGroupSlowMode(ctx context.Context, req *groupext.GetGroupSlowModeReq) (*groupext.GetGroupSlowModeResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupSlowModeResp{Seconds: group.SlowMode}, nil
+}
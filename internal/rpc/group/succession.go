@@ -0,0 +1,149 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+// 群主继任策略.
+const (
+	successionPolicyAdminThenMember = "adminThenMember"
+	successionPolicyAdminOnly       = "adminOnly"
+)
+
+// findOwnerSuccessor 按继任策略选择入群最早的管理员, 其次是入群最早的普通成员, 没有继任者返回nil.
+func (s *groupServer) findOwnerSuccessor(ctx context.Context, groupID string) (*relationTb.GroupMemberModel, error) {
+	roleLevels := []int32{constant.GroupAdmin}
+	if config.Config.GroupOwnerSuccession.Policy != successionPolicyAdminOnly {
+		roleLevels = append(roleLevels, constant.GroupOrdinaryUsers)
+	}
+	for _, roleLevel := range roleLevels {
+		filter := &relationTb.GroupMemberSearchFilter{
+			RoleLevels: []int32{roleLevel},
+			Sort:       relationTb.GroupMemberSortJoinTimeAsc,
+		}
+		_, members, err := s.GroupDatabase.SearchGroupMemberByCursor(ctx, groupID, filter, nil, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) > 0 {
+			return members[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// succeedGroupOwner 原群主降为普通成员, 以系统为操作者通知群成员.
+func (s *groupServer) succeedGroupOwner(ctx context.Context, groupID string, oldOwnerUserID, newOwnerUserID string) error {
+	if err := s.GroupDatabase.TransferGroupOwner(ctx, groupID, oldOwnerUserID, newOwnerUserID, constant.GroupOrdinaryUsers); err != nil {
+		return err
+	}
+	s.audit(ctx, groupID, unRelationTb.GroupAuditSucceedOwner, []string{oldOwnerUserID, newOwnerUserID},
+		map[string]any{"owner_user_id": oldOwnerUserID},
+		map[string]any{"owner_user_id": newOwnerUserID},
+	)
+	s.Notification.GroupOwnerSucceededNotification(ctx, groupID, newOwnerUserID)
+	return nil
+}
+
+func (s *groupServer) ReassignGroupOwner(ctx context.Context, req *groupext.ReassignGroupOwnerReq) (*groupext.ReassignGroupOwnerResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	group, err := s.GroupDatabase.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, errs.ErrDismissedAlready.Wrap()
+	}
+	owner, err := s.GroupDatabase.TakeGroupOwner(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	var newOwner *relationTb.GroupMemberModel
+	if req.NewOwnerUserID == "" {
+		newOwner, err = s.findOwnerSuccessor(ctx, req.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if newOwner == nil {
+			return nil, errs.ErrRecordNotFound.Wrap("no successor in group " + req.GroupID)
+		}
+	} else {
+		if req.NewOwnerUserID == owner.UserID {
+			return nil, errs.ErrArgs.Wrap("newOwnerUserID is already the group owner")
+		}
+		newOwner, err = s.GroupDatabase.TakeGroupMember(ctx, req.GroupID, req.NewOwnerUserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.succeedGroupOwner(ctx, req.GroupID, owner.UserID, newOwner.UserID); err != nil {
+		return nil, err
+	}
+	return &groupext.ReassignGroupOwnerResp{NewOwnerUserID: newOwner.UserID}, nil
+}
+
+func (s *groupServer) SucceedUserOwnedGroups(ctx context.Context, req *groupext.SucceedUserOwnedGroupsReq) (*groupext.SucceedUserOwnedGroupsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	owners, err := s.GroupDatabase.FindGroupMember(ctx, nil, []string{req.UserID}, []int32{constant.GroupOwner})
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.SucceedUserOwnedGroupsResp{NoSuccessorGroupIDs: []string{}}
+	if len(owners) == 0 {
+		return resp, nil
+	}
+	groups, err := s.GroupDatabase.FindNotDismissedGroup(ctx, utils.Slice(owners, func(e *relationTb.GroupMemberModel) string { return e.GroupID }))
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		newOwner, err := s.findOwnerSuccessor(ctx, group.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if newOwner == nil {
+			log.ZInfo(ctx, "no successor for group owner", "groupID", group.GroupID, "userID", req.UserID)
+			resp.NoSuccessorGroupIDs = append(resp.NoSuccessorGroupIDs, group.GroupID)
+			continue
+		}
+		if err := s.succeedGroupOwner(ctx, group.GroupID, req.UserID, newOwner.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
@@ -76,6 +76,9 @@ func (m *msgServer) SetConversationHasReadSeq(
 	if err := m.MsgDatabase.SetHasReadSeq(ctx, req.UserID, req.ConversationID, req.HasReadSeq); err != nil {
 		return nil, err
 	}
+	if err := m.MsgDatabase.BurnMsgsAfterRead(ctx, req.UserID, req.ConversationID, req.HasReadSeq); err != nil {
+		return nil, err
+	}
 	if err = m.sendMarkAsReadNotification(ctx, req.ConversationID, constant.SingleChatType, req.UserID, req.UserID, nil, req.HasReadSeq); err != nil {
 		return
 	}
@@ -114,6 +117,9 @@ func (m *msgServer) MarkMsgsAsRead(
 			return
 		}
 	}
+	if err = m.MsgDatabase.BurnMsgsAfterReadBySeqs(ctx, req.UserID, req.ConversationID, req.Seqs); err != nil {
+		return
+	}
 	if err = m.sendMarkAsReadNotification(ctx, req.ConversationID, conversation.ConversationType, req.UserID, m.conversationAndGetRecvID(conversation, req.UserID), req.Seqs, hasReadSeq); err != nil {
 		return
 	}
@@ -154,6 +160,12 @@ func (m *msgServer) MarkConversationAsRead(
 		}
 		hasReadSeq = req.HasReadSeq
 	}
+	if err = m.MsgDatabase.BurnMsgsAfterRead(ctx, req.UserID, req.ConversationID, hasReadSeq); err != nil {
+		return
+	}
+	if err = m.MsgDatabase.BurnMsgsAfterReadBySeqs(ctx, req.UserID, req.ConversationID, seqs); err != nil {
+		return
+	}
 	if err = m.sendMarkAsReadNotification(ctx, req.ConversationID, conversation.ConversationType, req.UserID, m.conversationAndGetRecvID(conversation, req.UserID), seqs, hasReadSeq); err != nil {
 		return
 	}
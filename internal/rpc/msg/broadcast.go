@@ -0,0 +1,104 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+)
+
+func (m *msgServer) CreateBroadcast(ctx context.Context, req *msgext.CreateBroadcastReq) (*msgext.CreateBroadcastResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	msgData, err := proto.Marshal(req.MsgData)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	segment := convert.BroadcastSegmentPb2DB(req.Segment)
+	job := &unRelationTb.BroadcastJobModel{
+		JobID:      utils.OperationIDGenerator(),
+		OpUserID:   mcontext.GetOpUserID(ctx),
+		Segment:    segment,
+		MsgData:    msgData,
+		Rate:       req.Rate,
+		Status:     unRelationTb.BroadcastStatusPending,
+		CreateTime: time.Now(),
+		UpdateTime: time.Now(),
+	}
+	if segment.Type == unRelationTb.BroadcastSegmentUserIDs {
+		// users are sent in user id order so that the cursor can resume the job
+		job.Segment.UserIDs = utils.Distinct(segment.UserIDs)
+		sort.Strings(job.Segment.UserIDs)
+		job.Total = int64(len(job.Segment.UserIDs))
+	}
+	if err := m.BroadcastDatabase.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return &msgext.CreateBroadcastResp{JobID: job.JobID}, nil
+}
+
+func (m *msgServer) GetBroadcast(ctx context.Context, req *msgext.GetBroadcastReq) (*msgext.GetBroadcastResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	job, err := m.BroadcastDatabase.TakeJob(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &msgext.GetBroadcastResp{Job: convert.BroadcastJobDB2Pb(job)}
+	resp.Job.Segment.UserIDs = nil
+	return resp, nil
+}
+
+func (m *msgServer) GetBroadcasts(ctx context.Context, req *msgext.GetBroadcastsReq) (*msgext.GetBroadcastsResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	total, jobs, err := m.BroadcastDatabase.PageJobs(ctx, req.Status, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &msgext.GetBroadcastsResp{Total: total, Jobs: utils.Slice(jobs, convert.BroadcastJobDB2Pb)}, nil
+}
+
+func (m *msgServer) CancelBroadcast(ctx context.Context, req *msgext.CancelBroadcastReq) (*msgext.CancelBroadcastResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ok, err := m.BroadcastDatabase.CancelJob(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if _, err := m.BroadcastDatabase.TakeJob(ctx, req.JobID); err != nil {
+			return nil, err
+		}
+		return nil, errs.ErrArgs.Wrap("broadcast job has already stopped")
+	}
+	return &msgext.CancelBroadcastResp{}, nil
+}
@@ -16,14 +16,20 @@ package msg
 
 import (
 	"context"
+	"time"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/conversation"
 	"github.com/OpenIMSDK/protocol/msg"
 	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
 	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/OpenIMSDK/tools/utils"
 )
 
@@ -195,3 +201,59 @@ func (m *msgServer) clearConversation(
 	}
 	return nil
 }
+
+// DeleteUserGroupMsgs 管理员删除某个用户最近在群里发送的消息, 并通知群成员.
+func (m *msgServer) DeleteUserGroupMsgs(ctx context.Context, req *msgext.DeleteUserGroupMsgsReq) (*msgext.DeleteUserGroupMsgsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	opUserID := mcontext.GetOpUserID(ctx)
+	if !authverify.IsAppManagerUid(ctx) {
+		if err := m.checkDeleteMemberMsgs(ctx, req.GroupID, opUserID, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+	conversationID := msgprocessor.GetConversationIDBySessionType(constant.SuperGroupChatType, req.GroupID)
+	seqs, err := m.MsgDatabase.FindUserMsgSeqsSince(ctx, conversationID, req.UserID, time.UnixMilli(req.Since))
+	if err != nil {
+		return nil, err
+	}
+	if len(seqs) == 0 {
+		return &msgext.DeleteUserGroupMsgsResp{Seqs: []int64{}}, nil
+	}
+	if err := m.MsgDatabase.DeleteMsgsPhysicalBySeqs(ctx, conversationID, seqs); err != nil {
+		return nil, err
+	}
+	tips := &sdkws.DeleteMsgsTips{UserID: opUserID, ConversationID: conversationID, Seqs: seqs}
+	m.notificationSender.NotificationWithSesstionType(ctx, opUserID, req.GroupID, constant.DeleteMsgsNotification, constant.SuperGroupChatType, tips)
+	return &msgext.DeleteUserGroupMsgsResp{Seqs: seqs}, nil
+}
+
+// checkDeleteMemberMsgs 操作者需要踢人权限, 且与踢人一样群主不能被管理, 非群主只能管理其他普通成员.
+// 已不在群内的用户没有群内角色, 只校验权限.
+func (m *msgServer) checkDeleteMemberMsgs(ctx context.Context, groupID string, opUserID string, userID string) error {
+	opMember, err := m.Group.GetGroupMemberExtInfo(ctx, groupID, opUserID)
+	if err != nil {
+		return err
+	}
+	if opMember.Permissions&relation.GroupPermissionKick == 0 {
+		return errs.ErrNoPermission.Wrap("no permission to delete member messages")
+	}
+	member, err := m.Group.GetGroupMemberExtInfo(ctx, groupID, userID)
+	if err != nil {
+		if errs.ErrNotInGroupYet.Is(err) {
+			return nil
+		}
+		return err
+	}
+	switch {
+	case member.RoleLevel == constant.GroupOwner:
+		return errs.ErrNoPermission.Wrap("group owner cannot be managed")
+	case opMember.RoleLevel == constant.GroupOwner:
+		return nil
+	case member.RoleLevel == constant.GroupOrdinaryUsers && member.UserID != opMember.UserID:
+		return nil
+	default:
+		return errs.ErrNoPermission.Wrap("only ordinary members can be managed")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"strings"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+)
+
+func (m *msgServer) GetMentions(ctx context.Context, req *msgext.GetMentionsReq) (*msgext.GetMentionsResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	conversationIDs, err := m.ConversationLocalCache.GetConversationIDs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.ConversationIDs) > 0 {
+		conversationIDs = utils.IntersectString(conversationIDs, req.ConversationIDs)
+	}
+	// only group chats have mentions
+	conversationIDs = utils.Filter(conversationIDs, func(conversationID string) (string, bool) {
+		return conversationID, msgprocessor.IsSuperGroupConversationID(conversationID)
+	})
+	resp := &msgext.GetMentionsResp{Mentions: []*msgext.Mention{}}
+	if len(conversationIDs) == 0 {
+		return resp, nil
+	}
+	// a mention is read once the hasReadSeq of its conversation reaches it
+	hasReadSeqs, err := m.MsgDatabase.GetHasReadSeqs(ctx, req.UserID, conversationIDs)
+	if err != nil {
+		return nil, err
+	}
+	// messages before the user's own min seq are invisible to the user
+	minSeqs, err := m.MsgDatabase.GetUserConversationsMinSeqs(ctx, req.UserID, conversationIDs)
+	if err != nil {
+		return nil, err
+	}
+	if req.UnreadOnly {
+		for conversationID, hasReadSeq := range hasReadSeqs {
+			if hasReadSeq > minSeqs[conversationID] {
+				minSeqs[conversationID] = hasReadSeq
+			}
+		}
+	}
+	joinTimes, err := m.getMentionJoinTimes(ctx, req.UserID, conversationIDs)
+	if err != nil {
+		return nil, err
+	}
+	total, mentions, err := m.MentionDatabase.PageUserMentions(ctx, req.UserID, conversationIDs, minSeqs, joinTimes, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	resp.Total = total
+	for _, mention := range mentions {
+		resp.Mentions = append(resp.Mentions, convert.MentionDB2Pb(mention, hasReadSeqs[mention.ConversationID]))
+	}
+	return resp, nil
+}
+
+// getMentionJoinTimes the join time of the user by conversation id, @all sent before the user joined are not the user's.
+func (m *msgServer) getMentionJoinTimes(ctx context.Context, userID string, conversationIDs []string) (map[string]int64, error) {
+	groupConversationIDs := make(map[string]string, len(conversationIDs))
+	for _, conversationID := range conversationIDs {
+		groupConversationIDs[strings.TrimPrefix(conversationID, "sg_")] = conversationID
+	}
+	members, err := m.Group.GetUserInGroupMembers(ctx, userID, utils.Keys(groupConversationIDs))
+	if err != nil {
+		return nil, err
+	}
+	joinTimes := make(map[string]int64, len(members))
+	for _, member := range members {
+		joinTimes[groupConversationIDs[member.GroupID]] = member.JoinTime
+	}
+	return joinTimes, nil
+}
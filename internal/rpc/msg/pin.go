@@ -0,0 +1,196 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
+)
+
+// checkPinPermission 单聊双方都可以置顶, 群聊需要置顶权限, 返回通知的接收者和会话类型.
+func (m *msgServer) checkPinPermission(ctx context.Context, userID, conversationID string) (string, int32, error) {
+	if err := authverify.CheckAccessV3(ctx, userID); err != nil {
+		return "", 0, err
+	}
+	conversation, err := m.Conversation.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		return "", 0, err
+	}
+	switch conversation.ConversationType {
+	case constant.SingleChatType:
+		return conversation.UserID, constant.SingleChatType, nil
+	case constant.SuperGroupChatType:
+		if !authverify.IsAppManagerUid(ctx) {
+			member, err := m.Group.GetGroupMemberExtInfo(ctx, conversation.GroupID, userID)
+			if err != nil {
+				return "", 0, err
+			}
+			if member.Permissions&relation.GroupPermissionPin == 0 {
+				return "", 0, errs.ErrNoPermission.Wrap("no permission to pin msg")
+			}
+		}
+		return conversation.GroupID, constant.SuperGroupChatType, nil
+	default:
+		return "", 0, errs.ErrArgs.Wrap("conversation type not supported")
+	}
+}
+
+// checkPinLimit 会话已置顶count条消息时能否再置顶一条, limit<=0不限制.
+func checkPinLimit(count int64, limit int) error {
+	if limit > 0 && count >= int64(limit) {
+		return errcode.ErrPinnedMsgLimit.Wrap()
+	}
+	return nil
+}
+
+func (m *msgServer) PinMsg(ctx context.Context, req *msgext.PinMsgReq) (*msgext.PinMsgResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	recvID, sessionType, err := m.checkPinPermission(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, []int64{req.Seq})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 || msgs[0] == nil || msgs[0].ClientMsgID == "" {
+		return nil, errs.ErrRecordNotFound.Wrap("msg not found")
+	}
+	if msgs[0].ContentType == constant.MsgRevokeNotification {
+		return nil, errs.ErrMsgAlreadyRevoke.Wrap("msg already revoke")
+	}
+	// 先统计提前拒绝, 并发置顶由PinMsg写入后再次校验
+	limit := config.Config.PinnedMsg.MaxPerConversation
+	if limit > 0 {
+		count, err := m.PinDatabase.CountPinnedMsgs(ctx, req.ConversationID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPinLimit(count, limit); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	created, err := m.PinDatabase.PinMsg(ctx, &unRelationTb.MsgPinModel{
+		ConversationID: req.ConversationID,
+		Seq:            req.Seq,
+		PinUserID:      req.UserID,
+		PinTime:        now,
+	}, limit)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return &msgext.PinMsgResp{}, nil
+	}
+	tips := &msgext.MsgPinTips{
+		ConversationID: req.ConversationID,
+		Seq:            req.Seq,
+		ClientMsgID:    msgs[0].ClientMsgID,
+		OpUserID:       req.UserID,
+		OperationTime:  now.UnixMilli(),
+	}
+	if err := m.notificationSender.JsonNotificationWithSesstionType(ctx, req.UserID, recvID, rpcclient.MsgPinnedNotification, sessionType, tips); err != nil {
+		log.ZError(ctx, "MsgPinnedNotification failed", err, "conversationID", req.ConversationID, "seq", req.Seq)
+	}
+	return &msgext.PinMsgResp{}, nil
+}
+
+func (m *msgServer) UnpinMsg(ctx context.Context, req *msgext.UnpinMsgReq) (*msgext.UnpinMsgResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	recvID, sessionType, err := m.checkPinPermission(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := m.PinDatabase.UnpinMsgs(ctx, req.ConversationID, []int64{req.Seq})
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return &msgext.UnpinMsgResp{}, nil
+	}
+	tips := &msgext.MsgPinTips{
+		ConversationID: req.ConversationID,
+		Seq:            req.Seq,
+		OpUserID:       req.UserID,
+		OperationTime:  time.Now().UnixMilli(),
+	}
+	// 消息可能已被删除, 此时不带clientMsgID
+	if _, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, []int64{req.Seq}); err == nil && len(msgs) > 0 && msgs[0] != nil {
+		tips.ClientMsgID = msgs[0].ClientMsgID
+	}
+	if err := m.notificationSender.JsonNotificationWithSesstionType(ctx, req.UserID, recvID, rpcclient.MsgUnpinnedNotification, sessionType, tips); err != nil {
+		log.ZError(ctx, "MsgUnpinnedNotification failed", err, "conversationID", req.ConversationID, "seq", req.Seq)
+	}
+	return &msgext.UnpinMsgResp{}, nil
+}
+
+func (m *msgServer) GetPinnedMsgs(ctx context.Context, req *msgext.GetPinnedMsgsReq) (*msgext.GetPinnedMsgsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if _, err := m.Conversation.GetConversation(ctx, req.UserID, req.ConversationID); err != nil {
+		return nil, err
+	}
+	pins, err := m.PinDatabase.FindPinnedMsgs(ctx, req.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &msgext.GetPinnedMsgsResp{Pins: make([]*msgext.PinnedMsg, 0, len(pins))}
+	if len(pins) == 0 {
+		return resp, nil
+	}
+	seqs := utils.Slice(pins, func(pin *unRelationTb.MsgPinModel) int64 { return pin.Seq })
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, seqs)
+	if err != nil {
+		return nil, err
+	}
+	msgMap := make(map[int64]*sdkws.MsgData, len(msgs))
+	for _, msg := range msgs {
+		if msg != nil && msg.ClientMsgID != "" && msg.ContentType != constant.MsgRevokeNotification {
+			msgMap[msg.Seq] = msg
+		}
+	}
+	for _, pin := range pins {
+		resp.Pins = append(resp.Pins, &msgext.PinnedMsg{
+			Seq:       pin.Seq,
+			PinUserID: pin.PinUserID,
+			PinTime:   pin.PinTime.UnixMilli(),
+			Msg:       msgMap[pin.Seq],
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,49 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+)
+
+func Test_CheckPinLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  int64
+		limit  int
+		hasErr bool
+	}{
+		{"unlimited", 1000, 0, false},
+		{"negative limit is unlimited", 1000, -1, false},
+		{"first pin", 0, 1, false},
+		{"below limit", 4, 5, false},
+		{"at limit", 5, 5, true},
+		{"over limit after config lowered", 8, 5, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkPinLimit(test.count, test.limit)
+			if test.hasErr {
+				assert.True(t, errcode.ErrPinnedMsgLimit.Is(err))
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+)
+
+// sendQuota the windows consumed by a message, refunded if it is not sent.
+type sendQuota struct {
+	groupKey string
+	userKey  string
+}
+
+// consumeSendQuota notifications and messages of app managers are not limited.
+func (m *msgServer) consumeSendQuota(ctx context.Context, msg *sdkws.MsgData) (*sendQuota, error) {
+	var quota sendQuota
+	if msgprocessor.IsNotificationByMsg(msg) || authverify.IsManagerUserID(msg.SendID) || authverify.IsAppManagerUid(ctx) {
+		return &quota, nil
+	}
+	var err error
+	if msg.SessionType == constant.SuperGroupChatType {
+		quota.groupKey, err = m.QuotaDatabase.ConsumeQuota(ctx, unRelationTb.QuotaKindGroupMsg, msg.GroupID, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	quota.userKey, err = m.QuotaDatabase.ConsumeQuota(ctx, unRelationTb.QuotaKindUserMsg, msg.SendID, 1)
+	if err != nil {
+		m.refundSendQuota(ctx, &quota)
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// refundSendQuota 消息未能投递到mq时退还consumeSendQuota扣除的额度.
+func (m *msgServer) refundSendQuota(ctx context.Context, quota *sendQuota) {
+	if err := m.QuotaDatabase.RefundQuota(ctx, quota.groupKey, 1); err != nil {
+		log.ZWarn(ctx, "refund group msg quota failed", err, "key", quota.groupKey)
+	}
+	if err := m.QuotaDatabase.RefundQuota(ctx, quota.userKey, 1); err != nil {
+		log.ZWarn(ctx, "refund user msg quota failed", err, "key", quota.userKey)
+	}
+}
+
+func (m *msgServer) GetQuotas(ctx context.Context, req *msgext.GetQuotasReq) (*msgext.GetQuotasResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var kinds [][2]string
+	if req.UserID != "" {
+		kinds = append(
+			kinds,
+			[2]string{unRelationTb.QuotaKindUserMsg, req.UserID},
+			[2]string{unRelationTb.QuotaKindUserFileSize, req.UserID},
+			[2]string{unRelationTb.QuotaKindUserFriendRequest, req.UserID},
+		)
+	}
+	if req.GroupID != "" {
+		kinds = append(kinds, [2]string{unRelationTb.QuotaKindGroupMsg, req.GroupID})
+	}
+	resp := &msgext.GetQuotasResp{Quotas: make([]*msgext.Quota, 0, len(kinds))}
+	for _, kind := range kinds {
+		quota, err := m.QuotaDatabase.GetQuota(ctx, kind[0], kind[1])
+		if err != nil {
+			return nil, err
+		}
+		resp.Quotas = append(resp.Quotas, &msgext.Quota{
+			Kind:     quota.Kind,
+			TargetID: quota.TargetID,
+			Limit:    quota.Limit,
+			Window:   int64(quota.Window / time.Second),
+			Used:     quota.Used,
+			Override: quota.Override,
+		})
+	}
+	return resp, nil
+}
+
+func (m *msgServer) SetQuota(ctx context.Context, req *msgext.SetQuotaReq) (*msgext.SetQuotaResp, error) {
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.Reset {
+		if err := m.QuotaDatabase.ResetQuota(ctx, req.Kind, req.TargetID); err != nil {
+			return nil, err
+		}
+		return &msgext.SetQuotaResp{}, nil
+	}
+	quota := &unRelationTb.QuotaModel{
+		Kind:       req.Kind,
+		TargetID:   req.TargetID,
+		Limit:      req.Limit,
+		OpUserID:   mcontext.GetOpUserID(ctx),
+		UpdateTime: time.Now(),
+	}
+	if err := m.QuotaDatabase.SetQuota(ctx, quota); err != nil {
+		return nil, err
+	}
+	return &msgext.SetQuotaResp{}, nil
+}
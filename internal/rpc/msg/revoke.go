@@ -28,6 +28,7 @@ import (
 	"github.com/OpenIMSDK/tools/log"
 	"github.com/OpenIMSDK/tools/utils"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
 	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
 )
 
@@ -80,13 +81,14 @@ func (m *msgServer) RevokeMsg(ctx context.Context, req *msg.RevokeMsgReq) (*msg.
 				return nil, err
 			}
 			if req.UserID != msgs[0].SendID {
-				switch members[req.UserID].RoleLevel {
-				case constant.GroupOwner:
-				case constant.GroupAdmin:
-					if members[msgs[0].SendID].RoleLevel != constant.GroupOrdinaryUsers {
-						return nil, errs.ErrNoPermission.Wrap("no permission")
-					}
-				default:
+				opMember, err := m.Group.GetGroupMemberExtInfo(ctx, msgs[0].GroupID, req.UserID)
+				if err != nil {
+					return nil, err
+				}
+				if opMember.Permissions&relation.GroupPermissionRevokeOthers == 0 {
+					return nil, errs.ErrNoPermission.Wrap("no permission")
+				}
+				if opMember.RoleLevel != constant.GroupOwner && members[msgs[0].SendID].RoleLevel != constant.GroupOrdinaryUsers {
 					return nil, errs.ErrNoPermission.Wrap("no permission")
 				}
 			}
@@ -40,6 +40,9 @@ func (m *msgServer) SendMsg(ctx context.Context, req *pbMsg.SendMsgReq) (resp *p
 			return nil, errs.ErrMessageHasReadDisable.Wrap()
 		}
 		m.encapsulateMsgData(req.MsgData)
+		if err := m.checkSendSuspension(ctx, req.MsgData); err != nil {
+			return nil, err
+		}
 		switch req.MsgData.SessionType {
 		case constant.SingleChatType:
 			return m.sendMsgSingleChat(ctx, req)
@@ -60,20 +63,43 @@ func (m *msgServer) sendMsgSuperGroupChat(
 	req *pbMsg.SendMsgReq,
 ) (resp *pbMsg.SendMsgResp, err error) {
 	promePkg.Inc(promePkg.WorkSuperGroupChatMsgRecvSuccessCounter)
-	if err = m.messageVerification(ctx, req); err != nil {
+	slowMode, err := m.groupMessageVerification(ctx, req)
+	if err != nil {
 		promePkg.Inc(promePkg.WorkSuperGroupChatMsgProcessFailedCounter)
 		return nil, err
 	}
+	if slowMode > 0 {
+		token, err := m.reserveGroupSlowMode(ctx, req.MsgData, slowMode)
+		if err != nil {
+			promePkg.Inc(promePkg.WorkSuperGroupChatMsgProcessFailedCounter)
+			return nil, err
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			if err := m.MsgDatabase.ReleaseGroupSlowMode(ctx, req.MsgData.GroupID, req.MsgData.SendID, token); err != nil {
+				log.ZWarn(ctx, "ReleaseGroupSlowMode failed", err, "groupID", req.MsgData.GroupID, "userID", req.MsgData.SendID)
+			}
+		}()
+	}
 	if err = callbackBeforeSendGroupMsg(ctx, req); err != nil {
 		return nil, err
 	}
 	if err := callbackMsgModify(ctx, req); err != nil {
 		return nil, err
 	}
+	quota, err := m.consumeSendQuota(ctx, req.MsgData)
+	if err != nil {
+		promePkg.Inc(promePkg.WorkSuperGroupChatMsgProcessFailedCounter)
+		return nil, err
+	}
 	err = m.MsgDatabase.MsgToMQ(ctx, utils.GenConversationUniqueKeyForGroup(req.MsgData.GroupID), req.MsgData)
 	if err != nil {
+		m.refundSendQuota(ctx, quota)
 		return nil, err
 	}
+	m.touchSenderActivity(ctx, req.MsgData)
 	if req.MsgData.ContentType == constant.AtText {
 		go m.setConversationAtInfo(ctx, req.MsgData)
 	}
@@ -88,6 +114,16 @@ func (m *msgServer) sendMsgSuperGroupChat(
 	return resp, nil
 }
 
+// touchSenderActivity 记录发送者的活跃时间, 通知消息不算用户活跃.
+func (m *msgServer) touchSenderActivity(ctx context.Context, msg *sdkws.MsgData) {
+	if msgprocessor.IsNotificationByMsg(msg) {
+		return
+	}
+	if err := m.UserActivity.Touch(ctx, msg.SendID); err != nil {
+		log.ZWarn(ctx, "touch user activity failed", err, "userID", msg.SendID)
+	}
+}
+
 func (m *msgServer) setConversationAtInfo(nctx context.Context, msg *sdkws.MsgData) {
 	log.ZDebug(nctx, "setConversationAtInfo", "msg", msg)
 	ctx := mcontext.NewCtx("@@@" + mcontext.GetOperationID(nctx))
@@ -175,10 +211,16 @@ func (m *msgServer) sendMsgSingleChat(ctx context.Context, req *pbMsg.SendMsgReq
 		if err := callbackMsgModify(ctx, req); err != nil {
 			return nil, err
 		}
+		quota, err := m.consumeSendQuota(ctx, req.MsgData)
+		if err != nil {
+			return nil, err
+		}
 		if err := m.MsgDatabase.MsgToMQ(ctx, utils.GenConversationUniqueKeyForSingle(req.MsgData.SendID, req.MsgData.RecvID), req.MsgData); err != nil {
+			m.refundSendQuota(ctx, quota)
 			promePkg.Inc(promePkg.SingleChatMsgProcessFailedCounter)
 			return nil, err
 		}
+		m.touchSenderActivity(ctx, req.MsgData)
 		err = callbackAfterSendSingleMsg(ctx, req)
 		if err != nil {
 			log.ZWarn(ctx, "CallbackAfterSendSingleMsg", err, "req", req)
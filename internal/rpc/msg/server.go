@@ -29,6 +29,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/localcache"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -45,6 +46,12 @@ type (
 		ConversationLocalCache *localcache.ConversationLocalCache
 		Handlers               MessageInterceptorChain
 		notificationSender     *rpcclient.NotificationSender
+		BroadcastDatabase      controller.BroadcastDatabase
+		MentionDatabase        controller.MentionDatabase
+		QuotaDatabase          controller.QuotaDatabase
+		PinDatabase            controller.MsgPinDatabase
+		UserSuspension         controller.UserSuspensionChecker
+		UserActivity           controller.UserActivityDatabase
 	}
 )
 
@@ -75,6 +82,21 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	if err := mongo.CreateMsgIndex(); err != nil {
 		return err
 	}
+	if err := mongo.CreateBroadcastIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateMentionIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateQuotaIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateMsgPinIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateUserActivityIndex(); err != nil {
+		return err
+	}
 	cacheModel := cache.NewMsgCacheModel(rdb)
 	msgDocModel := unrelation.NewMsgMongoDriver(mongo.GetDatabase())
 	conversationClient := rpcclient.NewConversationRpcClient(client)
@@ -82,6 +104,7 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	groupRpcClient := rpcclient.NewGroupRpcClient(client)
 	friendRpcClient := rpcclient.NewFriendRpcClient(client)
 	msgDatabase := controller.NewCommonMsgDatabase(msgDocModel, cacheModel)
+	quotaDB := unrelation.NewQuotaMongoDriver(mongo.GetDatabase())
 	s := &msgServer{
 		Conversation:           &conversationClient,
 		User:                   &userRpcClient,
@@ -91,11 +114,18 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 		GroupLocalCache:        localcache.NewGroupLocalCache(&groupRpcClient),
 		ConversationLocalCache: localcache.NewConversationLocalCache(&conversationClient),
 		friend:                 &friendRpcClient,
+		BroadcastDatabase:      controller.NewBroadcastDatabase(unrelation.NewBroadcastMongoDriver(mongo.GetDatabase())),
+		MentionDatabase:        controller.NewMentionDatabase(unrelation.NewMentionMongoDriver(mongo.GetDatabase())),
+		QuotaDatabase:          controller.NewQuotaDatabase(quotaDB, cache.NewQuotaCacheRedis(rdb, quotaDB, cache.GetDefaultOpt())),
+		PinDatabase:            controller.NewMsgPinDatabase(unrelation.NewMsgPinMongoDriver(mongo.GetDatabase())),
+		UserSuspension:         controller.InitUserSuspensionChecker(rdb, mongo.GetDatabase()),
+		UserActivity:           controller.InitUserActivityDatabase(rdb, mongo.GetDatabase()),
 	}
 	s.notificationSender = rpcclient.NewNotificationSender(rpcclient.WithLocalSendMsg(s.SendMsg))
 	s.addInterceptorHandler(MessageHasReadEnabled)
 	s.initPrometheus()
 	msg.RegisterMsgServer(server, s)
+	msgext.RegisterMsgExtServer(server, s)
 	return nil
 }
 
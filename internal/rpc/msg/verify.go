@@ -16,6 +16,7 @@ package msg
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"strconv"
 	"time"
@@ -27,6 +28,9 @@ import (
 	"github.com/OpenIMSDK/tools/utils"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
 )
 
 var ExcludeContentType = []int{constant.HasReadReceipt}
@@ -77,53 +81,139 @@ func (m *msgServer) messageVerification(ctx context.Context, data *msg.SendMsgRe
 		}
 		return nil
 	case constant.SuperGroupChatType:
-		groupInfo, err := m.Group.GetGroupInfoCache(ctx, data.MsgData.GroupID)
-		if err != nil {
-			return err
-		}
-		if groupInfo.Status == constant.GroupStatusDismissed &&
-			data.MsgData.ContentType != constant.GroupDismissedNotification {
-			return errs.ErrDismissedAlready.Wrap()
-		}
-		if groupInfo.GroupType == constant.SuperGroup {
-			return nil
-		}
-		if utils.IsContain(data.MsgData.SendID, config.Config.Manager.UserID) {
-			return nil
+		_, err := m.groupMessageVerification(ctx, data)
+		return err
+	default:
+		return nil
+	}
+}
+
+// groupMessageVerification 校验群消息, 返回发送者需要的慢速模式冷却时间, 0表示不受慢速模式限制.
+func (m *msgServer) groupMessageVerification(ctx context.Context, data *msg.SendMsgReq) (time.Duration, error) {
+	groupInfo, err := m.Group.GetGroupExtInfoCache(ctx, data.MsgData.GroupID)
+	if err != nil {
+		return 0, err
+	}
+	if groupInfo.Status == constant.GroupStatusDismissed &&
+		data.MsgData.ContentType != constant.GroupDismissedNotification {
+		return 0, errs.ErrDismissedAlready.Wrap()
+	}
+	if groupInfo.GroupType == constant.SuperGroup {
+		return 0, nil
+	}
+	if utils.IsContain(data.MsgData.SendID, config.Config.Manager.UserID) {
+		return 0, nil
+	}
+	if data.MsgData.ContentType <= constant.NotificationEnd &&
+		data.MsgData.ContentType >= constant.NotificationBegin {
+		return 0, nil
+	}
+	if groupInfo.GroupType == relation.CommunityGroup {
+		return 0, errs.ErrGroupTypeNotSupport.Wrap("community has no chat, send in its groups")
+	}
+	// memberIDs, err := m.GroupLocalCache.GetGroupMemberIDs(ctx, data.MsgData.GroupID)
+	// if err != nil {
+	// 	return err
+	// }
+	// if !utils.IsContain(data.MsgData.SendID, memberIDs) {
+	// 	return errs.ErrNotInGroupYet.Wrap()
+	// }
+
+	groupMemberInfo, err := m.Group.GetGroupMemberExtInfo(ctx, data.MsgData.GroupID, data.MsgData.SendID)
+	if err != nil {
+		if groupInfo.GroupType == relation.ChannelGroup && errs.ErrNotInGroupYet.Is(err) {
+			return 0, m.checkChannelSubscriberMsg(ctx, data.MsgData)
 		}
-		if data.MsgData.ContentType <= constant.NotificationEnd &&
-			data.MsgData.ContentType >= constant.NotificationBegin {
-			return nil
+		if err == errs.ErrRecordNotFound {
+			return 0, errs.ErrNotInGroupYet.Wrap(err.Error())
 		}
-		// memberIDs, err := m.GroupLocalCache.GetGroupMemberIDs(ctx, data.MsgData.GroupID)
-		// if err != nil {
-		// 	return err
-		// }
-		// if !utils.IsContain(data.MsgData.SendID, memberIDs) {
-		// 	return errs.ErrNotInGroupYet.Wrap()
-		// }
-
-		groupMemberInfo, err := m.Group.GetGroupMemberCache(ctx, data.MsgData.GroupID, data.MsgData.SendID)
-		if err != nil {
-			if err == errs.ErrRecordNotFound {
-				return errs.ErrNotInGroupYet.Wrap(err.Error())
-			}
-			return err
+		return 0, err
+	}
+	// 频道中只有管理员或被授予角色的成员可以发言
+	if groupInfo.GroupType == relation.ChannelGroup &&
+		groupMemberInfo.RoleLevel == constant.GroupOrdinaryUsers && groupMemberInfo.RoleID == "" {
+		return 0, errs.ErrNoPermission.Wrap("only admins can post in channel")
+	}
+	if groupMemberInfo.RoleLevel == constant.GroupOwner {
+		return 0, nil
+	} else {
+		if groupMemberInfo.MuteEndTime >= time.Now().Unix() {
+			return 0, errs.ErrMutedInGroup.Wrap()
 		}
-		if groupMemberInfo.RoleLevel == constant.GroupOwner {
-			return nil
-		} else {
-			if groupMemberInfo.MuteEndTime >= time.Now().Unix() {
-				return errs.ErrMutedInGroup.Wrap()
-			}
-			if groupInfo.Status == constant.GroupStatusMuted && groupMemberInfo.RoleLevel != constant.GroupAdmin {
-				return errs.ErrMutedGroup.Wrap()
-			}
+		if groupInfo.Status == constant.GroupStatusMuted && groupMemberInfo.RoleLevel != constant.GroupAdmin {
+			return 0, errs.ErrMutedGroup.Wrap()
 		}
+	}
+	if err := checkGroupSendPermission(data.MsgData, groupMemberInfo.Permissions); err != nil {
+		return 0, err
+	}
+	if groupMemberInfo.RoleLevel == constant.GroupAdmin {
+		return 0, nil
+	}
+	if groupInfo.SlowMode <= 0 {
+		return 0, nil
+	}
+	return time.Duration(groupInfo.SlowMode) * time.Second, nil
+}
+
+// checkSendSuspension 被封禁发消息的用户不能发送消息, 管理员和通知消息不受影响.
+func (m *msgServer) checkSendSuspension(ctx context.Context, msg *sdkws.MsgData) error {
+	if msg.SessionType == constant.NotificationChatType || utils.IsContain(msg.SendID, config.Config.Manager.UserID) {
 		return nil
-	default:
+	}
+	if msg.ContentType <= constant.NotificationEnd && msg.ContentType >= constant.NotificationBegin {
 		return nil
 	}
+	return m.UserSuspension.CheckUserSuspension(ctx, msg.SendID, unRelationTb.UserSuspensionScopeSendMsg)
+}
+
+// checkChannelSubscriberMsg 频道订阅者只能发送已读回执和表情回应.
+func (m *msgServer) checkChannelSubscriberMsg(ctx context.Context, msg *sdkws.MsgData) error {
+	switch msg.ContentType {
+	case constant.HasReadReceipt, constant.ReactionMessageModifier, constant.ReactionMessageDeleter:
+	default:
+		return errs.ErrNoPermission.Wrap("subscribers can not post in channel")
+	}
+	subscribed, err := m.Group.IsChannelSubscriber(ctx, msg.GroupID, msg.SendID)
+	if err != nil {
+		return err
+	}
+	if !subscribed {
+		return errs.ErrNotInGroupYet.Wrap("not subscribed")
+	}
+	return nil
+}
+
+// reserveGroupSlowMode 群开启慢速模式时, 普通成员两次发言间隔不能小于设置的秒数.
+// 发送前原子地预占冷却, 并发发送只有一条能通过, 发送失败时用返回的token释放.
+func (m *msgServer) reserveGroupSlowMode(ctx context.Context, msg *sdkws.MsgData, interval time.Duration) (string, error) {
+	token, remaining, err := m.MsgDatabase.ReserveGroupSlowMode(ctx, msg.GroupID, msg.SendID, interval)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		cooldown := int64((remaining + time.Second - 1) / time.Second)
+		return "", errcode.ErrGroupSlowMode.Wrap(fmt.Sprintf("remaining cooldown %d seconds", cooldown))
+	}
+	return token, nil
+}
+
+// checkGroupSendPermission 校验发送者的群权限是否允许发送该消息.
+func checkGroupSendPermission(msg *sdkws.MsgData, permissions int64) error {
+	if permissions&relation.GroupPermissionSendMsg == 0 {
+		return errs.ErrNoPermission.Wrap("no permission to send messages in group")
+	}
+	switch msg.ContentType {
+	case constant.Picture, constant.Voice, constant.Video, constant.File:
+		if permissions&relation.GroupPermissionSendMedia == 0 {
+			return errs.ErrNoPermission.Wrap("no permission to send media in group")
+		}
+	case constant.AtText:
+		if utils.IsContain(constant.AtAllString, msg.AtUserIDList) && permissions&relation.GroupPermissionAtAll == 0 {
+			return errs.ErrNoPermission.Wrap("no permission to @all in group")
+		}
+	}
+	return nil
 }
 
 func (m *msgServer) encapsulateMsgData(msg *sdkws.MsgData) {
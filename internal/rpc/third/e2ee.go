@@ -0,0 +1,218 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package third
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/thirdext"
+)
+
+// E2EEIdentityKeyChanged business notification key sent to the friends of a user whose device has a new identity key.
+const E2EEIdentityKeyChanged = "e2eeIdentityKeyChanged"
+
+func (t *thirdServer) PublishKeyBundle(ctx context.Context, req *thirdext.PublishKeyBundleReq) (*thirdext.PublishKeyBundleResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	bundle := &unRelationTb.E2EEKeyBundleModel{
+		UserID:         req.UserID,
+		DeviceID:       req.DeviceID,
+		RegistrationID: req.RegistrationID,
+		IdentityKey:    req.IdentityKey,
+		SignedPreKey: unRelationTb.E2EESignedPreKeyModel{
+			KeyID:     req.SignedPreKey.KeyID,
+			PublicKey: req.SignedPreKey.PublicKey,
+			Signature: req.SignedPreKey.Signature,
+		},
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	changed, err := t.e2eeDatabase.SetKeyBundle(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	count, err := t.e2eeDatabase.AddOneTimePreKeys(ctx, req.UserID, req.DeviceID, t.oneTimePreKeysPb2DB(req.UserID, req.DeviceID, req.OneTimePreKeys))
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		go t.identityKeyChangedNotification(mcontext.WithOpUserIDContext(mcontext.NewCtx("@@@"+mcontext.GetOperationID(ctx)), mcontext.GetOpUserID(ctx)), bundle)
+	}
+	return &thirdext.PublishKeyBundleResp{IdentityKeyChanged: changed, OneTimePreKeyCount: count}, nil
+}
+
+func (t *thirdServer) oneTimePreKeysPb2DB(userID string, deviceID string, preKeys []*thirdext.OneTimePreKey) []*unRelationTb.E2EEOneTimePreKeyModel {
+	now := time.Now()
+	return utils.Slice(preKeys, func(preKey *thirdext.OneTimePreKey) *unRelationTb.E2EEOneTimePreKeyModel {
+		return &unRelationTb.E2EEOneTimePreKeyModel{
+			UserID:     userID,
+			DeviceID:   deviceID,
+			KeyID:      preKey.KeyID,
+			PublicKey:  preKey.PublicKey,
+			CreateTime: now,
+		}
+	})
+}
+
+// identityKeyChangedNotification the sessions with the device have to be verified again,
+// it is called asynchronously since a user may have thousands of friends.
+func (t *thirdServer) identityKeyChangedNotification(ctx context.Context, bundle *unRelationTb.E2EEKeyBundleModel) {
+	friendIDs, err := t.friendRpcClient.GetFriendIDs(ctx, bundle.UserID)
+	if err != nil {
+		log.ZWarn(ctx, "get friend ids failed", err, "userID", bundle.UserID)
+		return
+	}
+	data := map[string]any{"userID": bundle.UserID, "deviceID": bundle.DeviceID, "identityKey": bundle.IdentityKey}
+	for _, friendID := range append(friendIDs, bundle.UserID) {
+		if err := t.notificationSender.BusinessNotification(ctx, bundle.UserID, friendID, constant.SingleChatType, E2EEIdentityKeyChanged, data); err != nil {
+			log.ZWarn(ctx, "identity key changed notification failed", err, "userID", bundle.UserID, "recvID", friendID)
+		}
+	}
+}
+
+func (t *thirdServer) UploadOneTimePreKeys(ctx context.Context, req *thirdext.UploadOneTimePreKeysReq) (*thirdext.UploadOneTimePreKeysResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	bundles, err := t.e2eeDatabase.FindKeyBundles(ctx, req.UserID, []string{req.DeviceID})
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, errs.ErrRecordNotFound.Wrap("key bundle of the device is not published")
+	}
+	count, err := t.e2eeDatabase.AddOneTimePreKeys(ctx, req.UserID, req.DeviceID, t.oneTimePreKeysPb2DB(req.UserID, req.DeviceID, req.OneTimePreKeys))
+	if err != nil {
+		return nil, err
+	}
+	return &thirdext.UploadOneTimePreKeysResp{OneTimePreKeyCount: count}, nil
+}
+
+func (t *thirdServer) GetOneTimePreKeyCount(ctx context.Context, req *thirdext.GetOneTimePreKeyCountReq) (*thirdext.GetOneTimePreKeyCountResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	count, err := t.e2eeDatabase.CountOneTimePreKeys(ctx, req.UserID, req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	return &thirdext.GetOneTimePreKeyCountResp{OneTimePreKeyCount: count}, nil
+}
+
+// FetchKeyBundles every returned bundle consumes one one-time prekey of its device,
+// a requester gets at most one prekey of a device per window, the bundles without prekey still work for x3dh.
+func (t *thirdServer) FetchKeyBundles(ctx context.Context, req *thirdext.FetchKeyBundlesReq) (*thirdext.FetchKeyBundlesResp, error) {
+	requesterID := mcontext.GetOpUserID(ctx)
+	bundles, err := t.e2eeDatabase.FindKeyBundles(ctx, req.UserID, req.DeviceIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp := &thirdext.FetchKeyBundlesResp{KeyBundles: make([]*thirdext.KeyBundle, 0, len(bundles))}
+	for _, bundle := range bundles {
+		keyBundle := &thirdext.KeyBundle{
+			UserID:         bundle.UserID,
+			DeviceID:       bundle.DeviceID,
+			RegistrationID: bundle.RegistrationID,
+			IdentityKey:    bundle.IdentityKey,
+			SignedPreKey: &thirdext.SignedPreKey{
+				KeyID:     bundle.SignedPreKey.KeyID,
+				PublicKey: bundle.SignedPreKey.PublicKey,
+				Signature: bundle.SignedPreKey.Signature,
+			},
+			UpdateTime: bundle.UpdateTime.UnixMilli(),
+		}
+		preKey, err := t.e2eeDatabase.TakeOneTimePreKey(ctx, requesterID, bundle.UserID, bundle.DeviceID)
+		if err != nil {
+			return nil, err
+		}
+		if preKey != nil {
+			keyBundle.OneTimePreKey = &thirdext.OneTimePreKey{KeyID: preKey.KeyID, PublicKey: preKey.PublicKey}
+		}
+		resp.KeyBundles = append(resp.KeyBundles, keyBundle)
+	}
+	return resp, nil
+}
+
+func (t *thirdServer) DeleteKeyBundle(ctx context.Context, req *thirdext.DeleteKeyBundleReq) (*thirdext.DeleteKeyBundleResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := t.e2eeDatabase.DeleteKeyBundle(ctx, req.UserID, req.DeviceID); err != nil {
+		return nil, err
+	}
+	return &thirdext.DeleteKeyBundleResp{}, nil
+}
+
+func (t *thirdServer) checkGroupMember(ctx context.Context, groupID string) error {
+	if authverify.IsAppManagerUid(ctx) {
+		return nil
+	}
+	_, err := t.groupRpcClient.GetGroupMemberCache(ctx, groupID, mcontext.GetOpUserID(ctx))
+	return err
+}
+
+func (t *thirdServer) SetSenderKeyDistribution(ctx context.Context, req *thirdext.SetSenderKeyDistributionReq) (*thirdext.SetSenderKeyDistributionResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := t.checkGroupMember(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	senderKey := &unRelationTb.E2EESenderKeyModel{
+		GroupID:        req.GroupID,
+		UserID:         req.UserID,
+		DeviceID:       req.DeviceID,
+		DistributionID: req.DistributionID,
+		Recipients:     utils.Distinct(req.Recipients),
+		UpdateTime:     time.Now(),
+	}
+	if err := t.e2eeDatabase.SetSenderKey(ctx, senderKey); err != nil {
+		return nil, err
+	}
+	return &thirdext.SetSenderKeyDistributionResp{}, nil
+}
+
+func (t *thirdServer) GetSenderKeyDistributions(ctx context.Context, req *thirdext.GetSenderKeyDistributionsReq) (*thirdext.GetSenderKeyDistributionsResp, error) {
+	if err := t.checkGroupMember(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	senderKeys, err := t.e2eeDatabase.FindSenderKeys(ctx, req.GroupID, req.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp := &thirdext.GetSenderKeyDistributionsResp{Distributions: make([]*thirdext.SenderKeyDistribution, 0, len(senderKeys))}
+	for _, senderKey := range senderKeys {
+		resp.Distributions = append(resp.Distributions, &thirdext.SenderKeyDistribution{
+			GroupID:        senderKey.GroupID,
+			UserID:         senderKey.UserID,
+			DeviceID:       senderKey.DeviceID,
+			DistributionID: senderKey.DistributionID,
+			Recipients:     senderKey.Recipients,
+			UpdateTime:     senderKey.UpdateTime.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
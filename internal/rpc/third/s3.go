@@ -17,6 +17,7 @@ package third
 import (
 	"context"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/s3"
@@ -27,8 +28,11 @@ import (
 	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/OpenIMSDK/tools/utils"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/s3/cont"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/thirdext"
 )
 
 func (t *thirdServer) PartLimit(ctx context.Context, req *third.PartLimitReq) (*third.PartLimitResp, error) {
@@ -57,6 +61,11 @@ func (t *thirdServer) InitiateMultipartUpload(ctx context.Context, req *third.In
 	result, err := t.s3dataBase.InitiateMultipartUpload(ctx, req.Hash, req.Size, t.defaultExpire, int(req.MaxParts))
 	if err != nil {
 		if haErr, ok := errs.Unwrap(err).(*cont.HashAlreadyExistsError); ok {
+			if !authverify.IsAppManagerUid(ctx) {
+				if _, err := t.quotaDatabase.ConsumeQuota(ctx, unRelationTb.QuotaKindUserFileSize, mcontext.GetOpUserID(ctx), req.Size); err != nil {
+					return nil, err
+				}
+			}
 			obj := &relation.ObjectModel{
 				Name:        req.Name,
 				UserID:      mcontext.GetOpUserID(ctx),
@@ -76,6 +85,12 @@ func (t *thirdServer) InitiateMultipartUpload(ctx context.Context, req *third.In
 		}
 		return nil, err
 	}
+	// 上传完成前只预占配额, 放弃的上传随上传地址一起过期归还
+	if !authverify.IsAppManagerUid(ctx) {
+		if err := t.quotaDatabase.HoldQuota(ctx, unRelationTb.QuotaKindUserFileSize, mcontext.GetOpUserID(ctx), result.UploadID, req.Size, expireTime); err != nil {
+			return nil, err
+		}
+	}
 	var sign *third.AuthSignParts
 	if result.Sign != nil && len(result.Sign.Parts) > 0 {
 		sign = &third.AuthSignParts{
@@ -149,6 +164,9 @@ func (t *thirdServer) CompleteMultipartUpload(ctx context.Context, req *third.Co
 	if err := t.s3dataBase.SetObject(ctx, obj); err != nil {
 		return nil, err
 	}
+	if err := t.quotaDatabase.CommitQuota(ctx, unRelationTb.QuotaKindUserFileSize, obj.UserID, req.UploadID); err != nil {
+		log.ZWarn(ctx, "commit file size quota failed", err, "uploadID", req.UploadID)
+	}
 	return &third.CompleteMultipartUploadResp{
 		Url: t.apiAddress(obj.Name),
 	}, nil
@@ -169,6 +187,9 @@ func (t *thirdServer) AccessURL(ctx context.Context, req *third.AccessURLReq) (*
 			return nil, errs.ErrArgs.Wrap("invalid query type")
 		}
 	}
+	if err := checkExportObjectAccess(ctx, req.Name); err != nil {
+		return nil, err
+	}
 	expireTime, rawURL, err := t.s3dataBase.AccessURL(ctx, req.Name, t.defaultExpire, opt)
 	if err != nil {
 		return nil, err
@@ -179,6 +200,106 @@ func (t *thirdServer) AccessURL(ctx context.Context, req *third.AccessURLReq) (*
 	}, nil
 }
 
+// DeleteUserObjects 删除用户上传的所有文件, 仅管理员可用.
+func (t *thirdServer) DeleteUserObjects(ctx context.Context, req *thirdext.DeleteUserObjectsReq) (*thirdext.DeleteUserObjectsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	count, err := t.s3dataBase.DeleteUserObjects(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	log.ZInfo(ctx, "user objects deleted", "userID", req.UserID, "count", count)
+	return &thirdext.DeleteUserObjectsResp{Count: count}, nil
+}
+
+func (t *thirdServer) GetUserObjects(ctx context.Context, req *thirdext.GetUserObjectsReq) (*thirdext.GetUserObjectsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	objs, err := t.s3dataBase.FindUserObjects(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &thirdext.GetUserObjectsResp{
+		Objects: utils.Slice(objs, func(obj *relation.ObjectModel) *thirdext.ObjectInfo {
+			return &thirdext.ObjectInfo{
+				Name:        obj.Name,
+				Size:        obj.Size,
+				ContentType: obj.ContentType,
+				Cause:       obj.Cause,
+				CreateTime:  obj.CreateTime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+// exportUploadExpire the upload url of an export archive is used at once.
+const exportUploadExpire = time.Hour
+
+const exportObjectPrefix = "export/"
+
+func exportObjectName(userID string, jobID string) string {
+	return exportObjectPrefix + userID + "/" + jobID + ".zip"
+}
+
+// checkExportObjectAccess 导出的归档只有被导出的用户和管理员可以访问.
+func checkExportObjectAccess(ctx context.Context, name string) error {
+	if !strings.HasPrefix(name, exportObjectPrefix) {
+		return nil
+	}
+	userID, _, _ := strings.Cut(strings.TrimPrefix(name, exportObjectPrefix), "/")
+	if userID == "" {
+		return errs.ErrNoPermission.Wrap("invalid export object name")
+	}
+	return authverify.CheckAccessV3(ctx, userID)
+}
+
+func (t *thirdServer) InitiateExportUpload(ctx context.Context, req *thirdext.InitiateExportUploadReq) (*thirdext.InitiateExportUploadResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	name := exportObjectName(req.UserID, req.JobID)
+	if err := checkValidObjectName(name); err != nil {
+		return nil, errs.ErrArgs.Wrap(err.Error())
+	}
+	expireTime := time.Now().Add(exportUploadExpire)
+	_, rawURL, err := t.s3dataBase.InitiateExportUpload(ctx, name, exportUploadExpire)
+	if err != nil {
+		return nil, err
+	}
+	return &thirdext.InitiateExportUploadResp{Name: name, Url: rawURL, ExpireTime: expireTime.UnixMilli()}, nil
+}
+
+func (t *thirdServer) CompleteExportUpload(ctx context.Context, req *thirdext.CompleteExportUploadReq) (*thirdext.CompleteExportUploadResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	obj := &relation.ObjectModel{
+		Name:        exportObjectName(req.UserID, req.JobID),
+		UserID:      req.UserID,
+		ContentType: "application/zip",
+		Cause:       "export",
+		CreateTime:  time.Now(),
+	}
+	if err := t.s3dataBase.CompleteExportUpload(ctx, obj); err != nil {
+		return nil, err
+	}
+	return &thirdext.CompleteExportUploadResp{Name: obj.Name, Size: obj.Size}, nil
+}
+
 func (t *thirdServer) apiAddress(name string) string {
 	return t.apiURL + name
 }
@@ -35,6 +35,8 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/relation"
 	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/thirdext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -77,22 +79,52 @@ func Start(client discoveryregistry.SvcDiscoveryRegistry, server *grpc.Server) e
 	if err != nil {
 		return err
 	}
-	third.RegisterThirdServer(server, &thirdServer{
-		apiURL:        apiURL,
-		thirdDatabase: controller.NewThirdDatabase(cache.NewMsgCacheModel(rdb)),
-		userRpcClient: rpcclient.NewUserRpcClient(client),
-		s3dataBase:    controller.NewS3Database(o, relation.NewObjectInfo(db)),
-		defaultExpire: time.Hour * 24 * 7,
-	})
+	mongo, err := unrelation.NewMongo()
+	if err != nil {
+		return err
+	}
+	if err := mongo.CreateQuotaIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateE2EEIndex(); err != nil {
+		return err
+	}
+	quotaDB := unrelation.NewQuotaMongoDriver(mongo.GetDatabase())
+	e2eeDatabase := controller.NewE2EEDatabase(
+		unrelation.NewE2EEKeyBundleMongoDriver(mongo.GetDatabase()),
+		unrelation.NewE2EEOneTimePreKeyMongoDriver(mongo.GetDatabase()),
+		unrelation.NewE2EESenderKeyMongoDriver(mongo.GetDatabase()),
+		cache.NewE2EECacheRedis(rdb),
+	)
+	msgRpcClient := rpcclient.NewMessageRpcClient(client)
+	s := &thirdServer{
+		apiURL:             apiURL,
+		thirdDatabase:      controller.NewThirdDatabase(cache.NewMsgCacheModel(rdb)),
+		userRpcClient:      rpcclient.NewUserRpcClient(client),
+		s3dataBase:         controller.NewS3Database(o, relation.NewObjectInfo(db)),
+		quotaDatabase:      controller.NewQuotaDatabase(quotaDB, cache.NewQuotaCacheRedis(rdb, quotaDB, cache.GetDefaultOpt())),
+		e2eeDatabase:       e2eeDatabase,
+		friendRpcClient:    rpcclient.NewFriendRpcClient(client),
+		groupRpcClient:     rpcclient.NewGroupRpcClient(client),
+		notificationSender: rpcclient.NewNotificationSender(rpcclient.WithRpcClient(&msgRpcClient)),
+		defaultExpire:      time.Hour * 24 * 7,
+	}
+	third.RegisterThirdServer(server, s)
+	thirdext.RegisterThirdExtServer(server, s)
 	return nil
 }
 
 type thirdServer struct {
-	apiURL        string
-	thirdDatabase controller.ThirdDatabase
-	s3dataBase    controller.S3Database
-	userRpcClient rpcclient.UserRpcClient
-	defaultExpire time.Duration
+	apiURL             string
+	thirdDatabase      controller.ThirdDatabase
+	s3dataBase         controller.S3Database
+	quotaDatabase      controller.QuotaDatabase
+	e2eeDatabase       controller.E2EEDatabase
+	userRpcClient      rpcclient.UserRpcClient
+	friendRpcClient    rpcclient.FriendRpcClient
+	groupRpcClient     rpcclient.GroupRpcClient
+	notificationSender *rpcclient.NotificationSender
+	defaultExpire      time.Duration
 }
 
 func (t *thirdServer) FcmUpdateToken(ctx context.Context, req *third.FcmUpdateTokenReq) (resp *third.FcmUpdateTokenResp, err error) {
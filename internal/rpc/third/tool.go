@@ -57,6 +57,10 @@ func checkUploadName(ctx context.Context, name string) error {
 		if !strings.HasPrefix(name, opUserID+"/") {
 			return errs.ErrNoPermission.Wrap(fmt.Sprintf("name must start with `%s/`", opUserID))
 		}
+		// 导出的归档由导出任务上传, 用户不能覆盖
+		if strings.HasPrefix(name, exportObjectPrefix) {
+			return errs.ErrNoPermission.Wrap("name cannot start with `" + exportObjectPrefix + "`")
+		}
 	}
 	return nil
 }
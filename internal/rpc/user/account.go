@@ -0,0 +1,213 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/msggateway"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	tablerelation "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
+)
+
+func (s *userServer) checkNotManager(userID string) error {
+	if authverify.IsManagerUserID(userID) {
+		return errs.ErrNoPermission.Wrap("app manager can not be deactivated, erased or suspended")
+	}
+	return nil
+}
+
+// DeactivateUser 停用账号, 已登录的设备被踢下线, 之后不能再获取token.
+func (s *userServer) DeactivateUser(ctx context.Context, req *userext.DeactivateUserReq) (*userext.DeactivateUserResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotManager(req.UserID); err != nil {
+		return nil, err
+	}
+	users, err := s.FindWithError(ctx, []string{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if users[0].Status != tablerelation.UserStatusDeactivated {
+		if err := s.UpdateByMap(ctx, req.UserID, map[string]any{"status": tablerelation.UserStatusDeactivated}); err != nil {
+			return nil, err
+		}
+		s.userInfoChanged(ctx, req.UserID)
+	}
+	// 重复停用时仍然吊销token, 以便清理停用后残留的连接
+	count, err := s.authDatabase.RevokeTokens(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	s.kickOffline(ctx, req.UserID)
+	log.ZInfo(ctx, "user deactivated", "userID", req.UserID, "revokedTokens", count)
+	return &userext.DeactivateUserResp{}, nil
+}
+
+func (s *userServer) ReactivateUser(ctx context.Context, req *userext.ReactivateUserReq) (*userext.ReactivateUserResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	users, err := s.FindWithError(ctx, []string{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if users[0].Status != tablerelation.UserStatusDeactivated {
+		return &userext.ReactivateUserResp{}, nil
+	}
+	job, err := s.userEraseDatabase.TakeUnfinishedJob(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		return nil, errs.ErrArgs.Wrap("user is being erased, jobID " + job.JobID)
+	}
+	if err := s.UpdateByMap(ctx, req.UserID, map[string]any{"status": tablerelation.UserStatusNormal}); err != nil {
+		return nil, err
+	}
+	s.userInfoChanged(ctx, req.UserID)
+	return &userext.ReactivateUserResp{}, nil
+}
+
+func (s *userServer) GetUsersAccountStatus(ctx context.Context, req *userext.GetUsersAccountStatusReq) (*userext.GetUsersAccountStatusResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	users, err := s.Find(ctx, utils.Distinct(req.UserIDs))
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetUsersAccountStatusResp{
+		Statuses: utils.Slice(users, func(user *tablerelation.UserModel) *userext.AccountStatus {
+			return &userext.AccountStatus{UserID: user.UserID, Status: user.Status}
+		}),
+	}, nil
+}
+
+// EraseUser 创建删除用户数据的任务, 由tools按步骤执行, 执行结果通过GetUserEraseJob查询.
+func (s *userServer) EraseUser(ctx context.Context, req *userext.EraseUserReq) (*userext.EraseUserResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotManager(req.UserID); err != nil {
+		return nil, err
+	}
+	job, err := s.userEraseDatabase.TakeUnfinishedJob(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		return &userext.EraseUserResp{Job: convert.UserEraseJobDB2Pb(job)}, nil
+	}
+	if _, err := s.FindWithError(ctx, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	job = &unRelationTb.UserEraseJobModel{
+		JobID:         utils.OperationIDGenerator(),
+		UserID:        req.UserID,
+		OpUserID:      mcontext.GetOpUserID(ctx),
+		AnonymizeMsgs: req.AnonymizeMsgs,
+		Status:        unRelationTb.UserEraseStatusPending,
+		Steps: utils.Slice(unRelationTb.UserEraseSteps, func(name string) *unRelationTb.UserEraseStepModel {
+			return &unRelationTb.UserEraseStepModel{Name: name, Status: unRelationTb.UserEraseStepPending}
+		}),
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	if err := s.userEraseDatabase.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return &userext.EraseUserResp{Job: convert.UserEraseJobDB2Pb(job)}, nil
+}
+
+func (s *userServer) GetUserEraseJob(ctx context.Context, req *userext.GetUserEraseJobReq) (*userext.GetUserEraseJobResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	job, err := s.userEraseDatabase.TakeJob(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetUserEraseJobResp{Job: convert.UserEraseJobDB2Pb(job)}, nil
+}
+
+func (s *userServer) GetUserEraseJobs(ctx context.Context, req *userext.GetUserEraseJobsReq) (*userext.GetUserEraseJobsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	total, jobs, err := s.userEraseDatabase.PageJobs(ctx, req.UserID, req.Status, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetUserEraseJobsResp{Total: total, Jobs: utils.Slice(jobs, convert.UserEraseJobDB2Pb)}, nil
+}
+
+// kickOffline 踢下用户所有平台的连接, 失败只记录日志.
+func (s *userServer) kickOffline(ctx context.Context, userID string) {
+	conns, err := s.RegisterCenter.GetConns(ctx, config.Config.RpcRegisterName.OpenImMessageGatewayName)
+	if err != nil {
+		log.ZError(ctx, "kickOffline GetConns failed", err, "userID", userID)
+		return
+	}
+	for _, conn := range conns {
+		client := msggateway.NewMsgGatewayClient(conn)
+		for platformID := range constant.PlatformID2Name {
+			kickReq := &msggateway.KickUserOfflineReq{KickUserIDList: []string{userID}, PlatformID: int32(platformID)}
+			if _, err := client.KickUserOffline(ctx, kickReq); err != nil {
+				log.ZError(ctx, "kickOffline failed", err, "kickReq", kickReq)
+			}
+		}
+	}
+}
+
+// userInfoChanged 停用或恢复后资料的可见性变化, 通知好友刷新.
+func (s *userServer) userInfoChanged(ctx context.Context, userID string) {
+	friendIDs, err := s.friendRpcClient.GetFriendIDs(ctx, userID)
+	if err != nil {
+		log.ZError(ctx, "userInfoChanged GetFriendIDs failed", err, "userID", userID)
+		return
+	}
+	for _, friendID := range friendIDs {
+		s.notificationSender.FriendInfoUpdatedNotification(ctx, userID, friendID)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/tools/mcontext"
+
+	cbapi "github.com/OpenIMSDK/Open-IM-Server/pkg/callbackstruct"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/http"
+)
+
+func toUserSuspensionCallback(ctx context.Context, command string, suspension *unRelationTb.UserSuspensionModel) *cbapi.CallbackUserSuspensionReq {
+	pb := convert.UserSuspensionDB2Pb(suspension)
+	return &cbapi.CallbackUserSuspensionReq{
+		CallbackCommand: cbapi.CallbackCommand(command),
+		OperationID:     mcontext.GetOperationID(ctx),
+		SuspensionID:    pb.SuspensionID,
+		UserID:          pb.UserID,
+		Scopes:          pb.Scopes,
+		Reason:          pb.Reason,
+		OpUserID:        pb.OpUserID,
+		StartTime:       pb.StartTime,
+		EndTime:         pb.EndTime,
+		Status:          pb.Status,
+		LiftOpUserID:    pb.LiftOpUserID,
+		LiftReason:      pb.LiftReason,
+		LiftTime:        pb.LiftTime,
+	}
+}
+
+func CallbackAfterSuspendUser(ctx context.Context, suspension *unRelationTb.UserSuspensionModel) error {
+	if !config.Config.Callback.CallbackAfterSuspendUser.Enable {
+		return nil
+	}
+	req := toUserSuspensionCallback(ctx, cbapi.CallbackAfterSuspendUserCommand, suspension)
+	resp := &cbapi.CallbackUserSuspensionResp{}
+	return http.CallBackPostReturn(ctx, config.Config.Callback.CallbackUrl, req, resp, config.Config.Callback.CallbackAfterSuspendUser)
+}
+
+func CallbackAfterLiftUserSuspension(ctx context.Context, suspension *unRelationTb.UserSuspensionModel) error {
+	if !config.Config.Callback.CallbackAfterLiftUserSuspension.Enable {
+		return nil
+	}
+	req := toUserSuspensionCallback(ctx, cbapi.CallbackAfterLiftUserSuspensionCommand, suspension)
+	resp := &cbapi.CallbackUserSuspensionResp{}
+	return http.CallBackPostReturn(ctx, config.Config.Callback.CallbackUrl, req, resp, config.Config.Callback.CallbackAfterLiftUserSuspension)
+}
@@ -0,0 +1,85 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
+)
+
+// SetDoNotDisturbSchedule 设置免打扰时段, 关闭时保留原时段设置.
+func (s *userServer) SetDoNotDisturbSchedule(ctx context.Context, req *userext.SetDoNotDisturbScheduleReq) (*userext.SetDoNotDisturbScheduleResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.FindWithError(ctx, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	args := map[string]any{"do_not_disturb": req.Schedule.Enable}
+	if req.Schedule.Enable {
+		args["do_not_disturb_start"] = req.Schedule.StartMinute
+		args["do_not_disturb_end"] = req.Schedule.EndMinute
+		args["do_not_disturb_timezone"] = req.Schedule.Timezone
+	}
+	if err := s.UpdateByMap(ctx, req.UserID, args); err != nil {
+		return nil, err
+	}
+	return &userext.SetDoNotDisturbScheduleResp{}, nil
+}
+
+func (s *userServer) GetDoNotDisturbSchedule(ctx context.Context, req *userext.GetDoNotDisturbScheduleReq) (*userext.GetDoNotDisturbScheduleResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	users, err := s.FindWithError(ctx, []string{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetDoNotDisturbScheduleResp{
+		Schedule:       convert.UserDoNotDisturbDB2Pb(users[0]),
+		InDoNotDisturb: users[0].InDoNotDisturb(time.Now()),
+	}, nil
+}
+
+// GetDoNotDisturbUserIDs 按各用户的时区判断当前是否处于免打扰时段, 不存在的用户忽略.
+func (s *userServer) GetDoNotDisturbUserIDs(ctx context.Context, req *userext.GetDoNotDisturbUserIDsReq) (*userext.GetDoNotDisturbUserIDsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	users, err := s.Find(ctx, utils.Distinct(req.UserIDs))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	resp := &userext.GetDoNotDisturbUserIDsResp{UserIDs: []string{}}
+	for _, user := range users {
+		if user.InDoNotDisturb(now) {
+			resp.UserIDs = append(resp.UserIDs, user.UserID)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/third"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
+)
+
+// ExportUser 创建导出用户数据的任务, 由tools执行.
+func (s *userServer) ExportUser(ctx context.Context, req *userext.ExportUserReq) (*userext.ExportUserResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.FindWithError(ctx, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	storage := config.Config.UserExport.Storage
+	if storage != unRelationTb.UserExportStorageLocal {
+		storage = unRelationTb.UserExportStorageObject
+	}
+	now := time.Now()
+	job := &unRelationTb.UserExportJobModel{
+		JobID:      utils.OperationIDGenerator(),
+		UserID:     req.UserID,
+		OpUserID:   mcontext.GetOpUserID(ctx),
+		Status:     unRelationTb.UserExportStatusPending,
+		Storage:    storage,
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	if err := s.userExportDatabase.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return &userext.ExportUserResp{Job: convert.UserExportJobDB2Pb(job)}, nil
+}
+
+func (s *userServer) GetUserExportJob(ctx context.Context, req *userext.GetUserExportJobReq) (*userext.GetUserExportJobResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	job, err := s.userExportDatabase.TakeJob(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &userext.GetUserExportJobResp{Job: convert.UserExportJobDB2Pb(job)}
+	if job.Status == unRelationTb.UserExportStatusFinished && job.Storage == unRelationTb.UserExportStorageObject {
+		// 下载地址每次重新生成, 过期后再次查询即可
+		urlResp, err := s.thirdRpcClient.Client.AccessURL(ctx, &third.AccessURLReq{Name: job.Location})
+		if err != nil {
+			return nil, err
+		}
+		resp.Job.Url = urlResp.Url
+		resp.Job.UrlExpireTime = urlResp.ExpireTime
+	}
+	return resp, nil
+}
+
+func (s *userServer) GetUserExportJobs(ctx context.Context, req *userext.GetUserExportJobsReq) (*userext.GetUserExportJobsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	total, jobs, err := s.userExportDatabase.PageJobs(ctx, req.UserID, req.Status, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetUserExportJobsResp{Total: total, Jobs: utils.Slice(jobs, convert.UserExportJobDB2Pb)}, nil
+}
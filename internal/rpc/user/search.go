@@ -0,0 +1,96 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	tablerelation "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
+)
+
+// SearchUsers 管理员可按任意条件搜索全部用户, 普通用户只能按userID前缀或昵称搜索可见的用户, 且只返回公开信息.
+func (s *userServer) SearchUsers(ctx context.Context, req *userext.SearchUsersReq) (*userext.SearchUsersResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	filter := &tablerelation.UserSearchFilter{
+		UserIDPrefix:    strings.TrimSpace(req.UserIDPrefix),
+		Nickname:        strings.TrimSpace(req.Nickname),
+		AppMangerLevels: req.AppMangerLevels,
+		ExcludeHidden:   !authverify.IsAppManagerUid(ctx),
+	}
+	if filter.ExcludeHidden {
+		if filter.UserIDPrefix == "" && filter.Nickname == "" {
+			return nil, errs.ErrArgs.Wrap("userIDPrefix or nickname is required")
+		}
+		if len(req.AppMangerLevels) > 0 || req.CreateTimeBegin > 0 || req.CreateTimeEnd > 0 {
+			return nil, errs.ErrNoPermission.Wrap("only app manager can filter by appMangerLevels or createTime")
+		}
+	}
+	if req.CreateTimeBegin > 0 {
+		filter.CreateTimeBegin = time.UnixMilli(req.CreateTimeBegin)
+	}
+	if req.CreateTimeEnd > 0 {
+		filter.CreateTimeEnd = time.UnixMilli(req.CreateTimeEnd)
+	}
+	users, total, err := s.Search(ctx, filter, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	if filter.ExcludeHidden {
+		return &userext.SearchUsersResp{Total: total, PublicUsers: convert.UsersDB2PublicPb(users)}, nil
+	}
+	return &userext.SearchUsersResp{Total: total, Users: convert.UsersDB2Pb(users)}, nil
+}
+
+func (s *userServer) SetUserSearchHidden(ctx context.Context, req *userext.SetUserSearchHiddenReq) (*userext.SetUserSearchHiddenResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	users, err := s.FindWithError(ctx, []string{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if users[0].SearchHidden != req.Hidden {
+		if err := s.UpdateByMap(ctx, req.UserID, map[string]any{"search_hidden": req.Hidden}); err != nil {
+			return nil, err
+		}
+	}
+	return &userext.SetUserSearchHiddenResp{}, nil
+}
+
+func (s *userServer) GetUserSearchHidden(ctx context.Context, req *userext.GetUserSearchHiddenReq) (*userext.GetUserSearchHiddenResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	users, err := s.FindWithError(ctx, []string{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetUserSearchHiddenResp{Hidden: users[0].SearchHidden}, nil
+}
@@ -0,0 +1,172 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
+)
+
+// 每次处理的到期封禁数.
+const expiredSuspensionBatch = 100
+
+var userSuspensionScopes = []string{
+	unRelationTb.UserSuspensionScopeLogin,
+	unRelationTb.UserSuspensionScopeSendMsg,
+	unRelationTb.UserSuspensionScopeCreateGroup,
+}
+
+// SuspendUser 封禁用户, 同一用户可以同时有多条封禁, 封禁登录时已登录的设备被踢下线.
+func (s *userServer) SuspendUser(ctx context.Context, req *userext.SuspendUserReq) (*userext.SuspendUserResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotManager(req.UserID); err != nil {
+		return nil, err
+	}
+	scopes := utils.Distinct(req.Scopes)
+	for _, scope := range scopes {
+		if !utils.IsContain(scope, userSuspensionScopes) {
+			return nil, errs.ErrArgs.Wrap("unknown scope " + scope)
+		}
+	}
+	now := time.Now()
+	var endTime time.Time
+	if req.EndTime > 0 {
+		endTime = time.UnixMilli(req.EndTime)
+		if !endTime.After(now) {
+			return nil, errs.ErrArgs.Wrap("endTime is before now")
+		}
+	}
+	if _, err := s.FindWithError(ctx, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	suspension := &unRelationTb.UserSuspensionModel{
+		SuspensionID: utils.OperationIDGenerator(),
+		UserID:       req.UserID,
+		Scopes:       scopes,
+		Reason:       req.Reason,
+		OpUserID:     mcontext.GetOpUserID(ctx),
+		StartTime:    now,
+		EndTime:      endTime,
+		Status:       unRelationTb.UserSuspensionStatusActive,
+		CreateTime:   now,
+	}
+	if err := s.suspensionDatabase.Suspend(ctx, suspension); err != nil {
+		return nil, err
+	}
+	if suspension.HasScope(unRelationTb.UserSuspensionScopeLogin) {
+		count, err := s.authDatabase.RevokeTokens(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		s.kickOffline(ctx, req.UserID)
+		log.ZInfo(ctx, "user login suspended", "userID", req.UserID, "revokedTokens", count)
+	}
+	if err := CallbackAfterSuspendUser(ctx, suspension); err != nil {
+		log.ZWarn(ctx, "CallbackAfterSuspendUser", err, "suspensionID", suspension.SuspensionID)
+	}
+	return &userext.SuspendUserResp{Suspension: convert.UserSuspensionDB2Pb(suspension)}, nil
+}
+
+// LiftUserSuspension 已解除或已到期的封禁直接返回成功.
+func (s *userServer) LiftUserSuspension(ctx context.Context, req *userext.LiftUserSuspensionReq) (*userext.LiftUserSuspensionResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	suspension, err := s.suspensionDatabase.TakeSuspension(ctx, req.SuspensionID)
+	if err != nil {
+		return nil, err
+	}
+	if suspension.Status != unRelationTb.UserSuspensionStatusActive {
+		return &userext.LiftUserSuspensionResp{}, nil
+	}
+	lifted, err := s.suspensionDatabase.Lift(ctx, suspension, unRelationTb.UserSuspensionStatusLifted, mcontext.GetOpUserID(ctx), req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if lifted {
+		if err := CallbackAfterLiftUserSuspension(ctx, suspension); err != nil {
+			log.ZWarn(ctx, "CallbackAfterLiftUserSuspension", err, "suspensionID", suspension.SuspensionID)
+		}
+	}
+	return &userext.LiftUserSuspensionResp{}, nil
+}
+
+func (s *userServer) GetUserSuspensions(ctx context.Context, req *userext.GetUserSuspensionsReq) (*userext.GetUserSuspensionsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	total, suspensions, err := s.suspensionDatabase.PageSuspensions(ctx, req.UserID, req.Status, req.Pagination.PageNumber, req.Pagination.ShowNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetUserSuspensionsResp{Total: total, Suspensions: utils.Slice(suspensions, convert.UserSuspensionDB2Pb)}, nil
+}
+
+// LiftExpiredUserSuspensions 到期的封禁在校验时已不生效, 这里只更新记录状态、刷新redis并回调.
+func (s *userServer) LiftExpiredUserSuspensions(
+	ctx context.Context,
+	req *userext.LiftExpiredUserSuspensionsReq,
+) (*userext.LiftExpiredUserSuspensionsResp, error) {
+	if err := req.Check(); err != nil {
+		return nil, err
+	}
+	if err := authverify.CheckAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var count int64
+	for {
+		suspensions, err := s.suspensionDatabase.FindExpiredSuspensions(ctx, expiredSuspensionBatch)
+		if err != nil {
+			return nil, err
+		}
+		for _, suspension := range suspensions {
+			lifted, err := s.suspensionDatabase.Lift(ctx, suspension, unRelationTb.UserSuspensionStatusExpired, "", "")
+			if err != nil {
+				return nil, err
+			}
+			if !lifted {
+				continue
+			}
+			count++
+			if err := CallbackAfterLiftUserSuspension(ctx, suspension); err != nil {
+				log.ZWarn(ctx, "CallbackAfterLiftUserSuspension", err, "suspensionID", suspension.SuspensionID)
+			}
+		}
+		if len(suspensions) < expiredSuspensionBatch {
+			return &userext.LiftExpiredUserSuspensionsResp{Count: count}, nil
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/OpenIMSDK/protocol/sdkws"
 	"github.com/OpenIMSDK/tools/errs"
 	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/OpenIMSDK/tools/tx"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/authverify"
@@ -37,6 +38,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/relation"
 	tablerelation "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient/notification"
 
@@ -47,6 +49,11 @@ import (
 
 type userServer struct {
 	controller.UserDatabase
+	authDatabase       controller.AuthDatabase
+	userEraseDatabase  controller.UserEraseDatabase
+	userExportDatabase controller.UserExportDatabase
+	suspensionDatabase controller.UserSuspensionDatabase
+	thirdRpcClient     *rpcclient.Third
 	notificationSender *notification.FriendNotificationSender
 	friendRpcClient    *rpcclient.FriendRpcClient
 	RegisterCenter     registry.SvcDiscoveryRegistry
@@ -68,6 +75,15 @@ func Start(client registry.SvcDiscoveryRegistry, server *grpc.Server) error {
 	if err := db.AutoMigrate(&tablerelation.UserModel{}); err != nil {
 		return err
 	}
+	if err := mongo.CreateUserEraseIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateUserExportIndex(); err != nil {
+		return err
+	}
+	if err := mongo.CreateUserSuspensionIndex(); err != nil {
+		return err
+	}
 	users := make([]*tablerelation.UserModel, 0)
 	if len(config.Config.Manager.UserID) != len(config.Config.Manager.Nickname) {
 		return errors.New("len(config.Config.Manager.AppManagerUid) != len(config.Config.Manager.Nickname)")
@@ -75,6 +91,12 @@ func Start(client registry.SvcDiscoveryRegistry, server *grpc.Server) error {
 	for k, v := range config.Config.Manager.UserID {
 		users = append(users, &tablerelation.UserModel{UserID: v, Nickname: config.Config.Manager.Nickname[k], AppMangerLevel: constant.AppAdmin})
 	}
+	authDatabase := controller.NewAuthDatabase(cache.NewMsgCacheModel(rdb), config.Config.Secret, config.Config.TokenPolicy.Expire)
+	suspensionDB := unrelation.NewUserSuspensionMongoDriver(mongo.GetDatabase())
+	suspensionDatabase := controller.NewUserSuspensionDatabase(
+		suspensionDB,
+		cache.NewUserSuspensionCacheRedis(rdb, suspensionDB, cache.GetDefaultOpt()),
+	)
 	userDB := relation.NewUserGorm(db)
 	cache := cache.NewUserCacheRedis(rdb, userDB, cache.GetDefaultOpt())
 	userMongoDB := unrelation.NewUserMongoDriver(mongo.GetDatabase())
@@ -83,11 +105,17 @@ func Start(client registry.SvcDiscoveryRegistry, server *grpc.Server) error {
 	msgRpcClient := rpcclient.NewMessageRpcClient(client)
 	u := &userServer{
 		UserDatabase:       database,
+		authDatabase:       authDatabase,
+		userEraseDatabase:  controller.NewUserEraseDatabase(unrelation.NewUserEraseMongoDriver(mongo.GetDatabase())),
+		userExportDatabase: controller.NewUserExportDatabase(unrelation.NewUserExportMongoDriver(mongo.GetDatabase())),
+		suspensionDatabase: suspensionDatabase,
+		thirdRpcClient:     rpcclient.NewThird(client),
 		RegisterCenter:     client,
 		friendRpcClient:    &friendRpcClient,
 		notificationSender: notification.NewFriendNotificationSender(&msgRpcClient, notification.WithDBFunc(database.FindWithError)),
 	}
 	pbuser.RegisterUserServer(server, u)
+	userext.RegisterUserExtServer(server, u)
 	return u.UserDatabase.InitOnce(context.Background(), users)
 }
 
@@ -97,6 +125,16 @@ func (s *userServer) GetDesignateUsers(ctx context.Context, req *pbuser.GetDesig
 	if err != nil {
 		return nil, err
 	}
+	if !authverify.IsAppManagerUid(ctx) {
+		for _, user := range users {
+			// 停用账号的资料对其他用户隐藏
+			if user.Status == tablerelation.UserStatusDeactivated && user.UserID != mcontext.GetOpUserID(ctx) {
+				user.Nickname = ""
+				user.FaceURL = ""
+				user.Ex = ""
+			}
+		}
+	}
 	resp.UsersInfo = convert.UsersDB2Pb(users)
 	if err != nil {
 		return nil, err
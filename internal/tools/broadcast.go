@@ -0,0 +1,244 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/OpenIMSDK/protocol/msg"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+const (
+	broadcastPollInterval = time.Second * 5
+	// a running job whose lease is not renewed within the timeout is taken over by another worker
+	broadcastStaleTimeout = time.Minute * 5
+	// renewed well within the stale timeout so that a slow batch never loses the lease while sending
+	broadcastLeaseRenewInterval = time.Minute
+)
+
+// StartBroadcast runs the pending broadcast jobs one by one until ctx is done.
+func (c *MsgTool) StartBroadcast(ctx context.Context) {
+	ticker := time.NewTicker(broadcastPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.RunBroadcastJobs()
+		}
+	}
+}
+
+func (c *MsgTool) RunBroadcastJobs() {
+	for {
+		ctx := mcontext.NewCtx(utils.GetSelfFuncName() + "-" + utils.OperationIDGenerator())
+		job, err := c.broadcastDatabase.ClaimJob(ctx, broadcastStaleTimeout)
+		if err != nil {
+			log.ZError(ctx, "claim broadcast job failed", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		c.runBroadcastJob(mcontext.SetOpUserID(ctx, job.OpUserID), job)
+	}
+}
+
+func (c *MsgTool) GetBroadcastJob(ctx context.Context, jobID string) (*unRelationTb.BroadcastJobModel, error) {
+	return c.broadcastDatabase.TakeJob(ctx, jobID)
+}
+
+func (c *MsgTool) GetBroadcastJobs(ctx context.Context, status []int32, pageNumber, showNumber int32) (int64, []*unRelationTb.BroadcastJobModel, error) {
+	return c.broadcastDatabase.PageJobs(ctx, status, pageNumber, showNumber)
+}
+
+func (c *MsgTool) CancelBroadcastJob(ctx context.Context, jobID string) error {
+	ok, err := c.broadcastDatabase.CancelJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errs.ErrArgs.Wrap("broadcast job has already stopped")
+	}
+	return nil
+}
+
+func (c *MsgTool) runBroadcastJob(ctx context.Context, job *unRelationTb.BroadcastJobModel) {
+	log.ZInfo(ctx, "run broadcast job", "jobID", job.JobID, "segment", job.Segment.Type, "cursor", job.Cursor)
+	var msgData sdkws.MsgData
+	if err := proto.Unmarshal(job.MsgData, &msgData); err != nil {
+		c.finishBroadcastJob(ctx, job, unRelationTb.BroadcastStatusFailed, err)
+		return
+	}
+	if err := c.setBroadcastTargets(ctx, job); err != nil {
+		log.ZError(ctx, "set broadcast targets failed", err, "jobID", job.JobID)
+		return
+	}
+	rate := job.Rate
+	if rate <= 0 {
+		rate = int32(config.Config.Broadcast.Rate)
+	}
+	if rate <= 0 {
+		rate = 100
+	}
+	batchSize := config.Config.Broadcast.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+	cursor := job.Cursor
+	renewTime := time.Now()
+	for {
+		userIDs, err := c.nextBroadcastUserIDs(ctx, job, cursor, batchSize)
+		if err != nil {
+			log.ZError(ctx, "get broadcast user ids failed", err, "jobID", job.JobID, "cursor", cursor)
+			return
+		}
+		if len(userIDs) == 0 {
+			c.finishBroadcastJob(ctx, job, unRelationTb.BroadcastStatusFinished, nil)
+			return
+		}
+		var (
+			successCount  int64
+			failedUserIDs []string
+		)
+		for _, userID := range userIDs {
+			<-ticker.C
+			if time.Since(renewTime) > broadcastLeaseRenewInterval {
+				// the progress of this batch is not saved yet, stop without sending if another worker has taken over
+				running, err := c.broadcastDatabase.RenewJob(ctx, job.JobID, job.LeaseID)
+				if err != nil {
+					log.ZError(ctx, "renew broadcast job failed", err, "jobID", job.JobID)
+					return
+				}
+				if !running {
+					log.ZInfo(ctx, "broadcast job stopped or taken over", "jobID", job.JobID, "cursor", cursor)
+					return
+				}
+				renewTime = time.Now()
+			}
+			req := &msg.SendMsgReq{MsgData: proto.Clone(&msgData).(*sdkws.MsgData)}
+			req.MsgData.RecvID = userID
+			req.MsgData.ClientMsgID = utils.GetMsgID(msgData.SendID)
+			req.MsgData.CreateTime = utils.GetCurrentTimestampByMill()
+			if _, err := c.msgRpcClient.SendMsg(ctx, req); err != nil {
+				log.ZWarn(ctx, "broadcast send msg failed", err, "jobID", job.JobID, "recvID", userID)
+				failedUserIDs = append(failedUserIDs, userID)
+				continue
+			}
+			successCount++
+		}
+		cursor = userIDs[len(userIDs)-1]
+		running, err := c.broadcastDatabase.AddJobProgress(ctx, job.JobID, job.LeaseID, cursor, successCount, int64(len(failedUserIDs)), failedUserIDs)
+		if err != nil {
+			log.ZError(ctx, "save broadcast progress failed", err, "jobID", job.JobID, "cursor", cursor)
+			return
+		}
+		if !running {
+			log.ZInfo(ctx, "broadcast job stopped", "jobID", job.JobID, "cursor", cursor)
+			return
+		}
+		renewTime = time.Now()
+	}
+}
+
+// setBroadcastTargets resolves the group members once and counts the target users.
+func (c *MsgTool) setBroadcastTargets(ctx context.Context, job *unRelationTb.BroadcastJobModel) error {
+	if job.Total > 0 || job.Cursor != "" {
+		return nil
+	}
+	var (
+		userIDs []string
+		total   int64
+		err     error
+	)
+	switch job.Segment.Type {
+	case unRelationTb.BroadcastSegmentUserIDs:
+		return nil
+	case unRelationTb.BroadcastSegmentGroups:
+		for _, groupID := range job.Segment.GroupIDs {
+			memberUserIDs, err := c.groupDatabase.FindGroupMemberUserID(ctx, groupID)
+			if err != nil {
+				return err
+			}
+			userIDs = append(userIDs, memberUserIDs...)
+		}
+		userIDs = utils.Distinct(userIDs)
+		sort.Strings(userIDs)
+		total = int64(len(userIDs))
+	default:
+		total, err = c.userDatabase.CountByFilter(ctx, broadcastUserFilter(&job.Segment))
+		if err != nil {
+			return err
+		}
+	}
+	running, err := c.broadcastDatabase.SetJobTargets(ctx, job.JobID, job.LeaseID, userIDs, total)
+	if err != nil {
+		return err
+	}
+	if !running {
+		return errs.ErrArgs.Wrap("broadcast job is not running")
+	}
+	job.Segment.UserIDs = userIDs
+	job.Total = total
+	return nil
+}
+
+func (c *MsgTool) nextBroadcastUserIDs(ctx context.Context, job *unRelationTb.BroadcastJobModel, cursor string, limit int) ([]string, error) {
+	switch job.Segment.Type {
+	case unRelationTb.BroadcastSegmentUserIDs, unRelationTb.BroadcastSegmentGroups:
+		userIDs := job.Segment.UserIDs
+		i := sort.SearchStrings(userIDs, cursor)
+		if i < len(userIDs) && userIDs[i] == cursor {
+			i++
+		}
+		return userIDs[i:utils.Min(i+limit, len(userIDs))], nil
+	default:
+		return c.userDatabase.FindUserIDsAfter(ctx, broadcastUserFilter(&job.Segment), cursor, limit)
+	}
+}
+
+func (c *MsgTool) finishBroadcastJob(ctx context.Context, job *unRelationTb.BroadcastJobModel, status int32, cause error) {
+	var errMsg string
+	if cause != nil {
+		log.ZError(ctx, "broadcast job failed", cause, "jobID", job.JobID)
+		errMsg = cause.Error()
+	}
+	if err := c.broadcastDatabase.FinishJob(ctx, job.JobID, job.LeaseID, status, errMsg); err != nil {
+		log.ZError(ctx, "finish broadcast job failed", err, "jobID", job.JobID, "status", status)
+	}
+}
+
+func broadcastUserFilter(segment *unRelationTb.BroadcastSegmentModel) *relation.UserFilter {
+	if segment.Type == unRelationTb.BroadcastSegmentAll {
+		return nil
+	}
+	return &relation.UserFilter{Ex: segment.Ex, AppMangerLevels: segment.AppMangerLevels}
+}
@@ -48,6 +48,57 @@ func StartTask() error {
 		fmt.Println("start conversationsDestructMsgs cron failed", err.Error(), config.Config.ChatRecordsClearTime)
 		panic(err)
 	}
+	log.ZInfo(context.Background(), "start groupRequestClear cron task", "cron config", config.Config.GroupRequestClearTime)
+	_, err = c.AddFunc(config.Config.GroupRequestClearTime, msgTool.ClearExpiredGroupRequests)
+	if err != nil {
+		fmt.Println("start clearExpiredGroupRequests cron failed", err.Error(), config.Config.GroupRequestClearTime)
+		panic(err)
+	}
+	if config.Config.UserSuspensionExpireTime != "" {
+		log.ZInfo(context.Background(), "start userSuspensionExpire cron task", "cron config", config.Config.UserSuspensionExpireTime)
+		_, err = c.AddFunc(config.Config.UserSuspensionExpireTime, msgTool.LiftExpiredUserSuspensions)
+		if err != nil {
+			fmt.Println("start liftExpiredUserSuspensions cron failed", err.Error(), config.Config.UserSuspensionExpireTime)
+			panic(err)
+		}
+	}
+	if config.Config.FriendRequest.Expire > 0 {
+		log.ZInfo(context.Background(), "start friendRequestClear cron task", "cron config", config.Config.FriendRequest.ClearTime)
+		_, err = c.AddFunc(config.Config.FriendRequest.ClearTime, msgTool.ClearExpiredFriendRequests)
+		if err != nil {
+			fmt.Println("start clearExpiredFriendRequests cron failed", err.Error(), config.Config.FriendRequest.ClearTime)
+			panic(err)
+		}
+	}
+	if config.Config.GroupOwnerSuccession.Enable {
+		log.ZInfo(context.Background(), "start groupOwnerSuccession cron task", "cron config", config.Config.GroupOwnerSuccession.CronTime)
+		_, err = c.AddFunc(config.Config.GroupOwnerSuccession.CronTime, msgTool.SucceedGroupOwners)
+		if err != nil {
+			fmt.Println("start succeedGroupOwners cron failed", err.Error(), config.Config.GroupOwnerSuccession.CronTime)
+			panic(err)
+		}
+	}
+	if config.Config.FriendSuggestion.Enable {
+		log.ZInfo(context.Background(), "start friendSuggestion cron task", "cron config", config.Config.FriendSuggestion.CronTime)
+		_, err = c.AddFunc(config.Config.FriendSuggestion.CronTime, msgTool.RefreshFriendSuggestions)
+		if err != nil {
+			fmt.Println("start refreshFriendSuggestions cron failed", err.Error(), config.Config.FriendSuggestion.CronTime)
+			panic(err)
+		}
+	}
+	if config.Config.VersionLog.RetainDays > 0 {
+		log.ZInfo(context.Background(), "start versionLogTruncate cron task", "cron config", config.Config.VersionLog.CronTime)
+		_, err = c.AddFunc(config.Config.VersionLog.CronTime, msgTool.TruncateVersionLogs)
+		if err != nil {
+			fmt.Println("start truncateVersionLogs cron failed", err.Error(), config.Config.VersionLog.CronTime)
+			panic(err)
+		}
+	}
+	log.ZInfo(context.Background(), "start msgTTL task", "interval", config.Config.MsgTTL.Interval)
+	go msgTool.StartMsgsDestruct(context.Background())
+	go msgTool.StartBroadcast(context.Background())
+	go msgTool.StartUserErase(context.Background())
+	go msgTool.StartUserExport(context.Background())
 	c.Start()
 	wg.Wait()
 	return nil
@@ -0,0 +1,37 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+)
+
+// ClearExpiredFriendRequests deletes the unhandled friend requests older than friendRequest.expire.
+func (c *MsgTool) ClearExpiredFriendRequests() {
+	ctx := mcontext.NewCtx(utils.GetSelfFuncName())
+	before := time.Now().Add(-time.Duration(config.Config.FriendRequest.Expire) * time.Second)
+	count, err := c.friendDatabase.ClearExpiredFriendRequests(ctx, before)
+	if err != nil {
+		log.ZError(ctx, "ClearExpiredFriendRequests failed", err, "before", before)
+		return
+	}
+	log.ZInfo(ctx, "expired friend requests cleared", "count", count, "before", before)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
+)
+
+const friendSuggestionBatchSize = 100
+
+// RefreshFriendSuggestions precomputes the friend suggestions of all users by the friend rpc,
+// so that /friend/get_suggestions does not walk the friend graph on request.
+func (c *MsgTool) RefreshFriendSuggestions() {
+	if len(config.Config.Manager.UserID) == 0 {
+		log.ZWarn(context.Background(), "manager userID is not configured, skip friend suggestion", nil)
+		return
+	}
+	ctx := mcontext.WithOpUserIDContext(mcontext.NewCtx(utils.GetSelfFuncName()), config.Config.Manager.UserID[0])
+	var lastUserID string
+	for {
+		userIDs, err := c.userDatabase.FindUserIDsAfter(ctx, nil, lastUserID, friendSuggestionBatchSize)
+		if err != nil {
+			log.ZError(ctx, "FindUserIDsAfter failed", err, "lastUserID", lastUserID)
+			return
+		}
+		if len(userIDs) == 0 {
+			return
+		}
+		lastUserID = userIDs[len(userIDs)-1]
+		if _, err := c.friendRpcClient.ExtClient.RefreshFriendSuggestions(ctx, &friendext.RefreshFriendSuggestionsReq{UserIDs: userIDs}); err != nil {
+			log.ZWarn(ctx, "RefreshFriendSuggestions failed", err, "lastUserID", lastUserID)
+		}
+		if len(userIDs) < friendSuggestionBatchSize {
+			return
+		}
+	}
+}
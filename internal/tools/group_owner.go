@@ -0,0 +1,109 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+)
+
+const groupOwnerBatchSize = 500
+
+// SucceedGroupOwners hands the groups whose owner is deleted or inactive over to a successor by the group rpc.
+func (c *MsgTool) SucceedGroupOwners() {
+	if len(config.Config.Manager.UserID) == 0 {
+		log.ZWarn(context.Background(), "manager userID is not configured, skip group owner succession", nil)
+		return
+	}
+	ctx := mcontext.WithOpUserIDContext(mcontext.NewCtx(utils.GetSelfFuncName()), config.Config.Manager.UserID[0])
+	var since time.Time
+	if days := config.Config.GroupOwnerSuccession.InactiveDays; days > 0 {
+		since = time.Now().AddDate(0, 0, -days)
+	}
+	var lastGroupID string
+	for {
+		owners, err := c.groupDatabase.FindGroupOwnersAfter(ctx, lastGroupID, groupOwnerBatchSize)
+		if err != nil {
+			log.ZError(ctx, "FindGroupOwnersAfter failed", err, "lastGroupID", lastGroupID)
+			return
+		}
+		if len(owners) == 0 {
+			return
+		}
+		lastGroupID = owners[len(owners)-1].GroupID
+		for _, owner := range c.findGoneGroupOwners(ctx, owners, since) {
+			resp, err := c.groupRpcClient.ExtClient.ReassignGroupOwner(ctx, &groupext.ReassignGroupOwnerReq{GroupID: owner.GroupID})
+			if err != nil {
+				log.ZWarn(ctx, "ReassignGroupOwner failed", err, "groupID", owner.GroupID, "ownerUserID", owner.UserID)
+				continue
+			}
+			log.ZInfo(ctx, "group owner succeeded", "groupID", owner.GroupID, "oldOwnerUserID", owner.UserID, "newOwnerUserID", resp.NewOwnerUserID)
+		}
+		if len(owners) < groupOwnerBatchSize {
+			return
+		}
+	}
+}
+
+// findGoneGroupOwners returns the owners who are deleted, or have not logged in or sent any message since,
+// a zero since checks deleted owners only.
+func (c *MsgTool) findGoneGroupOwners(ctx context.Context, owners []*relation.GroupMemberModel, since time.Time) []*relation.GroupMemberModel {
+	groups, err := c.groupDatabase.FindNotDismissedGroup(ctx, utils.Slice(owners, func(e *relation.GroupMemberModel) string { return e.GroupID }))
+	if err != nil {
+		log.ZError(ctx, "FindNotDismissedGroup failed", err)
+		return nil
+	}
+	groupIDs := utils.SliceSet(utils.Slice(groups, func(e *relation.GroupModel) string { return e.GroupID }))
+	ownerUserIDs := utils.Distinct(utils.Slice(owners, func(e *relation.GroupMemberModel) string { return e.UserID }))
+	users, err := c.userDatabase.Find(ctx, ownerUserIDs)
+	if err != nil {
+		log.ZError(ctx, "find group owners failed", err)
+		return nil
+	}
+	userIDs := utils.SliceSet(utils.Slice(users, func(e *relation.UserModel) string { return e.UserID }))
+	var lastActiveTimes map[string]time.Time
+	if !since.IsZero() {
+		lastActiveTimes, err = c.userActivityDatabase.FindLastActiveTimes(ctx, ownerUserIDs)
+		if err != nil {
+			log.ZError(ctx, "FindLastActiveTimes failed", err)
+			return nil
+		}
+	}
+	var gone []*relation.GroupMemberModel
+	for _, owner := range owners {
+		if _, ok := groupIDs[owner.GroupID]; !ok {
+			continue
+		}
+		if _, ok := userIDs[owner.UserID]; !ok {
+			gone = append(gone, owner)
+			continue
+		}
+		if since.IsZero() || owner.JoinTime.After(since) {
+			continue
+		}
+		if lastActiveTimes[owner.UserID].Before(since) {
+			gone = append(gone, owner)
+		}
+	}
+	return gone
+}
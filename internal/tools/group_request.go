@@ -0,0 +1,40 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+// ClearExpiredGroupRequests deletes the unhandled join requests older than the requestExpire of their group join policy.
+func (c *MsgTool) ClearExpiredGroupRequests() {
+	ctx := mcontext.NewCtx(utils.GetSelfFuncName())
+	policies, err := c.joinPolicyDatabase.FindRequestExpirePolicies(ctx)
+	if err != nil {
+		log.ZError(ctx, "FindRequestExpirePolicies failed", err)
+		return
+	}
+	now := time.Now()
+	for _, policy := range policies {
+		before := now.Add(-time.Duration(policy.RequestExpire) * time.Second)
+		if err := c.joinPolicyDatabase.ClearExpiredRequests(ctx, policy.GroupID, before); err != nil {
+			log.ZError(ctx, "ClearExpiredRequests failed", err, "groupID", policy.GroupID, "before", before)
+		}
+	}
+}
@@ -47,10 +47,35 @@ type MsgTool struct {
 	userDatabase          controller.UserDatabase
 	groupDatabase         controller.GroupDatabase
 	msgNotificationSender *notification.MsgNotificationSender
+	broadcastDatabase     controller.BroadcastDatabase
+	msgRpcClient          *rpcclient.MessageRpcClient
+	joinPolicyDatabase    controller.GroupJoinPolicyDatabase
+	groupRpcClient        *rpcclient.GroupRpcClient
+	friendRpcClient       *rpcclient.FriendRpcClient
+	friendDatabase        controller.FriendDatabase
+	versionLogDatabase    controller.VersionLogDatabase
+	blackDatabase         controller.BlackDatabase
+	userEraseDatabase     controller.UserEraseDatabase
+	userRpcClient         *rpcclient.UserRpcClient
+	thirdRpcClient        *rpcclient.Third
+	userExportDatabase    controller.UserExportDatabase
+	userActivityDatabase  controller.UserActivityDatabase
+	friendLabelDatabase   controller.FriendLabelDatabase
+	mentionDatabase       controller.MentionDatabase
+	msgPinDatabase        controller.MsgPinDatabase
+	e2eeDatabase          controller.E2EEDatabase
 }
 
 func NewMsgTool(msgDatabase controller.CommonMsgDatabase, userDatabase controller.UserDatabase,
 	groupDatabase controller.GroupDatabase, conversationDatabase controller.ConversationDatabase, msgNotificationSender *notification.MsgNotificationSender,
+	broadcastDatabase controller.BroadcastDatabase, msgRpcClient *rpcclient.MessageRpcClient,
+	joinPolicyDatabase controller.GroupJoinPolicyDatabase, groupRpcClient *rpcclient.GroupRpcClient,
+	friendRpcClient *rpcclient.FriendRpcClient, friendDatabase controller.FriendDatabase,
+	versionLogDatabase controller.VersionLogDatabase, blackDatabase controller.BlackDatabase,
+	userEraseDatabase controller.UserEraseDatabase, userRpcClient *rpcclient.UserRpcClient, thirdRpcClient *rpcclient.Third,
+	userExportDatabase controller.UserExportDatabase, userActivityDatabase controller.UserActivityDatabase,
+	friendLabelDatabase controller.FriendLabelDatabase, mentionDatabase controller.MentionDatabase,
+	msgPinDatabase controller.MsgPinDatabase, e2eeDatabase controller.E2EEDatabase,
 ) *MsgTool {
 	return &MsgTool{
 		msgDatabase:           msgDatabase,
@@ -58,6 +83,23 @@ func NewMsgTool(msgDatabase controller.CommonMsgDatabase, userDatabase controlle
 		groupDatabase:         groupDatabase,
 		conversationDatabase:  conversationDatabase,
 		msgNotificationSender: msgNotificationSender,
+		broadcastDatabase:     broadcastDatabase,
+		msgRpcClient:          msgRpcClient,
+		joinPolicyDatabase:    joinPolicyDatabase,
+		groupRpcClient:        groupRpcClient,
+		friendRpcClient:       friendRpcClient,
+		friendDatabase:        friendDatabase,
+		versionLogDatabase:    versionLogDatabase,
+		blackDatabase:         blackDatabase,
+		userEraseDatabase:     userEraseDatabase,
+		userRpcClient:         userRpcClient,
+		thirdRpcClient:        thirdRpcClient,
+		userExportDatabase:    userExportDatabase,
+		userActivityDatabase:  userActivityDatabase,
+		friendLabelDatabase:   friendLabelDatabase,
+		mentionDatabase:       mentionDatabase,
+		msgPinDatabase:        msgPinDatabase,
+		e2eeDatabase:          e2eeDatabase,
 	}
 }
 
@@ -91,14 +133,48 @@ func InitMsgTool() (*MsgTool, error) {
 		userMongoDB,
 	)
 	groupDatabase := controller.InitGroupDatabase(db, rdb, mongo.GetDatabase())
+	versionLogDB := unrelation.NewVersionLogMongoDriver(mongo.GetDatabase())
 	conversationDatabase := controller.NewConversationDatabase(
 		relation.NewConversationGorm(db),
 		cache.NewConversationRedis(rdb, cache.GetDefaultOpt(), relation.NewConversationGorm(db)),
 		tx.NewGorm(db),
+		versionLogDB,
 	)
 	msgRpcClient := rpcclient.NewMessageRpcClient(discov)
 	msgNotificationSender := notification.NewMsgNotificationSender(rpcclient.WithRpcClient(&msgRpcClient))
-	msgTool := NewMsgTool(msgDatabase, userDatabase, groupDatabase, conversationDatabase, msgNotificationSender)
+	broadcastDatabase := controller.NewBroadcastDatabase(unrelation.NewBroadcastMongoDriver(mongo.GetDatabase()))
+	joinPolicyDatabase := controller.NewGroupJoinPolicyDatabase(
+		unrelation.NewGroupJoinPolicyMongoDriver(mongo.GetDatabase()),
+		unrelation.NewGroupJoinAnswerMongoDriver(mongo.GetDatabase()),
+		relation.NewGroupRequest(db),
+	)
+	groupRpcClient := rpcclient.NewGroupRpcClient(discov)
+	friendRpcClient := rpcclient.NewFriendRpcClient(discov)
+	friendDB := relation.NewFriendGorm(db)
+	friendDatabase := controller.NewFriendDatabase(
+		friendDB,
+		relation.NewFriendRequestGorm(db),
+		cache.NewFriendCacheRedis(rdb, friendDB, cache.GetDefaultOpt()),
+		tx.NewGorm(db),
+		versionLogDB,
+	)
+	blackDB := relation.NewBlackGorm(db)
+	blackDatabase := controller.NewBlackDatabase(blackDB, cache.NewBlackCacheRedis(rdb, blackDB, cache.GetDefaultOpt()))
+	userEraseDatabase := controller.NewUserEraseDatabase(unrelation.NewUserEraseMongoDriver(mongo.GetDatabase()))
+	userRpcClient := rpcclient.NewUserRpcClient(discov)
+	userExportDatabase := controller.NewUserExportDatabase(unrelation.NewUserExportMongoDriver(mongo.GetDatabase()))
+	friendLabelDatabase := controller.NewFriendLabelDatabase(relation.NewFriendLabelDB(db), relation.NewFriendLabelMemberDB(db), tx.NewGorm(db))
+	e2eeDatabase := controller.NewE2EEDatabase(
+		unrelation.NewE2EEKeyBundleMongoDriver(mongo.GetDatabase()),
+		unrelation.NewE2EEOneTimePreKeyMongoDriver(mongo.GetDatabase()),
+		unrelation.NewE2EESenderKeyMongoDriver(mongo.GetDatabase()),
+		cache.NewE2EECacheRedis(rdb),
+	)
+	msgTool := NewMsgTool(msgDatabase, userDatabase, groupDatabase, conversationDatabase, msgNotificationSender, broadcastDatabase, &msgRpcClient, joinPolicyDatabase,
+		&groupRpcClient, &friendRpcClient, friendDatabase, controller.NewVersionLogDatabase(versionLogDB), blackDatabase,
+		userEraseDatabase, &userRpcClient, rpcclient.NewThird(discov), userExportDatabase, controller.InitUserActivityDatabase(rdb, mongo.GetDatabase()),
+		friendLabelDatabase, controller.NewMentionDatabase(unrelation.NewMentionMongoDriver(mongo.GetDatabase())),
+		controller.NewMsgPinDatabase(unrelation.NewMsgPinMongoDriver(mongo.GetDatabase())), e2eeDatabase)
 	return msgTool, nil
 }
 
@@ -0,0 +1,96 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+)
+
+// msgDestructRetryDelay 物理删除失败的消息延迟重试, 避免同一轮中反复取出.
+const msgDestructRetryDelay = time.Minute
+
+// StartMsgsDestruct polls the destruct queue for burn after reading and ttl msgs until ctx is done.
+func (c *MsgTool) StartMsgsDestruct(ctx context.Context) {
+	interval := time.Duration(config.Config.MsgTTL.Interval) * time.Second
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.MsgsDestruct()
+		}
+	}
+}
+
+func (c *MsgTool) MsgsDestruct() {
+	batchSize := int64(config.Config.MsgTTL.BatchSize)
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	for {
+		ctx := mcontext.NewCtx(utils.GetSelfFuncName() + "-" + utils.OperationIDGenerator())
+		items, err := c.msgDatabase.PopExpiredMsgsDestruct(ctx, batchSize)
+		if err != nil {
+			log.ZError(ctx, "pop expired msgs destruct failed", err)
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+		c.destructMsgs(ctx, items)
+		if int64(len(items)) < batchSize {
+			return
+		}
+	}
+}
+
+func (c *MsgTool) destructMsgs(ctx context.Context, items []*cache.MsgDestructItem) {
+	conversations := make(map[string][]*cache.MsgDestructItem)
+	for _, item := range items {
+		conversations[item.ConversationID] = append(conversations[item.ConversationID], item)
+	}
+	for conversationID, items := range conversations {
+		seqs := utils.Slice(items, func(e *cache.MsgDestructItem) int64 { return e.Seq })
+		log.ZDebug(ctx, "destruct msgs", "conversationID", conversationID, "seqs", seqs)
+		if err := c.msgDatabase.DeleteMsgsPhysicalBySeqs(ctx, conversationID, seqs); err != nil {
+			log.ZError(ctx, "DeleteMsgsPhysicalBySeqs failed", err, "conversationID", conversationID, "seqs", seqs)
+			retryTime := time.Now().Add(msgDestructRetryDelay).UnixMilli()
+			for _, item := range items {
+				item.DestructTime = retryTime
+			}
+			if err := c.msgDatabase.RequeueMsgsDestruct(ctx, items); err != nil {
+				log.ZError(ctx, "RequeueMsgsDestruct failed", err, "conversationID", conversationID, "seqs", seqs)
+			}
+			continue
+		}
+		item := items[0]
+		if err := c.msgNotificationSender.DestructMsgsNotification(ctx, item.SendID, item.RecvID, item.SessionType, conversationID, seqs); err != nil {
+			log.ZError(ctx, "DestructMsgsNotification failed", err, "conversationID", conversationID, "seqs", seqs)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/group"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/thirdext"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
+)
+
+const (
+	userErasePollInterval = time.Second * 5
+	// a running job whose step is not saved within the timeout is taken over by another worker
+	userEraseStaleTimeout = time.Minute * 10
+	userEraseChannelBatch = 100
+)
+
+// StartUserErase runs the pending user erase jobs one by one until ctx is done.
+func (c *MsgTool) StartUserErase(ctx context.Context) {
+	ticker := time.NewTicker(userErasePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.RunUserEraseJobs()
+		}
+	}
+}
+
+func (c *MsgTool) RunUserEraseJobs() {
+	for {
+		ctx := mcontext.NewCtx(utils.GetSelfFuncName() + "-" + utils.OperationIDGenerator())
+		job, err := c.userEraseDatabase.ClaimJob(ctx, userEraseStaleTimeout)
+		if err != nil {
+			log.ZError(ctx, "claim user erase job failed", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		c.runUserEraseJob(mcontext.SetOpUserID(ctx, job.OpUserID), job)
+	}
+}
+
+// runUserEraseJob runs the steps not finished yet in order, every step can be run again safely.
+func (c *MsgTool) runUserEraseJob(ctx context.Context, job *unRelationTb.UserEraseJobModel) {
+	log.ZInfo(ctx, "run user erase job", "jobID", job.JobID, "userID", job.UserID)
+	for i, step := range job.Steps {
+		if step.Status == unRelationTb.UserEraseStepFinished || step.Status == unRelationTb.UserEraseStepSkipped {
+			continue
+		}
+		result := &unRelationTb.UserEraseStepModel{Name: step.Name}
+		if step.Name == unRelationTb.UserEraseStepMessages && !job.AnonymizeMsgs {
+			result.Status = unRelationTb.UserEraseStepSkipped
+		} else {
+			count, err := c.runUserEraseStep(ctx, step.Name, job.UserID)
+			if err != nil {
+				log.ZError(ctx, "user erase step failed", err, "jobID", job.JobID, "step", step.Name)
+				result.Status = unRelationTb.UserEraseStepFailed
+				result.ErrMsg = err.Error()
+			} else {
+				result.Status = unRelationTb.UserEraseStepFinished
+				result.Count = count
+			}
+		}
+		result.FinishTime = time.Now()
+		running, err := c.userEraseDatabase.UpdateStep(ctx, job.JobID, i, result)
+		if err != nil {
+			log.ZError(ctx, "save user erase step failed", err, "jobID", job.JobID, "step", step.Name)
+			return
+		}
+		if !running {
+			log.ZInfo(ctx, "user erase job stopped", "jobID", job.JobID, "step", step.Name)
+			return
+		}
+		if result.Status == unRelationTb.UserEraseStepFailed {
+			c.finishUserEraseJob(ctx, job.JobID, unRelationTb.UserEraseStatusFailed, step.Name+": "+result.ErrMsg)
+			return
+		}
+	}
+	c.finishUserEraseJob(ctx, job.JobID, unRelationTb.UserEraseStatusFinished, "")
+}
+
+// runUserEraseStep returns the number of records removed or changed by the step.
+func (c *MsgTool) runUserEraseStep(ctx context.Context, name string, userID string) (int64, error) {
+	switch name {
+	case unRelationTb.UserEraseStepDeactivate:
+		_, err := c.userRpcClient.ExtClient.DeactivateUser(ctx, &userext.DeactivateUserReq{UserID: userID})
+		return 1, err
+	case unRelationTb.UserEraseStepGroups:
+		return c.eraseUserGroups(ctx, userID)
+	case unRelationTb.UserEraseStepChannels:
+		return c.eraseUserChannels(ctx, userID)
+	case unRelationTb.UserEraseStepGroupRequests:
+		return c.joinPolicyDatabase.DeleteUserRequests(ctx, userID)
+	case unRelationTb.UserEraseStepFriends:
+		return c.friendDatabase.DeleteUserFriends(ctx, userID)
+	case unRelationTb.UserEraseStepLabels:
+		return c.friendLabelDatabase.DeleteUserLabels(ctx, userID)
+	case unRelationTb.UserEraseStepBlacks:
+		return c.blackDatabase.DeleteUserBlacks(ctx, userID)
+	case unRelationTb.UserEraseStepConversations:
+		return c.conversationDatabase.DeleteUserConversations(ctx, userID)
+	case unRelationTb.UserEraseStepSubscriptions:
+		return c.userDatabase.DeleteSubscribeList(ctx, userID)
+	case unRelationTb.UserEraseStepMentions:
+		return c.mentionDatabase.DeleteUserMentions(ctx, userID)
+	case unRelationTb.UserEraseStepPins:
+		return c.msgPinDatabase.DeleteUserPins(ctx, userID)
+	case unRelationTb.UserEraseStepE2EE:
+		return c.e2eeDatabase.DeleteUserKeys(ctx, userID)
+	case unRelationTb.UserEraseStepObjects:
+		resp, err := c.thirdRpcClient.ExtClient.DeleteUserObjects(ctx, &thirdext.DeleteUserObjectsReq{UserID: userID})
+		if err != nil {
+			return 0, err
+		}
+		return resp.Count, nil
+	case unRelationTb.UserEraseStepMessages:
+		return c.msgDatabase.AnonymizeUserMsgs(ctx, userID)
+	case unRelationTb.UserEraseStepUser:
+		domains := []string{
+			unRelationTb.VersionDomainFriend,
+			unRelationTb.VersionDomainJoinedGroup,
+			unRelationTb.VersionDomainConversation,
+		}
+		if err := c.versionLogDatabase.DeleteDIDs(ctx, domains, userID); err != nil {
+			return 0, err
+		}
+		return 1, c.userDatabase.Delete(ctx, []string{userID})
+	default:
+		return 0, errs.ErrArgs.Wrap("unknown user erase step " + name)
+	}
+}
+
+// eraseUserGroups hands the owned groups over, dismisses the groups without successor and leaves the others.
+func (c *MsgTool) eraseUserGroups(ctx context.Context, userID string) (int64, error) {
+	resp, err := c.groupRpcClient.ExtClient.SucceedUserOwnedGroups(ctx, &groupext.SucceedUserOwnedGroupsReq{UserID: userID})
+	if err != nil {
+		return 0, err
+	}
+	for _, groupID := range resp.NoSuccessorGroupIDs {
+		if _, err := c.groupRpcClient.Client.DismissGroup(ctx, &group.DismissGroupReq{GroupID: groupID, DeleteMember: true}); err != nil {
+			return 0, err
+		}
+	}
+	groupIDs, err := c.groupDatabase.FindJoinedGroupIDs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	superGroupIDs, err := c.groupDatabase.FindJoinSuperGroup(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	count := int64(len(resp.NoSuccessorGroupIDs))
+	for _, groupID := range utils.Distinct(append(groupIDs, superGroupIDs...)) {
+		if utils.IsContain(groupID, resp.NoSuccessorGroupIDs) {
+			continue
+		}
+		req := &group.KickGroupMemberReq{GroupID: groupID, KickedUserIDs: []string{userID}, Reason: "user erased"}
+		if _, err := c.groupRpcClient.Client.KickGroupMember(ctx, req); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// eraseUserChannels unsubscribes all channels of the user.
+func (c *MsgTool) eraseUserChannels(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	for {
+		// unsubscribed channels leave the first page
+		_, subscribers, err := c.groupDatabase.PageUserSubscribedChannels(ctx, userID, 1, userEraseChannelBatch)
+		if err != nil {
+			return count, err
+		}
+		for _, subscriber := range subscribers {
+			if err := c.groupDatabase.UnsubscribeChannel(ctx, subscriber.GroupID, []string{userID}); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(subscribers) < userEraseChannelBatch {
+			return count, nil
+		}
+	}
+}
+
+func (c *MsgTool) finishUserEraseJob(ctx context.Context, jobID string, status int32, errMsg string) {
+	if err := c.userEraseDatabase.FinishJob(ctx, jobID, status, errMsg); err != nil {
+		log.ZError(ctx, "finish user erase job failed", err, "jobID", jobID, "status", status)
+	}
+}
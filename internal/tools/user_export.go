@@ -0,0 +1,459 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/thirdext"
+)
+
+const (
+	userExportPollInterval = time.Second * 5
+	// a running job without heartbeat within the timeout is taken over by another worker
+	userExportStaleTimeout = time.Minute * 10
+	// heartbeats during the message export are reported at most once per interval
+	userExportHeartbeatInterval = time.Minute
+	userExportMsgBatch          = 100
+	userExportBlackBatch        = 500
+)
+
+// exportWriter 导出文件的存放位置, 本地目录或者zip包.
+type exportWriter interface {
+	Create(name string) (io.Writer, error)
+	Close() error
+}
+
+type dirExportWriter struct {
+	dir  string
+	file *os.File
+}
+
+func (w *dirExportWriter) Create(name string) (io.Writer, error) {
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(w.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	w.file = file
+	return file, nil
+}
+
+func (w *dirExportWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return errs.Wrap(err)
+}
+
+type zipExportWriter struct {
+	zw *zip.Writer
+}
+
+func (w *zipExportWriter) Create(name string) (io.Writer, error) {
+	writer, err := w.zw.Create(name)
+	return writer, errs.Wrap(err)
+}
+
+func (w *zipExportWriter) Close() error {
+	return errs.Wrap(w.zw.Close())
+}
+
+type userExportGroup struct {
+	Group  *relationTb.GroupModel       `json:"group"`
+	Member *relationTb.GroupMemberModel `json:"member,omitempty"`
+}
+
+// StartUserExport runs the pending user export jobs one by one until ctx is done.
+func (c *MsgTool) StartUserExport(ctx context.Context) {
+	ticker := time.NewTicker(userExportPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.RunUserExportJobs()
+		}
+	}
+}
+
+func (c *MsgTool) RunUserExportJobs() {
+	for {
+		ctx := mcontext.NewCtx(utils.GetSelfFuncName() + "-" + utils.OperationIDGenerator())
+		job, err := c.userExportDatabase.ClaimJob(ctx, userExportStaleTimeout)
+		if err != nil {
+			log.ZError(ctx, "claim user export job failed", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		c.runUserExportJob(mcontext.SetOpUserID(ctx, job.OpUserID), job)
+	}
+}
+
+// ExportUserToDir 导出用户数据到本地目录, 返回各类数据的条数.
+func (c *MsgTool) ExportUserToDir(ctx context.Context, userID string, dir string) (map[string]int64, error) {
+	w := &dirExportWriter{dir: dir}
+	counts, err := c.exportUser(ctx, userID, w, nil)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	return counts, err
+}
+
+func (c *MsgTool) runUserExportJob(ctx context.Context, job *unRelationTb.UserExportJobModel) {
+	log.ZInfo(ctx, "run user export job", "jobID", job.JobID, "userID", job.UserID, "storage", job.Storage)
+	heartbeat := func() error {
+		running, err := c.userExportDatabase.Heartbeat(ctx, job.JobID)
+		if err != nil {
+			return err
+		}
+		if !running {
+			return errs.ErrArgs.Wrap("user export job " + job.JobID + " is not running")
+		}
+		return nil
+	}
+	var (
+		location string
+		counts   map[string]int64
+		err      error
+	)
+	if job.Storage == unRelationTb.UserExportStorageLocal {
+		location = filepath.Join(config.Config.UserExport.LocalDir, job.UserID, job.JobID)
+		w := &dirExportWriter{dir: location}
+		counts, err = c.exportUser(ctx, job.UserID, w, heartbeat)
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+	} else {
+		location, counts, err = c.exportUserToObject(ctx, job, heartbeat)
+	}
+	status := int32(unRelationTb.UserExportStatusFinished)
+	var errMsg string
+	if err != nil {
+		log.ZError(ctx, "user export job failed", err, "jobID", job.JobID, "userID", job.UserID)
+		status = unRelationTb.UserExportStatusFailed
+		errMsg = err.Error()
+	}
+	if err := c.userExportDatabase.FinishJob(ctx, job.JobID, status, location, counts, errMsg); err != nil {
+		log.ZError(ctx, "finish user export job failed", err, "jobID", job.JobID, "status", status)
+	}
+}
+
+// exportUserToObject 先打包到临时文件, 再通过预签名地址上传到对象存储, 返回对象名.
+func (c *MsgTool) exportUserToObject(ctx context.Context, job *unRelationTb.UserExportJobModel, heartbeat func() error) (string, map[string]int64, error) {
+	file, err := os.CreateTemp("", "openim-export-*.zip")
+	if err != nil {
+		return "", nil, errs.Wrap(err)
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+	w := &zipExportWriter{zw: zip.NewWriter(file)}
+	counts, err := c.exportUser(ctx, job.UserID, w, heartbeat)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", counts, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return "", counts, errs.Wrap(err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", counts, errs.Wrap(err)
+	}
+	initResp, err := c.thirdRpcClient.ExtClient.InitiateExportUpload(ctx, &thirdext.InitiateExportUploadReq{UserID: job.UserID, JobID: job.JobID})
+	if err != nil {
+		return "", counts, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, initResp.Url, file)
+	if err != nil {
+		return "", counts, errs.Wrap(err)
+	}
+	req.ContentLength = info.Size()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", counts, errs.Wrap(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", counts, errs.Wrap(fmt.Errorf("upload export object status %d: %s", resp.StatusCode, string(body)))
+	}
+	completeResp, err := c.thirdRpcClient.ExtClient.CompleteExportUpload(ctx, &thirdext.CompleteExportUploadReq{UserID: job.UserID, JobID: job.JobID})
+	if err != nil {
+		return "", counts, err
+	}
+	return completeResp.Name, counts, nil
+}
+
+// exportUser 写出用户资料、好友、黑名单、群组、会话、消息和上传的文件, heartbeat为空时不上报进度.
+func (c *MsgTool) exportUser(ctx context.Context, userID string, w exportWriter, heartbeat func() error) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	if heartbeat != nil {
+		heartbeat = throttleHeartbeat(heartbeat, userExportHeartbeatInterval)
+	}
+	steps := []struct {
+		name string
+		fn   func(ctx context.Context, userID string, w exportWriter) (int64, error)
+	}{
+		{"profile", c.exportUserProfile},
+		{"friends", c.exportUserFriends},
+		{"blacks", c.exportUserBlacks},
+		{"groups", c.exportUserGroups},
+		{"conversations", c.exportUserConversations},
+		{"messages", func(ctx context.Context, userID string, w exportWriter) (int64, error) {
+			return c.exportUserMsgs(ctx, userID, w, heartbeat)
+		}},
+		{"objects", c.exportUserObjects},
+	}
+	for _, step := range steps {
+		if heartbeat != nil {
+			if err := heartbeat(); err != nil {
+				return counts, err
+			}
+		}
+		count, err := step.fn(ctx, userID, w)
+		if err != nil {
+			return counts, errs.Wrap(err, "export "+step.name)
+		}
+		counts[step.name] = count
+	}
+	return counts, nil
+}
+
+// throttleHeartbeat 距上次上报不足interval时跳过, 避免每批消息都写一次数据库.
+func throttleHeartbeat(heartbeat func() error, interval time.Duration) func() error {
+	var last time.Time
+	return func() error {
+		if time.Since(last) < interval {
+			return nil
+		}
+		if err := heartbeat(); err != nil {
+			return err
+		}
+		last = time.Now()
+		return nil
+	}
+}
+
+func writeExportJSON(w exportWriter, name string, v any) error {
+	writer, err := w.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return errs.Wrap(encoder.Encode(v))
+}
+
+func (c *MsgTool) exportUserProfile(ctx context.Context, userID string, w exportWriter) (int64, error) {
+	users, err := c.userDatabase.FindWithError(ctx, []string{userID})
+	if err != nil {
+		return 0, err
+	}
+	return 1, writeExportJSON(w, "profile.json", users[0])
+}
+
+func (c *MsgTool) exportUserFriends(ctx context.Context, userID string, w exportWriter) (int64, error) {
+	friendUserIDs, err := c.friendDatabase.FindFriendUserIDs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	friends := []*relationTb.FriendModel{}
+	if len(friendUserIDs) > 0 {
+		friends, err = c.friendDatabase.FindFriends(ctx, userID, friendUserIDs)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(friends)), writeExportJSON(w, "friends.json", friends)
+}
+
+func (c *MsgTool) exportUserBlacks(ctx context.Context, userID string, w exportWriter) (int64, error) {
+	blacks := []*relationTb.BlackModel{}
+	for pageNumber := int32(1); ; pageNumber++ {
+		page, total, err := c.blackDatabase.FindOwnerBlacks(ctx, userID, pageNumber, userExportBlackBatch)
+		if err != nil {
+			return 0, err
+		}
+		blacks = append(blacks, page...)
+		if len(page) < userExportBlackBatch || int64(len(blacks)) >= total {
+			break
+		}
+	}
+	return int64(len(blacks)), writeExportJSON(w, "blacks.json", blacks)
+}
+
+func (c *MsgTool) exportUserGroups(ctx context.Context, userID string, w exportWriter) (int64, error) {
+	groupIDs, err := c.groupDatabase.FindJoinedGroupIDs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	superGroupIDs, err := c.groupDatabase.FindJoinSuperGroup(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	groupIDs = utils.Distinct(append(groupIDs, superGroupIDs...))
+	groups := []*userExportGroup{}
+	if len(groupIDs) > 0 {
+		groupModels, err := c.groupDatabase.FindGroup(ctx, groupIDs)
+		if err != nil {
+			return 0, err
+		}
+		members, err := c.groupDatabase.FindGroupMember(ctx, groupIDs, []string{userID}, nil)
+		if err != nil {
+			return 0, err
+		}
+		memberMap := utils.SliceToMap(members, func(e *relationTb.GroupMemberModel) string {
+			return e.GroupID
+		})
+		for _, group := range groupModels {
+			groups = append(groups, &userExportGroup{Group: group, Member: memberMap[group.GroupID]})
+		}
+	}
+	return int64(len(groups)), writeExportJSON(w, "groups.json", groups)
+}
+
+func (c *MsgTool) exportUserConversations(ctx context.Context, userID string, w exportWriter) (int64, error) {
+	conversations, err := c.conversationDatabase.GetUserAllConversation(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(conversations)), writeExportJSON(w, "conversations.json", conversations)
+}
+
+// exportUserMsgs 每个会话写一个messages/<conversationID>.json, 消息按seq升序分批读取, 不会一次加载到内存.
+// 消息量大时耗时较长, 每批消息都会调用heartbeat, 防止任务被当作超时而被其他节点接管.
+func (c *MsgTool) exportUserMsgs(ctx context.Context, userID string, w exportWriter, heartbeat func() error) (int64, error) {
+	conversations, err := c.conversationDatabase.GetUserAllConversation(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, conversation := range conversations {
+		count, err := c.exportConversationMsgs(ctx, userID, conversation, w, heartbeat)
+		if err != nil {
+			return total, errs.Wrap(err, "conversationID "+conversation.ConversationID)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (c *MsgTool) exportConversationMsgs(ctx context.Context, userID string, conversation *relationTb.ConversationModel, w exportWriter, heartbeat func() error) (int64, error) {
+	conversationID := conversation.ConversationID
+	maxSeq, err := c.msgDatabase.GetMaxSeq(ctx, conversationID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return 0, err
+	}
+	if conversation.MaxSeq > 0 && conversation.MaxSeq < maxSeq {
+		maxSeq = conversation.MaxSeq
+	}
+	minSeq, err := c.msgDatabase.GetMinSeq(ctx, conversationID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return 0, err
+	}
+	userMinSeq, err := c.msgDatabase.GetConversationUserMinSeq(ctx, conversationID, userID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return 0, err
+	}
+	begin := utils.Max(minSeq, userMinSeq, 1)
+	writer, err := w.Create("messages/" + conversationID + ".json")
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.WriteString(writer, "[\n"); err != nil {
+		return 0, errs.Wrap(err)
+	}
+	encoder := json.NewEncoder(writer)
+	var count int64
+	for ; begin <= maxSeq; begin += userExportMsgBatch {
+		if heartbeat != nil {
+			if err := heartbeat(); err != nil {
+				return count, err
+			}
+		}
+		end := utils.Min(begin+userExportMsgBatch-1, maxSeq)
+		_, _, msgs, err := c.msgDatabase.GetMsgBySeqsRange(ctx, userID, conversationID, begin, end, userExportMsgBatch, conversation.MaxSeq)
+		if err != nil {
+			return count, err
+		}
+		sort.Slice(msgs, func(i, j int) bool {
+			return msgs[i].Seq < msgs[j].Seq
+		})
+		for _, msg := range msgs {
+			if msg == nil {
+				continue
+			}
+			if count > 0 {
+				if _, err := io.WriteString(writer, ","); err != nil {
+					return count, errs.Wrap(err)
+				}
+			}
+			if err := encoder.Encode(msg); err != nil {
+				return count, errs.Wrap(err)
+			}
+			count++
+		}
+	}
+	if _, err := io.WriteString(writer, "]\n"); err != nil {
+		return count, errs.Wrap(err)
+	}
+	return count, nil
+}
+
+func (c *MsgTool) exportUserObjects(ctx context.Context, userID string, w exportWriter) (int64, error) {
+	resp, err := c.thirdRpcClient.ExtClient.GetUserObjects(ctx, &thirdext.GetUserObjectsReq{UserID: userID})
+	if err != nil {
+		return 0, err
+	}
+	objects := resp.Objects
+	if objects == nil {
+		objects = []*thirdext.ObjectInfo{}
+	}
+	return int64(len(objects)), writeExportJSON(w, "objects.json", objects)
+}
@@ -0,0 +1,44 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
+)
+
+// LiftExpiredUserSuspensions marks the suspensions whose end time is up as expired by the user rpc,
+// which refreshes the cached suspensions and calls the callback.
+func (c *MsgTool) LiftExpiredUserSuspensions() {
+	if len(config.Config.Manager.UserID) == 0 {
+		log.ZWarn(context.Background(), "manager userID is not configured, skip lifting expired user suspensions", nil)
+		return
+	}
+	ctx := mcontext.WithOpUserIDContext(mcontext.NewCtx(utils.GetSelfFuncName()), config.Config.Manager.UserID[0])
+	resp, err := c.userRpcClient.ExtClient.LiftExpiredUserSuspensions(ctx, &userext.LiftExpiredUserSuspensionsReq{})
+	if err != nil {
+		log.ZError(ctx, "LiftExpiredUserSuspensions failed", err)
+		return
+	}
+	if resp.Count > 0 {
+		log.ZInfo(ctx, "expired user suspensions lifted", "count", resp.Count)
+	}
+}
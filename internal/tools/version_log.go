@@ -0,0 +1,38 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"time"
+
+	"github.com/OpenIMSDK/tools/log"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+)
+
+// TruncateVersionLogs deletes the deletion records older than versionLog.retainDays,
+// clients whose version is older than the truncated records get a full sync.
+func (c *MsgTool) TruncateVersionLogs() {
+	ctx := mcontext.NewCtx(utils.GetSelfFuncName())
+	before := time.Now().AddDate(0, 0, -config.Config.VersionLog.RetainDays)
+	count, err := c.versionLogDatabase.TruncateDeleted(ctx, before)
+	if err != nil {
+		log.ZError(ctx, "TruncateVersionLogs failed", err, "before", before)
+		return
+	}
+	log.ZInfo(ctx, "version logs truncated", "count", count, "before", before)
+}
@@ -16,6 +16,8 @@ package apistruct
 
 import (
 	sdkws "github.com/OpenIMSDK/protocol/sdkws"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 )
 
 type SendMsg struct {
@@ -48,6 +50,13 @@ type BatchSendMsgResp struct {
 	FailedIDs []string              `json:"failedUserIDs"`
 }
 
+type CreateBroadcastReq struct {
+	SendMsg
+	Segment *msgext.BroadcastSegment `json:"segment" binding:"required"`
+	// messages sent per second, 0 uses the config
+	Rate int32 `json:"rate"`
+}
+
 type SingleReturnResult struct {
 	ServerMsgID string `json:"serverMsgID"`
 	ClientMsgID string `json:"clientMsgID"`
@@ -0,0 +1,42 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callbackstruct
+
+const (
+	CallbackAfterSuspendUserCommand        = "callbackAfterSuspendUserCommand"
+	CallbackAfterLiftUserSuspensionCommand = "callbackAfterLiftUserSuspensionCommand"
+)
+
+// CallbackUserSuspensionReq 封禁及解除封禁后的回调, 时间为毫秒时间戳.
+type CallbackUserSuspensionReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	OperationID     string   `json:"operationID"`
+	SuspensionID    string   `json:"suspensionID"`
+	UserID          string   `json:"userID"`
+	Scopes          []string `json:"scopes"`
+	Reason          string   `json:"reason"`
+	OpUserID        string   `json:"opUserID"`
+	StartTime       int64    `json:"startTime"`
+	EndTime         int64    `json:"endTime"`
+	// 0 active, 1 lifted, 2 expired
+	Status       int32  `json:"status"`
+	LiftOpUserID string `json:"liftOpUserID"`
+	LiftReason   string `json:"liftReason"`
+	LiftTime     int64  `json:"liftTime"`
+}
+
+type CallbackUserSuspensionResp struct {
+	CommonCallbackResp
+}
@@ -15,9 +15,19 @@
 package cmd
 
 import (
+	"fmt"
+	"path/filepath"
+	"time"
+
 	"github.com/spf13/cobra"
 
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/mcontext"
+	"github.com/OpenIMSDK/tools/utils"
+
 	"github.com/OpenIMSDK/Open-IM-Server/internal/tools"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
 )
 
 type MsgUtilsCmd struct {
@@ -79,6 +89,24 @@ func (m *MsgUtilsCmd) getLimitFlag(cmdLines *cobra.Command) int64 {
 	return limit
 }
 
+func (m *MsgUtilsCmd) AddJobIDFlag() {
+	m.Command.PersistentFlags().StringP("jobID", "j", "", "openIM broadcast jobID")
+}
+
+func (m *MsgUtilsCmd) getJobIDFlag(cmdLines *cobra.Command) string {
+	jobID, _ := cmdLines.Flags().GetString("jobID")
+	return jobID
+}
+
+func (m *MsgUtilsCmd) AddDirFlag() {
+	m.Command.PersistentFlags().StringP("dir", "d", "", "openIM export dir")
+}
+
+func (m *MsgUtilsCmd) getDirFlag(cmdLines *cobra.Command) string {
+	dir, _ := cmdLines.Flags().GetString("dir")
+	return dir
+}
+
 func (m *MsgUtilsCmd) Execute() error {
 	return m.Command.Execute()
 }
@@ -123,6 +151,26 @@ func NewClearCmd() *ClearCmd {
 	}
 }
 
+type CancelCmd struct {
+	*MsgUtilsCmd
+}
+
+func NewCancelCmd() *CancelCmd {
+	return &CancelCmd{
+		NewMsgUtilsCmd("cancel [resource]", "cancel action", cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)),
+	}
+}
+
+type ExportCmd struct {
+	*MsgUtilsCmd
+}
+
+func NewExportCmd() *ExportCmd {
+	return &ExportCmd{
+		NewMsgUtilsCmd("export [resource]", "export action", cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)),
+	}
+}
+
 type SeqCmd struct {
 	*MsgUtilsCmd
 }
@@ -183,3 +231,96 @@ func (m *MsgCmd) GetMsgCmd() *cobra.Command {
 func (m *MsgCmd) ClearMsgCmd() *cobra.Command {
 	return &m.Command
 }
+
+type BroadcastCmd struct {
+	*MsgUtilsCmd
+}
+
+func NewBroadcastCmd() *BroadcastCmd {
+	return &BroadcastCmd{
+		NewMsgUtilsCmd("broadcast", "broadcast job", nil),
+	}
+}
+
+// GetBroadcastCmd prints the job of --jobID, or the latest jobs without it.
+func (b *BroadcastCmd) GetBroadcastCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: b.Use, Short: b.Short}
+	cmd.Run = func(cmdLines *cobra.Command, args []string) {
+		msgTool, err := tools.InitMsgTool()
+		if err != nil {
+			panic(err)
+		}
+		ctx := mcontext.NewCtx("GetBroadcastCmd")
+		if jobID := b.getJobIDFlag(cmdLines); jobID != "" {
+			job, err := msgTool.GetBroadcastJob(ctx, jobID)
+			if err != nil {
+				panic(err)
+			}
+			fmt.Println(utils.StructToJsonString(convert.BroadcastJobDB2Pb(job)))
+			return
+		}
+		_, jobs, err := msgTool.GetBroadcastJobs(ctx, nil, 1, 20)
+		if err != nil {
+			panic(err)
+		}
+		for _, job := range jobs {
+			fmt.Println(utils.StructToJsonString(convert.BroadcastJobDB2Pb(job)))
+		}
+	}
+	return cmd
+}
+
+func (b *BroadcastCmd) CancelBroadcastCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: b.Use, Short: b.Short}
+	cmd.Run = func(cmdLines *cobra.Command, args []string) {
+		msgTool, err := tools.InitMsgTool()
+		if err != nil {
+			panic(err)
+		}
+		if err := msgTool.CancelBroadcastJob(mcontext.NewCtx("CancelBroadcastCmd"), b.getJobIDFlag(cmdLines)); err != nil {
+			panic(err)
+		}
+	}
+	return cmd
+}
+
+type UserCmd struct {
+	*MsgUtilsCmd
+}
+
+func NewUserCmd() *UserCmd {
+	return &UserCmd{
+		NewMsgUtilsCmd("user", "user data", nil),
+	}
+}
+
+// ExportUserCmd writes the data of --userID to <dir>/<userID>_<time>, dir defaults to userExport.localDir.
+func (u *UserCmd) ExportUserCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: u.Use, Short: u.Short}
+	cmd.RunE = func(cmdLines *cobra.Command, args []string) error {
+		userID := u.getUserIDFlag(cmdLines)
+		if userID == "" {
+			return errs.ErrArgs.Wrap("userID is empty")
+		}
+		msgTool, err := tools.InitMsgTool()
+		if err != nil {
+			return err
+		}
+		if len(config.Config.Manager.UserID) == 0 {
+			return errs.ErrArgs.Wrap("manager.userID is not configured")
+		}
+		dir := u.getDirFlag(cmdLines)
+		if dir == "" {
+			dir = config.Config.UserExport.LocalDir
+		}
+		dir = filepath.Join(dir, userID+"_"+time.Now().Format("20060102150405"))
+		ctx := mcontext.WithOpUserIDContext(mcontext.NewCtx("ExportUserCmd"), config.Config.Manager.UserID[0])
+		counts, err := msgTool.ExportUserToDir(ctx, userID, dir)
+		if err != nil {
+			return err
+		}
+		fmt.Println(dir, utils.StructToJsonString(counts))
+		return nil
+	}
+	return cmd
+}
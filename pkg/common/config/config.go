@@ -45,6 +45,11 @@ type POfflinePush struct {
 	Ext    string `yaml:"ext"`
 }
 
+type QuotaConf struct {
+	Limit  int64 `yaml:"limit"`  // 0 means unlimited
+	Window int   `yaml:"window"` // seconds
+}
+
 type configStruct struct {
 	Zookeeper struct {
 		Schema   string   `yaml:"schema"`
@@ -213,6 +218,8 @@ type configStruct struct {
 	RetainChatRecords                 int    `yaml:"retainChatRecords"`
 	ChatRecordsClearTime              string `yaml:"chatRecordsClearTime"`
 	MsgDestructTime                   string `yaml:"msgDestructTime"`
+	GroupRequestClearTime             string `yaml:"groupRequestClearTime"`
+	UserSuspensionExpireTime          string `yaml:"userSuspensionExpireTime"`
 	Secret                            string `yaml:"secret"`
 	TokenPolicy                       struct {
 		Expire int64 `yaml:"expire"`
@@ -220,6 +227,55 @@ type configStruct struct {
 	MessageVerify struct {
 		FriendVerify *bool `yaml:"friendVerify"`
 	} `yaml:"messageVerify"`
+	MsgTTL struct {
+		Interval  int `yaml:"interval"`
+		BatchSize int `yaml:"batchSize"`
+	} `yaml:"msgTTL"`
+	Broadcast struct {
+		Rate      int `yaml:"rate"`
+		BatchSize int `yaml:"batchSize"`
+	} `yaml:"broadcast"`
+	Quota struct {
+		UserMsg      QuotaConf `yaml:"userMsg"`
+		UserFileSize QuotaConf `yaml:"userFileSize"`
+		GroupMsg     QuotaConf `yaml:"groupMsg"`
+		// friend requests sent by a user
+		UserFriendRequest QuotaConf `yaml:"userFriendRequest"`
+	} `yaml:"quota"`
+	GroupAuditLog struct {
+		RetainDays int `yaml:"retainDays"`
+	} `yaml:"groupAuditLog"`
+	PinnedMsg struct {
+		MaxPerConversation int `yaml:"maxPerConversation"`
+	} `yaml:"pinnedMsg"`
+	GroupOwnerSuccession struct {
+		Enable       bool   `yaml:"enable"`
+		Policy       string `yaml:"policy"`
+		InactiveDays int    `yaml:"inactiveDays"`
+		CronTime     string `yaml:"cronTime"`
+	} `yaml:"groupOwnerSuccession"`
+	FriendSuggestion struct {
+		MaxCount            int    `yaml:"maxCount"`
+		MaxGroupMemberCount int    `yaml:"maxGroupMemberCount"`
+		ExpireHours         int    `yaml:"expireHours"`
+		Enable              bool   `yaml:"enable"`
+		CronTime            string `yaml:"cronTime"`
+	} `yaml:"friendSuggestion"`
+	FriendRequest struct {
+		Expire         int    `yaml:"expire"`
+		RefuseCooldown int    `yaml:"refuseCooldown"`
+		ClearTime      string `yaml:"clearTime"`
+	} `yaml:"friendRequest"`
+
+	VersionLog struct {
+		RetainDays int    `yaml:"retainDays"`
+		SyncLimit  int    `yaml:"syncLimit"`
+		CronTime   string `yaml:"cronTime"`
+	} `yaml:"versionLog"`
+	UserExport struct {
+		Storage  string `yaml:"storage"`
+		LocalDir string `yaml:"localDir"`
+	} `yaml:"userExport"`
 
 	IOSPush struct {
 		PushSound  string `yaml:"pushSound"`
@@ -243,6 +299,8 @@ type configStruct struct {
 		CallbackBeforeCreateGroup          CallBackConfig `yaml:"beforeCreateGroup"`
 		CallbackBeforeMemberJoinGroup      CallBackConfig `yaml:"beforeMemberJoinGroup"`
 		CallbackBeforeSetGroupMemberInfo   CallBackConfig `yaml:"beforeSetGroupMemberInfo"`
+		CallbackAfterSuspendUser           CallBackConfig `yaml:"afterSuspendUser"`
+		CallbackAfterLiftUserSuspension    CallBackConfig `yaml:"afterLiftUserSuspension"`
 	} `yaml:"callback"`
 
 	Prometheus struct {
@@ -283,6 +341,8 @@ type notification struct {
 	GroupMemberSetToOrdinary NotificationConf `yaml:"groupMemberSetToOrdinaryUser"`
 	GroupInfoSetAnnouncement NotificationConf `yaml:"groupInfoSetAnnouncement"`
 	GroupInfoSetName         NotificationConf `yaml:"groupInfoSetName"`
+	GroupSlowModeSet         NotificationConf `yaml:"groupSlowModeSet"`
+	GroupMemberBanned        NotificationConf `yaml:"groupMemberBanned"`
 	////////////////////////user///////////////////////
 	UserInfoUpdated NotificationConf `yaml:"userInfoUpdated"`
 	//////////////////////friend///////////////////////
@@ -295,9 +355,13 @@ type notification struct {
 	BlackAdded                NotificationConf `yaml:"blackAdded"`
 	BlackDeleted              NotificationConf `yaml:"blackDeleted"`
 	FriendInfoUpdated         NotificationConf `yaml:"friendInfoUpdated"`
+	FriendLabelsUpdated       NotificationConf `yaml:"friendLabelsUpdated"`
 	//////////////////////conversation///////////////////////
 	ConversationChanged    NotificationConf `yaml:"conversationChanged"`
 	ConversationSetPrivate NotificationConf `yaml:"conversationSetPrivate"`
+	//////////////////////msg///////////////////////
+	MsgPinned   NotificationConf `yaml:"msgPinned"`
+	MsgUnpinned NotificationConf `yaml:"msgUnpinned"`
 }
 
 func (c *configStruct) GetServiceNames() []string {
@@ -0,0 +1,63 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"time"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+)
+
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func BroadcastSegmentPb2DB(segment *msgext.BroadcastSegment) unRelationTb.BroadcastSegmentModel {
+	return unRelationTb.BroadcastSegmentModel{
+		Type:            segment.Type,
+		UserIDs:         segment.UserIDs,
+		Ex:              segment.Ex,
+		AppMangerLevels: segment.AppMangerLevels,
+		GroupIDs:        segment.GroupIDs,
+	}
+}
+
+func BroadcastJobDB2Pb(job *unRelationTb.BroadcastJobModel) *msgext.BroadcastJob {
+	return &msgext.BroadcastJob{
+		JobID:    job.JobID,
+		OpUserID: job.OpUserID,
+		Segment: &msgext.BroadcastSegment{
+			Type:            job.Segment.Type,
+			UserIDs:         job.Segment.UserIDs,
+			Ex:              job.Segment.Ex,
+			AppMangerLevels: job.Segment.AppMangerLevels,
+			GroupIDs:        job.Segment.GroupIDs,
+		},
+		Rate:          job.Rate,
+		Status:        job.Status,
+		Total:         job.Total,
+		SuccessCount:  job.SuccessCount,
+		FailedCount:   job.FailedCount,
+		FailedUserIDs: job.FailedUserIDs,
+		ErrMsg:        job.ErrMsg,
+		CreateTime:    unixMilli(job.CreateTime),
+		StartTime:     unixMilli(job.StartTime),
+		FinishTime:    unixMilli(job.FinishTime),
+	}
+}
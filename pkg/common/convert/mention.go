@@ -0,0 +1,33 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
+)
+
+func MentionDB2Pb(mention *unRelationTb.MentionModel, hasReadSeq int64) *msgext.Mention {
+	return &msgext.Mention{
+		ConversationID: mention.ConversationID,
+		GroupID:        mention.GroupID,
+		Seq:            mention.Seq,
+		SendID:         mention.SendID,
+		ClientMsgID:    mention.ClientMsgID,
+		SendTime:       mention.SendTime,
+		AtAll:          mention.AtAll,
+		IsRead:         mention.Seq <= hasReadSeq,
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/OpenIMSDK/protocol/sdkws"
 
 	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
 )
 
 func UsersDB2Pb(users []*relationTb.UserModel) (result []*sdkws.UserInfo) {
@@ -37,6 +38,19 @@ func UsersDB2Pb(users []*relationTb.UserModel) (result []*sdkws.UserInfo) {
 	return result
 }
 
+func UsersDB2PublicPb(users []*relationTb.UserModel) []*sdkws.PublicUserInfo {
+	result := make([]*sdkws.PublicUserInfo, 0, len(users))
+	for _, user := range users {
+		result = append(result, &sdkws.PublicUserInfo{
+			UserID:   user.UserID,
+			Nickname: user.Nickname,
+			FaceURL:  user.FaceURL,
+			Ex:       user.Ex,
+		})
+	}
+	return result
+}
+
 func UserPb2DB(user *sdkws.UserInfo) *relationTb.UserModel {
 	var userDB relationTb.UserModel
 	userDB.UserID = user.UserID
@@ -48,3 +62,12 @@ func UserPb2DB(user *sdkws.UserInfo) *relationTb.UserModel {
 	userDB.GlobalRecvMsgOpt = user.GlobalRecvMsgOpt
 	return &userDB
 }
+
+func UserDoNotDisturbDB2Pb(user *relationTb.UserModel) *userext.DoNotDisturbSchedule {
+	return &userext.DoNotDisturbSchedule{
+		Enable:      user.DoNotDisturb,
+		StartMinute: user.DoNotDisturbStart,
+		EndMinute:   user.DoNotDisturbEnd,
+		Timezone:    user.DoNotDisturbTimezone,
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
+)
+
+func UserExportJobDB2Pb(job *unRelationTb.UserExportJobModel) *userext.UserExportJob {
+	return &userext.UserExportJob{
+		JobID:      job.JobID,
+		UserID:     job.UserID,
+		OpUserID:   job.OpUserID,
+		Status:     job.Status,
+		Storage:    job.Storage,
+		Location:   job.Location,
+		Counts:     job.Counts,
+		ErrMsg:     job.ErrMsg,
+		CreateTime: unixMilli(job.CreateTime),
+		StartTime:  unixMilli(job.StartTime),
+		FinishTime: unixMilli(job.FinishTime),
+	}
+}
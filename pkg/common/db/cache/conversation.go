@@ -38,6 +38,7 @@ const (
 	recvMsgOptKey                            = "RECV_MSG_OPT:"
 	superGroupRecvMsgNotNotifyUserIDsKey     = "SUPER_GROUP_RECV_MSG_NOT_NOTIFY_USER_IDS:"
 	superGroupRecvMsgNotNotifyUserIDsHashKey = "SUPER_GROUP_RECV_MSG_NOT_NOTIFY_USER_IDS_HASH:"
+	conversationMuteUntilKey                 = "CONVERSATION_MUTE_UNTIL:"
 
 	conversationExpireTime = time.Second * 60 * 60 * 12
 )
@@ -74,6 +75,9 @@ type ConversationCache interface {
 	// get one super group recv msg but do not notification userID list hash
 	GetSuperGroupRecvMsgNotNotifyUserIDsHash(ctx context.Context, groupID string) (hash uint64, err error)
 	DelSuperGroupRecvMsgNotNotifyUserIDsHash(groupID string) ConversationCache
+	// get conversation mute until of users, expired entries are filtered by caller
+	GetConversationMuteUntil(ctx context.Context, conversationID string) (map[string]int64, error)
+	DelConversationMuteUntil(conversationIDs ...string) ConversationCache
 
 	GetUserAllHasReadSeqs(ctx context.Context, ownerUserID string) (map[string]int64, error)
 	DelUserAllHasReadSeqs(ownerUserID string, conversationIDs ...string) ConversationCache
@@ -149,6 +153,10 @@ func (c *ConversationRedisCache) getSuperGroupRecvNotNotifyUserIDsHashKey(groupI
 	return superGroupRecvMsgNotNotifyUserIDsHashKey + groupID
 }
 
+func (c *ConversationRedisCache) getConversationMuteUntilKey(conversationID string) string {
+	return conversationMuteUntilKey + conversationID
+}
+
 func (c *ConversationRedisCache) getConversationHasReadSeqKey(ownerUserID, conversationID string) string {
 	return conversationHasReadSeqKey + ownerUserID + ":" + conversationID
 }
@@ -374,6 +382,31 @@ func (c *ConversationRedisCache) DelSuperGroupRecvMsgNotNotifyUserIDsHash(groupI
 	return cache
 }
 
+func (c *ConversationRedisCache) GetConversationMuteUntil(
+	ctx context.Context,
+	conversationID string,
+) (map[string]int64, error) {
+	return getCache(
+		ctx,
+		c.rcClient,
+		c.getConversationMuteUntilKey(conversationID),
+		c.expireTime,
+		func(ctx context.Context) (map[string]int64, error) {
+			return c.conversationDB.FindMuteUntil(ctx, conversationID, time.Now().UnixMilli())
+		},
+	)
+}
+
+func (c *ConversationRedisCache) DelConversationMuteUntil(conversationIDs ...string) ConversationCache {
+	var keys []string
+	for _, conversationID := range conversationIDs {
+		keys = append(keys, c.getConversationMuteUntilKey(conversationID))
+	}
+	cache := c.NewCache()
+	cache.AddKeys(keys...)
+	return cache
+}
+
 func (c *ConversationRedisCache) getUserAllHasReadSeqsIndex(
 	conversationID string,
 	conversationIDs []string,
@@ -0,0 +1,47 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+const e2eePreKeyFetch = "E2EE_PREKEY_FETCH:"
+
+type E2EECache interface {
+	// AcquirePreKeyFetch requesterID在window内对同一设备只能成功一次
+	AcquirePreKeyFetch(ctx context.Context, requesterID string, userID string, deviceID string, window time.Duration) (bool, error)
+}
+
+func NewE2EECacheRedis(rdb redis.UniversalClient) E2EECache {
+	return &e2eeCacheRedis{rdb: rdb}
+}
+
+type e2eeCacheRedis struct {
+	rdb redis.UniversalClient
+}
+
+func (e *e2eeCacheRedis) getPreKeyFetchKey(requesterID string, userID string, deviceID string) string {
+	return e2eePreKeyFetch + requesterID + ":" + userID + ":" + deviceID
+}
+
+func (e *e2eeCacheRedis) AcquirePreKeyFetch(ctx context.Context, requesterID string, userID string, deviceID string, window time.Duration) (bool, error) {
+	ok, err := e.rdb.SetNX(ctx, e.getPreKeyFetchKey(requesterID, userID, deviceID), time.Now().UnixMilli(), window).Result()
+	return ok, errs.Wrap(err)
+}
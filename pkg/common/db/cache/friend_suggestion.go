@@ -0,0 +1,99 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+const friendSuggestionKey = "FRIEND_SUGGESTION:"
+
+// FriendSuggestion a non-friend the user may know.
+type FriendSuggestion struct {
+	UserID            string `json:"userID"`
+	MutualFriendCount int32  `json:"mutualFriendCount"`
+	SharedGroupCount  int32  `json:"sharedGroupCount"`
+}
+
+// FriendSuggestions the precomputed suggestions of a user, sorted by rank.
+type FriendSuggestions struct {
+	Suggestions []*FriendSuggestion `json:"suggestions"`
+	// unix milli
+	UpdateTime int64 `json:"updateTime"`
+}
+
+type FriendSuggestionCache interface {
+	// GetFriendSuggestions 获取预计算的好友推荐, 不存在时返回nil
+	GetFriendSuggestions(ctx context.Context, userID string) (*FriendSuggestions, error)
+	SetFriendSuggestions(ctx context.Context, userID string, suggestions *FriendSuggestions, expire time.Duration) error
+	DelFriendSuggestions(ctx context.Context, userIDs ...string) error
+}
+
+func NewFriendSuggestionCacheRedis(rdb redis.UniversalClient) FriendSuggestionCache {
+	return &friendSuggestionCacheRedis{rdb: rdb}
+}
+
+type friendSuggestionCacheRedis struct {
+	rdb redis.UniversalClient
+}
+
+func (f *friendSuggestionCacheRedis) getFriendSuggestionKey(userID string) string {
+	return friendSuggestionKey + userID
+}
+
+func (f *friendSuggestionCacheRedis) GetFriendSuggestions(ctx context.Context, userID string) (*FriendSuggestions, error) {
+	data, err := f.rdb.Get(ctx, f.getFriendSuggestionKey(userID)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var suggestions FriendSuggestions
+	if err := json.Unmarshal(data, &suggestions); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &suggestions, nil
+}
+
+func (f *friendSuggestionCacheRedis) SetFriendSuggestions(
+	ctx context.Context,
+	userID string,
+	suggestions *FriendSuggestions,
+	expire time.Duration,
+) error {
+	data, err := json.Marshal(suggestions)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	return errs.Wrap(f.rdb.Set(ctx, f.getFriendSuggestionKey(userID), data, expire).Err())
+}
+
+func (f *friendSuggestionCacheRedis) DelFriendSuggestions(ctx context.Context, userIDs ...string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, f.getFriendSuggestionKey(userID))
+	}
+	return errs.Wrap(f.rdb.Del(ctx, keys...).Err())
+}
@@ -39,6 +39,7 @@ const (
 	SuperGroupMemberIDsKey = "SUPER_GROUP_MEMBER_IDS:"
 	joinedGroupsKey        = "JOIN_GROUPS_KEY:"
 	groupMemberNumKey      = "GROUP_MEMBER_NUM_CACHE:"
+	groupRolesKey          = "GROUP_ROLES:"
 )
 
 type GroupCache interface {
@@ -86,6 +87,9 @@ type GroupCache interface {
 
 	GetGroupMemberNum(ctx context.Context, groupID string) (memberNum int64, err error)
 	DelGroupsMemberNum(groupID ...string) GroupCache
+
+	GetGroupRoles(ctx context.Context, groupID string) (roles []*relationTb.GroupRoleModel, err error)
+	DelGroupRoles(groupIDs ...string) GroupCache
 }
 
 type GroupCacheRedis struct {
@@ -93,6 +97,7 @@ type GroupCacheRedis struct {
 	groupDB        relationTb.GroupModelInterface
 	groupMemberDB  relationTb.GroupMemberModelInterface
 	groupRequestDB relationTb.GroupRequestModelInterface
+	groupRoleDB    relationTb.GroupRoleModelInterface
 	mongoDB        unrelationTb.SuperGroupModelInterface
 	expireTime     time.Duration
 	rcClient       *rockscache.Client
@@ -103,13 +108,14 @@ func NewGroupCacheRedis(
 	groupDB relationTb.GroupModelInterface,
 	groupMemberDB relationTb.GroupMemberModelInterface,
 	groupRequestDB relationTb.GroupRequestModelInterface,
+	groupRoleDB relationTb.GroupRoleModelInterface,
 	mongoClient unrelationTb.SuperGroupModelInterface,
 	opts rockscache.Options,
 ) GroupCache {
 	rcClient := rockscache.NewClient(rdb, opts)
 	return &GroupCacheRedis{
 		rcClient: rcClient, expireTime: groupExpireTime,
-		groupDB: groupDB, groupMemberDB: groupMemberDB, groupRequestDB: groupRequestDB,
+		groupDB: groupDB, groupMemberDB: groupMemberDB, groupRequestDB: groupRequestDB, groupRoleDB: groupRoleDB,
 		mongoDB: mongoClient, metaCache: NewMetaCacheRedis(rcClient),
 	}
 }
@@ -121,6 +127,7 @@ func (g *GroupCacheRedis) NewCache() GroupCache {
 		groupDB:        g.groupDB,
 		groupMemberDB:  g.groupMemberDB,
 		groupRequestDB: g.groupRequestDB,
+		groupRoleDB:    g.groupRoleDB,
 		mongoDB:        g.mongoDB,
 		metaCache:      NewMetaCacheRedis(g.rcClient, g.metaCache.GetPreDelKeys()...),
 	}
@@ -154,6 +161,10 @@ func (g *GroupCacheRedis) getGroupMemberInfoKey(groupID, userID string) string {
 	return groupMemberInfoKey + groupID + "-" + userID
 }
 
+func (g *GroupCacheRedis) getGroupRolesKey(groupID string) string {
+	return groupRolesKey + groupID
+}
+
 func (g *GroupCacheRedis) getGroupMemberNumKey(groupID string) string {
 	return groupMemberNumKey + groupID
 }
@@ -507,3 +518,19 @@ func (g *GroupCacheRedis) DelGroupsMemberNum(groupID ...string) GroupCache {
 	cache.AddKeys(keys...)
 	return cache
 }
+
+func (g *GroupCacheRedis) GetGroupRoles(ctx context.Context, groupID string) (roles []*relationTb.GroupRoleModel, err error) {
+	return getCache(ctx, g.rcClient, g.getGroupRolesKey(groupID), g.expireTime, func(ctx context.Context) ([]*relationTb.GroupRoleModel, error) {
+		return g.groupRoleDB.Find(ctx, groupID)
+	})
+}
+
+func (g *GroupCacheRedis) DelGroupRoles(groupIDs ...string) GroupCache {
+	cache := g.NewCache()
+	keys := make([]string, 0, len(groupIDs))
+	for _, groupID := range groupIDs {
+		keys = append(keys, g.getGroupRolesKey(groupID))
+	}
+	cache.AddKeys(keys...)
+	return cache
+}
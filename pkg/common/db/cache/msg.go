@@ -75,6 +75,8 @@ type SeqCache interface {
 	SetConversationUserMinSeqs(ctx context.Context, conversationID string, seqs map[string]int64) (err error)
 	// seqs map: key conversationID value minSeq
 	SetUserConversationsMinSeqs(ctx context.Context, userID string, seqs map[string]int64) error
+	// k: conversationID, v: minSeq
+	GetUserConversationsMinSeqs(ctx context.Context, userID string, conversationIDs []string) (map[string]int64, error)
 	// has read seq
 	SetHasReadSeq(ctx context.Context, userID string, conversationID string, hasReadSeq int64) error
 	// k: user, v: seq
@@ -101,6 +103,8 @@ type thirdCache interface {
 type MsgModel interface {
 	SeqCache
 	thirdCache
+	MsgDestructCache
+	SlowModeCache
 	AddTokenFlag(ctx context.Context, userID string, platformID int, token string, flag int) error
 	GetTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error)
 	SetTokenMapByUidPid(ctx context.Context, userID string, platformID int, m map[string]int) error
@@ -287,6 +291,16 @@ func (c *msgCache) SetUserConversationsMinSeqs(ctx context.Context, userID strin
 	})
 }
 
+func (c *msgCache) GetUserConversationsMinSeqs(
+	ctx context.Context,
+	userID string,
+	conversationIDs []string,
+) (map[string]int64, error) {
+	return c.getSeqs(ctx, conversationIDs, func(conversationID string) string {
+		return c.getConversationUserMinSeqKey(conversationID, userID)
+	})
+}
+
 func (c *msgCache) SetHasReadSeq(ctx context.Context, userID string, conversationID string, hasReadSeq int64) error {
 	return utils.Wrap1(c.rdb.Set(ctx, c.getHasReadSeqKey(conversationID, userID), hasReadSeq, 0).Err())
 }
@@ -0,0 +1,169 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+)
+
+const (
+	msgDestructQueue = "MSG_DESTRUCT_QUEUE"
+	msgBurnAfterRead = "MSG_BURN_AFTER_READ:"
+)
+
+// popByScore atomically takes the members whose score is not bigger than ARGV[1], so that
+// several workers polling the same key never handle one message twice.
+var popByScore = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+if #items > 0 then
+	redis.call('ZREM', KEYS[1], unpack(items))
+end
+return items
+`)
+
+// popBySeqs atomically takes the members whose score is one of ARGV.
+var popBySeqs = redis.NewScript(`
+local items = {}
+for i = 1, #ARGV do
+	local found = redis.call('ZRANGEBYSCORE', KEYS[1], ARGV[i], ARGV[i])
+	for _, v in ipairs(found) do
+		table.insert(items, v)
+	end
+end
+if #items > 0 then
+	redis.call('ZREM', KEYS[1], unpack(items))
+end
+return items
+`)
+
+// MsgDestructItem a message waiting to be physically deleted.
+type MsgDestructItem struct {
+	ConversationID string `json:"conversationID"`
+	Seq            int64  `json:"seq"`
+	SessionType    int32  `json:"sessionType"`
+	SendID         string `json:"sendID"`
+	// groupID for group chat
+	RecvID string `json:"recvID"`
+	// seconds to keep the message after it has been read
+	BurnDuration int32 `json:"burnDuration,omitempty"`
+	// unix milli
+	DestructTime int64 `json:"destructTime"`
+}
+
+type MsgDestructCache interface {
+	// messages are deleted after item.DestructTime
+	AddMsgsDestruct(ctx context.Context, items []*MsgDestructItem) error
+	PopExpiredMsgsDestruct(ctx context.Context, now time.Time, count int64) ([]*MsgDestructItem, error)
+	// messages start counting down after userID has read them
+	AddMsgsBurnAfterRead(ctx context.Context, userID string, conversationID string, items []*MsgDestructItem) error
+	PopMsgsBurnAfterRead(ctx context.Context, userID string, conversationID string, hasReadSeq int64) ([]*MsgDestructItem, error)
+	// only the msgs of seqs, the unread msgs before them keep waiting
+	PopMsgsBurnAfterReadBySeqs(ctx context.Context, userID string, conversationID string, seqs []int64) ([]*MsgDestructItem, error)
+}
+
+func (c *msgCache) getMsgBurnAfterReadKey(userID string, conversationID string) string {
+	return msgBurnAfterRead + userID + ":" + conversationID
+}
+
+func (c *msgCache) popMsgDestructItems(ctx context.Context, key string, maxScore int64, count int64) ([]*MsgDestructItem, error) {
+	res, err := popByScore.Run(ctx, c.rdb, []string{key}, strconv.FormatInt(maxScore, 10), count).StringSlice()
+	if err != nil && err != redis.Nil {
+		return nil, errs.Wrap(err)
+	}
+	return c.unmarshalMsgDestructItems(res)
+}
+
+func (c *msgCache) unmarshalMsgDestructItems(res []string) ([]*MsgDestructItem, error) {
+	items := make([]*MsgDestructItem, 0, len(res))
+	for _, v := range res {
+		var item MsgDestructItem
+		if err := json.Unmarshal([]byte(v), &item); err != nil {
+			return nil, utils.Wrap(err, v)
+		}
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+func (c *msgCache) AddMsgsDestruct(ctx context.Context, items []*MsgDestructItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	members := make([]redis.Z, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return utils.Wrap(err, "")
+		}
+		members = append(members, redis.Z{Score: float64(item.DestructTime), Member: string(data)})
+	}
+	return errs.Wrap(c.rdb.ZAdd(ctx, msgDestructQueue, members...).Err())
+}
+
+func (c *msgCache) PopExpiredMsgsDestruct(ctx context.Context, now time.Time, count int64) ([]*MsgDestructItem, error) {
+	return c.popMsgDestructItems(ctx, msgDestructQueue, now.UnixMilli(), count)
+}
+
+func (c *msgCache) AddMsgsBurnAfterRead(ctx context.Context, userID string, conversationID string, items []*MsgDestructItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	members := make([]redis.Z, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return utils.Wrap(err, "")
+		}
+		members = append(members, redis.Z{Score: float64(item.Seq), Member: string(data)})
+	}
+	key := c.getMsgBurnAfterReadKey(userID, conversationID)
+	pipe := c.rdb.Pipeline()
+	pipe.ZAdd(ctx, key, members...)
+	// unread messages never outlive the chat records themselves, kept until read if the records are kept forever
+	if config.Config.RetainChatRecords > 0 {
+		pipe.Expire(ctx, key, time.Duration(config.Config.RetainChatRecords)*24*time.Hour)
+	}
+	_, err := pipe.Exec(ctx)
+	return errs.Wrap(err)
+}
+
+func (c *msgCache) PopMsgsBurnAfterRead(ctx context.Context, userID string, conversationID string, hasReadSeq int64) ([]*MsgDestructItem, error) {
+	return c.popMsgDestructItems(ctx, c.getMsgBurnAfterReadKey(userID, conversationID), hasReadSeq, -1)
+}
+
+func (c *msgCache) PopMsgsBurnAfterReadBySeqs(ctx context.Context, userID string, conversationID string, seqs []int64) ([]*MsgDestructItem, error) {
+	if len(seqs) == 0 {
+		return nil, nil
+	}
+	args := make([]any, 0, len(seqs))
+	for _, seq := range seqs {
+		args = append(args, strconv.FormatInt(seq, 10))
+	}
+	res, err := popBySeqs.Run(ctx, c.rdb, []string{c.getMsgBurnAfterReadKey(userID, conversationID)}, args...).StringSlice()
+	if err != nil && err != redis.Nil {
+		return nil, errs.Wrap(err)
+	}
+	return c.unmarshalMsgDestructItems(res)
+}
@@ -0,0 +1,227 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dtm-labs/rockscache"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+const (
+	quotaExpireTime = time.Second * 60 * 60 * 12
+	quotaKey        = "QUOTA:"
+	quotaUsageKey   = "QUOTA_USAGE:"
+	quotaHoldKey    = "QUOTA_HOLD:"
+)
+
+// incrQuotaUsage adds ARGV[1] to the usage of the current window unless it would pass the limit ARGV[2],
+// returns the usage after the call and 1 if it was added.
+var incrQuotaUsage = redis.NewScript(`
+local used = tonumber(redis.call('GET', KEYS[1]) or '0')
+local amount = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+if limit > 0 and amount > 0 and used + amount > limit then
+	return {used, 0}
+end
+used = redis.call('INCRBY', KEYS[1], amount)
+if used == amount then
+	redis.call('EXPIRE', KEYS[1], ARGV[3])
+end
+return {used, 1}
+`)
+
+// decrQuotaUsage the window may have ended, do not recreate the key without expiration.
+var decrQuotaUsage = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('DECRBY', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// holdQuotaUsage adds the hold ARGV[1] of ARGV[2] until ARGV[5] if the usage of the window KEYS[1] and
+// the unexpired holds KEYS[2] do not pass the limit ARGV[3], returns 1 if it was added.
+var holdQuotaUsage = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', ARGV[4])
+local used = tonumber(redis.call('GET', KEYS[1]) or '0')
+for _, member in ipairs(redis.call('ZRANGE', KEYS[2], 0, -1)) do
+	used = used + tonumber(string.match(member, ':(%d+)$'))
+end
+local amount = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+if limit > 0 and used + amount > limit then
+	return 0
+end
+redis.call('ZADD', KEYS[2], ARGV[5], ARGV[1] .. ':' .. ARGV[2])
+if redis.call('PTTL', KEYS[2]) < tonumber(ARGV[5]) - tonumber(ARGV[4]) then
+	redis.call('PEXPIREAT', KEYS[2], ARGV[5])
+end
+return 1
+`)
+
+// commitQuotaHold moves the hold ARGV[1] into the usage of the window KEYS[1], returns the amount of the hold.
+var commitQuotaHold = redis.NewScript(`
+for _, member in ipairs(redis.call('ZRANGE', KEYS[2], 0, -1)) do
+	local id, amount = string.match(member, '^(.*):(%d+)$')
+	if id == ARGV[1] then
+		redis.call('ZREM', KEYS[2], member)
+		amount = tonumber(amount)
+		if redis.call('INCRBY', KEYS[1], amount) == amount then
+			redis.call('EXPIRE', KEYS[1], ARGV[2])
+		end
+		return amount
+	end
+end
+return 0
+`)
+
+type QuotaCache interface {
+	metaCache
+	NewCache() QuotaCache
+	// GetQuotas 获取用户或群的配额覆盖
+	GetQuotas(ctx context.Context, targetID string) ([]*unRelationTb.QuotaModel, error)
+	DelQuotas(targetIDs ...string) QuotaCache
+	// IncrQuotaUsage 在当前窗口内增加用量, 超过limit时不增加并返回false, limit为0不限制, 返回增加用量的key
+	IncrQuotaUsage(ctx context.Context, kind string, targetID string, window time.Duration, amount int64, limit int64) (string, bool, error)
+	// DecrQuotaUsage 归还IncrQuotaUsage返回的key的用量, 窗口已结束时忽略
+	DecrQuotaUsage(ctx context.Context, key string, amount int64) error
+	// HoldQuotaUsage 预占用量直到expireTime, 过期自动归还, 窗口用量加预占超过limit时返回false
+	HoldQuotaUsage(ctx context.Context, kind string, targetID string, window time.Duration, holdID string, amount int64, limit int64, expireTime time.Time) (bool, error)
+	// CommitQuotaHold 将预占转为当前窗口的用量, 返回预占的用量, 预占不存在或已过期返回0
+	CommitQuotaHold(ctx context.Context, kind string, targetID string, window time.Duration, holdID string) (int64, error)
+	// GetQuotaUsage 获取当前窗口的用量, 包含未过期的预占
+	GetQuotaUsage(ctx context.Context, kind string, targetID string, window time.Duration) (int64, error)
+}
+
+func NewQuotaCacheRedis(rdb redis.UniversalClient, quotaDB unRelationTb.QuotaModelInterface, options rockscache.Options) QuotaCache {
+	rcClient := rockscache.NewClient(rdb, options)
+	return &QuotaCacheRedis{
+		rdb:        rdb,
+		metaCache:  NewMetaCacheRedis(rcClient),
+		quotaDB:    quotaDB,
+		expireTime: quotaExpireTime,
+		rcClient:   rcClient,
+	}
+}
+
+type QuotaCacheRedis struct {
+	metaCache
+	rdb        redis.UniversalClient
+	quotaDB    unRelationTb.QuotaModelInterface
+	expireTime time.Duration
+	rcClient   *rockscache.Client
+}
+
+func (q *QuotaCacheRedis) NewCache() QuotaCache {
+	return &QuotaCacheRedis{
+		rdb:        q.rdb,
+		metaCache:  NewMetaCacheRedis(q.rcClient, q.metaCache.GetPreDelKeys()...),
+		quotaDB:    q.quotaDB,
+		expireTime: q.expireTime,
+		rcClient:   q.rcClient,
+	}
+}
+
+func (q *QuotaCacheRedis) getQuotaKey(targetID string) string {
+	return quotaKey + targetID
+}
+
+// the window start is part of the key, so every window counts from zero.
+// the usage and the holds of a target share the hash tag, the scripts use both in cluster mode.
+func (q *QuotaCacheRedis) getQuotaUsageKey(kind string, targetID string, window time.Duration) string {
+	start := time.Now().Unix() / int64(window/time.Second) * int64(window/time.Second)
+	return quotaUsageKey + "{" + kind + ":" + targetID + "}:" + strconv.FormatInt(start, 10)
+}
+
+func (q *QuotaCacheRedis) getQuotaHoldKey(kind string, targetID string) string {
+	return quotaHoldKey + "{" + kind + ":" + targetID + "}"
+}
+
+func (q *QuotaCacheRedis) GetQuotas(ctx context.Context, targetID string) ([]*unRelationTb.QuotaModel, error) {
+	return getCache(ctx, q.rcClient, q.getQuotaKey(targetID), q.expireTime, func(ctx context.Context) ([]*unRelationTb.QuotaModel, error) {
+		return q.quotaDB.Find(ctx, targetID)
+	})
+}
+
+func (q *QuotaCacheRedis) DelQuotas(targetIDs ...string) QuotaCache {
+	cache := q.NewCache()
+	keys := make([]string, 0, len(targetIDs))
+	for _, targetID := range targetIDs {
+		keys = append(keys, q.getQuotaKey(targetID))
+	}
+	cache.AddKeys(keys...)
+	return cache
+}
+
+func (q *QuotaCacheRedis) IncrQuotaUsage(ctx context.Context, kind string, targetID string, window time.Duration, amount int64, limit int64) (string, bool, error) {
+	key := q.getQuotaUsageKey(kind, targetID, window)
+	res, err := incrQuotaUsage.Run(ctx, q.rdb, []string{key}, amount, limit, int64(window/time.Second)).Int64Slice()
+	if err != nil {
+		return "", false, errs.Wrap(err)
+	}
+	return key, res[1] == 1, nil
+}
+
+func (q *QuotaCacheRedis) DecrQuotaUsage(ctx context.Context, key string, amount int64) error {
+	return errs.Wrap(decrQuotaUsage.Run(ctx, q.rdb, []string{key}, amount).Err())
+}
+
+func (q *QuotaCacheRedis) HoldQuotaUsage(ctx context.Context, kind string, targetID string, window time.Duration, holdID string, amount int64, limit int64, expireTime time.Time) (bool, error) {
+	keys := []string{q.getQuotaUsageKey(kind, targetID, window), q.getQuotaHoldKey(kind, targetID)}
+	ok, err := holdQuotaUsage.Run(ctx, q.rdb, keys, holdID, amount, limit, time.Now().UnixMilli(), expireTime.UnixMilli()).Int()
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return ok == 1, nil
+}
+
+func (q *QuotaCacheRedis) CommitQuotaHold(ctx context.Context, kind string, targetID string, window time.Duration, holdID string) (int64, error) {
+	keys := []string{q.getQuotaUsageKey(kind, targetID, window), q.getQuotaHoldKey(kind, targetID)}
+	amount, err := commitQuotaHold.Run(ctx, q.rdb, keys, holdID, int64(window/time.Second)).Int64()
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return amount, nil
+}
+
+func (q *QuotaCacheRedis) GetQuotaUsage(ctx context.Context, kind string, targetID string, window time.Duration) (int64, error) {
+	used, err := q.rdb.Get(ctx, q.getQuotaUsageKey(kind, targetID, window)).Int64()
+	if err != nil && err != redis.Nil {
+		return 0, errs.Wrap(err)
+	}
+	holds, err := q.rdb.ZRangeByScore(ctx, q.getQuotaHoldKey(kind, targetID), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	for _, hold := range holds {
+		amount, err := strconv.ParseInt(hold[strings.LastIndex(hold, ":")+1:], 10, 64)
+		if err != nil {
+			return 0, errs.Wrap(err)
+		}
+		used += amount
+	}
+	return used, nil
+}
@@ -0,0 +1,76 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+)
+
+const groupSlowMode = "GROUP_SLOW_MODE:"
+
+// releaseGroupSlowMode deletes KEYS[1] only when it still holds the reservation ARGV[1].
+var releaseGroupSlowMode = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type SlowModeCache interface {
+	// ReserveGroupSlowMode 原子地预占interval的冷却, 成功返回预占的token, 冷却中返回剩余时间
+	ReserveGroupSlowMode(ctx context.Context, groupID string, userID string, interval time.Duration) (string, time.Duration, error)
+	// ReleaseGroupSlowMode 发送失败时释放token对应的预占
+	ReleaseGroupSlowMode(ctx context.Context, groupID string, userID string, token string) error
+}
+
+func (c *msgCache) getGroupSlowModeKey(groupID string, userID string) string {
+	return groupSlowMode + groupID + ":" + userID
+}
+
+func (c *msgCache) ReserveGroupSlowMode(
+	ctx context.Context,
+	groupID string,
+	userID string,
+	interval time.Duration,
+) (string, time.Duration, error) {
+	key := c.getGroupSlowModeKey(groupID, userID)
+	token := utils.OperationIDGenerator()
+	ok, err := c.rdb.SetNX(ctx, key, token, interval).Result()
+	if err != nil {
+		return "", 0, errs.Wrap(err)
+	}
+	if ok {
+		return token, 0, nil
+	}
+	ttl, err := c.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return "", 0, errs.Wrap(err)
+	}
+	// 冷却刚好结束, 按剩余最短时间处理
+	if ttl <= 0 {
+		ttl = time.Millisecond
+	}
+	return "", ttl, nil
+}
+
+func (c *msgCache) ReleaseGroupSlowMode(ctx context.Context, groupID string, userID string, token string) error {
+	return errs.Wrap(releaseGroupSlowMode.Run(ctx, c.rdb, []string{c.getGroupSlowModeKey(groupID, userID)}, token).Err())
+}
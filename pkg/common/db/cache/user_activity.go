@@ -0,0 +1,43 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+const userActivityTouch = "USER_ACTIVITY_TOUCH:"
+
+type UserActivityCache interface {
+	// AcquireUserActivityTouch 距上次记录已超过interval时返回true, 用于限制活跃时间的写入频率
+	AcquireUserActivityTouch(ctx context.Context, userID string, interval time.Duration) (bool, error)
+}
+
+func NewUserActivityCacheRedis(rdb redis.UniversalClient) UserActivityCache {
+	return &userActivityCacheRedis{rdb: rdb}
+}
+
+type userActivityCacheRedis struct {
+	rdb redis.UniversalClient
+}
+
+func (u *userActivityCacheRedis) AcquireUserActivityTouch(ctx context.Context, userID string, interval time.Duration) (bool, error) {
+	ok, err := u.rdb.SetNX(ctx, userActivityTouch+userID, time.Now().UnixMilli(), interval).Result()
+	return ok, errs.Wrap(err)
+}
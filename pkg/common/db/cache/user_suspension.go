@@ -0,0 +1,96 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/dtm-labs/rockscache"
+	"github.com/redis/go-redis/v9"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+const (
+	userSuspensionKey        = "USER_SUSPENSION:"
+	userSuspensionExpireTime = time.Second * 60 * 60 * 12
+)
+
+// UserSuspensionCache 用户生效中的封禁, 缓存未命中时从mongo加载.
+type UserSuspensionCache interface {
+	metaCache
+	NewCache() UserSuspensionCache
+	// GetUserSuspensions 可能包含已过结束时间的封禁
+	GetUserSuspensions(ctx context.Context, userID string) ([]*unRelationTb.UserSuspensionModel, error)
+	// DelUserSuspensions 封禁或解除后删除
+	DelUserSuspensions(userIDs ...string) UserSuspensionCache
+}
+
+func NewUserSuspensionCacheRedis(
+	rdb redis.UniversalClient,
+	suspensionDB unRelationTb.UserSuspensionModelInterface,
+	options rockscache.Options,
+) UserSuspensionCache {
+	rcClient := rockscache.NewClient(rdb, options)
+	return &UserSuspensionCacheRedis{
+		metaCache:    NewMetaCacheRedis(rcClient),
+		rcClient:     rcClient,
+		suspensionDB: suspensionDB,
+		expireTime:   userSuspensionExpireTime,
+	}
+}
+
+type UserSuspensionCacheRedis struct {
+	metaCache
+	rcClient     *rockscache.Client
+	suspensionDB unRelationTb.UserSuspensionModelInterface
+	expireTime   time.Duration
+}
+
+func (u *UserSuspensionCacheRedis) NewCache() UserSuspensionCache {
+	return &UserSuspensionCacheRedis{
+		metaCache:    NewMetaCacheRedis(u.rcClient, u.metaCache.GetPreDelKeys()...),
+		rcClient:     u.rcClient,
+		suspensionDB: u.suspensionDB,
+		expireTime:   u.expireTime,
+	}
+}
+
+func (u *UserSuspensionCacheRedis) getUserSuspensionKey(userID string) string {
+	return userSuspensionKey + userID
+}
+
+func (u *UserSuspensionCacheRedis) GetUserSuspensions(ctx context.Context, userID string) ([]*unRelationTb.UserSuspensionModel, error) {
+	return getCache(
+		ctx,
+		u.rcClient,
+		u.getUserSuspensionKey(userID),
+		u.expireTime,
+		func(ctx context.Context) ([]*unRelationTb.UserSuspensionModel, error) {
+			return u.suspensionDB.FindActive(ctx, userID)
+		},
+	)
+}
+
+func (u *UserSuspensionCacheRedis) DelUserSuspensions(userIDs ...string) UserSuspensionCache {
+	keys := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, u.getUserSuspensionKey(userID))
+	}
+	cache := u.NewCache()
+	cache.AddKeys(keys...)
+	return cache
+}
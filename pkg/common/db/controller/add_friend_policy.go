@@ -0,0 +1,55 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type AddFriendPolicyDatabase interface {
+	SetAddFriendPolicy(ctx context.Context, userID string, policy int32) error
+	// GetAddFriendPolicy 未设置返回relation.AddFriendPolicyAnyone
+	GetAddFriendPolicy(ctx context.Context, userID string) (int32, error)
+}
+
+func NewAddFriendPolicyDatabase(policy relation.AddFriendPolicyModelInterface) AddFriendPolicyDatabase {
+	return &addFriendPolicyDatabase{policy: policy}
+}
+
+type addFriendPolicyDatabase struct {
+	policy relation.AddFriendPolicyModelInterface
+}
+
+func (a *addFriendPolicyDatabase) SetAddFriendPolicy(ctx context.Context, userID string, policy int32) error {
+	return a.policy.Set(ctx, &relation.AddFriendPolicyModel{UserID: userID, Policy: policy, UpdateTime: time.Now()})
+}
+
+func (a *addFriendPolicyDatabase) GetAddFriendPolicy(ctx context.Context, userID string) (int32, error) {
+	policy, err := a.policy.Take(ctx, userID)
+	if err != nil {
+		if errs.Unwrap(err) == gorm.ErrRecordNotFound {
+			return relation.AddFriendPolicyAnyone, nil
+		}
+		return 0, err
+	}
+	return policy.Policy, nil
+}
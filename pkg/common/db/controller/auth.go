@@ -33,6 +33,8 @@ type AuthDatabase interface {
 	GetTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error)
 	// 创建token
 	CreateToken(ctx context.Context, userID string, platformID int) (string, error)
+	// 将用户所有平台的token标记为已被踢, 返回标记的token数
+	RevokeTokens(ctx context.Context, userID string) (int, error)
 }
 
 type authDatabase struct {
@@ -82,3 +84,29 @@ func (a *authDatabase) CreateToken(ctx context.Context, userID string, platformI
 	}
 	return tokenString, a.cache.AddTokenFlag(ctx, userID, platformID, tokenString, constant.NormalToken)
 }
+
+// 将用户所有平台的token标记为已被踢, 返回标记的token数.
+func (a *authDatabase) RevokeTokens(ctx context.Context, userID string) (int, error) {
+	var count int
+	for platformID := range constant.PlatformID2Name {
+		tokens, err := a.cache.GetTokensWithoutError(ctx, userID, platformID)
+		if err != nil {
+			return count, err
+		}
+		var n int
+		for k, v := range tokens {
+			if v != constant.KickedToken {
+				tokens[k] = constant.KickedToken
+				n++
+			}
+		}
+		if n == 0 {
+			continue
+		}
+		if err := a.cache.SetTokenMapByUidPid(ctx, userID, platformID, tokens); err != nil {
+			return count, err
+		}
+		count += n
+	}
+	return count, nil
+}
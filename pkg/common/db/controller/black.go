@@ -38,6 +38,8 @@ type BlackDatabase interface {
 	FindBlackIDs(ctx context.Context, ownerUserID string) (blackIDs []string, err error)
 	// CheckIn 检查user2是否在user1的黑名单列表中(inUser1Blacks==true) 检查user1是否在user2的黑名单列表中(inUser2Blacks==true)
 	CheckIn(ctx context.Context, userID1, userID2 string) (inUser1Blacks bool, inUser2Blacks bool, err error)
+	// DeleteUserBlacks 删除userID的黑名单和userID所在的黑名单, 用于注销用户
+	DeleteUserBlacks(ctx context.Context, userID string) (count int64, err error)
 }
 
 type blackDatabase struct {
@@ -102,3 +104,18 @@ func (b *blackDatabase) CheckIn(
 func (b *blackDatabase) FindBlackIDs(ctx context.Context, ownerUserID string) (blackIDs []string, err error) {
 	return b.cache.GetBlackIDs(ctx, ownerUserID)
 }
+
+func (b *blackDatabase) DeleteUserBlacks(ctx context.Context, userID string) (count int64, err error) {
+	ownerUserIDs, err := b.black.FindOwnerUserIDs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if count, err = b.black.DeleteByUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	cache := b.cache.NewCache()
+	for _, ownerUserID := range append(ownerUserIDs, userID) {
+		cache = cache.DelBlackIDs(ctx, ownerUserID)
+	}
+	return count, cache.ExecDel(ctx)
+}
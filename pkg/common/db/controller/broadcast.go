@@ -0,0 +1,90 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type BroadcastDatabase interface {
+	// CreateJob 创建广播任务
+	CreateJob(ctx context.Context, job *unRelationTb.BroadcastJobModel) error
+	// TakeJob 获取广播任务 不存在返回错误
+	TakeJob(ctx context.Context, jobID string) (*unRelationTb.BroadcastJobModel, error)
+	// PageJobs 按状态分页获取广播任务, status为空获取全部
+	PageJobs(ctx context.Context, status []int32, pageNumber, showNumber int32) (int64, []*unRelationTb.BroadcastJobModel, error)
+	// CancelJob 取消未结束的广播任务
+	CancelJob(ctx context.Context, jobID string) (bool, error)
+	// ClaimJob 领取一个待执行或执行超时的任务并生成新的租约, 没有任务返回nil
+	ClaimJob(ctx context.Context, staleTimeout time.Duration) (*unRelationTb.BroadcastJobModel, error)
+	// RenewJob 续约, 返回false表示任务已不在执行中或租约已被其他worker取得
+	RenewJob(ctx context.Context, jobID string, leaseID string) (bool, error)
+	// SetJobTargets 设置任务目标用户, 返回false表示任务已不在执行中或租约已失效
+	SetJobTargets(ctx context.Context, jobID string, leaseID string, userIDs []string, total int64) (bool, error)
+	// AddJobProgress 记录发送进度, 返回false表示任务已不在执行中或租约已失效
+	AddJobProgress(ctx context.Context, jobID string, leaseID string, cursor string, successCount, failedCount int64, failedUserIDs []string) (bool, error)
+	// FinishJob 结束任务, 租约已失效时不做修改
+	FinishJob(ctx context.Context, jobID string, leaseID string, status int32, errMsg string) error
+}
+
+func NewBroadcastDatabase(jobDB unRelationTb.BroadcastJobModelInterface) BroadcastDatabase {
+	return &broadcastDatabase{jobDB: jobDB}
+}
+
+type broadcastDatabase struct {
+	jobDB unRelationTb.BroadcastJobModelInterface
+}
+
+func (b *broadcastDatabase) CreateJob(ctx context.Context, job *unRelationTb.BroadcastJobModel) error {
+	return b.jobDB.Create(ctx, job)
+}
+
+func (b *broadcastDatabase) TakeJob(ctx context.Context, jobID string) (*unRelationTb.BroadcastJobModel, error) {
+	return b.jobDB.Take(ctx, jobID)
+}
+
+func (b *broadcastDatabase) PageJobs(ctx context.Context, status []int32, pageNumber, showNumber int32) (int64, []*unRelationTb.BroadcastJobModel, error) {
+	return b.jobDB.Page(ctx, status, pageNumber, showNumber)
+}
+
+func (b *broadcastDatabase) CancelJob(ctx context.Context, jobID string) (bool, error) {
+	return b.jobDB.Cancel(ctx, jobID)
+}
+
+func (b *broadcastDatabase) ClaimJob(ctx context.Context, staleTimeout time.Duration) (*unRelationTb.BroadcastJobModel, error) {
+	return b.jobDB.Claim(ctx, time.Now().Add(-staleTimeout), utils.OperationIDGenerator())
+}
+
+func (b *broadcastDatabase) RenewJob(ctx context.Context, jobID string, leaseID string) (bool, error) {
+	return b.jobDB.UpdateByMap(ctx, jobID, leaseID, nil)
+}
+
+func (b *broadcastDatabase) SetJobTargets(ctx context.Context, jobID string, leaseID string, userIDs []string, total int64) (bool, error) {
+	return b.jobDB.UpdateByMap(ctx, jobID, leaseID, map[string]any{"segment.user_ids": userIDs, "total": total})
+}
+
+func (b *broadcastDatabase) AddJobProgress(ctx context.Context, jobID string, leaseID string, cursor string, successCount, failedCount int64, failedUserIDs []string) (bool, error) {
+	return b.jobDB.AddProgress(ctx, jobID, leaseID, cursor, successCount, failedCount, failedUserIDs)
+}
+
+func (b *broadcastDatabase) FinishJob(ctx context.Context, jobID string, leaseID string, status int32, errMsg string) error {
+	_, err := b.jobDB.UpdateByMap(ctx, jobID, leaseID, map[string]any{"status": status, "err_msg": errMsg, "finish_time": time.Now()})
+	return err
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
 	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
 )
 
 type ConversationDatabase interface {
@@ -53,13 +54,25 @@ type ConversationDatabase interface {
 	GetUserAllHasReadSeqs(ctx context.Context, ownerUserID string) (map[string]int64, error)
 	GetConversationsByConversationID(ctx context.Context, conversationIDs []string) ([]*relationTb.ConversationModel, error)
 	GetConversationIDsNeedDestruct(ctx context.Context) ([]*relationTb.ConversationModel, error)
+	// DeleteUserConversations 删除用户的所有会话
+	DeleteUserConversations(ctx context.Context, ownerUserID string) (int64, error)
+	// SetConversationMuteUntil 设置会话静音截止时间(毫秒), 0表示取消静音
+	SetConversationMuteUntil(ctx context.Context, ownerUserID, conversationID string, muteUntil int64) error
+	// FindMutedUserIDs 获取当前处于会话静音中的用户, userIDs为空时返回会话中所有静音的用户
+	FindMutedUserIDs(ctx context.Context, conversationID string, userIDs []string) ([]string, error)
 }
 
-func NewConversationDatabase(conversation relationTb.ConversationModelInterface, cache cache.ConversationCache, tx tx.Tx) ConversationDatabase {
+func NewConversationDatabase(
+	conversation relationTb.ConversationModelInterface,
+	cache cache.ConversationCache,
+	tx tx.Tx,
+	versionLog unRelationTb.VersionLogModelInterface,
+) ConversationDatabase {
 	return &conversationDatabase{
 		conversationDB: conversation,
 		cache:          cache,
 		tx:             tx,
+		versionLog:     versionLog,
 	}
 }
 
@@ -67,13 +80,34 @@ type conversationDatabase struct {
 	conversationDB relationTb.ConversationModelInterface
 	cache          cache.ConversationCache
 	tx             tx.Tx
+	versionLog     unRelationTb.VersionLogModelInterface
+}
+
+// incrVersion 记录userIDs的会话列表中conversationIDs的变更.
+func (c *conversationDatabase) incrVersion(ctx context.Context, userIDs []string, conversationIDs []string, state int32) error {
+	return c.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainConversation, userIDs, conversationIDs, state)
+}
+
+// incrConversationsVersion 按会话的ownerUserID记录变更.
+func (c *conversationDatabase) incrConversationsVersion(ctx context.Context, conversations []*relationTb.ConversationModel, state int32) error {
+	userConversationIDs := make(map[string][]string)
+	for _, conversation := range conversations {
+		userConversationIDs[conversation.OwnerUserID] = append(userConversationIDs[conversation.OwnerUserID], conversation.ConversationID)
+	}
+	for userID, conversationIDs := range userConversationIDs {
+		if err := c.incrVersion(ctx, []string{userID}, conversationIDs, state); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *conversationDatabase) SetUsersConversationFiledTx(ctx context.Context, userIDs []string, conversation *relationTb.ConversationModel, filedMap map[string]interface{}) (err error) {
 	cache := c.cache.NewCache()
+	var haveUserIDs, NotUserIDs []string
 	if err := c.tx.Transaction(func(tx any) error {
 		conversationTx := c.conversationDB.NewTx(tx)
-		haveUserIDs, err := conversationTx.FindUserID(ctx, userIDs, []string{conversation.ConversationID})
+		haveUserIDs, err = conversationTx.FindUserID(ctx, userIDs, []string{conversation.ConversationID})
 		if err != nil {
 			return err
 		}
@@ -89,7 +123,7 @@ func (c *conversationDatabase) SetUsersConversationFiledTx(ctx context.Context,
 				}
 			}
 		}
-		NotUserIDs := utils.DifferenceString(haveUserIDs, userIDs)
+		NotUserIDs = utils.DifferenceString(haveUserIDs, userIDs)
 		log.ZDebug(ctx, "SetUsersConversationFiledTx", "NotUserIDs", NotUserIDs, "haveUserIDs", haveUserIDs, "userIDs", userIDs)
 		var conversations []*relationTb.ConversationModel
 		now := time.Now()
@@ -113,7 +147,13 @@ func (c *conversationDatabase) SetUsersConversationFiledTx(ctx context.Context,
 	}); err != nil {
 		return err
 	}
-	return cache.ExecDel(ctx)
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	if err := c.incrVersion(ctx, haveUserIDs, []string{conversation.ConversationID}, unRelationTb.VersionStateUpdate); err != nil {
+		return err
+	}
+	return c.incrVersion(ctx, NotUserIDs, []string{conversation.ConversationID}, unRelationTb.VersionStateInsert)
 }
 
 func (c *conversationDatabase) UpdateUsersConversationFiled(ctx context.Context, userIDs []string, conversationID string, args map[string]interface{}) error {
@@ -121,7 +161,10 @@ func (c *conversationDatabase) UpdateUsersConversationFiled(ctx context.Context,
 	if err != nil {
 		return err
 	}
-	return c.cache.DelUsersConversation(conversationID, userIDs...).ExecDel(ctx)
+	if err := c.cache.DelUsersConversation(conversationID, userIDs...).ExecDel(ctx); err != nil {
+		return err
+	}
+	return c.incrVersion(ctx, userIDs, []string{conversationID}, unRelationTb.VersionStateUpdate)
 }
 
 func (c *conversationDatabase) CreateConversation(ctx context.Context, conversations []*relationTb.ConversationModel) error {
@@ -134,11 +177,15 @@ func (c *conversationDatabase) CreateConversation(ctx context.Context, conversat
 		cache = cache.DelConversations(conversation.OwnerUserID, conversation.ConversationID)
 		userIDs = append(userIDs, conversation.OwnerUserID)
 	}
-	return cache.DelConversationIDs(userIDs...).DelUserConversationIDsHash(userIDs...).ExecDel(ctx)
+	if err := cache.DelConversationIDs(userIDs...).DelUserConversationIDsHash(userIDs...).ExecDel(ctx); err != nil {
+		return err
+	}
+	return c.incrConversationsVersion(ctx, conversations, unRelationTb.VersionStateInsert)
 }
 
 func (c *conversationDatabase) SyncPeerUserPrivateConversationTx(ctx context.Context, conversations []*relationTb.ConversationModel) error {
 	cache := c.cache.NewCache()
+	var updated, created []*relationTb.ConversationModel
 	if err := c.tx.Transaction(func(tx any) error {
 		conversationTx := c.conversationDB.NewTx(tx)
 		for _, conversation := range conversations {
@@ -155,6 +202,7 @@ func (c *conversationDatabase) SyncPeerUserPrivateConversationTx(ctx context.Con
 						return err
 					}
 					cache = cache.DelUsersConversation(conversation.ConversationID, ownerUserID)
+					updated = append(updated, &relationTb.ConversationModel{OwnerUserID: ownerUserID, ConversationID: conversation.ConversationID})
 				} else {
 					newConversation := *conversation
 					newConversation.OwnerUserID = ownerUserID
@@ -165,6 +213,7 @@ func (c *conversationDatabase) SyncPeerUserPrivateConversationTx(ctx context.Con
 						return err
 					}
 					cache = cache.DelConversationIDs(ownerUserID).DelUserConversationIDsHash(ownerUserID)
+					created = append(created, &newConversation)
 				}
 			}
 		}
@@ -172,7 +221,13 @@ func (c *conversationDatabase) SyncPeerUserPrivateConversationTx(ctx context.Con
 	}); err != nil {
 		return err
 	}
-	return cache.ExecDel(ctx)
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	if err := c.incrConversationsVersion(ctx, updated, unRelationTb.VersionStateUpdate); err != nil {
+		return err
+	}
+	return c.incrConversationsVersion(ctx, created, unRelationTb.VersionStateInsert)
 }
 
 func (c *conversationDatabase) FindConversations(ctx context.Context, ownerUserID string, conversationIDs []string) ([]*relationTb.ConversationModel, error) {
@@ -189,6 +244,7 @@ func (c *conversationDatabase) GetUserAllConversation(ctx context.Context, owner
 
 func (c *conversationDatabase) SetUserConversations(ctx context.Context, ownerUserID string, conversations []*relationTb.ConversationModel) error {
 	cache := c.cache.NewCache()
+	var existConversationIDs, notExistConversationIDs []string
 	if err := c.tx.Transaction(func(tx any) error {
 		var conversationIDs []string
 		for _, conversation := range conversations {
@@ -208,7 +264,6 @@ func (c *conversationDatabase) SetUserConversations(ctx context.Context, ownerUs
 				}
 			}
 		}
-		var existConversationIDs []string
 		for _, conversation := range existConversations {
 			existConversationIDs = append(existConversationIDs, conversation.ConversationID)
 		}
@@ -217,6 +272,7 @@ func (c *conversationDatabase) SetUserConversations(ctx context.Context, ownerUs
 		for _, conversation := range conversations {
 			if !utils.IsContain(conversation.ConversationID, existConversationIDs) {
 				notExistConversations = append(notExistConversations, conversation)
+				notExistConversationIDs = append(notExistConversationIDs, conversation.ConversationID)
 			}
 		}
 		if len(notExistConversations) > 0 {
@@ -230,7 +286,13 @@ func (c *conversationDatabase) SetUserConversations(ctx context.Context, ownerUs
 	}); err != nil {
 		return err
 	}
-	return cache.ExecDel(ctx)
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	if err := c.incrVersion(ctx, []string{ownerUserID}, existConversationIDs, unRelationTb.VersionStateUpdate); err != nil {
+		return err
+	}
+	return c.incrVersion(ctx, []string{ownerUserID}, notExistConversationIDs, unRelationTb.VersionStateInsert)
 }
 
 func (c *conversationDatabase) FindRecvMsgNotNotifyUserIDs(ctx context.Context, groupID string) ([]string, error) {
@@ -240,12 +302,14 @@ func (c *conversationDatabase) FindRecvMsgNotNotifyUserIDs(ctx context.Context,
 func (c *conversationDatabase) CreateGroupChatConversation(ctx context.Context, groupID string, userIDs []string) error {
 	cache := c.cache.NewCache()
 	conversationID := msgprocessor.GetConversationIDBySessionType(constant.SuperGroupChatType, groupID)
+	var existConversationUserIDs, notExistUserIDs []string
 	if err := c.tx.Transaction(func(tx any) error {
-		existConversationUserIDs, err := c.conversationDB.FindUserID(ctx, userIDs, []string{conversationID})
+		var err error
+		existConversationUserIDs, err = c.conversationDB.FindUserID(ctx, userIDs, []string{conversationID})
 		if err != nil {
 			return err
 		}
-		notExistUserIDs := utils.DifferenceString(userIDs, existConversationUserIDs)
+		notExistUserIDs = utils.DifferenceString(userIDs, existConversationUserIDs)
 		var conversations []*relationTb.ConversationModel
 		for _, v := range notExistUserIDs {
 			conversation := relationTb.ConversationModel{ConversationType: constant.SuperGroupChatType, GroupID: groupID, OwnerUserID: v, ConversationID: conversationID}
@@ -270,7 +334,13 @@ func (c *conversationDatabase) CreateGroupChatConversation(ctx context.Context,
 	}); err != nil {
 		return err
 	}
-	return cache.ExecDel(ctx)
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	if err := c.incrVersion(ctx, existConversationUserIDs, []string{conversationID}, unRelationTb.VersionStateUpdate); err != nil {
+		return err
+	}
+	return c.incrVersion(ctx, notExistUserIDs, []string{conversationID}, unRelationTb.VersionStateInsert)
 }
 
 func (c *conversationDatabase) GetConversationIDs(ctx context.Context, userID string) ([]string, error) {
@@ -296,3 +366,56 @@ func (c *conversationDatabase) GetConversationsByConversationID(ctx context.Cont
 func (c *conversationDatabase) GetConversationIDsNeedDestruct(ctx context.Context) ([]*relationTb.ConversationModel, error) {
 	return c.conversationDB.GetConversationIDsNeedDestruct(ctx)
 }
+
+func (c *conversationDatabase) DeleteUserConversations(ctx context.Context, ownerUserID string) (int64, error) {
+	conversationIDs, err := c.conversationDB.FindUserIDAllConversationID(ctx, ownerUserID)
+	if err != nil {
+		return 0, err
+	}
+	count, err := c.conversationDB.DeleteByOwner(ctx, ownerUserID)
+	if err != nil {
+		return 0, err
+	}
+	return count, c.cache.DelConversationIDs(ownerUserID).
+		DelUserConversationIDsHash(ownerUserID).
+		DelConversations(ownerUserID, conversationIDs...).
+		DelUserAllHasReadSeqs(ownerUserID, conversationIDs...).
+		DelConversationMuteUntil(conversationIDs...).
+		ExecDel(ctx)
+}
+
+func (c *conversationDatabase) SetConversationMuteUntil(ctx context.Context, ownerUserID, conversationID string, muteUntil int64) error {
+	if _, err := c.conversationDB.Take(ctx, ownerUserID, conversationID); err != nil {
+		return err
+	}
+	if _, err := c.conversationDB.UpdateByMap(ctx, []string{ownerUserID}, conversationID, map[string]any{"mute_until": muteUntil}); err != nil {
+		return err
+	}
+	if err := c.cache.DelConversations(ownerUserID, conversationID).DelConversationMuteUntil(conversationID).ExecDel(ctx); err != nil {
+		return err
+	}
+	return c.incrVersion(ctx, []string{ownerUserID}, []string{conversationID}, unRelationTb.VersionStateUpdate)
+}
+
+func (c *conversationDatabase) FindMutedUserIDs(ctx context.Context, conversationID string, userIDs []string) ([]string, error) {
+	muteUntil, err := c.cache.GetConversationMuteUntil(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	mutedUserIDs := make([]string, 0, len(muteUntil))
+	if len(userIDs) == 0 {
+		for userID, until := range muteUntil {
+			if until > now {
+				mutedUserIDs = append(mutedUserIDs, userID)
+			}
+		}
+		return mutedUserIDs, nil
+	}
+	for _, userID := range userIDs {
+		if muteUntil[userID] > now {
+			mutedUserIDs = append(mutedUserIDs, userID)
+		}
+	}
+	return mutedUserIDs, nil
+}
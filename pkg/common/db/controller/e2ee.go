@@ -0,0 +1,131 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+// e2eePreKeyFetchWindow 同一请求者在窗口内对同一设备只能取走一个一次性预密钥, 防止预密钥被刷光.
+const e2eePreKeyFetchWindow = time.Hour
+
+type E2EEDatabase interface {
+	// SetKeyBundle 发布设备的身份密钥和签名预密钥, 身份密钥变化时删除旧的一次性预密钥, 返回身份密钥是否变化
+	SetKeyBundle(ctx context.Context, bundle *unRelationTb.E2EEKeyBundleModel) (bool, error)
+	// FindKeyBundles deviceIDs为空获取用户全部设备
+	FindKeyBundles(ctx context.Context, userID string, deviceIDs []string) ([]*unRelationTb.E2EEKeyBundleModel, error)
+	// DeleteKeyBundle 删除设备的全部密钥
+	DeleteKeyBundle(ctx context.Context, userID string, deviceID string) error
+	// AddOneTimePreKeys 上传一次性预密钥, 返回设备剩余数量
+	AddOneTimePreKeys(ctx context.Context, userID string, deviceID string, preKeys []*unRelationTb.E2EEOneTimePreKeyModel) (int64, error)
+	// TakeOneTimePreKey 为requesterID取出并删除一个一次性预密钥, 没有或requesterID在窗口内已取过时返回nil
+	TakeOneTimePreKey(ctx context.Context, requesterID string, userID string, deviceID string) (*unRelationTb.E2EEOneTimePreKeyModel, error)
+	CountOneTimePreKeys(ctx context.Context, userID string, deviceID string) (int64, error)
+	// SetSenderKey 记录群发送者密钥的分发
+	SetSenderKey(ctx context.Context, senderKey *unRelationTb.E2EESenderKeyModel) error
+	FindSenderKeys(ctx context.Context, groupID string, userIDs []string) ([]*unRelationTb.E2EESenderKeyModel, error)
+	// DeleteUserKeys 注销用户时删除其全部设备的密钥, 并从别人的发送者密钥分发记录中移除
+	DeleteUserKeys(ctx context.Context, userID string) (int64, error)
+}
+
+func NewE2EEDatabase(bundleDB unRelationTb.E2EEKeyBundleModelInterface, preKeyDB unRelationTb.E2EEOneTimePreKeyModelInterface, senderKeyDB unRelationTb.E2EESenderKeyModelInterface, cache cache.E2EECache) E2EEDatabase {
+	return &e2eeDatabase{bundleDB: bundleDB, preKeyDB: preKeyDB, senderKeyDB: senderKeyDB, cache: cache}
+}
+
+type e2eeDatabase struct {
+	bundleDB    unRelationTb.E2EEKeyBundleModelInterface
+	preKeyDB    unRelationTb.E2EEOneTimePreKeyModelInterface
+	senderKeyDB unRelationTb.E2EESenderKeyModelInterface
+	cache       cache.E2EECache
+}
+
+func (e *e2eeDatabase) SetKeyBundle(ctx context.Context, bundle *unRelationTb.E2EEKeyBundleModel) (bool, error) {
+	old, err := e.bundleDB.Set(ctx, bundle)
+	if err != nil {
+		return false, err
+	}
+	if old == nil || old.IdentityKey == bundle.IdentityKey {
+		return false, nil
+	}
+	// prekeys and sender keys signed by the old identity are useless
+	if err := e.preKeyDB.DeleteDevice(ctx, bundle.UserID, bundle.DeviceID); err != nil {
+		return false, err
+	}
+	if err := e.senderKeyDB.DeleteDevice(ctx, bundle.UserID, bundle.DeviceID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (e *e2eeDatabase) FindKeyBundles(ctx context.Context, userID string, deviceIDs []string) ([]*unRelationTb.E2EEKeyBundleModel, error) {
+	return e.bundleDB.Find(ctx, userID, deviceIDs)
+}
+
+func (e *e2eeDatabase) DeleteKeyBundle(ctx context.Context, userID string, deviceID string) error {
+	if err := e.bundleDB.Delete(ctx, userID, deviceID); err != nil {
+		return err
+	}
+	if err := e.preKeyDB.DeleteDevice(ctx, userID, deviceID); err != nil {
+		return err
+	}
+	return e.senderKeyDB.DeleteDevice(ctx, userID, deviceID)
+}
+
+func (e *e2eeDatabase) AddOneTimePreKeys(ctx context.Context, userID string, deviceID string, preKeys []*unRelationTb.E2EEOneTimePreKeyModel) (int64, error) {
+	if err := e.preKeyDB.Create(ctx, preKeys); err != nil {
+		return 0, err
+	}
+	return e.preKeyDB.Count(ctx, userID, deviceID)
+}
+
+func (e *e2eeDatabase) TakeOneTimePreKey(ctx context.Context, requesterID string, userID string, deviceID string) (*unRelationTb.E2EEOneTimePreKeyModel, error) {
+	ok, err := e.cache.AcquirePreKeyFetch(ctx, requesterID, userID, deviceID, e2eePreKeyFetchWindow)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return e.preKeyDB.Take(ctx, userID, deviceID)
+}
+
+func (e *e2eeDatabase) CountOneTimePreKeys(ctx context.Context, userID string, deviceID string) (int64, error) {
+	return e.preKeyDB.Count(ctx, userID, deviceID)
+}
+
+func (e *e2eeDatabase) SetSenderKey(ctx context.Context, senderKey *unRelationTb.E2EESenderKeyModel) error {
+	return e.senderKeyDB.Set(ctx, senderKey)
+}
+
+func (e *e2eeDatabase) FindSenderKeys(ctx context.Context, groupID string, userIDs []string) ([]*unRelationTb.E2EESenderKeyModel, error) {
+	return e.senderKeyDB.Find(ctx, groupID, userIDs)
+}
+
+func (e *e2eeDatabase) DeleteUserKeys(ctx context.Context, userID string) (int64, error) {
+	bundleCount, err := e.bundleDB.DeleteUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	preKeyCount, err := e.preKeyDB.DeleteUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	senderKeyCount, err := e.senderKeyDB.DeleteUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return bundleCount + preKeyCount + senderKeyCount, nil
+}
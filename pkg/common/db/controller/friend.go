@@ -29,6 +29,7 @@ import (
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
 )
 
 type FriendDatabase interface {
@@ -76,15 +77,31 @@ type FriendDatabase interface {
 		ownerUserID string,
 		friendUserIDs []string,
 	) (friends []*relation.FriendModel, err error)
+	// 获取某人指定好友的信息, 不是好友的忽略
+	FindFriends(ctx context.Context, ownerUserID string, friendUserIDs []string) (friends []*relation.FriendModel, err error)
 	FindFriendUserIDs(ctx context.Context, ownerUserID string) (friendUserIDs []string, err error)
 	FindBothFriendRequests(ctx context.Context, fromUserID, toUserID string) (friends []*relation.FriendRequestModel, err error)
+	// 获取多个用户的好友关系
+	FindOwnersFriends(ctx context.Context, ownerUserIDs []string) (friends []*relation.FriendModel, err error)
+	// 获取userID发出或收到的未处理的好友申请
+	FindUnhandledFriendRequests(ctx context.Context, userID string) (friendRequests []*relation.FriendRequestModel, err error)
+	// 获取fromUserID发给toUserID的好友申请, 不存在返回错误
+	TakeFriendRequest(ctx context.Context, fromUserID, toUserID string) (friendRequest *relation.FriendRequestModel, err error)
+	// 删除before之前发出的未处理的好友申请
+	ClearExpiredFriendRequests(ctx context.Context, before time.Time) (count int64, err error)
+	// 删除userID的双向好友关系和全部好友申请, 用于注销用户
+	DeleteUserFriends(ctx context.Context, userID string) (count int64, err error)
 }
 
+// 注销用户时每次删除的好友关系数.
+const deleteUserFriendsBatch = 500
+
 type friendDatabase struct {
 	friend        relation.FriendModelInterface
 	friendRequest relation.FriendRequestModelInterface
 	tx            tx.Tx
 	cache         cache.FriendCache
+	versionLog    unRelationTb.VersionLogModelInterface
 }
 
 func NewFriendDatabase(
@@ -92,8 +109,9 @@ func NewFriendDatabase(
 	friendRequest relation.FriendRequestModelInterface,
 	cache cache.FriendCache,
 	tx tx.Tx,
+	versionLog unRelationTb.VersionLogModelInterface,
 ) FriendDatabase {
-	return &friendDatabase{friend: friend, friendRequest: friendRequest, cache: cache, tx: tx}
+	return &friendDatabase{friend: friend, friendRequest: friendRequest, cache: cache, tx: tx, versionLog: versionLog}
 }
 
 // ok 检查user2是否在user1的好友列表中(inUser1Friends==true) 检查user1是否在user2的好友列表中(inUser2Friends==true).
@@ -194,7 +212,13 @@ func (f *friendDatabase) BecomeFriends(
 	}); err != nil {
 		return nil
 	}
-	return cache.ExecDel(ctx)
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	if err := f.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainFriend, []string{ownerUserID}, friendUserIDs, unRelationTb.VersionStateInsert); err != nil {
+		return err
+	}
+	return f.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainFriend, friendUserIDs, []string{ownerUserID}, unRelationTb.VersionStateInsert)
 }
 
 // 拒绝好友申请 (1)检查是否有申请记录且为未处理状态 （没有记录返回错误） (2)修改申请记录 已拒绝.
@@ -224,7 +248,8 @@ func (f *friendDatabase) AgreeFriendRequest(
 	ctx context.Context,
 	friendRequest *relation.FriendRequestModel,
 ) (err error) {
-	return f.tx.Transaction(func(tx any) error {
+	var adds []*relation.FriendModel
+	if err := f.tx.Transaction(func(tx any) error {
 		defer log.ZDebug(ctx, "return line")
 		now := time.Now()
 		fr, err := f.friendRequest.NewTx(tx).Take(ctx, friendRequest.FromUserID, friendRequest.ToUserID)
@@ -262,7 +287,6 @@ func (f *friendDatabase) AgreeFriendRequest(
 		existsMap := utils.SliceSet(utils.Slice(exists, func(friend *relation.FriendModel) [2]string {
 			return [...]string{friend.OwnerUserID, friend.FriendUserID} // 自己 - 好友
 		}))
-		var adds []*relation.FriendModel
 		if _, ok := existsMap[[...]string{friendRequest.ToUserID, friendRequest.FromUserID}]; !ok { // 自己 - 好友
 			adds = append(
 				adds,
@@ -291,7 +315,15 @@ func (f *friendDatabase) AgreeFriendRequest(
 			}
 		}
 		return f.cache.DelFriendIDs(friendRequest.ToUserID, friendRequest.FromUserID).ExecDel(ctx)
-	})
+	}); err != nil {
+		return err
+	}
+	for _, friend := range adds {
+		if err := f.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainFriend, []string{friend.OwnerUserID}, []string{friend.FriendUserID}, unRelationTb.VersionStateInsert); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // 删除好友  外部判断是否好友关系.
@@ -299,7 +331,10 @@ func (f *friendDatabase) Delete(ctx context.Context, ownerUserID string, friendU
 	if err := f.friend.Delete(ctx, ownerUserID, friendUserIDs); err != nil {
 		return err
 	}
-	return f.cache.DelFriendIDs(append(friendUserIDs, ownerUserID)...).ExecDel(ctx)
+	if err := f.cache.DelFriendIDs(append(friendUserIDs, ownerUserID)...).ExecDel(ctx); err != nil {
+		return err
+	}
+	return f.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainFriend, []string{ownerUserID}, friendUserIDs, unRelationTb.VersionStateDelete)
 }
 
 // 更新好友备注 零值也支持.
@@ -307,7 +342,10 @@ func (f *friendDatabase) UpdateRemark(ctx context.Context, ownerUserID, friendUs
 	if err := f.friend.UpdateRemark(ctx, ownerUserID, friendUserID, remark); err != nil {
 		return err
 	}
-	return f.cache.DelFriend(ownerUserID, friendUserID).ExecDel(ctx)
+	if err := f.cache.DelFriend(ownerUserID, friendUserID).ExecDel(ctx); err != nil {
+		return err
+	}
+	return f.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainFriend, []string{ownerUserID}, []string{friendUserID}, unRelationTb.VersionStateUpdate)
 }
 
 // 获取ownerUserID的好友列表 无结果不返回错误.
@@ -362,6 +400,14 @@ func (f *friendDatabase) FindFriendsWithError(
 	return
 }
 
+func (f *friendDatabase) FindFriends(
+	ctx context.Context,
+	ownerUserID string,
+	friendUserIDs []string,
+) (friends []*relation.FriendModel, err error) {
+	return f.friend.FindFriends(ctx, ownerUserID, friendUserIDs)
+}
+
 func (f *friendDatabase) FindFriendUserIDs(
 	ctx context.Context,
 	ownerUserID string,
@@ -372,3 +418,53 @@ func (f *friendDatabase) FindFriendUserIDs(
 func (f *friendDatabase) FindBothFriendRequests(ctx context.Context, fromUserID, toUserID string) (friends []*relation.FriendRequestModel, err error) {
 	return f.friendRequest.FindBothFriendRequests(ctx, fromUserID, toUserID)
 }
+
+func (f *friendDatabase) FindOwnersFriends(ctx context.Context, ownerUserIDs []string) (friends []*relation.FriendModel, err error) {
+	return f.friend.FindOwnersFriends(ctx, ownerUserIDs)
+}
+
+func (f *friendDatabase) FindUnhandledFriendRequests(ctx context.Context, userID string) (friendRequests []*relation.FriendRequestModel, err error) {
+	return f.friendRequest.FindUnhandled(ctx, userID)
+}
+
+func (f *friendDatabase) TakeFriendRequest(ctx context.Context, fromUserID, toUserID string) (friendRequest *relation.FriendRequestModel, err error) {
+	return f.friendRequest.Take(ctx, fromUserID, toUserID)
+}
+
+func (f *friendDatabase) ClearExpiredFriendRequests(ctx context.Context, before time.Time) (count int64, err error) {
+	return f.friendRequest.DeleteUnhandledBefore(ctx, before)
+}
+
+func (f *friendDatabase) DeleteUserFriends(ctx context.Context, userID string) (count int64, err error) {
+	friendUserIDs, err := f.friend.FindFriendUserIDs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(friendUserIDs) > 0 {
+		if err := f.Delete(ctx, userID, friendUserIDs); err != nil {
+			return 0, err
+		}
+		count += int64(len(friendUserIDs))
+	}
+	// 删除后重新从第一页获取
+	for {
+		friends, _, err := f.friend.FindInWhoseFriends(ctx, userID, 1, deleteUserFriendsBatch)
+		if err != nil {
+			return count, err
+		}
+		for _, friend := range friends {
+			if err := f.Delete(ctx, friend.OwnerUserID, []string{userID}); err != nil {
+				return count, err
+			}
+		}
+		count += int64(len(friends))
+		if len(friends) < deleteUserFriendsBatch {
+			break
+		}
+	}
+	n, err := f.friendRequest.DeleteByUser(ctx, userID)
+	if err != nil {
+		return count, err
+	}
+	return count + n, nil
+}
@@ -0,0 +1,172 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/OpenIMSDK/tools/tx"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type FriendLabelDatabase interface {
+	// CreateLabel 创建分组并加入好友
+	CreateLabel(ctx context.Context, label *relation.FriendLabelModel, members []*relation.FriendLabelMemberModel) error
+	UpdateLabel(ctx context.Context, ownerUserID string, labelID string, data map[string]any) error
+	// DeleteLabel 删除分组及其成员关系, 不影响好友关系
+	DeleteLabel(ctx context.Context, ownerUserID string, labelID string) error
+	// SortLabels 按labelIDs的顺序设置分组排序
+	SortLabels(ctx context.Context, ownerUserID string, labelIDs []string) error
+	TakeLabel(ctx context.Context, ownerUserID string, labelID string) (*relation.FriendLabelModel, error)
+	FindLabels(ctx context.Context, ownerUserID string) ([]*relation.FriendLabelModel, error)
+	AddLabelMembers(ctx context.Context, members []*relation.FriendLabelMemberModel) error
+	RemoveLabelMembers(ctx context.Context, ownerUserID string, labelID string, friendUserIDs []string) error
+	// SetFriendLabels 覆盖好友所在的分组
+	SetFriendLabels(ctx context.Context, ownerUserID string, friendUserID string, members []*relation.FriendLabelMemberModel) error
+	// RemoveFriends 删除好友时把好友从所有分组中移除
+	RemoveFriends(ctx context.Context, ownerUserID string, friendUserIDs []string) error
+	// FindLabelMembers friendUserIDs为空获取所有分组的成员
+	FindLabelMembers(ctx context.Context, ownerUserID string, friendUserIDs []string) ([]*relation.FriendLabelMemberModel, error)
+	PageLabelFriendUserIDs(ctx context.Context, ownerUserID string, labelID string, pageNumber, showNumber int32) (int64, []string, error)
+	// DeleteUserLabels 注销用户时删除其全部分组, 并把用户从别人的分组中移除
+	DeleteUserLabels(ctx context.Context, userID string) (int64, error)
+}
+
+func NewFriendLabelDatabase(
+	label relation.FriendLabelModelInterface,
+	member relation.FriendLabelMemberModelInterface,
+	tx tx.Tx,
+) FriendLabelDatabase {
+	return &friendLabelDatabase{label: label, member: member, tx: tx}
+}
+
+type friendLabelDatabase struct {
+	label  relation.FriendLabelModelInterface
+	member relation.FriendLabelMemberModelInterface
+	tx     tx.Tx
+}
+
+func (f *friendLabelDatabase) CreateLabel(
+	ctx context.Context,
+	label *relation.FriendLabelModel,
+	members []*relation.FriendLabelMemberModel,
+) error {
+	return f.tx.Transaction(func(tx any) error {
+		if err := f.label.NewTx(tx).Create(ctx, []*relation.FriendLabelModel{label}); err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		return f.member.NewTx(tx).Create(ctx, members)
+	})
+}
+
+func (f *friendLabelDatabase) UpdateLabel(ctx context.Context, ownerUserID string, labelID string, data map[string]any) error {
+	return f.label.Update(ctx, ownerUserID, labelID, data)
+}
+
+func (f *friendLabelDatabase) DeleteLabel(ctx context.Context, ownerUserID string, labelID string) error {
+	return f.tx.Transaction(func(tx any) error {
+		if err := f.label.NewTx(tx).Delete(ctx, ownerUserID, []string{labelID}); err != nil {
+			return err
+		}
+		return f.member.NewTx(tx).Delete(ctx, ownerUserID, labelID, nil)
+	})
+}
+
+func (f *friendLabelDatabase) SortLabels(ctx context.Context, ownerUserID string, labelIDs []string) error {
+	return f.tx.Transaction(func(tx any) error {
+		labelDB := f.label.NewTx(tx)
+		for i, labelID := range labelIDs {
+			if err := labelDB.Update(ctx, ownerUserID, labelID, map[string]any{"sort": i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (f *friendLabelDatabase) TakeLabel(ctx context.Context, ownerUserID string, labelID string) (*relation.FriendLabelModel, error) {
+	return f.label.Take(ctx, ownerUserID, labelID)
+}
+
+func (f *friendLabelDatabase) FindLabels(ctx context.Context, ownerUserID string) ([]*relation.FriendLabelModel, error) {
+	return f.label.Find(ctx, ownerUserID)
+}
+
+func (f *friendLabelDatabase) AddLabelMembers(ctx context.Context, members []*relation.FriendLabelMemberModel) error {
+	return f.member.Create(ctx, members)
+}
+
+func (f *friendLabelDatabase) RemoveLabelMembers(ctx context.Context, ownerUserID string, labelID string, friendUserIDs []string) error {
+	return f.member.Delete(ctx, ownerUserID, labelID, friendUserIDs)
+}
+
+func (f *friendLabelDatabase) SetFriendLabels(
+	ctx context.Context,
+	ownerUserID string,
+	friendUserID string,
+	members []*relation.FriendLabelMemberModel,
+) error {
+	return f.tx.Transaction(func(tx any) error {
+		memberDB := f.member.NewTx(tx)
+		if err := memberDB.DeleteFriends(ctx, ownerUserID, []string{friendUserID}); err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		return memberDB.Create(ctx, members)
+	})
+}
+
+func (f *friendLabelDatabase) RemoveFriends(ctx context.Context, ownerUserID string, friendUserIDs []string) error {
+	return f.member.DeleteFriends(ctx, ownerUserID, friendUserIDs)
+}
+
+func (f *friendLabelDatabase) FindLabelMembers(
+	ctx context.Context,
+	ownerUserID string,
+	friendUserIDs []string,
+) ([]*relation.FriendLabelMemberModel, error) {
+	return f.member.Find(ctx, ownerUserID, friendUserIDs)
+}
+
+func (f *friendLabelDatabase) PageLabelFriendUserIDs(
+	ctx context.Context,
+	ownerUserID string,
+	labelID string,
+	pageNumber, showNumber int32,
+) (int64, []string, error) {
+	return f.member.PageFriendUserIDs(ctx, ownerUserID, labelID, pageNumber, showNumber)
+}
+
+func (f *friendLabelDatabase) DeleteUserLabels(ctx context.Context, userID string) (count int64, err error) {
+	err = f.tx.Transaction(func(tx any) error {
+		labelCount, err := f.label.NewTx(tx).DeleteOwner(ctx, userID)
+		if err != nil {
+			return err
+		}
+		memberCount, err := f.member.NewTx(tx).DeleteUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		count = labelCount + memberCount
+		return nil
+	})
+	return count, err
+}
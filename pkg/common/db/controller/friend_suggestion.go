@@ -0,0 +1,55 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+)
+
+type FriendSuggestionDatabase interface {
+	// GetFriendSuggestions 获取预计算的好友推荐, 未计算或已过期时返回nil
+	GetFriendSuggestions(ctx context.Context, userID string) (*cache.FriendSuggestions, error)
+	SetFriendSuggestions(ctx context.Context, userID string, suggestions *cache.FriendSuggestions, expire time.Duration) error
+	// DelFriendSuggestions 好友关系变化后删除, 下次获取时重新计算
+	DelFriendSuggestions(ctx context.Context, userIDs ...string) error
+}
+
+func NewFriendSuggestionDatabase(cache cache.FriendSuggestionCache) FriendSuggestionDatabase {
+	return &friendSuggestionDatabase{cache: cache}
+}
+
+type friendSuggestionDatabase struct {
+	cache cache.FriendSuggestionCache
+}
+
+func (f *friendSuggestionDatabase) GetFriendSuggestions(ctx context.Context, userID string) (*cache.FriendSuggestions, error) {
+	return f.cache.GetFriendSuggestions(ctx, userID)
+}
+
+func (f *friendSuggestionDatabase) SetFriendSuggestions(
+	ctx context.Context,
+	userID string,
+	suggestions *cache.FriendSuggestions,
+	expire time.Duration,
+) error {
+	return f.cache.SetFriendSuggestions(ctx, userID, suggestions, expire)
+}
+
+func (f *friendSuggestionDatabase) DelFriendSuggestions(ctx context.Context, userIDs ...string) error {
+	return f.cache.DelFriendSuggestions(ctx, userIDs...)
+}
@@ -65,6 +65,9 @@ type GroupDatabase interface {
 	FindGroupMemberUserID(ctx context.Context, groupID string) ([]string, error)
 	FindGroupMemberNum(ctx context.Context, groupID string) (uint32, error)
 	FindUserManagedGroupID(ctx context.Context, userID string) (groupIDs []string, err error)
+	// FindGroupOwnersAfter 按group_id顺序获取afterGroupID之后的群主, 用于遍历所有群
+	FindGroupOwnersAfter(ctx context.Context, afterGroupID string, limit int) ([]*relationTb.GroupMemberModel, error)
+	FindJoinedGroupIDs(ctx context.Context, userID string) (groupIDs []string, err error)
 	PageGroupRequest(
 		ctx context.Context,
 		groupIDs []string,
@@ -90,6 +93,14 @@ type GroupDatabase interface {
 		roleLevels []int32,
 		pageNumber, showNumber int32,
 	) (uint32, []*relationTb.GroupMemberModel, error)
+	// SearchGroupMemberByCursor 按条件搜索群成员, 返回符合条件的总数
+	SearchGroupMemberByCursor(
+		ctx context.Context,
+		groupID string,
+		filter *relationTb.GroupMemberSearchFilter,
+		cursor *relationTb.GroupMemberCursor,
+		limit int,
+	) (int64, []*relationTb.GroupMemberModel, error)
 	HandlerGroupRequest(
 		ctx context.Context,
 		groupID string,
@@ -126,6 +137,64 @@ type GroupDatabase interface {
 	DeleteSuperGroupMember(ctx context.Context, groupID string, userIDs []string) error
 	CreateSuperGroupMember(ctx context.Context, groupID string, userIDs []string) error
 
+	// GroupRole
+	CreateGroupRole(ctx context.Context, role *relationTb.GroupRoleModel) error
+	UpdateGroupRole(ctx context.Context, groupID string, roleID string, data map[string]any) error
+	// DeleteGroupRole 删除角色并清除成员的角色
+	DeleteGroupRole(ctx context.Context, groupID string, roleID string) error
+	TakeGroupRole(ctx context.Context, groupID string, roleID string) (*relationTb.GroupRoleModel, error)
+	FindGroupRoles(ctx context.Context, groupID string) ([]*relationTb.GroupRoleModel, error)
+	// SetGroupMembersRole roleID为空清除角色
+	SetGroupMembersRole(ctx context.Context, groupID string, userIDs []string, roleID string) error
+
+	// GroupInviteLink
+	CreateGroupInviteLink(ctx context.Context, link *relationTb.GroupInviteLinkModel) error
+	TakeGroupInviteLink(ctx context.Context, code string) (*relationTb.GroupInviteLinkModel, error)
+	FindGroupInviteLinks(ctx context.Context, groupID string, includeRevoked bool) ([]*relationTb.GroupInviteLinkModel, error)
+	RevokeGroupInviteLinks(ctx context.Context, groupID string, codes []string) error
+	// JoinGroupByInviteLink 在同一事务中校验并使用邀请链接, 然后直接入群(member)或提交入群申请(request), 链接无效返回false
+	JoinGroupByInviteLink(ctx context.Context, code string, member *relationTb.GroupMemberModel, request *relationTb.GroupRequestModel) (bool, error)
+	IncrGroupInviteLinkJoinCount(ctx context.Context, code string) error
+
+	// Channel
+	// CreateChannel 在同一事务中创建群、群主管理员成员和频道信息
+	CreateChannel(ctx context.Context, group *relationTb.GroupModel, members []*relationTb.GroupMemberModel, channel *relationTb.ChannelModel) error
+	TakeChannel(ctx context.Context, groupID string) (*relationTb.ChannelModel, error)
+	FindChannels(ctx context.Context, groupIDs []string) ([]*relationTb.ChannelModel, error)
+	UpdateChannelPublic(ctx context.Context, groupID string, isPublic bool) error
+	SearchPublicChannels(ctx context.Context, keyword string, pageNumber, showNumber int32) (int64, []*relationTb.ChannelModel, error)
+	// SubscribeChannel 添加订阅者并增加订阅数
+	SubscribeChannel(ctx context.Context, subscriber *relationTb.ChannelSubscriberModel) error
+	// UnsubscribeChannel 删除订阅者并减少订阅数
+	UnsubscribeChannel(ctx context.Context, groupID string, userIDs []string) error
+	TakeChannelSubscriber(ctx context.Context, groupID string, userID string) (*relationTb.ChannelSubscriberModel, error)
+	FindChannelSubscriberIDs(ctx context.Context, groupID string, afterUserID string, limit int) ([]string, error)
+	PageUserSubscribedChannels(ctx context.Context, userID string, pageNumber, showNumber int32) (int64, []*relationTb.ChannelSubscriberModel, error)
+	SetChannelSubscriberOpened(ctx context.Context, groupID string, userID string) error
+
+	// GroupBan
+	// BanGroupMembers 添加或覆盖封禁
+	BanGroupMembers(ctx context.Context, bans []*relationTb.GroupBanModel) error
+	UnbanGroupMembers(ctx context.Context, groupID string, userIDs []string) error
+	// FindActiveGroupBans 获取仍然生效的封禁
+	FindActiveGroupBans(ctx context.Context, groupID string, userIDs []string) ([]*relationTb.GroupBanModel, error)
+	PageGroupBans(ctx context.Context, groupID string, pageNumber, showNumber int32) (int64, []*relationTb.GroupBanModel, error)
+
+	// Community
+	// CreateCommunity 在同一事务中创建社区群、公告频道及其成员, 并把公告频道加入社区
+	CreateCommunity(
+		ctx context.Context,
+		groups []*relationTb.GroupModel,
+		members []*relationTb.GroupMemberModel,
+		channel *relationTb.ChannelModel,
+		communityGroups []*relationTb.CommunityGroupModel,
+	) error
+	AttachCommunityGroups(ctx context.Context, groups []*relationTb.CommunityGroupModel) error
+	DetachCommunityGroups(ctx context.Context, communityID string, groupIDs []string) error
+	FindCommunityGroups(ctx context.Context, communityID string) ([]*relationTb.CommunityGroupModel, error)
+	// FindGroupsCommunity 获取群所属的社区, 不属于社区的群不返回
+	FindGroupsCommunity(ctx context.Context, groupIDs []string) ([]*relationTb.CommunityGroupModel, error)
+
 	// 获取群总数
 	CountTotal(ctx context.Context, before *time.Time) (count int64, err error)
 	// 获取范围内群增量
@@ -136,19 +205,33 @@ func NewGroupDatabase(
 	group relationTb.GroupModelInterface,
 	member relationTb.GroupMemberModelInterface,
 	request relationTb.GroupRequestModelInterface,
+	role relationTb.GroupRoleModelInterface,
+	inviteLink relationTb.GroupInviteLinkModelInterface,
+	channel relationTb.ChannelModelInterface,
+	channelSubscriber relationTb.ChannelSubscriberModelInterface,
+	ban relationTb.GroupBanModelInterface,
+	community relationTb.CommunityGroupModelInterface,
 	tx tx.Tx,
 	ctxTx tx.CtxTx,
 	superGroup unRelationTb.SuperGroupModelInterface,
+	versionLog unRelationTb.VersionLogModelInterface,
 	cache cache.GroupCache,
 ) GroupDatabase {
 	database := &groupDatabase{
 		groupDB:        group,
 		groupMemberDB:  member,
 		groupRequestDB: request,
+		groupRoleDB:    role,
+		inviteLinkDB:   inviteLink,
+		channelDB:      channel,
+		subscriberDB:   channelSubscriber,
+		banDB:          ban,
+		communityDB:    community,
 		tx:             tx,
 		ctxTx:          ctxTx,
 		cache:          cache,
 		mongoDB:        superGroup,
+		versionLog:     versionLog,
 	}
 	return database
 }
@@ -161,14 +244,22 @@ func InitGroupDatabase(db *gorm.DB, rdb redis.UniversalClient, database *mongo.D
 		relation.NewGroupDB(db),
 		relation.NewGroupMemberDB(db),
 		relation.NewGroupRequest(db),
+		relation.NewGroupRoleDB(db),
+		relation.NewGroupInviteLinkDB(db),
+		relation.NewChannelDB(db),
+		relation.NewChannelSubscriberDB(db),
+		relation.NewGroupBanDB(db),
+		relation.NewCommunityGroupDB(db),
 		tx.NewGorm(db),
 		tx.NewMongo(database.Client()),
 		unrelation.NewSuperGroupMongoDriver(database),
+		unrelation.NewVersionLogMongoDriver(database),
 		cache.NewGroupCacheRedis(
 			rdb,
 			relation.NewGroupDB(db),
 			relation.NewGroupMemberDB(db),
 			relation.NewGroupRequest(db),
+			relation.NewGroupRoleDB(db),
 			unrelation.NewSuperGroupMongoDriver(database),
 			rcOptions,
 		),
@@ -179,10 +270,47 @@ type groupDatabase struct {
 	groupDB        relationTb.GroupModelInterface
 	groupMemberDB  relationTb.GroupMemberModelInterface
 	groupRequestDB relationTb.GroupRequestModelInterface
+	groupRoleDB    relationTb.GroupRoleModelInterface
+	inviteLinkDB   relationTb.GroupInviteLinkModelInterface
+	channelDB      relationTb.ChannelModelInterface
+	subscriberDB   relationTb.ChannelSubscriberModelInterface
+	banDB          relationTb.GroupBanModelInterface
+	communityDB    relationTb.CommunityGroupModelInterface
 	tx             tx.Tx
 	ctxTx          tx.CtxTx
 	cache          cache.GroupCache
 	mongoDB        unRelationTb.SuperGroupModelInterface
+	versionLog     unRelationTb.VersionLogModelInterface
+}
+
+// incrMembersVersion 成员变更同时记录到群成员列表和成员的已加入群列表.
+func (g *groupDatabase) incrMembersVersion(ctx context.Context, groupID string, userIDs []string, state int32) error {
+	if err := g.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainGroupMember, []string{groupID}, userIDs, state); err != nil {
+		return err
+	}
+	return g.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainJoinedGroup, userIDs, []string{groupID}, state)
+}
+
+func (g *groupDatabase) incrGroupMembersVersion(ctx context.Context, members []*relationTb.GroupMemberModel, state int32) error {
+	groupUserIDs := make(map[string][]string)
+	for _, member := range members {
+		groupUserIDs[member.GroupID] = append(groupUserIDs[member.GroupID], member.UserID)
+	}
+	for groupID, userIDs := range groupUserIDs {
+		if err := g.incrMembersVersion(ctx, groupID, userIDs, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// incrGroupInfoVersion 群信息变更记录到所有成员的已加入群列表.
+func (g *groupDatabase) incrGroupInfoVersion(ctx context.Context, groupID string) error {
+	userIDs, err := g.cache.GetGroupMemberIDs(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	return g.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainJoinedGroup, userIDs, []string{groupID}, unRelationTb.VersionStateUpdate)
 }
 
 func (g *groupDatabase) GetGroupIDsByGroupType(ctx context.Context, groupType int) (groupIDs []string, err error) {
@@ -235,7 +363,10 @@ func (g *groupDatabase) CreateGroup(
 	}); err != nil {
 		return err
 	}
-	return cache.ExecDel(ctx)
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.incrGroupMembersVersion(ctx, groupMembers, unRelationTb.VersionStateInsert)
 }
 
 func (g *groupDatabase) TakeGroup(ctx context.Context, groupID string) (group *relationTb.GroupModel, err error) {
@@ -258,31 +389,57 @@ func (g *groupDatabase) UpdateGroup(ctx context.Context, groupID string, data ma
 	if err := g.groupDB.UpdateMap(ctx, groupID, data); err != nil {
 		return err
 	}
-	return g.cache.DelGroupsInfo(groupID).ExecDel(ctx)
+	if err := g.cache.DelGroupsInfo(groupID).ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.incrGroupInfoVersion(ctx, groupID)
 }
 
 func (g *groupDatabase) DismissGroup(ctx context.Context, groupID string, deleteMember bool) error {
 	cache := g.cache.NewCache()
+	var userIDs []string
 	if err := g.tx.Transaction(func(tx any) error {
 		if err := g.groupDB.NewTx(tx).UpdateStatus(ctx, groupID, constant.GroupStatusDismissed); err != nil {
 			return err
 		}
+		if err := g.inviteLinkDB.NewTx(tx).RevokeGroup(ctx, []string{groupID}); err != nil {
+			return err
+		}
+		if err := g.communityDB.NewTx(tx).DeleteGroup(ctx, []string{groupID}); err != nil {
+			return err
+		}
 		if deleteMember {
 			if err := g.groupMemberDB.NewTx(tx).DeleteGroup(ctx, []string{groupID}); err != nil {
 				return err
 			}
-			userIDs, err := g.cache.GetGroupMemberIDs(ctx, groupID)
+			if err := g.groupRoleDB.NewTx(tx).DeleteGroup(ctx, []string{groupID}); err != nil {
+				return err
+			}
+			if err := g.subscriberDB.NewTx(tx).DeleteGroup(ctx, []string{groupID}); err != nil {
+				return err
+			}
+			if err := g.banDB.NewTx(tx).DeleteGroup(ctx, []string{groupID}); err != nil {
+				return err
+			}
+			var err error
+			userIDs, err = g.cache.GetGroupMemberIDs(ctx, groupID)
 			if err != nil {
 				return err
 			}
-			cache = cache.DelJoinedGroupID(userIDs...).DelGroupMemberIDs(groupID).DelGroupsMemberNum(groupID).DelGroupMembersHash(groupID)
+			cache = cache.DelJoinedGroupID(userIDs...).DelGroupMemberIDs(groupID).DelGroupsMemberNum(groupID).DelGroupMembersHash(groupID).DelGroupRoles(groupID)
 		}
 		cache = cache.DelGroupsInfo(groupID)
 		return nil
 	}); err != nil {
 		return err
 	}
-	return cache.ExecDel(ctx)
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	if deleteMember {
+		return g.incrMembersVersion(ctx, groupID, userIDs, unRelationTb.VersionStateDelete)
+	}
+	return g.incrGroupInfoVersion(ctx, groupID)
 }
 
 func (g *groupDatabase) TakeGroupMember(
@@ -301,6 +458,14 @@ func (g *groupDatabase) FindUserManagedGroupID(ctx context.Context, userID strin
 	return g.groupMemberDB.FindUserManagedGroupID(ctx, userID)
 }
 
+func (g *groupDatabase) FindGroupOwnersAfter(
+	ctx context.Context,
+	afterGroupID string,
+	limit int,
+) ([]*relationTb.GroupMemberModel, error) {
+	return g.groupMemberDB.FindOwnersAfter(ctx, afterGroupID, limit)
+}
+
 func (g *groupDatabase) PageGroupRequest(
 	ctx context.Context,
 	groupIDs []string,
@@ -328,6 +493,10 @@ func (g *groupDatabase) FindGroupMember(
 	return g.groupMemberDB.Find(ctx, groupIDs, userIDs, roleLevels)
 }
 
+func (g *groupDatabase) FindJoinedGroupIDs(ctx context.Context, userID string) (groupIDs []string, err error) {
+	return g.cache.GetJoinedGroupIDs(ctx, userID)
+}
+
 func (g *groupDatabase) PageGetJoinGroup(
 	ctx context.Context,
 	userID string,
@@ -378,6 +547,16 @@ func (g *groupDatabase) SearchGroupMember(
 	return g.groupMemberDB.SearchMember(ctx, keyword, groupIDs, userIDs, roleLevels, pageNumber, showNumber)
 }
 
+func (g *groupDatabase) SearchGroupMemberByCursor(
+	ctx context.Context,
+	groupID string,
+	filter *relationTb.GroupMemberSearchFilter,
+	cursor *relationTb.GroupMemberCursor,
+	limit int,
+) (int64, []*relationTb.GroupMemberModel, error) {
+	return g.groupMemberDB.SearchMemberByCursor(ctx, groupID, filter, cursor, limit)
+}
+
 func (g *groupDatabase) HandlerGroupRequest(
 	ctx context.Context,
 	groupID string,
@@ -403,7 +582,7 @@ func (g *groupDatabase) HandlerGroupRequest(
 	//}
 	//return cache.ExecDel(ctx)
 
-	return g.tx.Transaction(func(tx any) error {
+	if err := g.tx.Transaction(func(tx any) error {
 		if err := g.groupRequestDB.NewTx(tx).UpdateHandler(ctx, groupID, userID, handledMsg, handleResult); err != nil {
 			return err
 		}
@@ -416,19 +595,28 @@ func (g *groupDatabase) HandlerGroupRequest(
 			}
 		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	if member == nil {
+		return nil
+	}
+	return g.incrMembersVersion(ctx, groupID, []string{member.UserID}, unRelationTb.VersionStateInsert)
 }
 
 func (g *groupDatabase) DeleteGroupMember(ctx context.Context, groupID string, userIDs []string) error {
 	if err := g.groupMemberDB.Delete(ctx, groupID, userIDs); err != nil {
 		return err
 	}
-	return g.cache.DelGroupMembersHash(groupID).
+	if err := g.cache.DelGroupMembersHash(groupID).
 		DelGroupMemberIDs(groupID).
 		DelGroupsMemberNum(groupID).
 		DelJoinedGroupID(userIDs...).
 		DelGroupMembersInfo(groupID, userIDs...).
-		ExecDel(ctx)
+		ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.incrMembersVersion(ctx, groupID, userIDs, unRelationTb.VersionStateDelete)
 }
 
 func (g *groupDatabase) MapGroupMemberUserID(
@@ -475,7 +663,10 @@ func (g *groupDatabase) TransferGroupOwner(
 	}); err != nil {
 		return err
 	}
-	return g.cache.DelGroupMembersInfo(groupID, oldOwnerUserID, newOwnerUserID).ExecDel(ctx)
+	if err := g.cache.DelGroupMembersInfo(groupID, oldOwnerUserID, newOwnerUserID).ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainGroupMember, []string{groupID}, []string{oldOwnerUserID, newOwnerUserID}, unRelationTb.VersionStateUpdate)
 }
 
 func (g *groupDatabase) UpdateGroupMember(
@@ -487,7 +678,10 @@ func (g *groupDatabase) UpdateGroupMember(
 	if err := g.groupMemberDB.Update(ctx, groupID, userID, data); err != nil {
 		return err
 	}
-	return g.cache.DelGroupMembersInfo(groupID, userID).ExecDel(ctx)
+	if err := g.cache.DelGroupMembersInfo(groupID, userID).ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainGroupMember, []string{groupID}, []string{userID}, unRelationTb.VersionStateUpdate)
 }
 
 func (g *groupDatabase) UpdateGroupMembers(ctx context.Context, data []*relationTb.BatchUpdateGroupMember) error {
@@ -503,7 +697,15 @@ func (g *groupDatabase) UpdateGroupMembers(ctx context.Context, data []*relation
 	}); err != nil {
 		return err
 	}
-	return cache.ExecDel(ctx)
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	for _, item := range data {
+		if err := g.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainGroupMember, []string{item.GroupID}, []string{item.UserID}, unRelationTb.VersionStateUpdate); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (g *groupDatabase) CreateGroupRequest(ctx context.Context, requests []*relationTb.GroupRequestModel) error {
@@ -602,3 +804,333 @@ func (g *groupDatabase) FindGroupRequests(ctx context.Context, groupID string, u
 func (g *groupDatabase) FindNotDismissedGroup(ctx context.Context, groupIDs []string) (groups []*relationTb.GroupModel, err error) {
 	return g.groupDB.FindNotDismissedGroup(ctx, groupIDs)
 }
+
+func (g *groupDatabase) CreateGroupRole(ctx context.Context, role *relationTb.GroupRoleModel) error {
+	if err := g.groupRoleDB.Create(ctx, []*relationTb.GroupRoleModel{role}); err != nil {
+		return err
+	}
+	return g.cache.DelGroupRoles(role.GroupID).ExecDel(ctx)
+}
+
+func (g *groupDatabase) UpdateGroupRole(ctx context.Context, groupID string, roleID string, data map[string]any) error {
+	if err := g.groupRoleDB.Update(ctx, groupID, roleID, data); err != nil {
+		return err
+	}
+	return g.cache.DelGroupRoles(groupID).ExecDel(ctx)
+}
+
+func (g *groupDatabase) DeleteGroupRole(ctx context.Context, groupID string, roleID string) error {
+	cache := g.cache.NewCache()
+	var userIDs []string
+	if err := g.tx.Transaction(func(tx any) error {
+		var err error
+		userIDs, err = g.groupMemberDB.NewTx(tx).FindRoleUserIDs(ctx, groupID, []string{roleID})
+		if err != nil {
+			return err
+		}
+		if len(userIDs) > 0 {
+			if err := g.groupMemberDB.NewTx(tx).UpdateRoleID(ctx, groupID, userIDs, ""); err != nil {
+				return err
+			}
+			cache = cache.DelGroupMembersInfo(groupID, userIDs...)
+		}
+		if err := g.groupRoleDB.NewTx(tx).Delete(ctx, groupID, []string{roleID}); err != nil {
+			return err
+		}
+		cache = cache.DelGroupRoles(groupID)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainGroupMember, []string{groupID}, userIDs, unRelationTb.VersionStateUpdate)
+}
+
+func (g *groupDatabase) TakeGroupRole(ctx context.Context, groupID string, roleID string) (*relationTb.GroupRoleModel, error) {
+	return g.groupRoleDB.Take(ctx, groupID, roleID)
+}
+
+func (g *groupDatabase) FindGroupRoles(ctx context.Context, groupID string) ([]*relationTb.GroupRoleModel, error) {
+	return g.cache.GetGroupRoles(ctx, groupID)
+}
+
+func (g *groupDatabase) SetGroupMembersRole(ctx context.Context, groupID string, userIDs []string, roleID string) error {
+	if err := g.groupMemberDB.UpdateRoleID(ctx, groupID, userIDs, roleID); err != nil {
+		return err
+	}
+	if err := g.cache.DelGroupMembersInfo(groupID, userIDs...).ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.versionLog.IncrVersion(ctx, unRelationTb.VersionDomainGroupMember, []string{groupID}, userIDs, unRelationTb.VersionStateUpdate)
+}
+
+func (g *groupDatabase) CreateGroupInviteLink(ctx context.Context, link *relationTb.GroupInviteLinkModel) error {
+	return g.inviteLinkDB.Create(ctx, []*relationTb.GroupInviteLinkModel{link})
+}
+
+func (g *groupDatabase) TakeGroupInviteLink(ctx context.Context, code string) (*relationTb.GroupInviteLinkModel, error) {
+	return g.inviteLinkDB.Take(ctx, code)
+}
+
+func (g *groupDatabase) FindGroupInviteLinks(
+	ctx context.Context,
+	groupID string,
+	includeRevoked bool,
+) ([]*relationTb.GroupInviteLinkModel, error) {
+	return g.inviteLinkDB.Find(ctx, groupID, includeRevoked)
+}
+
+func (g *groupDatabase) RevokeGroupInviteLinks(ctx context.Context, groupID string, codes []string) error {
+	return g.inviteLinkDB.Revoke(ctx, groupID, codes)
+}
+
+func (g *groupDatabase) JoinGroupByInviteLink(
+	ctx context.Context,
+	code string,
+	member *relationTb.GroupMemberModel,
+	request *relationTb.GroupRequestModel,
+) (bool, error) {
+	var ok bool
+	cache := g.cache.NewCache()
+	if err := g.tx.Transaction(func(tx any) error {
+		var err error
+		ok, err = g.inviteLinkDB.NewTx(tx).Use(ctx, code, time.Now())
+		if err != nil || !ok {
+			return err
+		}
+		if member != nil {
+			if err := g.groupMemberDB.NewTx(tx).Create(ctx, []*relationTb.GroupMemberModel{member}); err != nil {
+				return err
+			}
+			if err := g.inviteLinkDB.NewTx(tx).IncrJoinCount(ctx, code); err != nil {
+				return err
+			}
+			cache = cache.DelGroupMembersHash(member.GroupID).
+				DelGroupMembersInfo(member.GroupID, member.UserID).
+				DelGroupMemberIDs(member.GroupID).
+				DelGroupsMemberNum(member.GroupID).
+				DelJoinedGroupID(member.UserID)
+		}
+		if request != nil {
+			db := g.groupRequestDB.NewTx(tx)
+			if err := db.Delete(ctx, request.GroupID, request.UserID); err != nil {
+				return err
+			}
+			if err := db.Create(ctx, []*relationTb.GroupRequestModel{request}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+	if err := cache.ExecDel(ctx); err != nil {
+		return false, err
+	}
+	if member == nil {
+		return true, nil
+	}
+	return true, g.incrMembersVersion(ctx, member.GroupID, []string{member.UserID}, unRelationTb.VersionStateInsert)
+}
+
+func (g *groupDatabase) IncrGroupInviteLinkJoinCount(ctx context.Context, code string) error {
+	return g.inviteLinkDB.IncrJoinCount(ctx, code)
+}
+
+func (g *groupDatabase) CreateChannel(
+	ctx context.Context,
+	group *relationTb.GroupModel,
+	members []*relationTb.GroupMemberModel,
+	channel *relationTb.ChannelModel,
+) error {
+	if err := g.tx.Transaction(func(tx any) error {
+		if err := g.groupDB.NewTx(tx).Create(ctx, []*relationTb.GroupModel{group}); err != nil {
+			return err
+		}
+		if err := g.groupMemberDB.NewTx(tx).Create(ctx, members); err != nil {
+			return err
+		}
+		return g.channelDB.NewTx(tx).Create(ctx, []*relationTb.ChannelModel{channel})
+	}); err != nil {
+		return err
+	}
+	cache := g.cache.NewCache().
+		DelGroupsInfo(group.GroupID).
+		DelGroupMemberIDs(group.GroupID).
+		DelGroupMembersHash(group.GroupID).
+		DelGroupsMemberNum(group.GroupID)
+	for _, member := range members {
+		cache = cache.DelJoinedGroupID(member.UserID).DelGroupMembersInfo(group.GroupID, member.UserID)
+	}
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.incrGroupMembersVersion(ctx, members, unRelationTb.VersionStateInsert)
+}
+
+func (g *groupDatabase) TakeChannel(ctx context.Context, groupID string) (*relationTb.ChannelModel, error) {
+	return g.channelDB.Take(ctx, groupID)
+}
+
+func (g *groupDatabase) FindChannels(ctx context.Context, groupIDs []string) ([]*relationTb.ChannelModel, error) {
+	return g.channelDB.Find(ctx, groupIDs)
+}
+
+func (g *groupDatabase) UpdateChannelPublic(ctx context.Context, groupID string, isPublic bool) error {
+	return g.channelDB.UpdatePublic(ctx, groupID, isPublic)
+}
+
+func (g *groupDatabase) SearchPublicChannels(
+	ctx context.Context,
+	keyword string,
+	pageNumber, showNumber int32,
+) (int64, []*relationTb.ChannelModel, error) {
+	return g.channelDB.SearchPublic(ctx, keyword, pageNumber, showNumber)
+}
+
+func (g *groupDatabase) SubscribeChannel(ctx context.Context, subscriber *relationTb.ChannelSubscriberModel) error {
+	return g.tx.Transaction(func(tx any) error {
+		if err := g.subscriberDB.NewTx(tx).Create(ctx, []*relationTb.ChannelSubscriberModel{subscriber}); err != nil {
+			return err
+		}
+		return g.channelDB.NewTx(tx).IncrSubscriberCount(ctx, subscriber.GroupID, 1)
+	})
+}
+
+func (g *groupDatabase) UnsubscribeChannel(ctx context.Context, groupID string, userIDs []string) error {
+	return g.tx.Transaction(func(tx any) error {
+		count, err := g.subscriberDB.NewTx(tx).Delete(ctx, groupID, userIDs)
+		if err != nil || count == 0 {
+			return err
+		}
+		return g.channelDB.NewTx(tx).IncrSubscriberCount(ctx, groupID, -count)
+	})
+}
+
+func (g *groupDatabase) TakeChannelSubscriber(
+	ctx context.Context,
+	groupID string,
+	userID string,
+) (*relationTb.ChannelSubscriberModel, error) {
+	return g.subscriberDB.Take(ctx, groupID, userID)
+}
+
+func (g *groupDatabase) FindChannelSubscriberIDs(
+	ctx context.Context,
+	groupID string,
+	afterUserID string,
+	limit int,
+) ([]string, error) {
+	return g.subscriberDB.FindUserIDs(ctx, groupID, afterUserID, limit)
+}
+
+func (g *groupDatabase) PageUserSubscribedChannels(
+	ctx context.Context,
+	userID string,
+	pageNumber, showNumber int32,
+) (int64, []*relationTb.ChannelSubscriberModel, error) {
+	return g.subscriberDB.PageByUser(ctx, userID, pageNumber, showNumber)
+}
+
+func (g *groupDatabase) SetChannelSubscriberOpened(ctx context.Context, groupID string, userID string) error {
+	return g.subscriberDB.SetOpened(ctx, groupID, userID)
+}
+
+func (g *groupDatabase) BanGroupMembers(ctx context.Context, bans []*relationTb.GroupBanModel) error {
+	if len(bans) == 0 {
+		return nil
+	}
+	return g.tx.Transaction(func(tx any) error {
+		db := g.banDB.NewTx(tx)
+		userIDs := utils.Slice(bans, func(e *relationTb.GroupBanModel) string { return e.UserID })
+		if err := db.Delete(ctx, bans[0].GroupID, userIDs); err != nil {
+			return err
+		}
+		return db.Create(ctx, bans)
+	})
+}
+
+func (g *groupDatabase) UnbanGroupMembers(ctx context.Context, groupID string, userIDs []string) error {
+	return g.banDB.Delete(ctx, groupID, userIDs)
+}
+
+func (g *groupDatabase) FindActiveGroupBans(
+	ctx context.Context,
+	groupID string,
+	userIDs []string,
+) ([]*relationTb.GroupBanModel, error) {
+	bans, err := g.banDB.Find(ctx, groupID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return utils.Filter(bans, func(e *relationTb.GroupBanModel) (*relationTb.GroupBanModel, bool) {
+		return e, e.Active(now)
+	}), nil
+}
+
+func (g *groupDatabase) PageGroupBans(
+	ctx context.Context,
+	groupID string,
+	pageNumber, showNumber int32,
+) (int64, []*relationTb.GroupBanModel, error) {
+	return g.banDB.Page(ctx, groupID, pageNumber, showNumber)
+}
+
+func (g *groupDatabase) CreateCommunity(
+	ctx context.Context,
+	groups []*relationTb.GroupModel,
+	members []*relationTb.GroupMemberModel,
+	channel *relationTb.ChannelModel,
+	communityGroups []*relationTb.CommunityGroupModel,
+) error {
+	if err := g.tx.Transaction(func(tx any) error {
+		if err := g.groupDB.NewTx(tx).Create(ctx, groups); err != nil {
+			return err
+		}
+		if err := g.groupMemberDB.NewTx(tx).Create(ctx, members); err != nil {
+			return err
+		}
+		if err := g.channelDB.NewTx(tx).Create(ctx, []*relationTb.ChannelModel{channel}); err != nil {
+			return err
+		}
+		return g.communityDB.NewTx(tx).Create(ctx, communityGroups)
+	}); err != nil {
+		return err
+	}
+	cache := g.cache.NewCache()
+	for _, group := range groups {
+		cache = cache.DelGroupsInfo(group.GroupID).
+			DelGroupMemberIDs(group.GroupID).
+			DelGroupMembersHash(group.GroupID).
+			DelGroupsMemberNum(group.GroupID)
+	}
+	for _, member := range members {
+		cache = cache.DelJoinedGroupID(member.UserID).DelGroupMembersInfo(member.GroupID, member.UserID)
+	}
+	if err := cache.ExecDel(ctx); err != nil {
+		return err
+	}
+	return g.incrGroupMembersVersion(ctx, members, unRelationTb.VersionStateInsert)
+}
+
+func (g *groupDatabase) AttachCommunityGroups(ctx context.Context, groups []*relationTb.CommunityGroupModel) error {
+	return g.communityDB.Create(ctx, groups)
+}
+
+func (g *groupDatabase) DetachCommunityGroups(ctx context.Context, communityID string, groupIDs []string) error {
+	return g.communityDB.Delete(ctx, communityID, groupIDs)
+}
+
+func (g *groupDatabase) FindCommunityGroups(ctx context.Context, communityID string) ([]*relationTb.CommunityGroupModel, error) {
+	return g.communityDB.Find(ctx, communityID)
+}
+
+func (g *groupDatabase) FindGroupsCommunity(ctx context.Context, groupIDs []string) ([]*relationTb.CommunityGroupModel, error) {
+	return g.communityDB.FindByGroupIDs(ctx, groupIDs)
+}
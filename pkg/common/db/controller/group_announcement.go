@@ -0,0 +1,46 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type GroupAnnouncementDatabase interface {
+	AddAnnouncement(ctx context.Context, announcement *unRelationTb.GroupAnnouncementModel) error
+	PageAnnouncements(ctx context.Context, groupID string, pageNumber, showNumber int32) (int64, []*unRelationTb.GroupAnnouncementModel, error)
+}
+
+func NewGroupAnnouncementDatabase(announcementDB unRelationTb.GroupAnnouncementModelInterface) GroupAnnouncementDatabase {
+	return &groupAnnouncementDatabase{announcementDB: announcementDB}
+}
+
+type groupAnnouncementDatabase struct {
+	announcementDB unRelationTb.GroupAnnouncementModelInterface
+}
+
+func (g *groupAnnouncementDatabase) AddAnnouncement(ctx context.Context, announcement *unRelationTb.GroupAnnouncementModel) error {
+	return g.announcementDB.Create(ctx, announcement)
+}
+
+func (g *groupAnnouncementDatabase) PageAnnouncements(
+	ctx context.Context,
+	groupID string,
+	pageNumber, showNumber int32,
+) (int64, []*unRelationTb.GroupAnnouncementModel, error) {
+	return g.announcementDB.Page(ctx, groupID, pageNumber, showNumber)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type GroupAuditLogDatabase interface {
+	AddLogs(ctx context.Context, logs ...*unRelationTb.GroupAuditLogModel) error
+	PageLogs(
+		ctx context.Context,
+		filter *unRelationTb.GroupAuditLogFilter,
+		pageNumber, showNumber int32,
+	) (int64, []*unRelationTb.GroupAuditLogModel, error)
+}
+
+func NewGroupAuditLogDatabase(logDB unRelationTb.GroupAuditLogModelInterface) GroupAuditLogDatabase {
+	return &groupAuditLogDatabase{logDB: logDB}
+}
+
+type groupAuditLogDatabase struct {
+	logDB unRelationTb.GroupAuditLogModelInterface
+}
+
+func (g *groupAuditLogDatabase) AddLogs(ctx context.Context, logs ...*unRelationTb.GroupAuditLogModel) error {
+	return g.logDB.Create(ctx, logs)
+}
+
+func (g *groupAuditLogDatabase) PageLogs(
+	ctx context.Context,
+	filter *unRelationTb.GroupAuditLogFilter,
+	pageNumber, showNumber int32,
+) (int64, []*unRelationTb.GroupAuditLogModel, error) {
+	return g.logDB.Page(ctx, filter, pageNumber, showNumber)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type GroupJoinPolicyDatabase interface {
+	SetPolicy(ctx context.Context, policy *unRelationTb.GroupJoinPolicyModel) error
+	// TakePolicy 未设置时返回nil
+	TakePolicy(ctx context.Context, groupID string) (*unRelationTb.GroupJoinPolicyModel, error)
+	DeletePolicy(ctx context.Context, groupID string) error
+	SetAnswer(ctx context.Context, answer *unRelationTb.GroupJoinAnswerModel) error
+	FindAnswers(ctx context.Context, groupID string, userIDs []string) ([]*unRelationTb.GroupJoinAnswerModel, error)
+	DeleteAnswers(ctx context.Context, groupID string, userIDs []string) error
+	// FindRequestExpirePolicies 获取设置了入群申请过期时间的群
+	FindRequestExpirePolicies(ctx context.Context) ([]*unRelationTb.GroupJoinPolicyModel, error)
+	// ClearExpiredRequests 删除申请时间早于before的未处理入群申请及其回答
+	ClearExpiredRequests(ctx context.Context, groupID string, before time.Time) error
+	// DeleteUserRequests 删除用户在所有群的入群申请及其回答
+	DeleteUserRequests(ctx context.Context, userID string) (int64, error)
+}
+
+func NewGroupJoinPolicyDatabase(
+	policyDB unRelationTb.GroupJoinPolicyModelInterface,
+	answerDB unRelationTb.GroupJoinAnswerModelInterface,
+	requestDB relationTb.GroupRequestModelInterface,
+) GroupJoinPolicyDatabase {
+	return &groupJoinPolicyDatabase{policyDB: policyDB, answerDB: answerDB, requestDB: requestDB}
+}
+
+type groupJoinPolicyDatabase struct {
+	policyDB  unRelationTb.GroupJoinPolicyModelInterface
+	answerDB  unRelationTb.GroupJoinAnswerModelInterface
+	requestDB relationTb.GroupRequestModelInterface
+}
+
+func (g *groupJoinPolicyDatabase) SetPolicy(ctx context.Context, policy *unRelationTb.GroupJoinPolicyModel) error {
+	return g.policyDB.Set(ctx, policy)
+}
+
+func (g *groupJoinPolicyDatabase) TakePolicy(ctx context.Context, groupID string) (*unRelationTb.GroupJoinPolicyModel, error) {
+	return g.policyDB.Take(ctx, groupID)
+}
+
+func (g *groupJoinPolicyDatabase) DeletePolicy(ctx context.Context, groupID string) error {
+	return g.policyDB.Delete(ctx, groupID)
+}
+
+func (g *groupJoinPolicyDatabase) SetAnswer(ctx context.Context, answer *unRelationTb.GroupJoinAnswerModel) error {
+	return g.answerDB.Set(ctx, answer)
+}
+
+func (g *groupJoinPolicyDatabase) FindAnswers(
+	ctx context.Context,
+	groupID string,
+	userIDs []string,
+) ([]*unRelationTb.GroupJoinAnswerModel, error) {
+	return g.answerDB.Find(ctx, groupID, userIDs)
+}
+
+func (g *groupJoinPolicyDatabase) DeleteAnswers(ctx context.Context, groupID string, userIDs []string) error {
+	return g.answerDB.Delete(ctx, groupID, userIDs)
+}
+
+func (g *groupJoinPolicyDatabase) FindRequestExpirePolicies(ctx context.Context) ([]*unRelationTb.GroupJoinPolicyModel, error) {
+	return g.policyDB.FindRequestExpire(ctx)
+}
+
+func (g *groupJoinPolicyDatabase) ClearExpiredRequests(ctx context.Context, groupID string, before time.Time) error {
+	userIDs, err := g.requestDB.DeleteUnhandledBefore(ctx, groupID, before)
+	if err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return g.answerDB.Delete(ctx, groupID, userIDs)
+}
+
+func (g *groupJoinPolicyDatabase) DeleteUserRequests(ctx context.Context, userID string) (int64, error) {
+	count, err := g.requestDB.DeleteUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return count, g.answerDB.DeleteUser(ctx, userID)
+}
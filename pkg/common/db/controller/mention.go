@@ -0,0 +1,82 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/utils"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type MentionDatabase interface {
+	// AddMsgsMentions 记录群聊@消息, 消息需已分配seq
+	AddMsgsMentions(ctx context.Context, conversationID string, msgs []*sdkws.MsgData) error
+	// PageUserMentions 分页获取用户在会话中被@的记录, minSeqs为各会话的seq下界(不包含), 早于joinTimes(毫秒)的@所有人不返回
+	PageUserMentions(ctx context.Context, userID string, conversationIDs []string, minSeqs map[string]int64, joinTimes map[string]int64, pageNumber, showNumber int32) (int64, []*unRelationTb.MentionModel, error)
+	// DeleteUserMentions 删除用户被@的记录
+	DeleteUserMentions(ctx context.Context, userID string) (int64, error)
+}
+
+func NewMentionDatabase(mentionDB unRelationTb.MentionModelInterface) MentionDatabase {
+	return &mentionDatabase{mentionDB: mentionDB}
+}
+
+type mentionDatabase struct {
+	mentionDB unRelationTb.MentionModelInterface
+}
+
+func (m *mentionDatabase) AddMsgsMentions(ctx context.Context, conversationID string, msgs []*sdkws.MsgData) error {
+	var mentions []*unRelationTb.MentionModel
+	now := time.Now()
+	for _, msg := range msgs {
+		if msg.ContentType != constant.AtText || msg.SessionType != constant.SuperGroupChatType || msg.Seq == 0 {
+			continue
+		}
+		mention := func(userID string, atAll bool) *unRelationTb.MentionModel {
+			return &unRelationTb.MentionModel{
+				UserID:         userID,
+				ConversationID: conversationID,
+				GroupID:        msg.GroupID,
+				Seq:            msg.Seq,
+				SendID:         msg.SendID,
+				ClientMsgID:    msg.ClientMsgID,
+				SendTime:       msg.SendTime,
+				AtAll:          atAll,
+				CreateTime:     now,
+			}
+		}
+		for _, userID := range utils.Distinct(msg.AtUserIDList) {
+			if userID == constant.AtAllString {
+				mentions = append(mentions, mention("", true))
+			} else if userID != msg.SendID {
+				mentions = append(mentions, mention(userID, false))
+			}
+		}
+	}
+	return m.mentionDB.Create(ctx, mentions)
+}
+
+func (m *mentionDatabase) PageUserMentions(ctx context.Context, userID string, conversationIDs []string, minSeqs map[string]int64, joinTimes map[string]int64, pageNumber, showNumber int32) (int64, []*unRelationTb.MentionModel, error) {
+	return m.mentionDB.FindUserMentions(ctx, userID, conversationIDs, minSeqs, joinTimes, pageNumber, showNumber)
+}
+
+func (m *mentionDatabase) DeleteUserMentions(ctx context.Context, userID string) (int64, error) {
+	return m.mentionDB.DeleteUser(ctx, userID)
+}
@@ -31,9 +31,11 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/kafka"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/prome"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/msgprocessor"
 
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/OpenIMSDK/protocol/constant"
 	pbMsg "github.com/OpenIMSDK/protocol/msg"
 	"github.com/OpenIMSDK/protocol/sdkws"
 	"github.com/OpenIMSDK/tools/utils"
@@ -44,6 +46,9 @@ const (
 	updateKeyRevoke
 )
 
+// findCachedMsgsBatch 从redis向前查找消息时每次读取的seq个数.
+const findCachedMsgsBatch = 100
+
 type CommonMsgDatabase interface {
 	// 批量插入消息
 	BatchInsertChat2DB(ctx context.Context, conversationID string, msgs []*sdkws.MsgData, currentMaxSeq int64) error
@@ -70,6 +75,22 @@ type CommonMsgDatabase interface {
 	DeleteUserMsgsBySeqs(ctx context.Context, userID string, conversationID string, seqs []int64) error
 	// 物理删除消息置空
 	DeleteMsgsPhysicalBySeqs(ctx context.Context, conversationID string, seqs []int64) error
+	// schedule burn after reading and destruct after send by the msg attached info
+	ScheduleMsgsDestruct(ctx context.Context, conversationID string, msgs []*sdkws.MsgData) error
+	// start the burn countdown of the msgs userID has read
+	BurnMsgsAfterRead(ctx context.Context, userID string, conversationID string, hasReadSeq int64) error
+	// start the burn countdown of the msgs of seqs only
+	BurnMsgsAfterReadBySeqs(ctx context.Context, userID string, conversationID string, seqs []int64) error
+	// take the msgs whose destruct time is up, each item is returned only once
+	PopExpiredMsgsDestruct(ctx context.Context, count int64) ([]*cache.MsgDestructItem, error)
+	// put the popped items back when they failed to be deleted
+	RequeueMsgsDestruct(ctx context.Context, items []*cache.MsgDestructItem) error
+	// 从最新的消息向前查找userID在since之后发送的消息seq, 包括还未写入mongo的缓存消息
+	FindUserMsgSeqsSince(ctx context.Context, conversationID string, userID string, since time.Time) ([]int64, error)
+	// 群慢速模式, 原子地预占冷却, 成功返回token, 冷却中返回剩余时间
+	ReserveGroupSlowMode(ctx context.Context, groupID string, userID string, interval time.Duration) (string, time.Duration, error)
+	// 群慢速模式, 发送失败时释放预占
+	ReleaseGroupSlowMode(ctx context.Context, groupID string, userID string, token string) error
 
 	SetMaxSeq(ctx context.Context, conversationID string, maxSeq int64) error
 	GetMaxSeqs(ctx context.Context, conversationIDs []string) (map[string]int64, error)
@@ -84,6 +105,7 @@ type CommonMsgDatabase interface {
 	SetConversationUserMinSeq(ctx context.Context, conversationID string, userID string, minSeq int64) error
 	SetConversationUserMinSeqs(ctx context.Context, conversationID string, seqs map[string]int64) (err error)
 	SetUserConversationsMinSeqs(ctx context.Context, userID string, seqs map[string]int64) (err error)
+	GetUserConversationsMinSeqs(ctx context.Context, userID string, conversationIDs []string) (map[string]int64, error)
 	SetHasReadSeq(ctx context.Context, userID string, conversationID string, hasReadSeq int64) error
 	GetHasReadSeqs(ctx context.Context, userID string, conversationIDs []string) (map[string]int64, error)
 	GetHasReadSeq(ctx context.Context, userID string, conversationID string) (int64, error)
@@ -119,6 +141,8 @@ type CommonMsgDatabase interface {
 		showNumber int32,
 	) (msgCount int64, userCount int64, groups []*unRelationTb.GroupCount, dateCount map[string]int64, err error)
 	ConvertMsgsDocLen(ctx context.Context, conversationIDs []string)
+	// AnonymizeUserMsgs 清除userID发送的消息中的发送者昵称及头像, 返回修改的文档数
+	AnonymizeUserMsgs(ctx context.Context, userID string) (int64, error)
 }
 
 func NewCommonMsgDatabase(msgDocModel unRelationTb.MsgDocModelInterface, cacheModel cache.MsgModel) CommonMsgDatabase {
@@ -766,6 +790,77 @@ func (db *commonMsgDatabase) DeleteMsgsPhysicalBySeqs(ctx context.Context, conve
 	return nil
 }
 
+func (db *commonMsgDatabase) ScheduleMsgsDestruct(ctx context.Context, conversationID string, msgs []*sdkws.MsgData) error {
+	var destructItems []*cache.MsgDestructItem
+	burnItems := make(map[string][]*cache.MsgDestructItem)
+	for _, msg := range msgs {
+		if msg.AttachedInfo == "" {
+			continue
+		}
+		attachedInfo := msgprocessor.ParseAttachedInfo(msg.AttachedInfo)
+		item := &cache.MsgDestructItem{
+			ConversationID: conversationID,
+			Seq:            msg.Seq,
+			SessionType:    msg.SessionType,
+			SendID:         msg.SendID,
+			RecvID:         msg.RecvID,
+		}
+		if msg.SessionType == constant.SuperGroupChatType {
+			item.RecvID = msg.GroupID
+		}
+		if attachedInfo.IsDestructAfterSend() {
+			destructItem := *item
+			destructItem.DestructTime = msg.SendTime + int64(attachedInfo.DestructDuration)*1000
+			destructItems = append(destructItems, &destructItem)
+		}
+		if attachedInfo.IsBurnAfterRead(msg) {
+			item.BurnDuration = attachedInfo.BurnDuration
+			burnItems[msg.RecvID] = append(burnItems[msg.RecvID], item)
+		}
+	}
+	if err := db.cache.AddMsgsDestruct(ctx, destructItems); err != nil {
+		return err
+	}
+	for userID, items := range burnItems {
+		if err := db.cache.AddMsgsBurnAfterRead(ctx, userID, conversationID, items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *commonMsgDatabase) BurnMsgsAfterRead(ctx context.Context, userID string, conversationID string, hasReadSeq int64) error {
+	items, err := db.cache.PopMsgsBurnAfterRead(ctx, userID, conversationID, hasReadSeq)
+	if err != nil {
+		return err
+	}
+	return db.startBurnCountdown(ctx, items)
+}
+
+func (db *commonMsgDatabase) BurnMsgsAfterReadBySeqs(ctx context.Context, userID string, conversationID string, seqs []int64) error {
+	items, err := db.cache.PopMsgsBurnAfterReadBySeqs(ctx, userID, conversationID, seqs)
+	if err != nil {
+		return err
+	}
+	return db.startBurnCountdown(ctx, items)
+}
+
+func (db *commonMsgDatabase) startBurnCountdown(ctx context.Context, items []*cache.MsgDestructItem) error {
+	now := time.Now().UnixMilli()
+	for _, item := range items {
+		item.DestructTime = now + int64(item.BurnDuration)*1000
+	}
+	return db.cache.AddMsgsDestruct(ctx, items)
+}
+
+func (db *commonMsgDatabase) PopExpiredMsgsDestruct(ctx context.Context, count int64) ([]*cache.MsgDestructItem, error) {
+	return db.cache.PopExpiredMsgsDestruct(ctx, time.Now(), count)
+}
+
+func (db *commonMsgDatabase) RequeueMsgsDestruct(ctx context.Context, items []*cache.MsgDestructItem) error {
+	return db.cache.AddMsgsDestruct(ctx, items)
+}
+
 func (db *commonMsgDatabase) DeleteUserMsgsBySeqs(ctx context.Context, userID string, conversationID string, seqs []int64) error {
 	cachedMsgs, _, err := db.cache.GetMessagesBySeq(ctx, conversationID, seqs)
 	if err != nil && errs.Unwrap(err) != redis.Nil {
@@ -857,6 +952,14 @@ func (db *commonMsgDatabase) SetConversationUserMinSeqs(ctx context.Context, con
 	return db.cache.SetConversationUserMinSeqs(ctx, conversationID, seqs)
 }
 
+func (db *commonMsgDatabase) GetUserConversationsMinSeqs(
+	ctx context.Context,
+	userID string,
+	conversationIDs []string,
+) (map[string]int64, error) {
+	return db.cache.GetUserConversationsMinSeqs(ctx, userID, conversationIDs)
+}
+
 func (db *commonMsgDatabase) SetUserConversationsMinSeqs(ctx context.Context, userID string, seqs map[string]int64) error {
 	return db.cache.SetUserConversationsMinSeqs(ctx, userID, seqs)
 }
@@ -960,3 +1063,102 @@ func (db *commonMsgDatabase) SearchMessage(ctx context.Context, req *pbMsg.Searc
 func (db *commonMsgDatabase) ConvertMsgsDocLen(ctx context.Context, conversationIDs []string) {
 	db.msgDocDatabase.ConvertMsgsDocLen(ctx, conversationIDs)
 }
+
+func (db *commonMsgDatabase) AnonymizeUserMsgs(ctx context.Context, userID string) (int64, error) {
+	return db.msgDocDatabase.AnonymizeUserMsgs(ctx, userID)
+}
+
+func (db *commonMsgDatabase) ReserveGroupSlowMode(
+	ctx context.Context,
+	groupID string,
+	userID string,
+	interval time.Duration,
+) (string, time.Duration, error) {
+	return db.cache.ReserveGroupSlowMode(ctx, groupID, userID, interval)
+}
+
+func (db *commonMsgDatabase) ReleaseGroupSlowMode(ctx context.Context, groupID string, userID string, token string) error {
+	return db.cache.ReleaseGroupSlowMode(ctx, groupID, userID, token)
+}
+
+func (db *commonMsgDatabase) FindUserMsgSeqsSince(
+	ctx context.Context,
+	conversationID string,
+	userID string,
+	since time.Time,
+) ([]int64, error) {
+	seqs, err := db.findUserCachedMsgSeqsSince(ctx, conversationID, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	found := utils.SliceSetAny(seqs, func(seq int64) int64 { return seq })
+	for index := int64(0); ; index++ {
+		msgDocModel, err := db.msgDocDatabase.GetMsgDocModelByIndex(ctx, conversationID, index, -1)
+		if err != nil {
+			if err == unrelation.ErrMsgListNotExist {
+				return seqs, nil
+			}
+			return nil, err
+		}
+		var reachSince bool
+		for _, msg := range msgDocModel.Msg {
+			if msg.Msg == nil {
+				continue
+			}
+			if msg.Msg.SendTime < since.UnixMilli() {
+				reachSince = true
+				continue
+			}
+			if _, ok := found[msg.Msg.Seq]; !ok && msg.Msg.SendID == userID {
+				seqs = append(seqs, msg.Msg.Seq)
+			}
+		}
+		if reachSince {
+			return seqs, nil
+		}
+	}
+}
+
+// findUserCachedMsgSeqsSince 从缓存的最大seq向前分批读取redis中的消息, 直到消息早于since或不在缓存中.
+func (db *commonMsgDatabase) findUserCachedMsgSeqsSince(
+	ctx context.Context,
+	conversationID string,
+	userID string,
+	since time.Time,
+) ([]int64, error) {
+	maxSeq, err := db.cache.GetMaxSeq(ctx, conversationID)
+	if err != nil {
+		if errs.Unwrap(err) == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var seqs []int64
+	for end := maxSeq; end > 0; end -= findCachedMsgsBatch {
+		batch := make([]int64, 0, findCachedMsgsBatch)
+		for seq := end; seq > 0 && seq > end-findCachedMsgsBatch; seq-- {
+			batch = append(batch, seq)
+		}
+		cachedMsgs, _, err := db.cache.GetMessagesBySeq(ctx, conversationID, batch)
+		if err != nil && errs.Unwrap(err) != redis.Nil {
+			return nil, err
+		}
+		if len(cachedMsgs) == 0 {
+			return seqs, nil
+		}
+		var reachSince bool
+		for _, msg := range cachedMsgs {
+			if msg.SendTime < since.UnixMilli() {
+				reachSince = true
+				continue
+			}
+			if msg.SendID == userID {
+				seqs = append(seqs, msg.Seq)
+			}
+		}
+		if reachSince {
+			return seqs, nil
+		}
+	}
+	return seqs, nil
+}
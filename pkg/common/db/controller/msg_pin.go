@@ -0,0 +1,76 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+)
+
+type MsgPinDatabase interface {
+	// PinMsg 已经置顶时返回false, 会话置顶数超过limit时返回errcode.ErrPinnedMsgLimit, limit<=0不限制
+	PinMsg(ctx context.Context, pin *unRelationTb.MsgPinModel, limit int) (bool, error)
+	// UnpinMsgs 返回实际取消置顶的数量
+	UnpinMsgs(ctx context.Context, conversationID string, seqs []int64) (int64, error)
+	CountPinnedMsgs(ctx context.Context, conversationID string) (int64, error)
+	FindPinnedMsgs(ctx context.Context, conversationID string) ([]*unRelationTb.MsgPinModel, error)
+	// DeleteUserPins 删除用户置顶的全部消息
+	DeleteUserPins(ctx context.Context, userID string) (int64, error)
+}
+
+func NewMsgPinDatabase(pinDB unRelationTb.MsgPinModelInterface) MsgPinDatabase {
+	return &msgPinDatabase{pinDB: pinDB}
+}
+
+type msgPinDatabase struct {
+	pinDB unRelationTb.MsgPinModelInterface
+}
+
+// PinMsg 并发置顶时都会先写入, 只保留最早写入的limit条, 其余撤销.
+func (m *msgPinDatabase) PinMsg(ctx context.Context, pin *unRelationTb.MsgPinModel, limit int) (bool, error) {
+	created, err := m.pinDB.Create(ctx, pin)
+	if err != nil || !created || limit <= 0 {
+		return created, err
+	}
+	rank, err := m.pinDB.Rank(ctx, pin.ConversationID, pin.Seq)
+	if err != nil {
+		return false, err
+	}
+	if rank > int64(limit) {
+		if _, err := m.pinDB.Delete(ctx, pin.ConversationID, []int64{pin.Seq}); err != nil {
+			return false, err
+		}
+		return false, errcode.ErrPinnedMsgLimit.Wrap()
+	}
+	return true, nil
+}
+
+func (m *msgPinDatabase) UnpinMsgs(ctx context.Context, conversationID string, seqs []int64) (int64, error) {
+	return m.pinDB.Delete(ctx, conversationID, seqs)
+}
+
+func (m *msgPinDatabase) CountPinnedMsgs(ctx context.Context, conversationID string) (int64, error) {
+	return m.pinDB.Count(ctx, conversationID)
+}
+
+func (m *msgPinDatabase) FindPinnedMsgs(ctx context.Context, conversationID string) ([]*unRelationTb.MsgPinModel, error) {
+	return m.pinDB.Find(ctx, conversationID)
+}
+
+func (m *msgPinDatabase) DeleteUserPins(ctx context.Context, userID string) (int64, error) {
+	return m.pinDB.DeletePinUser(ctx, userID)
+}
@@ -0,0 +1,188 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+)
+
+// Quota the limit and the usage of one kind in the current window.
+type Quota struct {
+	Kind     string
+	TargetID string
+	Limit    int64
+	Window   time.Duration
+	Used     int64
+	// the limit is set for the target instead of the config
+	Override bool
+}
+
+type QuotaDatabase interface {
+	// ConsumeQuota 消耗配额, 超出返回errcode.ErrQuotaExceeded, 返回消耗的窗口, 不限制时为空
+	ConsumeQuota(ctx context.Context, kind string, targetID string, amount int64) (string, error)
+	// RefundQuota 归还ConsumeQuota在返回的窗口中消耗的配额
+	RefundQuota(ctx context.Context, usageKey string, amount int64) error
+	// HoldQuota 预占配额直到expireTime, 未提交则过期自动归还, 超出返回errcode.ErrQuotaExceeded
+	HoldQuota(ctx context.Context, kind string, targetID string, holdID string, amount int64, expireTime time.Time) error
+	// CommitQuota 将HoldQuota的预占转为消耗
+	CommitQuota(ctx context.Context, kind string, targetID string, holdID string) error
+	// GetQuota 获取配额和当前窗口用量, 不限制时不统计用量
+	GetQuota(ctx context.Context, kind string, targetID string) (*Quota, error)
+	// SetQuota 覆盖配置的配额
+	SetQuota(ctx context.Context, quota *unRelationTb.QuotaModel) error
+	// ResetQuota 删除覆盖, 恢复配置的配额
+	ResetQuota(ctx context.Context, kind string, targetID string) error
+}
+
+func NewQuotaDatabase(quotaDB unRelationTb.QuotaModelInterface, cache cache.QuotaCache) QuotaDatabase {
+	return &quotaDatabase{quotaDB: quotaDB, cache: cache}
+}
+
+type quotaDatabase struct {
+	quotaDB unRelationTb.QuotaModelInterface
+	cache   cache.QuotaCache
+}
+
+func (q *quotaDatabase) getQuotaConf(kind string) (*config.QuotaConf, error) {
+	switch kind {
+	case unRelationTb.QuotaKindUserMsg:
+		return &config.Config.Quota.UserMsg, nil
+	case unRelationTb.QuotaKindUserFileSize:
+		return &config.Config.Quota.UserFileSize, nil
+	case unRelationTb.QuotaKindGroupMsg:
+		return &config.Config.Quota.GroupMsg, nil
+	case unRelationTb.QuotaKindUserFriendRequest:
+		return &config.Config.Quota.UserFriendRequest, nil
+	default:
+		return nil, errs.ErrArgs.Wrap("invalid quota kind " + kind)
+	}
+}
+
+func (q *quotaDatabase) getLimit(ctx context.Context, kind string, targetID string) (*Quota, error) {
+	conf, err := q.getQuotaConf(kind)
+	if err != nil {
+		return nil, err
+	}
+	quota := &Quota{Kind: kind, TargetID: targetID, Limit: conf.Limit, Window: time.Duration(conf.Window) * time.Second}
+	if quota.Window <= 0 {
+		quota.Window = time.Hour * 24
+	}
+	overrides, err := q.cache.GetQuotas(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range overrides {
+		if override.Kind == kind {
+			quota.Limit = override.Limit
+			quota.Override = true
+			break
+		}
+	}
+	return quota, nil
+}
+
+func (q *quotaDatabase) ConsumeQuota(ctx context.Context, kind string, targetID string, amount int64) (string, error) {
+	quota, err := q.getLimit(ctx, kind, targetID)
+	if err != nil {
+		return "", err
+	}
+	if quota.Limit <= 0 {
+		return "", nil
+	}
+	usageKey, ok, err := q.cache.IncrQuotaUsage(ctx, kind, targetID, quota.Window, amount, quota.Limit)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", errcode.ErrQuotaExceeded.Wrap(kind + " quota exceeded")
+	}
+	return usageKey, nil
+}
+
+// RefundQuota the window may have changed since the quota was consumed, so the key of the consumed window is used.
+func (q *quotaDatabase) RefundQuota(ctx context.Context, usageKey string, amount int64) error {
+	if usageKey == "" {
+		return nil
+	}
+	return q.cache.DecrQuotaUsage(ctx, usageKey, amount)
+}
+
+func (q *quotaDatabase) HoldQuota(ctx context.Context, kind string, targetID string, holdID string, amount int64, expireTime time.Time) error {
+	quota, err := q.getLimit(ctx, kind, targetID)
+	if err != nil {
+		return err
+	}
+	if quota.Limit <= 0 {
+		return nil
+	}
+	ok, err := q.cache.HoldQuotaUsage(ctx, kind, targetID, quota.Window, holdID, amount, quota.Limit, expireTime)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errcode.ErrQuotaExceeded.Wrap(kind + " quota exceeded")
+	}
+	return nil
+}
+
+// CommitQuota the hold is committed even if the limit is removed after it was held.
+func (q *quotaDatabase) CommitQuota(ctx context.Context, kind string, targetID string, holdID string) error {
+	quota, err := q.getLimit(ctx, kind, targetID)
+	if err != nil {
+		return err
+	}
+	_, err = q.cache.CommitQuotaHold(ctx, kind, targetID, quota.Window, holdID)
+	return err
+}
+
+func (q *quotaDatabase) GetQuota(ctx context.Context, kind string, targetID string) (*Quota, error) {
+	quota, err := q.getLimit(ctx, kind, targetID)
+	if err != nil {
+		return nil, err
+	}
+	quota.Used, err = q.cache.GetQuotaUsage(ctx, kind, targetID, quota.Window)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+func (q *quotaDatabase) SetQuota(ctx context.Context, quota *unRelationTb.QuotaModel) error {
+	if _, err := q.getQuotaConf(quota.Kind); err != nil {
+		return err
+	}
+	if err := q.quotaDB.Set(ctx, quota); err != nil {
+		return err
+	}
+	return q.cache.DelQuotas(quota.TargetID).ExecDel(ctx)
+}
+
+func (q *quotaDatabase) ResetQuota(ctx context.Context, kind string, targetID string) error {
+	if _, err := q.getQuotaConf(kind); err != nil {
+		return err
+	}
+	if err := q.quotaDB.Delete(ctx, kind, targetID); err != nil {
+		return err
+	}
+	return q.cache.DelQuotas(targetID).ExecDel(ctx)
+}
@@ -19,6 +19,8 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/OpenIMSDK/tools/utils"
+
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/s3"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/s3/cont"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
@@ -32,6 +34,14 @@ type S3Database interface {
 	CompleteMultipartUpload(ctx context.Context, uploadID string, parts []string) (*cont.UploadResult, error)
 	AccessURL(ctx context.Context, name string, expire time.Duration, opt *s3.AccessURLOption) (time.Time, string, error)
 	SetObject(ctx context.Context, info *relation.ObjectModel) error
+	// DeleteUserObjects 删除用户上传的文件记录, 存储中的文件在没有其他记录引用时删除
+	DeleteUserObjects(ctx context.Context, userID string) (int64, error)
+	// FindUserObjects 获取用户上传的文件记录
+	FindUserObjects(ctx context.Context, userID string) ([]*relation.ObjectModel, error)
+	// InitiateExportUpload 获取导出文件的预签名上传地址
+	InitiateExportUpload(ctx context.Context, name string, expire time.Duration) (key string, rawURL string, err error)
+	// CompleteExportUpload 上传完成后记录导出文件, key由name生成, 大小以存储中的为准
+	CompleteExportUpload(ctx context.Context, obj *relation.ObjectModel) error
 }
 
 func NewS3Database(s3 s3.Interface, obj relation.ObjectInfoModelInterface) S3Database {
@@ -91,3 +101,60 @@ func (s *s3Database) AccessURL(ctx context.Context, name string, expire time.Dur
 	}
 	return expireTime, rawURL, nil
 }
+
+func (s *s3Database) DeleteUserObjects(ctx context.Context, userID string) (int64, error) {
+	objs, err := s.obj.FindByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(objs) == 0 {
+		return 0, nil
+	}
+	names := make([]string, 0, len(objs))
+	keys := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		names = append(names, obj.Name)
+		keys = append(keys, obj.Key)
+	}
+	if err := s.obj.Delete(ctx, names); err != nil {
+		return 0, err
+	}
+	// 相同内容的文件共用一个key, 仍被其他用户引用的不删除
+	for _, key := range utils.Distinct(keys) {
+		count, err := s.obj.CountByKey(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := s.s3.DeleteObject(ctx, key); err != nil && !s.s3.IsNotFound(err) {
+			return 0, err
+		}
+	}
+	return int64(len(objs)), nil
+}
+
+func (s *s3Database) FindUserObjects(ctx context.Context, userID string) ([]*relation.ObjectModel, error) {
+	return s.obj.FindByUserID(ctx, userID)
+}
+
+func (s *s3Database) InitiateExportUpload(ctx context.Context, name string, expire time.Duration) (string, string, error) {
+	key := s.s3.ExportPath(name)
+	rawURL, err := s.s3.PresignedPutObject(ctx, key, expire)
+	if err != nil {
+		return "", "", err
+	}
+	return key, rawURL, nil
+}
+
+func (s *s3Database) CompleteExportUpload(ctx context.Context, obj *relation.ObjectModel) error {
+	obj.Key = s.s3.ExportPath(obj.Name)
+	info, err := s.s3.StatObject(ctx, obj.Key)
+	if err != nil {
+		return err
+	}
+	obj.Size = info.Size
+	obj.Hash = info.ETag
+	return s.obj.SetObject(ctx, obj)
+}
@@ -41,12 +41,20 @@ type UserDatabase interface {
 	Update(ctx context.Context, user *relation.UserModel) (err error)
 	// UpdateByMap update (zero value) external guarantee userID exists
 	UpdateByMap(ctx context.Context, userID string, args map[string]interface{}) (err error)
+	// Delete delete the users, no error is returned if the userID is not found
+	Delete(ctx context.Context, userIDs []string) (err error)
 	// Page If not found, no error is returned
 	Page(ctx context.Context, pageNumber, showNumber int32) (users []*relation.UserModel, count int64, err error)
 	// IsExist true as long as one exists
 	IsExist(ctx context.Context, userIDs []string) (exist bool, err error)
 	// GetAllUserID Get all user IDs
 	GetAllUserID(ctx context.Context, pageNumber, showNumber int32) ([]string, error)
+	// FindUserIDsAfter Get the user IDs matching filter after lastUserID in user ID order
+	FindUserIDsAfter(ctx context.Context, filter *relation.UserFilter, lastUserID string, limit int) ([]string, error)
+	// CountByFilter Get the number of users matching filter
+	CountByFilter(ctx context.Context, filter *relation.UserFilter) (int64, error)
+	// Search Get the users matching filter, newest first
+	Search(ctx context.Context, filter *relation.UserSearchFilter, pageNumber, showNumber int32) (users []*relation.UserModel, count int64, err error)
 	// InitOnce Inside the function, first query whether it exists in the db, if it exists, do nothing; if it does not exist, insert it
 	InitOnce(ctx context.Context, users []*relation.UserModel) (err error)
 	// CountTotal Get the total number of users
@@ -61,6 +69,8 @@ type UserDatabase interface {
 	GetAllSubscribeList(ctx context.Context, userID string) ([]string, error)
 	// GetSubscribedList Get all subscribed lists
 	GetSubscribedList(ctx context.Context, userID string) ([]string, error)
+	// DeleteSubscribeList Delete the subscriptions of the user and remove the user from the others' lists
+	DeleteSubscribeList(ctx context.Context, userID string) (int64, error)
 	// GetUserStatus Get the online status of the user
 	GetUserStatus(ctx context.Context, userIDs []string) ([]*user.OnlineStatus, error)
 	// SetUserStatus Set the user status and store the user status in redis
@@ -145,6 +155,19 @@ func (u *userDatabase) UpdateByMap(ctx context.Context, userID string, args map[
 	return u.cache.DelUsersInfo(userID).ExecDel(ctx)
 }
 
+// Delete delete the users, no error is returned if the userID is not found.
+func (u *userDatabase) Delete(ctx context.Context, userIDs []string) (err error) {
+	if err := u.userDB.Delete(ctx, userIDs); err != nil {
+		return err
+	}
+	return u.cache.DelUsersInfo(userIDs...).DelUsersGlobalRecvMsgOpt(userIDs...).ExecDel(ctx)
+}
+
+// Search Get the users matching filter, newest first.
+func (u *userDatabase) Search(ctx context.Context, filter *relation.UserSearchFilter, pageNumber, showNumber int32) (users []*relation.UserModel, count int64, err error) {
+	return u.userDB.Search(ctx, filter, pageNumber, showNumber)
+}
+
 // Page Gets, returns no error if not found.
 func (u *userDatabase) Page(
 	ctx context.Context,
@@ -170,6 +193,16 @@ func (u *userDatabase) GetAllUserID(ctx context.Context, pageNumber, showNumber
 	return u.userDB.GetAllUserID(ctx, pageNumber, showNumber)
 }
 
+// FindUserIDsAfter Get the user IDs matching filter after lastUserID in user ID order.
+func (u *userDatabase) FindUserIDsAfter(ctx context.Context, filter *relation.UserFilter, lastUserID string, limit int) ([]string, error) {
+	return u.userDB.FindUserIDsAfter(ctx, filter, lastUserID, limit)
+}
+
+// CountByFilter Get the number of users matching filter.
+func (u *userDatabase) CountByFilter(ctx context.Context, filter *relation.UserFilter) (int64, error) {
+	return u.userDB.CountByFilter(ctx, filter)
+}
+
 // CountTotal Get the total number of users.
 func (u *userDatabase) CountTotal(ctx context.Context, before *time.Time) (count int64, err error) {
 	return u.userDB.CountTotal(ctx, before)
@@ -210,6 +243,11 @@ func (u *userDatabase) GetSubscribedList(ctx context.Context, userID string) ([]
 	return list, nil
 }
 
+// DeleteSubscribeList Delete the subscriptions of the user and remove the user from the others' lists.
+func (u *userDatabase) DeleteSubscribeList(ctx context.Context, userID string) (int64, error) {
+	return u.mongoDB.DeleteUser(ctx, userID)
+}
+
 // GetUserStatus get user status.
 func (u *userDatabase) GetUserStatus(ctx context.Context, userIDs []string) ([]*user.OnlineStatus, error) {
 	onlineStatusList, err := u.cache.GetUserStatus(ctx, userIDs)
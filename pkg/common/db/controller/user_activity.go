@@ -0,0 +1,87 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/utils"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
+)
+
+// userActivityTouchInterval 活跃时间只用于按天判断不活跃, 每个用户每小时最多写入一次.
+const userActivityTouchInterval = time.Hour
+
+type UserActivityDatabase interface {
+	// Touch 记录用户登录或发送消息
+	Touch(ctx context.Context, userID string) error
+	// FindLastActiveTimes 返回用户最近活跃时间, 没有记录的用户以当前时间补录,
+	// 避免上线前已存在的用户被当作从未活跃
+	FindLastActiveTimes(ctx context.Context, userIDs []string) (map[string]time.Time, error)
+}
+
+func NewUserActivityDatabase(activityDB unRelationTb.UserActivityModelInterface, cache cache.UserActivityCache) UserActivityDatabase {
+	return &userActivityDatabase{activityDB: activityDB, cache: cache}
+}
+
+func InitUserActivityDatabase(rdb redis.UniversalClient, database *mongo.Database) UserActivityDatabase {
+	return NewUserActivityDatabase(unrelation.NewUserActivityMongoDriver(database), cache.NewUserActivityCacheRedis(rdb))
+}
+
+type userActivityDatabase struct {
+	activityDB unRelationTb.UserActivityModelInterface
+	cache      cache.UserActivityCache
+}
+
+func (u *userActivityDatabase) Touch(ctx context.Context, userID string) error {
+	ok, err := u.cache.AcquireUserActivityTouch(ctx, userID, userActivityTouchInterval)
+	if err != nil || !ok {
+		return err
+	}
+	return u.activityDB.Touch(ctx, []string{userID}, time.Now())
+}
+
+func (u *userActivityDatabase) FindLastActiveTimes(ctx context.Context, userIDs []string) (map[string]time.Time, error) {
+	activities, err := u.activityDB.Find(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	lastActiveTimes := make(map[string]time.Time, len(userIDs))
+	for _, activity := range activities {
+		lastActiveTimes[activity.UserID] = activity.LastActiveTime
+	}
+	var missing []string
+	for _, userID := range utils.Distinct(userIDs) {
+		if _, ok := lastActiveTimes[userID]; !ok {
+			missing = append(missing, userID)
+		}
+	}
+	if len(missing) > 0 {
+		now := time.Now()
+		if err := u.activityDB.Touch(ctx, missing, now); err != nil {
+			return nil, err
+		}
+		for _, userID := range missing {
+			lastActiveTimes[userID] = now
+		}
+	}
+	return lastActiveTimes, nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type UserEraseDatabase interface {
+	// CreateJob 创建用户数据删除任务
+	CreateJob(ctx context.Context, job *unRelationTb.UserEraseJobModel) error
+	// TakeJob 获取任务 不存在返回错误
+	TakeJob(ctx context.Context, jobID string) (*unRelationTb.UserEraseJobModel, error)
+	// TakeUnfinishedJob 获取用户未结束的任务, 没有返回nil
+	TakeUnfinishedJob(ctx context.Context, userID string) (*unRelationTb.UserEraseJobModel, error)
+	// PageJobs 分页获取任务, userID和status为空获取全部
+	PageJobs(ctx context.Context, userID string, status []int32, pageNumber, showNumber int32) (int64, []*unRelationTb.UserEraseJobModel, error)
+	// ClaimJob 领取一个待执行或执行超时的任务, 没有任务返回nil
+	ClaimJob(ctx context.Context, staleTimeout time.Duration) (*unRelationTb.UserEraseJobModel, error)
+	// UpdateStep 记录步骤结果, 返回false表示任务已不在执行中
+	UpdateStep(ctx context.Context, jobID string, index int, step *unRelationTb.UserEraseStepModel) (bool, error)
+	// FinishJob 结束任务
+	FinishJob(ctx context.Context, jobID string, status int32, errMsg string) error
+}
+
+func NewUserEraseDatabase(jobDB unRelationTb.UserEraseJobModelInterface) UserEraseDatabase {
+	return &userEraseDatabase{jobDB: jobDB}
+}
+
+type userEraseDatabase struct {
+	jobDB unRelationTb.UserEraseJobModelInterface
+}
+
+func (u *userEraseDatabase) CreateJob(ctx context.Context, job *unRelationTb.UserEraseJobModel) error {
+	return u.jobDB.Create(ctx, job)
+}
+
+func (u *userEraseDatabase) TakeJob(ctx context.Context, jobID string) (*unRelationTb.UserEraseJobModel, error) {
+	return u.jobDB.Take(ctx, jobID)
+}
+
+func (u *userEraseDatabase) TakeUnfinishedJob(ctx context.Context, userID string) (*unRelationTb.UserEraseJobModel, error) {
+	return u.jobDB.TakeUnfinished(ctx, userID)
+}
+
+func (u *userEraseDatabase) PageJobs(ctx context.Context, userID string, status []int32, pageNumber, showNumber int32) (int64, []*unRelationTb.UserEraseJobModel, error) {
+	return u.jobDB.Page(ctx, userID, status, pageNumber, showNumber)
+}
+
+func (u *userEraseDatabase) ClaimJob(ctx context.Context, staleTimeout time.Duration) (*unRelationTb.UserEraseJobModel, error) {
+	return u.jobDB.Claim(ctx, time.Now().Add(-staleTimeout))
+}
+
+func (u *userEraseDatabase) UpdateStep(ctx context.Context, jobID string, index int, step *unRelationTb.UserEraseStepModel) (bool, error) {
+	return u.jobDB.UpdateStep(ctx, jobID, index, step)
+}
+
+func (u *userEraseDatabase) FinishJob(ctx context.Context, jobID string, status int32, errMsg string) error {
+	return u.jobDB.Finish(ctx, jobID, status, errMsg)
+}
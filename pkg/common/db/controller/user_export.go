@@ -0,0 +1,76 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type UserExportDatabase interface {
+	// CreateJob 创建用户数据导出任务
+	CreateJob(ctx context.Context, job *unRelationTb.UserExportJobModel) error
+	// TakeJob 获取任务 不存在返回错误
+	TakeJob(ctx context.Context, jobID string) (*unRelationTb.UserExportJobModel, error)
+	// PageJobs 分页获取任务, userID和status为空获取全部
+	PageJobs(ctx context.Context, userID string, status []int32, pageNumber, showNumber int32) (int64, []*unRelationTb.UserExportJobModel, error)
+	// ClaimJob 领取一个待执行或执行超时的任务, 没有任务返回nil
+	ClaimJob(ctx context.Context, staleTimeout time.Duration) (*unRelationTb.UserExportJobModel, error)
+	// Heartbeat 更新执行中任务的时间, 返回false表示任务已不在执行中
+	Heartbeat(ctx context.Context, jobID string) (bool, error)
+	// FinishJob 结束任务
+	FinishJob(ctx context.Context, jobID string, status int32, location string, counts map[string]int64, errMsg string) error
+}
+
+func NewUserExportDatabase(jobDB unRelationTb.UserExportJobModelInterface) UserExportDatabase {
+	return &userExportDatabase{jobDB: jobDB}
+}
+
+type userExportDatabase struct {
+	jobDB unRelationTb.UserExportJobModelInterface
+}
+
+func (u *userExportDatabase) CreateJob(ctx context.Context, job *unRelationTb.UserExportJobModel) error {
+	return u.jobDB.Create(ctx, job)
+}
+
+func (u *userExportDatabase) TakeJob(ctx context.Context, jobID string) (*unRelationTb.UserExportJobModel, error) {
+	return u.jobDB.Take(ctx, jobID)
+}
+
+func (u *userExportDatabase) PageJobs(ctx context.Context, userID string, status []int32, pageNumber, showNumber int32) (int64, []*unRelationTb.UserExportJobModel, error) {
+	return u.jobDB.Page(ctx, userID, status, pageNumber, showNumber)
+}
+
+func (u *userExportDatabase) ClaimJob(ctx context.Context, staleTimeout time.Duration) (*unRelationTb.UserExportJobModel, error) {
+	return u.jobDB.Claim(ctx, time.Now().Add(-staleTimeout))
+}
+
+func (u *userExportDatabase) Heartbeat(ctx context.Context, jobID string) (bool, error) {
+	return u.jobDB.Heartbeat(ctx, jobID)
+}
+
+func (u *userExportDatabase) FinishJob(
+	ctx context.Context,
+	jobID string,
+	status int32,
+	location string,
+	counts map[string]int64,
+	errMsg string,
+) error {
+	return u.jobDB.Finish(ctx, jobID, status, location, counts, errMsg)
+}
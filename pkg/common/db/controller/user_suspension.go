@@ -0,0 +1,140 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/cache"
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/unrelation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+)
+
+// UserSuspensionChecker 供登录、发消息、建群等校验封禁, 优先读取redis.
+type UserSuspensionChecker interface {
+	// CheckUserSuspension 用户被封禁了scope时返回errcode.ErrUserSuspended
+	CheckUserSuspension(ctx context.Context, userID string, scope string) error
+}
+
+func NewUserSuspensionChecker(cache cache.UserSuspensionCache) UserSuspensionChecker {
+	return &userSuspensionChecker{cache: cache}
+}
+
+func InitUserSuspensionChecker(rdb redis.UniversalClient, database *mongo.Database) UserSuspensionChecker {
+	return NewUserSuspensionChecker(cache.NewUserSuspensionCacheRedis(rdb, unrelation.NewUserSuspensionMongoDriver(database), cache.GetDefaultOpt()))
+}
+
+type userSuspensionChecker struct {
+	cache cache.UserSuspensionCache
+}
+
+func (u *userSuspensionChecker) CheckUserSuspension(ctx context.Context, userID string, scope string) error {
+	suspensions, err := u.cache.GetUserSuspensions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, suspension := range suspensions {
+		if !suspension.IsActive(now) || !suspension.HasScope(scope) {
+			continue
+		}
+		var endTime int64
+		if !suspension.EndTime.IsZero() {
+			endTime = suspension.EndTime.UnixMilli()
+		}
+		return errcode.ErrUserSuspended.Wrap(fmt.Sprintf("scope %s, reason %s, endTime %d", scope, suspension.Reason, endTime))
+	}
+	return nil
+}
+
+// UserSuspensionDatabase 封禁记录保存在mongo作为审计记录, 生效中的封禁缓存在redis.
+type UserSuspensionDatabase interface {
+	UserSuspensionChecker
+	// Suspend 创建封禁
+	Suspend(ctx context.Context, suspension *unRelationTb.UserSuspensionModel) error
+	// Lift 解除生效中的封禁, 封禁已不在生效中时返回false
+	Lift(ctx context.Context, suspension *unRelationTb.UserSuspensionModel, status int32, opUserID string, reason string) (bool, error)
+	// TakeSuspension 获取封禁 不存在返回错误
+	TakeSuspension(ctx context.Context, suspensionID string) (*unRelationTb.UserSuspensionModel, error)
+	// PageSuspensions 分页获取封禁记录, userID和status为空获取全部
+	PageSuspensions(ctx context.Context, userID string, status []int32, pageNumber, showNumber int32) (int64, []*unRelationTb.UserSuspensionModel, error)
+	// FindExpiredSuspensions 获取已到结束时间但还未解除的封禁
+	FindExpiredSuspensions(ctx context.Context, limit int) ([]*unRelationTb.UserSuspensionModel, error)
+}
+
+func NewUserSuspensionDatabase(suspensionDB unRelationTb.UserSuspensionModelInterface, cache cache.UserSuspensionCache) UserSuspensionDatabase {
+	return &userSuspensionDatabase{
+		UserSuspensionChecker: NewUserSuspensionChecker(cache),
+		suspensionDB:          suspensionDB,
+		cache:                 cache,
+	}
+}
+
+type userSuspensionDatabase struct {
+	UserSuspensionChecker
+	suspensionDB unRelationTb.UserSuspensionModelInterface
+	cache        cache.UserSuspensionCache
+}
+
+func (u *userSuspensionDatabase) Suspend(ctx context.Context, suspension *unRelationTb.UserSuspensionModel) error {
+	if err := u.suspensionDB.Create(ctx, suspension); err != nil {
+		return err
+	}
+	return u.cache.DelUserSuspensions(suspension.UserID).ExecDel(ctx)
+}
+
+func (u *userSuspensionDatabase) Lift(
+	ctx context.Context,
+	suspension *unRelationTb.UserSuspensionModel,
+	status int32,
+	opUserID string,
+	reason string,
+) (bool, error) {
+	liftTime := time.Now()
+	ok, err := u.suspensionDB.Lift(ctx, suspension.SuspensionID, status, opUserID, reason, liftTime)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		suspension.Status = status
+		suspension.LiftOpUserID = opUserID
+		suspension.LiftReason = reason
+		suspension.LiftTime = liftTime
+	}
+	return ok, u.cache.DelUserSuspensions(suspension.UserID).ExecDel(ctx)
+}
+
+func (u *userSuspensionDatabase) TakeSuspension(ctx context.Context, suspensionID string) (*unRelationTb.UserSuspensionModel, error) {
+	return u.suspensionDB.Take(ctx, suspensionID)
+}
+
+func (u *userSuspensionDatabase) PageSuspensions(
+	ctx context.Context,
+	userID string,
+	status []int32,
+	pageNumber, showNumber int32,
+) (int64, []*unRelationTb.UserSuspensionModel, error) {
+	return u.suspensionDB.Page(ctx, userID, status, pageNumber, showNumber)
+}
+
+func (u *userSuspensionDatabase) FindExpiredSuspensions(ctx context.Context, limit int) ([]*unRelationTb.UserSuspensionModel, error) {
+	return u.suspensionDB.FindExpired(ctx, time.Now(), limit)
+}
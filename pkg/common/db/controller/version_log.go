@@ -0,0 +1,162 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+// VersionChanges the elements of a list changed after a version.
+type VersionChanges struct {
+	// the version of the list after applying the changes
+	Version int64
+	// the changes can not be computed, the client should reload the whole list
+	FullSync bool
+	// there are more changes after Version
+	More bool
+	// an element inserted and then updated after the version is in Update
+	Insert []string
+	Update []string
+	Delete []string
+}
+
+type VersionLogDatabase interface {
+	// IncrVersion 每个did的版本号加一, 记录eids的变更
+	IncrVersion(ctx context.Context, domain string, dids []string, eids []string, state int32) error
+	// GetChanges 获取version之后已写完的变更, 最多约limit个(0不限制), version为0或日志已被截断时返回FullSync
+	GetChanges(ctx context.Context, domain string, did string, version int64, limit int) (*VersionChanges, error)
+	// GetVersions 获取当前版本号, 未变更过的did不返回
+	GetVersions(ctx context.Context, domain string, dids []string) (map[string]int64, error)
+	// TruncateDeleted 删除before之前的删除记录
+	TruncateDeleted(ctx context.Context, before time.Time) (int64, error)
+	// DeleteDIDs 删除did在各domain下的版本号及日志, 之后的同步均为FullSync
+	DeleteDIDs(ctx context.Context, domains []string, did string) error
+}
+
+// versionPendingTimeout 超过该时间仍未写完的版本视为写入失败.
+const versionPendingTimeout = time.Minute
+
+func NewVersionLogDatabase(versionLog unRelationTb.VersionLogModelInterface) VersionLogDatabase {
+	return &versionLogDatabase{versionLog: versionLog}
+}
+
+type versionLogDatabase struct {
+	versionLog unRelationTb.VersionLogModelInterface
+}
+
+func (v *versionLogDatabase) IncrVersion(ctx context.Context, domain string, dids []string, eids []string, state int32) error {
+	return v.versionLog.IncrVersion(ctx, domain, utils.Distinct(dids), utils.Distinct(eids), state)
+}
+
+func (v *versionLogDatabase) GetChanges(ctx context.Context, domain string, did string, version int64, limit int) (*VersionChanges, error) {
+	current, err := v.takeVersion(ctx, domain, did)
+	if err != nil {
+		return nil, err
+	}
+	visible := current.VisibleVersion()
+	changes := &VersionChanges{Version: visible, Insert: []string{}, Update: []string{}, Delete: []string{}}
+	// the elements existing before the log began are never logged, so version 0 always reloads
+	if version <= 0 || version > current.Version || version < current.TruncatedVersion {
+		changes.FullSync = true
+		return changes, nil
+	}
+	if version >= visible {
+		// the later versions are still being written
+		changes.Version = version
+		return changes, nil
+	}
+	logs, err := v.versionLog.FindLogsAfter(ctx, domain, did, version, visible, limit)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(logs) > 0 && len(logs) >= limit {
+		// a version is never split between two calls
+		last := logs[len(logs)-1].Version
+		atLast, err := v.versionLog.FindLogsAt(ctx, domain, did, last)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(utils.Filter(logs, func(e *unRelationTb.VersionLogModel) (*unRelationTb.VersionLogModel, bool) {
+			return e, e.Version < last
+		}), atLast...)
+		changes.Version = last
+		changes.More = true
+	}
+	for _, log := range logs {
+		if !changes.More && log.Version > changes.Version {
+			changes.Version = log.Version
+		}
+		switch log.State {
+		case unRelationTb.VersionStateInsert:
+			changes.Insert = append(changes.Insert, log.EID)
+		case unRelationTb.VersionStateUpdate:
+			changes.Update = append(changes.Update, log.EID)
+		case unRelationTb.VersionStateDelete:
+			changes.Delete = append(changes.Delete, log.EID)
+		}
+	}
+	return changes, nil
+}
+
+// takeVersion 获取did的版本号, 先截断写入超时的版本.
+func (v *versionLogDatabase) takeVersion(ctx context.Context, domain string, did string) (*unRelationTb.VersionModel, error) {
+	versions, err := v.versionLog.FindVersions(ctx, domain, []string{did})
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return &unRelationTb.VersionModel{Domain: domain, DID: did}, nil
+	}
+	before := time.Now().Add(-versionPendingTimeout)
+	for _, pending := range versions[0].Pending {
+		if pending.StartTime.Before(before) {
+			if err := v.versionLog.ExpirePending(ctx, domain, did, before); err != nil {
+				return nil, err
+			}
+			return v.takeVersion(ctx, domain, did)
+		}
+	}
+	return versions[0], nil
+}
+
+func (v *versionLogDatabase) GetVersions(ctx context.Context, domain string, dids []string) (map[string]int64, error) {
+	versions, err := v.versionLog.FindVersions(ctx, domain, dids)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]int64, len(versions))
+	for _, version := range versions {
+		m[version.DID] = version.VisibleVersion()
+	}
+	return m, nil
+}
+
+func (v *versionLogDatabase) TruncateDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return v.versionLog.TruncateDeleted(ctx, before)
+}
+
+func (v *versionLogDatabase) DeleteDIDs(ctx context.Context, domains []string, did string) error {
+	for _, domain := range domains {
+		if err := v.versionLog.DeleteDID(ctx, domain, did); err != nil {
+			return err
+		}
+	}
+	return nil
+}
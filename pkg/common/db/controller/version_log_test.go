@@ -0,0 +1,199 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	unRelationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+// fakeVersionLog an in-memory VersionLogModelInterface of a single domain.
+type fakeVersionLog struct {
+	versions map[string]*unRelationTb.VersionModel
+	logs     []*unRelationTb.VersionLogModel
+}
+
+func newFakeVersionLog() *fakeVersionLog {
+	return &fakeVersionLog{versions: make(map[string]*unRelationTb.VersionModel)}
+}
+
+func (f *fakeVersionLog) IncrVersion(ctx context.Context, domain string, dids []string, eids []string, state int32) error {
+	for _, did := range dids {
+		version, ok := f.versions[did]
+		if !ok {
+			version = &unRelationTb.VersionModel{Domain: domain, DID: did}
+			f.versions[did] = version
+		}
+		version.Version++
+		for _, eid := range eids {
+			f.setLog(&unRelationTb.VersionLogModel{Domain: domain, DID: did, EID: eid, State: state, Version: version.Version, UpdateTime: time.Now()})
+		}
+	}
+	return nil
+}
+
+func (f *fakeVersionLog) setLog(log *unRelationTb.VersionLogModel) {
+	for i, e := range f.logs {
+		if e.DID == log.DID && e.EID == log.EID {
+			f.logs[i] = log
+			return
+		}
+	}
+	f.logs = append(f.logs, log)
+}
+
+func (f *fakeVersionLog) FindVersions(ctx context.Context, domain string, dids []string) ([]*unRelationTb.VersionModel, error) {
+	var versions []*unRelationTb.VersionModel
+	for _, did := range dids {
+		if version, ok := f.versions[did]; ok {
+			copied := *version
+			versions = append(versions, &copied)
+		}
+	}
+	return versions, nil
+}
+
+func (f *fakeVersionLog) FindLogsAfter(ctx context.Context, domain string, did string, version int64, until int64, limit int) ([]*unRelationTb.VersionLogModel, error) {
+	var logs []*unRelationTb.VersionLogModel
+	for _, log := range f.logs {
+		if log.DID == did && log.Version > version && log.Version <= until {
+			logs = append(logs, log)
+		}
+	}
+	sort.SliceStable(logs, func(i, j int) bool { return logs[i].Version < logs[j].Version })
+	if limit > 0 && len(logs) > limit {
+		logs = logs[:limit]
+	}
+	return logs, nil
+}
+
+func (f *fakeVersionLog) FindLogsAt(ctx context.Context, domain string, did string, version int64) ([]*unRelationTb.VersionLogModel, error) {
+	return f.FindLogsAfter(ctx, domain, did, version-1, version, 0)
+}
+
+func (f *fakeVersionLog) ExpirePending(ctx context.Context, domain string, did string, before time.Time) error {
+	version, ok := f.versions[did]
+	if !ok {
+		return nil
+	}
+	var pending []*unRelationTb.VersionPending
+	for _, p := range version.Pending {
+		if p.StartTime.Before(before) {
+			if p.Version > version.TruncatedVersion {
+				version.TruncatedVersion = p.Version
+			}
+			continue
+		}
+		pending = append(pending, p)
+	}
+	version.Pending = pending
+	return nil
+}
+
+func (f *fakeVersionLog) TruncateDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeVersionLog) DeleteDID(ctx context.Context, domain string, did string) error {
+	delete(f.versions, did)
+	return nil
+}
+
+func Test_GetChanges(t *testing.T) {
+	ctx := context.Background()
+	domain := unRelationTb.VersionDomainFriend
+	versionLog := newFakeVersionLog()
+	// did1: a inserted at 1, b inserted at 2, a updated at 3, c inserted at 4, b deleted at 5
+	for _, change := range []struct {
+		eid   string
+		state int32
+	}{
+		{"a", unRelationTb.VersionStateInsert},
+		{"b", unRelationTb.VersionStateInsert},
+		{"a", unRelationTb.VersionStateUpdate},
+		{"c", unRelationTb.VersionStateInsert},
+		{"b", unRelationTb.VersionStateDelete},
+	} {
+		assert.Nil(t, versionLog.IncrVersion(ctx, domain, []string{"did1"}, []string{change.eid}, change.state))
+	}
+	// did2: version 3 is still being written
+	versionLog.IncrVersion(ctx, domain, []string{"did2"}, []string{"a"}, unRelationTb.VersionStateInsert)
+	versionLog.IncrVersion(ctx, domain, []string{"did2"}, []string{"b"}, unRelationTb.VersionStateInsert)
+	versionLog.versions["did2"].Version = 3
+	versionLog.versions["did2"].Pending = []*unRelationTb.VersionPending{{Token: "t1", Version: 3, StartTime: time.Now()}}
+	// did3: version 3 started too long ago, its writer is gone
+	versionLog.IncrVersion(ctx, domain, []string{"did3"}, []string{"a"}, unRelationTb.VersionStateInsert)
+	versionLog.IncrVersion(ctx, domain, []string{"did3"}, []string{"b"}, unRelationTb.VersionStateInsert)
+	versionLog.versions["did3"].Version = 3
+	versionLog.versions["did3"].Pending = []*unRelationTb.VersionPending{{Token: "t2", Version: 3, StartTime: time.Now().Add(-2 * versionPendingTimeout)}}
+	// did4: deletions not after 2 are truncated
+	versionLog.IncrVersion(ctx, domain, []string{"did4"}, []string{"a"}, unRelationTb.VersionStateInsert)
+	versionLog.IncrVersion(ctx, domain, []string{"did4"}, []string{"b"}, unRelationTb.VersionStateInsert)
+	versionLog.IncrVersion(ctx, domain, []string{"did4"}, []string{"c"}, unRelationTb.VersionStateInsert)
+	versionLog.versions["did4"].TruncatedVersion = 2
+
+	tests := []struct {
+		name     string
+		did      string
+		version  int64
+		limit    int
+		expected *VersionChanges
+	}{
+		{"first sync", "did1", 0, 0, &VersionChanges{Version: 5, FullSync: true}},
+		{"version from the future", "did1", 6, 0, &VersionChanges{Version: 5, FullSync: true}},
+		{"never changed", "none", 1, 0, &VersionChanges{Version: 0, FullSync: true}},
+		{"up to date", "did1", 5, 0, &VersionChanges{Version: 5}},
+		{"all changes", "did1", 2, 0, &VersionChanges{Version: 5, Insert: []string{"c"}, Update: []string{"a"}, Delete: []string{"b"}}},
+		{"limited", "did1", 3, 1, &VersionChanges{Version: 4, More: true, Insert: []string{"c"}}},
+		{"pending version hidden", "did2", 1, 0, &VersionChanges{Version: 2, Insert: []string{"b"}}},
+		{"pending version only", "did2", 2, 0, &VersionChanges{Version: 2}},
+		{"stale pending truncated", "did3", 2, 0, &VersionChanges{Version: 3, FullSync: true}},
+		{"before truncated", "did4", 1, 0, &VersionChanges{Version: 3, FullSync: true}},
+		{"at truncated", "did4", 2, 0, &VersionChanges{Version: 3, Insert: []string{"c"}}},
+	}
+	db := NewVersionLogDatabase(versionLog)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := db.GetChanges(ctx, domain, test.did, test.version, test.limit)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected.Version, changes.Version)
+			assert.Equal(t, test.expected.FullSync, changes.FullSync)
+			assert.Equal(t, test.expected.More, changes.More)
+			if !test.expected.FullSync {
+				assert.ElementsMatch(t, test.expected.Insert, changes.Insert)
+				assert.ElementsMatch(t, test.expected.Update, changes.Update)
+				assert.ElementsMatch(t, test.expected.Delete, changes.Delete)
+			}
+		})
+	}
+}
+
+func Test_GetVersions(t *testing.T) {
+	ctx := context.Background()
+	domain := unRelationTb.VersionDomainFriend
+	versionLog := newFakeVersionLog()
+	versionLog.IncrVersion(ctx, domain, []string{"did1", "did2"}, []string{"a"}, unRelationTb.VersionStateInsert)
+	versionLog.IncrVersion(ctx, domain, []string{"did2"}, []string{"b"}, unRelationTb.VersionStateInsert)
+	versionLog.versions["did2"].Pending = []*unRelationTb.VersionPending{{Token: "t1", Version: 2, StartTime: time.Now()}}
+	versions, err := NewVersionLogDatabase(versionLog).GetVersions(ctx, domain, []string{"did1", "did2", "did3"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"did1": 1, "did2": 1}, versions)
+}
@@ -17,6 +17,7 @@ package localcache
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/OpenIMSDK/protocol/group"
 	"github.com/OpenIMSDK/tools/errs"
@@ -24,10 +25,17 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
+const (
+	groupTypeCacheSize = 10000
+	groupTypeCacheTTL  = time.Hour
+)
+
 type GroupLocalCache struct {
 	lock   sync.Mutex
 	cache  map[string]GroupMemberIDsHash
 	client *rpcclient.GroupRpcClient
+	// groupTypes 群类型创建后不会改变, 解散的群随淘汰或过期移出
+	groupTypes *lruCache[string, int32]
 }
 
 type GroupMemberIDsHash struct {
@@ -37,8 +45,9 @@ type GroupMemberIDsHash struct {
 
 func NewGroupLocalCache(client *rpcclient.GroupRpcClient) *GroupLocalCache {
 	return &GroupLocalCache{
-		cache:  make(map[string]GroupMemberIDsHash, 0),
-		client: client,
+		cache:      make(map[string]GroupMemberIDsHash, 0),
+		client:     client,
+		groupTypes: newLRUCache[string, int32](groupTypeCacheSize, groupTypeCacheTTL),
 	}
 }
 
@@ -70,3 +79,15 @@ func (g *GroupLocalCache) GetGroupMemberIDs(ctx context.Context, groupID string)
 	}
 	return g.cache[groupID].userIDs, nil
 }
+
+func (g *GroupLocalCache) GetGroupType(ctx context.Context, groupID string) (int32, error) {
+	if groupType, ok := g.groupTypes.Get(groupID); ok {
+		return groupType, nil
+	}
+	groupInfo, err := g.client.GetGroupInfoCache(ctx, groupID)
+	if err != nil {
+		return 0, err
+	}
+	g.groupTypes.Set(groupID, groupInfo.GroupType)
+	return groupInfo.GroupType, nil
+}
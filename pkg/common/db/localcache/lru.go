@@ -0,0 +1,89 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lruCache 本地缓存, 超过容量时淘汰最久未使用的, 过期后重新查询.
+type lruCache[K comparable, V any] struct {
+	lock     sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[K]*list.Element
+	order    *list.List
+}
+
+type lruEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	expireTime time.Time
+}
+
+func newLRUCache[K comparable, V any](capacity int, ttl time.Duration) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (l *lruCache[K, V]) Get(key K) (V, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	elem, ok := l.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	entry := elem.Value.(*lruEntry[K, V])
+	if time.Now().After(entry.expireTime) {
+		l.order.Remove(elem)
+		delete(l.items, key)
+		var zero V
+		return zero, false
+	}
+	l.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (l *lruCache[K, V]) Set(key K, value V) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	expireTime := time.Now().Add(l.ttl)
+	if elem, ok := l.items[key]; ok {
+		entry := elem.Value.(*lruEntry[K, V])
+		entry.value = value
+		entry.expireTime = expireTime
+		l.order.MoveToFront(elem)
+		return
+	}
+	l.items[key] = l.order.PushFront(&lruEntry[K, V]{key: key, value: value, expireTime: expireTime})
+	for l.order.Len() > l.capacity {
+		elem := l.order.Back()
+		l.order.Remove(elem)
+		delete(l.items, elem.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+func (l *lruCache[K, V]) Len() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.order.Len()
+}
@@ -0,0 +1,46 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LRUCacheEvict(t *testing.T) {
+	cache := newLRUCache[string, int32](2, time.Hour)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+	cache.Set("c", 3)
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, int32(1), value)
+}
+
+func Test_LRUCacheExpire(t *testing.T) {
+	cache := newLRUCache[string, int32](2, time.Millisecond)
+	cache.Set("a", 1)
+	time.Sleep(time.Millisecond * 5)
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
@@ -0,0 +1,49 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type AddFriendPolicyGorm struct {
+	*MetaDB
+}
+
+func NewAddFriendPolicyDB(db *gorm.DB) relation.AddFriendPolicyModelInterface {
+	return &AddFriendPolicyGorm{NewMetaDB(db, &relation.AddFriendPolicyModel{})}
+}
+
+func (a *AddFriendPolicyGorm) Set(ctx context.Context, policy *relation.AddFriendPolicyModel) (err error) {
+	return utils.Wrap(
+		a.db(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"policy", "update_time"}),
+		}).Create(policy).Error,
+		"",
+	)
+}
+
+func (a *AddFriendPolicyGorm) Take(ctx context.Context, userID string) (policy *relation.AddFriendPolicyModel, err error) {
+	policy = &relation.AddFriendPolicyModel{}
+	return policy, utils.Wrap(a.db(ctx).Where("user_id = ?", userID).Take(policy).Error, "")
+}
@@ -103,3 +103,15 @@ func (b *BlackGorm) FindBlackUserIDs(ctx context.Context, ownerUserID string) (b
 		"",
 	)
 }
+
+func (b *BlackGorm) FindOwnerUserIDs(ctx context.Context, blockUserID string) (ownerUserIDs []string, err error) {
+	return ownerUserIDs, utils.Wrap(
+		b.db(ctx).Where("block_user_id = ?", blockUserID).Pluck("owner_user_id", &ownerUserIDs).Error,
+		"",
+	)
+}
+
+func (b *BlackGorm) DeleteByUser(ctx context.Context, userID string) (count int64, err error) {
+	db := b.db(ctx).Where("owner_user_id = ? or block_user_id = ?", userID, userID).Delete(&relation.BlackModel{})
+	return db.RowsAffected, utils.Wrap(db.Error, "")
+}
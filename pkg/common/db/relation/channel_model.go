@@ -0,0 +1,167 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type ChannelGorm struct {
+	*MetaDB
+}
+
+func NewChannelDB(db *gorm.DB) relation.ChannelModelInterface {
+	return &ChannelGorm{NewMetaDB(db, &relation.ChannelModel{})}
+}
+
+func (c *ChannelGorm) NewTx(tx any) relation.ChannelModelInterface {
+	return &ChannelGorm{NewMetaDB(tx.(*gorm.DB), &relation.ChannelModel{})}
+}
+
+func (c *ChannelGorm) Create(ctx context.Context, channels []*relation.ChannelModel) (err error) {
+	return utils.Wrap(c.db(ctx).Create(&channels).Error, "")
+}
+
+func (c *ChannelGorm) Take(ctx context.Context, groupID string) (channel *relation.ChannelModel, err error) {
+	channel = &relation.ChannelModel{}
+	return channel, utils.Wrap(c.db(ctx).Where("group_id = ?", groupID).Take(channel).Error, "")
+}
+
+func (c *ChannelGorm) Find(ctx context.Context, groupIDs []string) (channels []*relation.ChannelModel, err error) {
+	return channels, utils.Wrap(c.db(ctx).Where("group_id in ?", groupIDs).Find(&channels).Error, "")
+}
+
+func (c *ChannelGorm) UpdatePublic(ctx context.Context, groupID string, isPublic bool) (err error) {
+	return utils.Wrap(c.db(ctx).Where("group_id = ?", groupID).Update("is_public", isPublic).Error, "")
+}
+
+func (c *ChannelGorm) IncrSubscriberCount(ctx context.Context, groupID string, delta int64) (err error) {
+	return utils.Wrap(
+		c.db(ctx).
+			Where("group_id = ?", groupID).
+			UpdateColumn("subscriber_count", gorm.Expr("subscriber_count + ?", delta)).
+			Error,
+		"",
+	)
+}
+
+func (c *ChannelGorm) SearchPublic(
+	ctx context.Context,
+	keyword string,
+	pageNumber, showNumber int32,
+) (total int64, channels []*relation.ChannelModel, err error) {
+	db := c.db(ctx).
+		Joins("JOIN "+relation.GroupModelTableName+" ON "+relation.GroupModelTableName+".group_id = "+relation.ChannelModelTableName+".group_id").
+		Where(relation.ChannelModelTableName+".is_public = ?", true).
+		Where(relation.GroupModelTableName+".status <> ?", constant.GroupStatusDismissed)
+	if keyword != "" {
+		db = db.Where(relation.GroupModelTableName+".name like ?", "%"+keyword+"%")
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, utils.Wrap(err, "")
+	}
+	err = db.Select(relation.ChannelModelTableName + ".*").
+		Order(relation.ChannelModelTableName + ".subscriber_count desc").
+		Limit(int(showNumber)).
+		Offset(int((pageNumber - 1) * showNumber)).
+		Find(&channels).
+		Error
+	return total, channels, utils.Wrap(err, "")
+}
+
+type ChannelSubscriberGorm struct {
+	*MetaDB
+}
+
+func NewChannelSubscriberDB(db *gorm.DB) relation.ChannelSubscriberModelInterface {
+	return &ChannelSubscriberGorm{NewMetaDB(db, &relation.ChannelSubscriberModel{})}
+}
+
+func (c *ChannelSubscriberGorm) NewTx(tx any) relation.ChannelSubscriberModelInterface {
+	return &ChannelSubscriberGorm{NewMetaDB(tx.(*gorm.DB), &relation.ChannelSubscriberModel{})}
+}
+
+func (c *ChannelSubscriberGorm) Create(ctx context.Context, subscribers []*relation.ChannelSubscriberModel) (err error) {
+	return utils.Wrap(c.db(ctx).Create(&subscribers).Error, "")
+}
+
+func (c *ChannelSubscriberGorm) Delete(ctx context.Context, groupID string, userIDs []string) (count int64, err error) {
+	res := c.db(ctx).Where("group_id = ? and user_id in ?", groupID, userIDs).Delete(&relation.ChannelSubscriberModel{})
+	return res.RowsAffected, utils.Wrap(res.Error, "")
+}
+
+func (c *ChannelSubscriberGorm) DeleteGroup(ctx context.Context, groupIDs []string) (err error) {
+	return utils.Wrap(c.db(ctx).Where("group_id in ?", groupIDs).Delete(&relation.ChannelSubscriberModel{}).Error, "")
+}
+
+func (c *ChannelSubscriberGorm) Take(
+	ctx context.Context,
+	groupID string,
+	userID string,
+) (subscriber *relation.ChannelSubscriberModel, err error) {
+	subscriber = &relation.ChannelSubscriberModel{}
+	return subscriber, utils.Wrap(
+		c.db(ctx).Where("group_id = ? and user_id = ?", groupID, userID).Take(subscriber).Error,
+		"",
+	)
+}
+
+func (c *ChannelSubscriberGorm) FindUserIDs(
+	ctx context.Context,
+	groupID string,
+	afterUserID string,
+	limit int,
+) (userIDs []string, err error) {
+	return userIDs, utils.Wrap(
+		c.db(ctx).
+			Where("group_id = ? and user_id > ?", groupID, afterUserID).
+			Order("user_id").
+			Limit(limit).
+			Pluck("user_id", &userIDs).
+			Error,
+		"",
+	)
+}
+
+func (c *ChannelSubscriberGorm) PageByUser(
+	ctx context.Context,
+	userID string,
+	pageNumber, showNumber int32,
+) (total int64, subscribers []*relation.ChannelSubscriberModel, err error) {
+	db := c.db(ctx).Where("user_id = ?", userID)
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, utils.Wrap(err, "")
+	}
+	err = db.Order("subscribe_time desc").
+		Limit(int(showNumber)).
+		Offset(int((pageNumber - 1) * showNumber)).
+		Find(&subscribers).
+		Error
+	return total, subscribers, utils.Wrap(err, "")
+}
+
+func (c *ChannelSubscriberGorm) SetOpened(ctx context.Context, groupID string, userID string) (err error) {
+	return utils.Wrap(
+		c.db(ctx).Where("group_id = ? and user_id = ?", groupID, userID).Update("opened", true).Error,
+		"",
+	)
+}
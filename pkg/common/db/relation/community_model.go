@@ -0,0 +1,66 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type CommunityGroupGorm struct {
+	*MetaDB
+}
+
+func NewCommunityGroupDB(db *gorm.DB) relation.CommunityGroupModelInterface {
+	return &CommunityGroupGorm{NewMetaDB(db, &relation.CommunityGroupModel{})}
+}
+
+func (c *CommunityGroupGorm) NewTx(tx any) relation.CommunityGroupModelInterface {
+	return &CommunityGroupGorm{NewMetaDB(tx.(*gorm.DB), &relation.CommunityGroupModel{})}
+}
+
+func (c *CommunityGroupGorm) Create(ctx context.Context, groups []*relation.CommunityGroupModel) (err error) {
+	return utils.Wrap(c.db(ctx).Create(&groups).Error, "")
+}
+
+func (c *CommunityGroupGorm) Delete(ctx context.Context, communityID string, groupIDs []string) (err error) {
+	return utils.Wrap(
+		c.db(ctx).Where("community_id = ? and group_id in ?", communityID, groupIDs).Delete(&relation.CommunityGroupModel{}).Error,
+		"",
+	)
+}
+
+func (c *CommunityGroupGorm) DeleteGroup(ctx context.Context, groupIDs []string) (err error) {
+	return utils.Wrap(
+		c.db(ctx).Where("community_id in ? or group_id in ?", groupIDs, groupIDs).Delete(&relation.CommunityGroupModel{}).Error,
+		"",
+	)
+}
+
+func (c *CommunityGroupGorm) Find(ctx context.Context, communityID string) (groups []*relation.CommunityGroupModel, err error) {
+	return groups, utils.Wrap(
+		c.db(ctx).Where("community_id = ?", communityID).Order("create_time").Find(&groups).Error,
+		"",
+	)
+}
+
+func (c *CommunityGroupGorm) FindByGroupIDs(ctx context.Context, groupIDs []string) (groups []*relation.CommunityGroupModel, err error) {
+	return groups, utils.Wrap(c.db(ctx).Where("group_id in ?", groupIDs).Find(&groups).Error, "")
+}
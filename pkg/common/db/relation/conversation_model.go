@@ -45,6 +45,11 @@ func (c *ConversationGorm) Delete(ctx context.Context, groupIDs []string) (err e
 	return utils.Wrap(c.db(ctx).Where("group_id in (?)", groupIDs).Delete(&relation.ConversationModel{}).Error, "")
 }
 
+func (c *ConversationGorm) DeleteByOwner(ctx context.Context, ownerUserID string) (count int64, err error) {
+	result := c.db(ctx).Where("owner_user_id = ?", ownerUserID).Delete(&relation.ConversationModel{})
+	return result.RowsAffected, utils.Wrap(result.Error, "")
+}
+
 func (c *ConversationGorm) UpdateByMap(
 	ctx context.Context,
 	userIDList []string,
@@ -214,3 +219,23 @@ func (c *ConversationGorm) GetConversationIDsNeedDestruct(
 		"",
 	)
 }
+
+func (c *ConversationGorm) FindMuteUntil(
+	ctx context.Context,
+	conversationID string,
+	after int64,
+) (map[string]int64, error) {
+	var conversations []*relation.ConversationModel
+	if err := c.db(ctx).
+		Where("conversation_id = ? and mute_until > ?", conversationID, after).
+		Select("owner_user_id", "mute_until").
+		Find(&conversations).
+		Error; err != nil {
+		return nil, utils.Wrap(err, "")
+	}
+	muteUntil := make(map[string]int64, len(conversations))
+	for _, conversation := range conversations {
+		muteUntil[conversation.OwnerUserID] = conversation.MuteUntil
+	}
+	return muteUntil, nil
+}
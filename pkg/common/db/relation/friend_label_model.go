@@ -0,0 +1,147 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type FriendLabelGorm struct {
+	*MetaDB
+}
+
+func NewFriendLabelDB(db *gorm.DB) relation.FriendLabelModelInterface {
+	return &FriendLabelGorm{NewMetaDB(db, &relation.FriendLabelModel{})}
+}
+
+func (f *FriendLabelGorm) NewTx(tx any) relation.FriendLabelModelInterface {
+	return &FriendLabelGorm{NewMetaDB(tx.(*gorm.DB), &relation.FriendLabelModel{})}
+}
+
+func (f *FriendLabelGorm) Create(ctx context.Context, labels []*relation.FriendLabelModel) (err error) {
+	return utils.Wrap(f.db(ctx).Create(&labels).Error, "")
+}
+
+func (f *FriendLabelGorm) Update(ctx context.Context, ownerUserID string, labelID string, data map[string]any) (err error) {
+	return utils.Wrap(
+		f.db(ctx).Where("owner_user_id = ? and label_id = ?", ownerUserID, labelID).Updates(data).Error,
+		"",
+	)
+}
+
+func (f *FriendLabelGorm) Delete(ctx context.Context, ownerUserID string, labelIDs []string) (err error) {
+	return utils.Wrap(
+		f.db(ctx).Where("owner_user_id = ? and label_id in ?", ownerUserID, labelIDs).Delete(&relation.FriendLabelModel{}).Error,
+		"",
+	)
+}
+
+func (f *FriendLabelGorm) Take(ctx context.Context, ownerUserID string, labelID string) (label *relation.FriendLabelModel, err error) {
+	label = &relation.FriendLabelModel{}
+	return label, utils.Wrap(
+		f.db(ctx).Where("owner_user_id = ? and label_id = ?", ownerUserID, labelID).Take(label).Error,
+		"",
+	)
+}
+
+func (f *FriendLabelGorm) Find(ctx context.Context, ownerUserID string) (labels []*relation.FriendLabelModel, err error) {
+	return labels, utils.Wrap(
+		f.db(ctx).Where("owner_user_id = ?", ownerUserID).Order("sort").Order("create_time").Find(&labels).Error,
+		"",
+	)
+}
+
+func (f *FriendLabelGorm) DeleteOwner(ctx context.Context, ownerUserID string) (count int64, err error) {
+	db := f.db(ctx).Where("owner_user_id = ?", ownerUserID).Delete(&relation.FriendLabelModel{})
+	return db.RowsAffected, utils.Wrap(db.Error, "")
+}
+
+type FriendLabelMemberGorm struct {
+	*MetaDB
+}
+
+func NewFriendLabelMemberDB(db *gorm.DB) relation.FriendLabelMemberModelInterface {
+	return &FriendLabelMemberGorm{NewMetaDB(db, &relation.FriendLabelMemberModel{})}
+}
+
+func (f *FriendLabelMemberGorm) NewTx(tx any) relation.FriendLabelMemberModelInterface {
+	return &FriendLabelMemberGorm{NewMetaDB(tx.(*gorm.DB), &relation.FriendLabelMemberModel{})}
+}
+
+func (f *FriendLabelMemberGorm) Create(ctx context.Context, members []*relation.FriendLabelMemberModel) (err error) {
+	return utils.Wrap(f.db(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&members).Error, "")
+}
+
+func (f *FriendLabelMemberGorm) Delete(ctx context.Context, ownerUserID string, labelID string, friendUserIDs []string) (err error) {
+	db := f.db(ctx).Where("owner_user_id = ? and label_id = ?", ownerUserID, labelID)
+	if len(friendUserIDs) > 0 {
+		db = db.Where("friend_user_id in ?", friendUserIDs)
+	}
+	return utils.Wrap(db.Delete(&relation.FriendLabelMemberModel{}).Error, "")
+}
+
+func (f *FriendLabelMemberGorm) DeleteFriends(ctx context.Context, ownerUserID string, friendUserIDs []string) (err error) {
+	return utils.Wrap(
+		f.db(ctx).
+			Where("owner_user_id = ? and friend_user_id in ?", ownerUserID, friendUserIDs).
+			Delete(&relation.FriendLabelMemberModel{}).
+			Error,
+		"",
+	)
+}
+
+func (f *FriendLabelMemberGorm) Find(
+	ctx context.Context,
+	ownerUserID string,
+	friendUserIDs []string,
+) (members []*relation.FriendLabelMemberModel, err error) {
+	db := f.db(ctx).Where("owner_user_id = ?", ownerUserID)
+	if len(friendUserIDs) > 0 {
+		db = db.Where("friend_user_id in ?", friendUserIDs)
+	}
+	return members, utils.Wrap(db.Find(&members).Error, "")
+}
+
+func (f *FriendLabelMemberGorm) PageFriendUserIDs(
+	ctx context.Context,
+	ownerUserID string,
+	labelID string,
+	pageNumber, showNumber int32,
+) (total int64, friendUserIDs []string, err error) {
+	db := f.db(ctx).Where("owner_user_id = ? and label_id = ?", ownerUserID, labelID)
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, utils.Wrap(err, "")
+	}
+	err = db.Order("create_time").
+		Limit(int(showNumber)).
+		Offset(int((pageNumber-1)*showNumber)).
+		Pluck("friend_user_id", &friendUserIDs).
+		Error
+	return total, friendUserIDs, utils.Wrap(err, "")
+}
+
+func (f *FriendLabelMemberGorm) DeleteUser(ctx context.Context, userID string) (count int64, err error) {
+	db := f.db(ctx).
+		Where("owner_user_id = ? or friend_user_id = ?", userID, userID).
+		Delete(&relation.FriendLabelMemberModel{})
+	return db.RowsAffected, utils.Wrap(db.Error, "")
+}
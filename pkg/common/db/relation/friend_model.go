@@ -191,3 +191,8 @@ func (f *FriendGorm) FindFriendUserIDs(ctx context.Context, ownerUserID string)
 		"",
 	)
 }
+
+// 获取多个ownerUserID的好友关系.
+func (f *FriendGorm) FindOwnersFriends(ctx context.Context, ownerUserIDs []string) (friends []*relation.FriendModel, err error) {
+	return friends, utils.Wrap(f.db(ctx).Where("owner_user_id in (?)", ownerUserIDs).Find(&friends).Error, "")
+}
@@ -16,6 +16,7 @@ package relation
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -162,3 +163,28 @@ func (f *FriendRequestGorm) FindBothFriendRequests(ctx context.Context, fromUser
 	)
 	return
 }
+
+// 获取userID发出或收到的未处理的好友申请.
+func (f *FriendRequestGorm) FindUnhandled(
+	ctx context.Context,
+	userID string,
+) (friendRequests []*relation.FriendRequestModel, err error) {
+	return friendRequests, utils.Wrap(
+		f.db(ctx).
+			Where("(from_user_id = ? or to_user_id = ?) and handle_result = ?", userID, userID, 0).
+			Find(&friendRequests).
+			Error,
+		"",
+	)
+}
+
+// 删除before之前发出的未处理的好友申请.
+func (f *FriendRequestGorm) DeleteUnhandledBefore(ctx context.Context, before time.Time) (count int64, err error) {
+	db := f.db(ctx).Where("handle_result = ? and create_time < ?", 0, before).Delete(&relation.FriendRequestModel{})
+	return db.RowsAffected, utils.Wrap(db.Error, "")
+}
+
+func (f *FriendRequestGorm) DeleteByUser(ctx context.Context, userID string) (count int64, err error) {
+	db := f.db(ctx).Where("from_user_id = ? or to_user_id = ?", userID, userID).Delete(&relation.FriendRequestModel{})
+	return db.RowsAffected, utils.Wrap(db.Error, "")
+}
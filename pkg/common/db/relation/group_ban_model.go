@@ -0,0 +1,81 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type GroupBanGorm struct {
+	*MetaDB
+}
+
+func NewGroupBanDB(db *gorm.DB) relation.GroupBanModelInterface {
+	return &GroupBanGorm{NewMetaDB(db, &relation.GroupBanModel{})}
+}
+
+func (g *GroupBanGorm) NewTx(tx any) relation.GroupBanModelInterface {
+	return &GroupBanGorm{NewMetaDB(tx.(*gorm.DB), &relation.GroupBanModel{})}
+}
+
+func (g *GroupBanGorm) Create(ctx context.Context, bans []*relation.GroupBanModel) (err error) {
+	return utils.Wrap(g.db(ctx).Create(&bans).Error, "")
+}
+
+func (g *GroupBanGorm) Delete(ctx context.Context, groupID string, userIDs []string) (err error) {
+	return utils.Wrap(
+		g.db(ctx).Where("group_id = ? and user_id in ?", groupID, userIDs).Delete(&relation.GroupBanModel{}).Error,
+		"",
+	)
+}
+
+func (g *GroupBanGorm) DeleteGroup(ctx context.Context, groupIDs []string) (err error) {
+	return utils.Wrap(g.db(ctx).Where("group_id in ?", groupIDs).Delete(&relation.GroupBanModel{}).Error, "")
+}
+
+func (g *GroupBanGorm) Take(ctx context.Context, groupID string, userID string) (ban *relation.GroupBanModel, err error) {
+	ban = &relation.GroupBanModel{}
+	return ban, utils.Wrap(g.db(ctx).Where("group_id = ? and user_id = ?", groupID, userID).Take(ban).Error, "")
+}
+
+func (g *GroupBanGorm) Find(ctx context.Context, groupID string, userIDs []string) (bans []*relation.GroupBanModel, err error) {
+	return bans, utils.Wrap(
+		g.db(ctx).Where("group_id = ? and user_id in ?", groupID, userIDs).Find(&bans).Error,
+		"",
+	)
+}
+
+func (g *GroupBanGorm) Page(
+	ctx context.Context,
+	groupID string,
+	pageNumber, showNumber int32,
+) (total int64, bans []*relation.GroupBanModel, err error) {
+	db := g.db(ctx).Where("group_id = ?", groupID)
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, utils.Wrap(err, "")
+	}
+	err = db.Order("create_time desc").
+		Limit(int(showNumber)).
+		Offset(int((pageNumber - 1) * showNumber)).
+		Find(&bans).
+		Error
+	return total, bans, utils.Wrap(err, "")
+}
@@ -0,0 +1,89 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type GroupInviteLinkGorm struct {
+	*MetaDB
+}
+
+func NewGroupInviteLinkDB(db *gorm.DB) relation.GroupInviteLinkModelInterface {
+	return &GroupInviteLinkGorm{NewMetaDB(db, &relation.GroupInviteLinkModel{})}
+}
+
+func (g *GroupInviteLinkGorm) NewTx(tx any) relation.GroupInviteLinkModelInterface {
+	return &GroupInviteLinkGorm{NewMetaDB(tx.(*gorm.DB), &relation.GroupInviteLinkModel{})}
+}
+
+func (g *GroupInviteLinkGorm) Create(ctx context.Context, links []*relation.GroupInviteLinkModel) (err error) {
+	return utils.Wrap(g.db(ctx).Create(&links).Error, "")
+}
+
+func (g *GroupInviteLinkGorm) Take(ctx context.Context, code string) (link *relation.GroupInviteLinkModel, err error) {
+	link = &relation.GroupInviteLinkModel{}
+	return link, utils.Wrap(g.db(ctx).Where("code = ?", code).Take(link).Error, "")
+}
+
+func (g *GroupInviteLinkGorm) Find(
+	ctx context.Context,
+	groupID string,
+	includeRevoked bool,
+) (links []*relation.GroupInviteLinkModel, err error) {
+	db := g.db(ctx).Where("group_id = ?", groupID)
+	if !includeRevoked {
+		db = db.Where("revoked = ?", false)
+	}
+	return links, utils.Wrap(db.Order("create_time desc").Find(&links).Error, "")
+}
+
+func (g *GroupInviteLinkGorm) Revoke(ctx context.Context, groupID string, codes []string) (err error) {
+	return utils.Wrap(
+		g.db(ctx).Where("group_id = ? and code in ?", groupID, codes).Update("revoked", true).Error,
+		"",
+	)
+}
+
+func (g *GroupInviteLinkGorm) RevokeGroup(ctx context.Context, groupIDs []string) (err error) {
+	return utils.Wrap(g.db(ctx).Where("group_id in ?", groupIDs).Update("revoked", true).Error, "")
+}
+
+func (g *GroupInviteLinkGorm) Use(ctx context.Context, code string, now time.Time) (ok bool, err error) {
+	res := g.db(ctx).
+		Where("code = ? and revoked = ?", code, false).
+		Where("(max_uses = 0 or use_count < max_uses)").
+		Where("(expire_time <= ? or expire_time > ?)", time.Unix(0, 0), now).
+		UpdateColumn("use_count", gorm.Expr("use_count + 1"))
+	if res.Error != nil {
+		return false, utils.Wrap(res.Error, "")
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (g *GroupInviteLinkGorm) IncrJoinCount(ctx context.Context, code string) (err error) {
+	return utils.Wrap(
+		g.db(ctx).Where("code = ?", code).UpdateColumn("join_count", gorm.Expr("join_count + 1")).Error,
+		"",
+	)
+}
@@ -16,6 +16,7 @@ package relation
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -71,6 +72,20 @@ func (g *GroupMemberGorm) UpdateRoleLevel(
 	return db.RowsAffected, utils.Wrap(db.Error, "")
 }
 
+func (g *GroupMemberGorm) UpdateRoleID(ctx context.Context, groupID string, userIDs []string, roleID string) (err error) {
+	return utils.Wrap(
+		g.db(ctx).Where("group_id = ? and user_id in (?)", groupID, userIDs).Updates(map[string]any{"role_id": roleID}).Error,
+		"",
+	)
+}
+
+func (g *GroupMemberGorm) FindRoleUserIDs(ctx context.Context, groupID string, roleIDs []string) (userIDs []string, err error) {
+	return userIDs, utils.Wrap(
+		g.db(ctx).Where("group_id = ? and role_id in (?)", groupID, roleIDs).Pluck("user_id", &userIDs).Error,
+		"",
+	)
+}
+
 func (g *GroupMemberGorm) Find(
 	ctx context.Context,
 	groupIDs []string,
@@ -128,6 +143,70 @@ func (g *GroupMemberGorm) SearchMember(
 	return ormutil.GormSearch[relation.GroupMemberModel](db, []string{"nickname"}, keyword, pageNumber, showNumber)
 }
 
+func (g *GroupMemberGorm) SearchMemberByCursor(
+	ctx context.Context,
+	groupID string,
+	filter *relation.GroupMemberSearchFilter,
+	cursor *relation.GroupMemberCursor,
+	limit int,
+) (total int64, groupMembers []*relation.GroupMemberModel, err error) {
+	db := g.db(ctx).Where("group_members.group_id = ?", groupID)
+	if filter.Keyword != "" {
+		keyword := "%" + escapeLike(filter.Keyword) + "%"
+		if filter.KeywordPrefix {
+			keyword = escapeLike(filter.Keyword) + "%"
+		}
+		db = db.Joins("left join users on users.user_id = group_members.user_id").
+			Where("(group_members.nickname like ? or (group_members.nickname = '' and users.name like ?) or group_members.user_id = ?)",
+				keyword, keyword, filter.Keyword)
+	}
+	if len(filter.RoleLevels) > 0 {
+		db = db.Where("group_members.role_level in (?)", filter.RoleLevels)
+	}
+	if filter.Muted != nil {
+		if *filter.Muted {
+			db = db.Where("group_members.mute_end_time > ?", time.Now())
+		} else {
+			db = db.Where("group_members.mute_end_time <= ?", time.Now())
+		}
+	}
+	if !filter.JoinTimeBegin.IsZero() {
+		db = db.Where("group_members.join_time >= ?", filter.JoinTimeBegin)
+	}
+	if !filter.JoinTimeEnd.IsZero() {
+		db = db.Where("group_members.join_time < ?", filter.JoinTimeEnd)
+	}
+	if len(filter.JoinSources) > 0 {
+		db = db.Where("group_members.join_source in (?)", filter.JoinSources)
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, utils.Wrap(err, "")
+	}
+	switch filter.Sort {
+	case relation.GroupMemberSortJoinTimeDesc:
+		if cursor != nil {
+			db = db.Where("(group_members.join_time < ? or (group_members.join_time = ? and group_members.user_id < ?))",
+				cursor.JoinTime, cursor.JoinTime, cursor.UserID)
+		}
+		db = db.Order("group_members.join_time desc, group_members.user_id desc")
+	case relation.GroupMemberSortRoleLevel:
+		if cursor != nil {
+			db = db.Where("(group_members.role_level < ? or (group_members.role_level = ? and "+
+				"(group_members.join_time > ? or (group_members.join_time = ? and group_members.user_id > ?))))",
+				cursor.RoleLevel, cursor.RoleLevel, cursor.JoinTime, cursor.JoinTime, cursor.UserID)
+		}
+		db = db.Order("group_members.role_level desc, group_members.join_time, group_members.user_id")
+	default:
+		if cursor != nil {
+			db = db.Where("(group_members.join_time > ? or (group_members.join_time = ? and group_members.user_id > ?))",
+				cursor.JoinTime, cursor.JoinTime, cursor.UserID)
+		}
+		db = db.Order("group_members.join_time, group_members.user_id")
+	}
+	err = db.Select("group_members.*").Limit(limit).Find(&groupMembers).Error
+	return total, groupMembers, utils.Wrap(err, "")
+}
+
 func (g *GroupMemberGorm) MapGroupMemberNum(
 	ctx context.Context,
 	groupIDs []string,
@@ -185,6 +264,22 @@ func (g *GroupMemberGorm) FindUsersJoinedGroupID(ctx context.Context, userIDs []
 	return result, nil
 }
 
+func (g *GroupMemberGorm) FindOwnersAfter(
+	ctx context.Context,
+	afterGroupID string,
+	limit int,
+) (groupMembers []*relation.GroupMemberModel, err error) {
+	return groupMembers, utils.Wrap(
+		g.db(ctx).
+			Where("group_id > ? and role_level = ?", afterGroupID, constant.GroupOwner).
+			Order("group_id").
+			Limit(limit).
+			Find(&groupMembers).
+			Error,
+		"",
+	)
+}
+
 func (g *GroupMemberGorm) FindUserManagedGroupID(ctx context.Context, userID string) (groupIDs []string, err error) {
 	return groupIDs, utils.Wrap(
 		g.db(ctx).
@@ -16,6 +16,7 @@ package relation
 
 import (
 	"context"
+	"time"
 
 	"github.com/OpenIMSDK/tools/ormutil"
 
@@ -54,6 +55,32 @@ func (g *GroupRequestGorm) Delete(ctx context.Context, groupID string, userID st
 	)
 }
 
+func (g *GroupRequestGorm) DeleteUnhandledBefore(
+	ctx context.Context,
+	groupID string,
+	before time.Time,
+) (userIDs []string, err error) {
+	db := g.DB.WithContext(ctx).Model(&relation.GroupRequestModel{})
+	if err := db.Where("group_id = ? and handle_result = ? and req_time < ?", groupID, 0, before).Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, utils.Wrap(err, "")
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	return userIDs, utils.Wrap(
+		g.DB.WithContext(ctx).
+			Where("group_id = ? and handle_result = ? and user_id in (?)", groupID, 0, userIDs).
+			Delete(&relation.GroupRequestModel{}).
+			Error,
+		utils.GetSelfFuncName(),
+	)
+}
+
+func (g *GroupRequestGorm) DeleteUser(ctx context.Context, userID string) (count int64, err error) {
+	db := g.DB.WithContext(ctx).Where("user_id = ?", userID).Delete(&relation.GroupRequestModel{})
+	return db.RowsAffected, utils.Wrap(db.Error, utils.GetSelfFuncName())
+}
+
 func (g *GroupRequestGorm) UpdateHandler(
 	ctx context.Context,
 	groupID string,
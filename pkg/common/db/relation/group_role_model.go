@@ -0,0 +1,74 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+type GroupRoleGorm struct {
+	*MetaDB
+}
+
+func NewGroupRoleDB(db *gorm.DB) relation.GroupRoleModelInterface {
+	return &GroupRoleGorm{NewMetaDB(db, &relation.GroupRoleModel{})}
+}
+
+func (g *GroupRoleGorm) NewTx(tx any) relation.GroupRoleModelInterface {
+	return &GroupRoleGorm{NewMetaDB(tx.(*gorm.DB), &relation.GroupRoleModel{})}
+}
+
+func (g *GroupRoleGorm) Create(ctx context.Context, roles []*relation.GroupRoleModel) (err error) {
+	return utils.Wrap(g.db(ctx).Create(&roles).Error, "")
+}
+
+func (g *GroupRoleGorm) Update(ctx context.Context, groupID string, roleID string, data map[string]any) (err error) {
+	return utils.Wrap(
+		g.db(ctx).Where("group_id = ? and role_id = ?", groupID, roleID).Updates(data).Error,
+		"",
+	)
+}
+
+func (g *GroupRoleGorm) Delete(ctx context.Context, groupID string, roleIDs []string) (err error) {
+	return utils.Wrap(
+		g.db(ctx).Where("group_id = ? and role_id in ?", groupID, roleIDs).Delete(&relation.GroupRoleModel{}).Error,
+		"",
+	)
+}
+
+func (g *GroupRoleGorm) DeleteGroup(ctx context.Context, groupIDs []string) (err error) {
+	return utils.Wrap(
+		g.db(ctx).Where("group_id in ?", groupIDs).Delete(&relation.GroupRoleModel{}).Error,
+		"",
+	)
+}
+
+func (g *GroupRoleGorm) Take(ctx context.Context, groupID string, roleID string) (role *relation.GroupRoleModel, err error) {
+	role = &relation.GroupRoleModel{}
+	return role, utils.Wrap(
+		g.db(ctx).Where("group_id = ? and role_id = ?", groupID, roleID).Take(role).Error,
+		"",
+	)
+}
+
+func (g *GroupRoleGorm) Find(ctx context.Context, groupID string) (roles []*relation.GroupRoleModel, err error) {
+	return roles, utils.Wrap(g.db(ctx).Where("group_id = ?", groupID).Find(&roles).Error, "")
+}
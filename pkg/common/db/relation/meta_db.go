@@ -16,6 +16,7 @@ package relation
 
 import (
 	"context"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -36,3 +37,8 @@ func (g *MetaDB) db(ctx context.Context) *gorm.DB {
 	db := g.DB.WithContext(ctx).Model(g.table)
 	return db
 }
+
+// escapeLike 转义like中的通配符, 关键字按字面匹配.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
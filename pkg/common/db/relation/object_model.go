@@ -51,3 +51,18 @@ func (o *ObjectInfoGorm) Take(ctx context.Context, name string) (info *relation.
 	info = &relation.ObjectModel{}
 	return info, errs.Wrap(o.DB.WithContext(ctx).Where("name = ?", name).Take(info).Error)
 }
+
+func (o *ObjectInfoGorm) FindByUserID(ctx context.Context, userID string) (objs []*relation.ObjectModel, err error) {
+	return objs, errs.Wrap(o.DB.WithContext(ctx).Where("user_id = ?", userID).Find(&objs).Error)
+}
+
+func (o *ObjectInfoGorm) Delete(ctx context.Context, names []string) (err error) {
+	if len(names) == 0 {
+		return nil
+	}
+	return errs.Wrap(o.DB.WithContext(ctx).Where("name in (?)", names).Delete(&relation.ObjectModel{}).Error)
+}
+
+func (o *ObjectInfoGorm) CountByKey(ctx context.Context, key string) (count int64, err error) {
+	return count, errs.Wrap(o.DB.WithContext(ctx).Model(&relation.ObjectModel{}).Where("`key` = ?", key).Count(&count).Error)
+}
@@ -50,6 +50,10 @@ func (u *UserGorm) Update(ctx context.Context, user *relation.UserModel) (err er
 	return utils.Wrap(u.db(ctx).Model(user).Updates(user).Error, "")
 }
 
+func (u *UserGorm) Delete(ctx context.Context, userIDs []string) (err error) {
+	return utils.Wrap(u.db(ctx).Where("user_id in (?)", userIDs).Delete(&relation.UserModel{}).Error, "")
+}
+
 // 获取指定用户信息  不存在，也不返回错误.
 func (u *UserGorm) Find(ctx context.Context, userIDs []string) (users []*relation.UserModel, err error) {
 	err = utils.Wrap(u.db(ctx).Where("user_id in (?)", userIDs).Find(&users).Error, "")
@@ -98,6 +102,63 @@ func (u *UserGorm) GetUserGlobalRecvMsgOpt(ctx context.Context, userID string) (
 	return opt, err
 }
 
+func (u *UserGorm) filter(ctx context.Context, filter *relation.UserFilter) *gorm.DB {
+	db := u.db(ctx)
+	if filter == nil {
+		return db
+	}
+	if filter.Ex != nil {
+		db = db.Where("ex = ?", *filter.Ex)
+	}
+	if len(filter.AppMangerLevels) > 0 {
+		db = db.Where("app_manger_level in (?)", filter.AppMangerLevels)
+	}
+	return db
+}
+
+func (u *UserGorm) FindUserIDsAfter(ctx context.Context, filter *relation.UserFilter, lastUserID string, limit int) (userIDs []string, err error) {
+	return userIDs, errs.Wrap(u.filter(ctx, filter).Where("user_id > ?", lastUserID).Order("user_id").Limit(limit).Pluck("user_id", &userIDs).Error)
+}
+
+func (u *UserGorm) CountByFilter(ctx context.Context, filter *relation.UserFilter) (count int64, err error) {
+	return count, errs.Wrap(u.filter(ctx, filter).Count(&count).Error)
+}
+
+func (u *UserGorm) Search(
+	ctx context.Context,
+	filter *relation.UserSearchFilter,
+	pageNumber, showNumber int32,
+) (users []*relation.UserModel, count int64, err error) {
+	db := u.db(ctx)
+	if filter.UserIDPrefix != "" {
+		db = db.Where("user_id like ?", escapeLike(filter.UserIDPrefix)+"%")
+	}
+	if filter.Nickname != "" {
+		// 显式指定不区分大小写的排序规则, 不依赖name列及连接的排序规则, 中文等多字节字符按字符匹配
+		db = db.Where("name collate utf8mb4_unicode_ci like ?", "%"+escapeLike(filter.Nickname)+"%")
+	}
+	if !filter.CreateTimeBegin.IsZero() {
+		db = db.Where("create_time >= ?", filter.CreateTimeBegin)
+	}
+	if !filter.CreateTimeEnd.IsZero() {
+		db = db.Where("create_time < ?", filter.CreateTimeEnd)
+	}
+	if len(filter.AppMangerLevels) > 0 {
+		db = db.Where("app_manger_level in (?)", filter.AppMangerLevels)
+	}
+	if filter.ExcludeHidden {
+		db = db.Where("search_hidden = ? and status = ?", false, relation.UserStatusNormal)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return nil, 0, errs.Wrap(err)
+	}
+	err = db.Order("create_time desc, user_id").
+		Limit(int(showNumber)).
+		Offset(int((pageNumber - 1) * showNumber)).
+		Find(&users).Error
+	return users, count, errs.Wrap(err)
+}
+
 func (u *UserGorm) CountTotal(ctx context.Context, before *time.Time) (count int64, err error) {
 	db := u.db(ctx).Model(&relation.UserModel{})
 	if before != nil {
@@ -44,6 +44,11 @@ func (c *Controller) HashPath(md5 string) string {
 	return path.Join(hashPath, md5)
 }
 
+// ExportPath 导出文件的key, 不按hash去重.
+func (c *Controller) ExportPath(name string) string {
+	return path.Join(exportPath, name)
+}
+
 func (c *Controller) NowPath() string {
 	now := time.Now()
 	return path.Join(
@@ -256,6 +261,18 @@ func (c *Controller) IsNotFound(err error) bool {
 	return c.impl.IsNotFound(err)
 }
 
+func (c *Controller) PresignedPutObject(ctx context.Context, key string, expire time.Duration) (string, error) {
+	return c.impl.PresignedPutObject(ctx, key, expire)
+}
+
+func (c *Controller) StatObject(ctx context.Context, key string) (*s3.ObjectInfo, error) {
+	return c.impl.StatObject(ctx, key)
+}
+
+func (c *Controller) DeleteObject(ctx context.Context, name string) error {
+	return c.impl.DeleteObject(ctx, name)
+}
+
 func (c *Controller) AccessURL(ctx context.Context, name string, expire time.Duration, opt *s3.AccessURLOption) (string, error) {
 	if opt.Image != nil {
 		opt.Filename = ""
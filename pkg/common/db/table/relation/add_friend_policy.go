@@ -0,0 +1,48 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+)
+
+const AddFriendPolicyModelTableName = "add_friend_policies"
+
+// 谁可以加我为好友.
+const (
+	AddFriendPolicyAnyone           = 0 // 任何人
+	AddFriendPolicyFriendsOfFriends = 1 // 有共同好友的人
+	AddFriendPolicyGroupCoMembers   = 2 // 有共同群的人
+	AddFriendPolicyNobody           = 3 // 不允许, 只能由管理员导入
+)
+
+// AddFriendPolicyModel 用户的加好友权限, 没有记录为AddFriendPolicyAnyone.
+type AddFriendPolicyModel struct {
+	UserID     string    `gorm:"column:user_id;primary_key;size:64"`
+	Policy     int32     `gorm:"column:policy"`
+	UpdateTime time.Time `gorm:"column:update_time"`
+}
+
+func (AddFriendPolicyModel) TableName() string {
+	return AddFriendPolicyModelTableName
+}
+
+type AddFriendPolicyModelInterface interface {
+	// Set 不存在则新增
+	Set(ctx context.Context, policy *AddFriendPolicyModel) (err error)
+	// Take 不存在返回gorm.ErrRecordNotFound
+	Take(ctx context.Context, userID string) (policy *AddFriendPolicyModel, err error)
+}
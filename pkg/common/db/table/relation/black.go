@@ -49,4 +49,8 @@ type BlackModelInterface interface {
 		pageNumber, showNumber int32,
 	) (blacks []*BlackModel, total int64, err error)
 	FindBlackUserIDs(ctx context.Context, ownerUserID string) (blackUserIDs []string, err error)
+	// 获取将blockUserID加入黑名单的用户
+	FindOwnerUserIDs(ctx context.Context, blockUserID string) (ownerUserIDs []string, err error)
+	// 删除userID的黑名单和userID所在的黑名单
+	DeleteByUser(ctx context.Context, userID string) (count int64, err error)
 }
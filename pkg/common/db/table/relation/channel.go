@@ -0,0 +1,78 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ChannelModelTableName           = "channels"
+	ChannelSubscriberModelTableName = "channel_subscribers"
+)
+
+// ChannelGroup 频道的群类型, 与constant.SuperGroup、constant.WorkingGroup并列.
+// 频道的群成员只有群主和管理员(及授权角色), 普通订阅者记录在channel_subscribers中.
+const ChannelGroup int32 = 3
+
+// ChannelModel 频道信息, 群基础信息仍保存在groups中.
+type ChannelModel struct {
+	GroupID         string    `gorm:"column:group_id;primary_key;size:64"`
+	IsPublic        bool      `gorm:"column:is_public;index:public_subscriber,priority:1"`
+	SubscriberCount int64     `gorm:"column:subscriber_count;index:public_subscriber,priority:2"`
+	CreateTime      time.Time `gorm:"column:create_time"`
+	Ex              string    `gorm:"column:ex;size:1024"`
+}
+
+func (ChannelModel) TableName() string {
+	return ChannelModelTableName
+}
+
+// ChannelSubscriberModel 频道订阅者, Opened表示是否已为订阅者创建会话.
+type ChannelSubscriberModel struct {
+	GroupID       string    `gorm:"column:group_id;primary_key;size:64"`
+	UserID        string    `gorm:"column:user_id;primary_key;index:user_subscribe_time,priority:1;size:64"`
+	SubscribeTime time.Time `gorm:"column:subscribe_time;index:user_subscribe_time,priority:2"`
+	Opened        bool      `gorm:"column:opened"`
+}
+
+func (ChannelSubscriberModel) TableName() string {
+	return ChannelSubscriberModelTableName
+}
+
+type ChannelModelInterface interface {
+	NewTx(tx any) ChannelModelInterface
+	Create(ctx context.Context, channels []*ChannelModel) (err error)
+	Take(ctx context.Context, groupID string) (channel *ChannelModel, err error)
+	Find(ctx context.Context, groupIDs []string) (channels []*ChannelModel, err error)
+	UpdatePublic(ctx context.Context, groupID string, isPublic bool) (err error)
+	IncrSubscriberCount(ctx context.Context, groupID string, delta int64) (err error)
+	// SearchPublic 按群名搜索公开且未解散的频道, 按订阅数倒序
+	SearchPublic(ctx context.Context, keyword string, pageNumber, showNumber int32) (total int64, channels []*ChannelModel, err error)
+}
+
+type ChannelSubscriberModelInterface interface {
+	NewTx(tx any) ChannelSubscriberModelInterface
+	Create(ctx context.Context, subscribers []*ChannelSubscriberModel) (err error)
+	// Delete 返回实际删除的数量
+	Delete(ctx context.Context, groupID string, userIDs []string) (count int64, err error)
+	DeleteGroup(ctx context.Context, groupIDs []string) (err error)
+	Take(ctx context.Context, groupID string, userID string) (subscriber *ChannelSubscriberModel, err error)
+	// FindUserIDs 按userID顺序返回afterUserID之后的订阅者, 用于分批推送
+	FindUserIDs(ctx context.Context, groupID string, afterUserID string, limit int) (userIDs []string, err error)
+	PageByUser(ctx context.Context, userID string, pageNumber, showNumber int32) (total int64, subscribers []*ChannelSubscriberModel, err error)
+	SetOpened(ctx context.Context, groupID string, userID string) (err error)
+}
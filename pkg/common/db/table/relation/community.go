@@ -0,0 +1,55 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+)
+
+// CommunityGroup 社区, 社区本身是一个群, 社区成员、角色和权限即该群的成员、角色和权限, 社区群不能发消息.
+const CommunityGroup int32 = 4
+
+// JoinByCommunity 加入社区时自动加入社区公开群的JoinSource.
+const JoinByCommunity int32 = 6
+
+const (
+	CommunityGroupModelTableName = "community_groups"
+)
+
+// CommunityGroupModel 社区下的群或频道, 一个群只能属于一个社区.
+type CommunityGroupModel struct {
+	CommunityID string `gorm:"column:community_id;primary_key;size:64"`
+	GroupID     string `gorm:"column:group_id;primary_key;size:64;uniqueIndex:idx_community_group"`
+	// 公开的群社区成员可以直接加入, 加入社区时自动加入
+	IsPublic bool `gorm:"column:is_public"`
+	// 社区默认公告频道, 不能移出社区
+	IsAnnouncement bool      `gorm:"column:is_announcement"`
+	CreateTime     time.Time `gorm:"column:create_time"`
+}
+
+func (CommunityGroupModel) TableName() string {
+	return CommunityGroupModelTableName
+}
+
+type CommunityGroupModelInterface interface {
+	NewTx(tx any) CommunityGroupModelInterface
+	Create(ctx context.Context, groups []*CommunityGroupModel) (err error)
+	Delete(ctx context.Context, communityID string, groupIDs []string) (err error)
+	// DeleteGroup 删除社区或群的所有关联
+	DeleteGroup(ctx context.Context, groupIDs []string) (err error)
+	Find(ctx context.Context, communityID string) (groups []*CommunityGroupModel, err error)
+	FindByGroupIDs(ctx context.Context, groupIDs []string) (groups []*CommunityGroupModel, err error)
+}
@@ -42,6 +42,8 @@ type ConversationModel struct {
 	IsMsgDestruct         bool      `gorm:"column:is_msg_destruct;default:false"`
 	MsgDestructTime       int64     `gorm:"column:msg_destruct_time;default:604800"`
 	LatestMsgDestructTime time.Time `gorm:"column:latest_msg_destruct_time;autoCreateTime"`
+	// 会话静音截止时间(毫秒时间戳), 截止前不做离线推送, 到期自动失效
+	MuteUntil int64 `gorm:"column:mute_until;default:0"`
 }
 
 func (ConversationModel) TableName() string {
@@ -51,6 +53,7 @@ func (ConversationModel) TableName() string {
 type ConversationModelInterface interface {
 	Create(ctx context.Context, conversations []*ConversationModel) (err error)
 	Delete(ctx context.Context, groupIDs []string) (err error)
+	DeleteByOwner(ctx context.Context, ownerUserID string) (count int64, err error)
 	UpdateByMap(ctx context.Context, userIDs []string, conversationID string, args map[string]interface{}) (rows int64, err error)
 	Update(ctx context.Context, conversation *ConversationModel) (err error)
 	Find(ctx context.Context, ownerUserID string, conversationIDs []string) (conversations []*ConversationModel, err error)
@@ -66,5 +69,7 @@ type ConversationModelInterface interface {
 	GetUserAllHasReadSeqs(ctx context.Context, ownerUserID string) (hashReadSeqs map[string]int64, err error)
 	GetConversationsByConversationID(ctx context.Context, conversationIDs []string) ([]*ConversationModel, error)
 	GetConversationIDsNeedDestruct(ctx context.Context) ([]*ConversationModel, error)
+	// FindMuteUntil 获取会话中静音截止时间晚于after的用户及其截止时间
+	FindMuteUntil(ctx context.Context, conversationID string, after int64) (map[string]int64, error)
 	NewTx(tx any) ConversationModelInterface
 }
@@ -74,5 +74,7 @@ type FriendModelInterface interface {
 	) (friends []*FriendModel, total int64, err error)
 	// 获取好友UserID列表
 	FindFriendUserIDs(ctx context.Context, ownerUserID string) (friendUserIDs []string, err error)
+	// 获取多个ownerUserID的好友关系
+	FindOwnersFriends(ctx context.Context, ownerUserIDs []string) (friends []*FriendModel, err error)
 	NewTx(tx any) FriendModelInterface
 }
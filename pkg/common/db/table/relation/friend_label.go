@@ -0,0 +1,77 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	FriendLabelModelTableName       = "friend_labels"
+	FriendLabelMemberModelTableName = "friend_label_members"
+)
+
+// FriendLabelModel 好友分组, 同一用户的分组名称不能重复.
+type FriendLabelModel struct {
+	OwnerUserID string    `gorm:"column:owner_user_id;primary_key;size:64;uniqueIndex:owner_label_name,priority:1"`
+	LabelID     string    `gorm:"column:label_id;primary_key;size:64"`
+	Name        string    `gorm:"column:name;size:128;uniqueIndex:owner_label_name,priority:2"`
+	Sort        int32     `gorm:"column:sort"`
+	CreateTime  time.Time `gorm:"column:create_time"`
+}
+
+func (FriendLabelModel) TableName() string {
+	return FriendLabelModelTableName
+}
+
+// FriendLabelMemberModel 好友和分组多对多.
+type FriendLabelMemberModel struct {
+	OwnerUserID  string    `gorm:"column:owner_user_id;primary_key;size:64;index:owner_friend,priority:1"`
+	LabelID      string    `gorm:"column:label_id;primary_key;size:64"`
+	FriendUserID string    `gorm:"column:friend_user_id;primary_key;size:64;index:owner_friend,priority:2;index:friend_user_id"`
+	CreateTime   time.Time `gorm:"column:create_time"`
+}
+
+func (FriendLabelMemberModel) TableName() string {
+	return FriendLabelMemberModelTableName
+}
+
+type FriendLabelModelInterface interface {
+	NewTx(tx any) FriendLabelModelInterface
+	Create(ctx context.Context, labels []*FriendLabelModel) (err error)
+	Update(ctx context.Context, ownerUserID string, labelID string, data map[string]any) (err error)
+	Delete(ctx context.Context, ownerUserID string, labelIDs []string) (err error)
+	Take(ctx context.Context, ownerUserID string, labelID string) (label *FriendLabelModel, err error)
+	// Find 按sort和创建时间排序
+	Find(ctx context.Context, ownerUserID string) (labels []*FriendLabelModel, err error)
+	// DeleteOwner 删除用户的全部分组, 返回删除的数量
+	DeleteOwner(ctx context.Context, ownerUserID string) (count int64, err error)
+}
+
+type FriendLabelMemberModelInterface interface {
+	NewTx(tx any) FriendLabelMemberModelInterface
+	// Create 已经在分组中的好友忽略
+	Create(ctx context.Context, members []*FriendLabelMemberModel) (err error)
+	// Delete friendUserIDs为空删除分组的所有成员
+	Delete(ctx context.Context, ownerUserID string, labelID string, friendUserIDs []string) (err error)
+	// DeleteFriends 把好友从所有分组中移除
+	DeleteFriends(ctx context.Context, ownerUserID string, friendUserIDs []string) (err error)
+	// Find friendUserIDs为空获取所有分组的成员
+	Find(ctx context.Context, ownerUserID string, friendUserIDs []string) (members []*FriendLabelMemberModel, err error)
+	PageFriendUserIDs(ctx context.Context, ownerUserID string, labelID string, pageNumber, showNumber int32) (total int64, friendUserIDs []string, err error)
+	// DeleteUser 删除用户分组中的成员以及用户在别人分组中的记录, 返回删除的数量
+	DeleteUser(ctx context.Context, userID string) (count int64, err error)
+}
@@ -62,5 +62,11 @@ type FriendRequestModelInterface interface {
 		pageNumber, showNumber int32,
 	) (friendRequests []*FriendRequestModel, total int64, err error)
 	FindBothFriendRequests(ctx context.Context, fromUserID, toUserID string) (friends []*FriendRequestModel, err error)
+	// 获取userID发出或收到的未处理的好友申请
+	FindUnhandled(ctx context.Context, userID string) (friendRequests []*FriendRequestModel, err error)
+	// 删除before之前发出的未处理的好友申请
+	DeleteUnhandledBefore(ctx context.Context, before time.Time) (count int64, err error)
+	// 删除userID发出和收到的全部好友申请
+	DeleteByUser(ctx context.Context, userID string) (count int64, err error)
 	NewTx(tx any) FriendRequestModelInterface
 }
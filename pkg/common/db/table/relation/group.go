@@ -39,6 +39,8 @@ type GroupModel struct {
 	ApplyMemberFriend      int32     `gorm:"column:apply_member_friend"                          json:"applyMemberFriend"`
 	NotificationUpdateTime time.Time `gorm:"column:notification_update_time"`
 	NotificationUserID     string    `gorm:"column:notification_user_id;size:64"`
+	// 慢速模式, 普通成员两次发言的最小间隔秒数, 0为关闭
+	SlowMode int32 `gorm:"column:slow_mode"`
 }
 
 func (GroupModel) TableName() string {
@@ -0,0 +1,54 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	GroupBanModelTableName = "group_bans"
+)
+
+// GroupBanModel 群封禁, 封禁期间不能申请入群、被邀请或通过邀请链接入群, ExpireTime为time.Unix(0, 0)永久封禁.
+type GroupBanModel struct {
+	GroupID        string    `gorm:"column:group_id;primary_key;size:64"`
+	UserID         string    `gorm:"column:user_id;primary_key;size:64"`
+	Reason         string    `gorm:"column:reason;size:255"`
+	OperatorUserID string    `gorm:"column:operator_user_id;size:64"`
+	ExpireTime     time.Time `gorm:"column:expire_time"`
+	CreateTime     time.Time `gorm:"column:create_time"`
+	Ex             string    `gorm:"column:ex;size:1024"`
+}
+
+func (GroupBanModel) TableName() string {
+	return GroupBanModelTableName
+}
+
+// Active 封禁是否仍然生效.
+func (g *GroupBanModel) Active(now time.Time) bool {
+	return !g.ExpireTime.After(time.Unix(0, 0)) || g.ExpireTime.After(now)
+}
+
+type GroupBanModelInterface interface {
+	NewTx(tx any) GroupBanModelInterface
+	Create(ctx context.Context, bans []*GroupBanModel) (err error)
+	Delete(ctx context.Context, groupID string, userIDs []string) (err error)
+	DeleteGroup(ctx context.Context, groupIDs []string) (err error)
+	Take(ctx context.Context, groupID string, userID string) (ban *GroupBanModel, err error)
+	Find(ctx context.Context, groupID string, userIDs []string) (bans []*GroupBanModel, err error)
+	Page(ctx context.Context, groupID string, pageNumber, showNumber int32) (total int64, bans []*GroupBanModel, err error)
+}
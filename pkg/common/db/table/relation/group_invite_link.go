@@ -0,0 +1,69 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	GroupInviteLinkModelTableName = "group_invite_links"
+)
+
+// JoinByInviteLink 通过邀请链接入群的JoinSource.
+const JoinByInviteLink int32 = 5
+
+// GroupInviteLinkModel 群邀请链接, MaxUses为0不限次数, ExpireTime为time.Unix(0, 0)永不过期.
+type GroupInviteLinkModel struct {
+	Code          string    `gorm:"column:code;primary_key;size:64"`
+	GroupID       string    `gorm:"column:group_id;index:group_id;size:64"`
+	CreatorUserID string    `gorm:"column:creator_user_id;size:64"`
+	MaxUses       int32     `gorm:"column:max_uses"`
+	UseCount      int32     `gorm:"column:use_count"`
+	JoinCount     int32     `gorm:"column:join_count"`
+	ExpireTime    time.Time `gorm:"column:expire_time"`
+	AutoApprove   bool      `gorm:"column:auto_approve"`
+	RoleID        string    `gorm:"column:role_id;size:64"`
+	Revoked       bool      `gorm:"column:revoked"`
+	CreateTime    time.Time `gorm:"column:create_time"`
+	Ex            string    `gorm:"column:ex;size:1024"`
+}
+
+func (GroupInviteLinkModel) TableName() string {
+	return GroupInviteLinkModelTableName
+}
+
+// Expired 链接是否已过期.
+func (g *GroupInviteLinkModel) Expired(now time.Time) bool {
+	return g.ExpireTime.After(time.Unix(0, 0)) && !g.ExpireTime.After(now)
+}
+
+// Exhausted 链接使用次数是否已用完.
+func (g *GroupInviteLinkModel) Exhausted() bool {
+	return g.MaxUses > 0 && g.UseCount >= g.MaxUses
+}
+
+type GroupInviteLinkModelInterface interface {
+	NewTx(tx any) GroupInviteLinkModelInterface
+	Create(ctx context.Context, links []*GroupInviteLinkModel) (err error)
+	Take(ctx context.Context, code string) (link *GroupInviteLinkModel, err error)
+	Find(ctx context.Context, groupID string, includeRevoked bool) (links []*GroupInviteLinkModel, err error)
+	Revoke(ctx context.Context, groupID string, codes []string) (err error)
+	RevokeGroup(ctx context.Context, groupIDs []string) (err error)
+	// Use 链接有效时使用次数加一, 返回是否成功
+	Use(ctx context.Context, code string, now time.Time) (ok bool, err error)
+	IncrJoinCount(ctx context.Context, code string) (err error)
+}
@@ -24,16 +24,17 @@ const (
 )
 
 type GroupMemberModel struct {
-	GroupID        string    `gorm:"column:group_id;primary_key;size:64"`
+	GroupID        string    `gorm:"column:group_id;primary_key;size:64;index:group_join_time,priority:1;index:group_role_level,priority:1;index:group_nickname,priority:1"`
 	UserID         string    `gorm:"column:user_id;primary_key;size:64"`
-	Nickname       string    `gorm:"column:nickname;size:255"`
+	Nickname       string    `gorm:"column:nickname;size:255;index:group_nickname,priority:2"`
 	FaceURL        string    `gorm:"column:user_group_face_url;size:255"`
-	RoleLevel      int32     `gorm:"column:role_level"`
-	JoinTime       time.Time `gorm:"column:join_time"`
+	RoleLevel      int32     `gorm:"column:role_level;index:group_role_level,priority:2"`
+	JoinTime       time.Time `gorm:"column:join_time;index:group_join_time,priority:2;index:group_role_level,priority:3"`
 	JoinSource     int32     `gorm:"column:join_source"`
 	InviterUserID  string    `gorm:"column:inviter_user_id;size:64"`
 	OperatorUserID string    `gorm:"column:operator_user_id;size:64"`
 	MuteEndTime    time.Time `gorm:"column:mute_end_time"`
+	RoleID         string    `gorm:"column:role_id;size:64"` // 自定义角色
 	Ex             string    `gorm:"column:ex;size:1024"`
 }
 
@@ -41,6 +42,33 @@ func (GroupMemberModel) TableName() string {
 	return GroupMemberModelTableName
 }
 
+// 群成员搜索排序.
+const (
+	GroupMemberSortJoinTimeAsc  = "joinTimeAsc"
+	GroupMemberSortJoinTimeDesc = "joinTimeDesc"
+	GroupMemberSortRoleLevel    = "roleLevel" // 群主、管理员在前, 相同角色按入群时间
+)
+
+// GroupMemberSearchFilter 群成员搜索条件, 零值不过滤.
+type GroupMemberSearchFilter struct {
+	// 匹配群昵称、用户昵称或等于userID
+	Keyword       string
+	KeywordPrefix bool
+	RoleLevels    []int32
+	Muted         *bool
+	JoinTimeBegin time.Time
+	JoinTimeEnd   time.Time
+	JoinSources   []int32
+	Sort          string
+}
+
+// GroupMemberCursor 上一页最后一个成员的排序字段.
+type GroupMemberCursor struct {
+	RoleLevel int32     `json:"roleLevel"`
+	JoinTime  time.Time `json:"joinTime"`
+	UserID    string    `json:"userID"`
+}
+
 type GroupMemberModelInterface interface {
 	NewTx(tx any) GroupMemberModelInterface
 	Create(ctx context.Context, groupMembers []*GroupMemberModel) (err error)
@@ -48,6 +76,8 @@ type GroupMemberModelInterface interface {
 	DeleteGroup(ctx context.Context, groupIDs []string) (err error)
 	Update(ctx context.Context, groupID string, userID string, data map[string]any) (err error)
 	UpdateRoleLevel(ctx context.Context, groupID string, userID string, roleLevel int32) (rowsAffected int64, err error)
+	UpdateRoleID(ctx context.Context, groupID string, userIDs []string, roleID string) (err error)
+	FindRoleUserIDs(ctx context.Context, groupID string, roleIDs []string) (userIDs []string, err error)
 	Find(
 		ctx context.Context,
 		groupIDs []string,
@@ -65,10 +95,20 @@ type GroupMemberModelInterface interface {
 		roleLevels []int32,
 		pageNumber, showNumber int32,
 	) (total uint32, groupList []*GroupMemberModel, err error)
+	// SearchMemberByCursor cursor为nil从第一个开始
+	SearchMemberByCursor(
+		ctx context.Context,
+		groupID string,
+		filter *GroupMemberSearchFilter,
+		cursor *GroupMemberCursor,
+		limit int,
+	) (total int64, groupMembers []*GroupMemberModel, err error)
 	MapGroupMemberNum(ctx context.Context, groupIDs []string) (count map[string]uint32, err error)
 	FindJoinUserID(ctx context.Context, groupIDs []string) (groupUsers map[string][]string, err error)
 	FindUserJoinedGroupID(ctx context.Context, userID string) (groupIDs []string, err error)
 	TakeGroupMemberNum(ctx context.Context, groupID string) (count int64, err error)
 	FindUsersJoinedGroupID(ctx context.Context, userIDs []string) (map[string][]string, error)
 	FindUserManagedGroupID(ctx context.Context, userID string) (groupIDs []string, err error)
+	// FindOwnersAfter 按group_id顺序获取afterGroupID之后的群主
+	FindOwnersAfter(ctx context.Context, afterGroupID string, limit int) (groupMembers []*GroupMemberModel, err error)
 }
@@ -34,6 +34,7 @@ type GroupRequestModel struct {
 	HandledTime   time.Time `gorm:"column:handle_time"`
 	JoinSource    int32     `gorm:"column:join_source"`
 	InviterUserID string    `gorm:"column:inviter_user_id;size:64"`
+	InviteCode    string    `gorm:"column:invite_code;size:64"`
 	Ex            string    `gorm:"column:ex;size:1024"`
 }
 
@@ -45,6 +46,10 @@ type GroupRequestModelInterface interface {
 	NewTx(tx any) GroupRequestModelInterface
 	Create(ctx context.Context, groupRequests []*GroupRequestModel) (err error)
 	Delete(ctx context.Context, groupID string, userID string) (err error)
+	// DeleteUnhandledBefore 删除申请时间早于before的未处理申请, 返回被删除申请的用户
+	DeleteUnhandledBefore(ctx context.Context, groupID string, before time.Time) (userIDs []string, err error)
+	// DeleteUser 删除用户在所有群的申请, 返回删除的数量
+	DeleteUser(ctx context.Context, userID string) (count int64, err error)
 	UpdateHandler(ctx context.Context, groupID string, userID string, handledMsg string, handleResult int32) (err error)
 	Take(ctx context.Context, groupID string, userID string) (groupRequest *GroupRequestModel, err error)
 	FindGroupRequests(ctx context.Context, groupID string, userIDs []string) (int64, []*GroupRequestModel, error)
@@ -0,0 +1,79 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/constant"
+)
+
+const (
+	GroupRoleModelTableName = "group_roles"
+)
+
+// 群权限位.
+const (
+	GroupPermissionSendMsg      int64 = 1 << iota // 发送消息
+	GroupPermissionSendMedia                      // 发送图片、语音、视频、文件
+	GroupPermissionAtAll                          // @所有人
+	GroupPermissionInvite                         // 邀请入群
+	GroupPermissionKick                           // 踢出群成员
+	GroupPermissionMute                           // 禁言
+	GroupPermissionEditInfo                       // 修改群资料
+	GroupPermissionPin                            // 置顶消息
+	GroupPermissionRevokeOthers                   // 撤回他人消息
+	GroupPermissionApproveJoin                    // 审批入群申请
+
+	GroupPermissionAll = GroupPermissionApproveJoin<<1 - 1
+)
+
+// GroupPermissionOrdinary the permissions of ordinary members without a custom role.
+const GroupPermissionOrdinary = GroupPermissionSendMsg | GroupPermissionSendMedia | GroupPermissionAtAll | GroupPermissionInvite
+
+// DefaultGroupPermissions the permissions of a member without a custom role.
+func DefaultGroupPermissions(roleLevel int32) int64 {
+	switch roleLevel {
+	case constant.GroupOwner, constant.GroupAdmin:
+		return GroupPermissionAll
+	default:
+		return GroupPermissionOrdinary
+	}
+}
+
+// GroupRoleModel 群自定义角色, 分配给成员后替代管理员和普通成员的默认权限, 群主始终拥有全部权限.
+type GroupRoleModel struct {
+	GroupID     string    `gorm:"column:group_id;primary_key;size:64"`
+	RoleID      string    `gorm:"column:role_id;primary_key;size:64"`
+	Name        string    `gorm:"column:name;size:255"`
+	Permissions int64     `gorm:"column:permissions"`
+	CreateTime  time.Time `gorm:"column:create_time"`
+	Ex          string    `gorm:"column:ex;size:1024"`
+}
+
+func (GroupRoleModel) TableName() string {
+	return GroupRoleModelTableName
+}
+
+type GroupRoleModelInterface interface {
+	NewTx(tx any) GroupRoleModelInterface
+	Create(ctx context.Context, roles []*GroupRoleModel) (err error)
+	Update(ctx context.Context, groupID string, roleID string, data map[string]any) (err error)
+	Delete(ctx context.Context, groupID string, roleIDs []string) (err error)
+	DeleteGroup(ctx context.Context, groupIDs []string) (err error)
+	Take(ctx context.Context, groupID string, roleID string) (role *GroupRoleModel, err error)
+	Find(ctx context.Context, groupID string) (roles []*GroupRoleModel, err error)
+}
@@ -42,4 +42,7 @@ type ObjectInfoModelInterface interface {
 	NewTx(tx any) ObjectInfoModelInterface
 	SetObject(ctx context.Context, obj *ObjectModel) error
 	Take(ctx context.Context, name string) (*ObjectModel, error)
+	FindByUserID(ctx context.Context, userID string) ([]*ObjectModel, error)
+	Delete(ctx context.Context, names []string) error
+	CountByKey(ctx context.Context, key string) (int64, error)
 }
@@ -16,6 +16,7 @@ package relation
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -23,14 +24,57 @@ const (
 	UserModelTableName = "users"
 )
 
+// 账号状态.
+const (
+	UserStatusNormal      = 0
+	UserStatusDeactivated = 1 // 已停用, 不能登录, 资料对其他用户隐藏
+)
+
 type UserModel struct {
 	UserID           string    `gorm:"column:user_id;primary_key;size:64"`
 	Nickname         string    `gorm:"column:name;size:255"`
 	FaceURL          string    `gorm:"column:face_url;size:255"`
 	Ex               string    `gorm:"column:ex;size:1024"`
 	CreateTime       time.Time `gorm:"column:create_time;index:create_time;autoCreateTime"`
-	AppMangerLevel   int32     `gorm:"column:app_manger_level;default:1"`
+	AppMangerLevel   int32     `gorm:"column:app_manger_level;index:app_manger_level;default:1"`
 	GlobalRecvMsgOpt int32     `gorm:"column:global_recv_msg_opt"`
+	Status           int32     `gorm:"column:status;default:0"`
+	// 用户设置不出现在其他用户的搜索结果中
+	SearchHidden bool `gorm:"column:search_hidden;default:false"`
+	// 免打扰时段, 按DoNotDisturbTimezone的当天分钟数, 开始大于结束表示跨零点, 时段内只在线投递不做离线推送
+	DoNotDisturb         bool   `gorm:"column:do_not_disturb;default:false"`
+	DoNotDisturbStart    int32  `gorm:"column:do_not_disturb_start;default:0"`
+	DoNotDisturbEnd      int32  `gorm:"column:do_not_disturb_end;default:0"`
+	DoNotDisturbTimezone string `gorm:"column:do_not_disturb_timezone;size:64"`
+}
+
+// locations 缓存已加载的时区, time.LoadLocation每次都会读取时区文件.
+var locations sync.Map
+
+// loadLocation 时区无效时返回UTC.
+func loadLocation(name string) *time.Location {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	locations.Store(name, loc)
+	return loc
+}
+
+// InDoNotDisturb 判断now是否处于用户的免打扰时段, 时区无效时按UTC计算.
+func (u *UserModel) InDoNotDisturb(now time.Time) bool {
+	if !u.DoNotDisturb || u.DoNotDisturbStart == u.DoNotDisturbEnd {
+		return false
+	}
+	now = now.In(loadLocation(u.DoNotDisturbTimezone))
+	minute := int32(now.Hour()*60 + now.Minute())
+	if u.DoNotDisturbStart < u.DoNotDisturbEnd {
+		return minute >= u.DoNotDisturbStart && minute < u.DoNotDisturbEnd
+	}
+	return minute >= u.DoNotDisturbStart || minute < u.DoNotDisturbEnd
 }
 
 func (u *UserModel) GetNickname() string {
@@ -53,10 +97,29 @@ func (UserModel) TableName() string {
 	return UserModelTableName
 }
 
+// UserFilter selects users by attributes, empty fields match all users.
+type UserFilter struct {
+	Ex              *string
+	AppMangerLevels []int32
+}
+
+// UserSearchFilter 用户搜索条件, 零值不过滤.
+type UserSearchFilter struct {
+	UserIDPrefix string
+	// 昵称包含即匹配, 不区分大小写
+	Nickname        string
+	CreateTimeBegin time.Time
+	CreateTimeEnd   time.Time
+	AppMangerLevels []int32
+	// 排除设置了不可搜索及已停用的用户
+	ExcludeHidden bool
+}
+
 type UserModelInterface interface {
 	Create(ctx context.Context, users []*UserModel) (err error)
 	UpdateByMap(ctx context.Context, userID string, args map[string]interface{}) (err error)
 	Update(ctx context.Context, user *UserModel) (err error)
+	Delete(ctx context.Context, userIDs []string) (err error)
 	// 获取指定用户信息  不存在，也不返回错误
 	Find(ctx context.Context, userIDs []string) (users []*UserModel, err error)
 	// 获取某个用户信息  不存在，则返回错误
@@ -65,6 +128,11 @@ type UserModelInterface interface {
 	Page(ctx context.Context, pageNumber, showNumber int32) (users []*UserModel, count int64, err error)
 	GetAllUserID(ctx context.Context, pageNumber, showNumber int32) (userIDs []string, err error)
 	GetUserGlobalRecvMsgOpt(ctx context.Context, userID string) (opt int, err error)
+	// 按user_id顺序获取lastUserID之后的用户ID
+	FindUserIDsAfter(ctx context.Context, filter *UserFilter, lastUserID string, limit int) (userIDs []string, err error)
+	CountByFilter(ctx context.Context, filter *UserFilter) (count int64, err error)
+	// 按创建时间倒序分页搜索用户
+	Search(ctx context.Context, filter *UserSearchFilter, pageNumber, showNumber int32) (users []*UserModel, count int64, err error)
 	// 获取用户总数
 	CountTotal(ctx context.Context, before *time.Time) (count int64, err error)
 	// 获取范围内用户增量
@@ -0,0 +1,48 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InDoNotDisturb(t *testing.T) {
+	// 2023-06-01 15:30 UTC, 23:30 in Asia/Shanghai, 11:30 in America/New_York
+	now := time.Date(2023, 6, 1, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		user     UserModel
+		expected bool
+	}{
+		{"disabled", UserModel{DoNotDisturb: false, DoNotDisturbStart: 0, DoNotDisturbEnd: 24 * 60}, false},
+		{"empty period", UserModel{DoNotDisturb: true, DoNotDisturbStart: 600, DoNotDisturbEnd: 600}, false},
+		{"same day inside", UserModel{DoNotDisturb: true, DoNotDisturbStart: 15 * 60, DoNotDisturbEnd: 16 * 60}, true},
+		{"same day end exclusive", UserModel{DoNotDisturb: true, DoNotDisturbStart: 14 * 60, DoNotDisturbEnd: 15*60 + 30}, false},
+		{"same day start inclusive", UserModel{DoNotDisturb: true, DoNotDisturbStart: 15*60 + 30, DoNotDisturbEnd: 16 * 60}, true},
+		{"across midnight before midnight", UserModel{DoNotDisturb: true, DoNotDisturbStart: 23 * 60, DoNotDisturbEnd: 7 * 60, DoNotDisturbTimezone: "Asia/Shanghai"}, true},
+		{"across midnight outside", UserModel{DoNotDisturb: true, DoNotDisturbStart: 23 * 60, DoNotDisturbEnd: 7 * 60, DoNotDisturbTimezone: "America/New_York"}, false},
+		{"across midnight after midnight", UserModel{DoNotDisturb: true, DoNotDisturbStart: 22 * 60, DoNotDisturbEnd: 16 * 60}, true},
+		{"timezone shifts the day", UserModel{DoNotDisturb: true, DoNotDisturbStart: 11 * 60, DoNotDisturbEnd: 12 * 60, DoNotDisturbTimezone: "America/New_York"}, true},
+		{"invalid timezone uses utc", UserModel{DoNotDisturb: true, DoNotDisturbStart: 15 * 60, DoNotDisturbEnd: 16 * 60, DoNotDisturbTimezone: "Invalid/Zone"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.user.InDoNotDisturb(now))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	BroadcastJob = "broadcast_job"
+)
+
+// broadcast target segments.
+const (
+	BroadcastSegmentAll      = 1 // all users
+	BroadcastSegmentUserIDs  = 2 // the given user ids
+	BroadcastSegmentUserAttr = 3 // users matching ex and app manger level
+	BroadcastSegmentGroups   = 4 // members of the given groups
+)
+
+// broadcast job status.
+const (
+	BroadcastStatusPending   = 0
+	BroadcastStatusRunning   = 1
+	BroadcastStatusFinished  = 2
+	BroadcastStatusCancelled = 3
+	BroadcastStatusFailed    = 4
+)
+
+// MaxBroadcastFailedUserIDs caps the failed user ids kept in a job.
+const MaxBroadcastFailedUserIDs = 1000
+
+type BroadcastSegmentModel struct {
+	Type            int32    `bson:"type"`
+	UserIDs         []string `bson:"user_ids"`
+	Ex              *string  `bson:"ex"`
+	AppMangerLevels []int32  `bson:"app_manger_levels"`
+	GroupIDs        []string `bson:"group_ids"`
+}
+
+type BroadcastJobModel struct {
+	JobID    string                `bson:"job_id"`
+	OpUserID string                `bson:"op_user_id"`
+	Segment  BroadcastSegmentModel `bson:"segment"`
+	// sdkws.MsgData encoded by protobuf, recv id is filled per user
+	MsgData []byte `bson:"msg_data"`
+	// messages sent per second
+	Rate   int32 `bson:"rate"`
+	Status int32 `bson:"status"`
+	// regenerated on every claim, only the worker holding the lease can update the job
+	LeaseID string `bson:"lease_id"`
+	// last user id handled, users are sent in user id order
+	Cursor        string    `bson:"cursor"`
+	Total         int64     `bson:"total"`
+	SuccessCount  int64     `bson:"success_count"`
+	FailedCount   int64     `bson:"failed_count"`
+	FailedUserIDs []string  `bson:"failed_user_ids"`
+	ErrMsg        string    `bson:"err_msg"`
+	CreateTime    time.Time `bson:"create_time"`
+	StartTime     time.Time `bson:"start_time"`
+	UpdateTime    time.Time `bson:"update_time"`
+	FinishTime    time.Time `bson:"finish_time"`
+}
+
+func (BroadcastJobModel) TableName() string {
+	return BroadcastJob
+}
+
+type BroadcastJobModelInterface interface {
+	Create(ctx context.Context, job *BroadcastJobModel) error
+	Take(ctx context.Context, jobID string) (*BroadcastJobModel, error)
+	// status empty matches all jobs
+	Page(ctx context.Context, status []int32, pageNumber, showNumber int32) (total int64, jobs []*BroadcastJobModel, err error)
+	// Claim marks the oldest pending job, or a running job not updated since staleTime, as running with leaseID.
+	// returns nil when there is no job to run
+	Claim(ctx context.Context, staleTime time.Time, leaseID string) (*BroadcastJobModel, error)
+	// UpdateByMap only updates running jobs held by leaseID, false means the job is no longer running or the lease is lost
+	UpdateByMap(ctx context.Context, jobID string, leaseID string, args map[string]any) (bool, error)
+	// AddProgress only updates running jobs held by leaseID, false means the job is no longer running or the lease is lost
+	AddProgress(ctx context.Context, jobID string, leaseID string, cursor string, successCount, failedCount int64, failedUserIDs []string) (bool, error)
+	// Cancel pending or running jobs, false means the job has already stopped
+	Cancel(ctx context.Context, jobID string) (bool, error)
+}
@@ -0,0 +1,107 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	E2EEKeyBundle     = "e2ee_key_bundle"
+	E2EEOneTimePreKey = "e2ee_one_time_pre_key"
+	E2EESenderKey     = "e2ee_sender_key"
+)
+
+// The key directory only stores public keys, private keys never leave the devices.
+
+type E2EESignedPreKeyModel struct {
+	KeyID     int64  `bson:"key_id"`
+	PublicKey string `bson:"public_key"`
+	Signature string `bson:"signature"`
+}
+
+// E2EEKeyBundleModel the identity key and the signed prekey of one device.
+type E2EEKeyBundleModel struct {
+	UserID         string                `bson:"user_id"`
+	DeviceID       string                `bson:"device_id"`
+	RegistrationID int32                 `bson:"registration_id"`
+	IdentityKey    string                `bson:"identity_key"`
+	SignedPreKey   E2EESignedPreKeyModel `bson:"signed_pre_key"`
+	CreateTime     time.Time             `bson:"create_time"`
+	UpdateTime     time.Time             `bson:"update_time"`
+}
+
+func (E2EEKeyBundleModel) TableName() string {
+	return E2EEKeyBundle
+}
+
+// E2EEOneTimePreKeyModel every one-time prekey is handed out once.
+type E2EEOneTimePreKeyModel struct {
+	UserID     string    `bson:"user_id"`
+	DeviceID   string    `bson:"device_id"`
+	KeyID      int64     `bson:"key_id"`
+	PublicKey  string    `bson:"public_key"`
+	CreateTime time.Time `bson:"create_time"`
+}
+
+func (E2EEOneTimePreKeyModel) TableName() string {
+	return E2EEOneTimePreKey
+}
+
+// E2EESenderKeyModel which devices have received the sender key a device uses in a group.
+type E2EESenderKeyModel struct {
+	GroupID        string `bson:"group_id"`
+	UserID         string `bson:"user_id"`
+	DeviceID       string `bson:"device_id"`
+	DistributionID string `bson:"distribution_id"`
+	// userID/deviceID
+	Recipients []string  `bson:"recipients"`
+	UpdateTime time.Time `bson:"update_time"`
+}
+
+func (E2EESenderKeyModel) TableName() string {
+	return E2EESenderKey
+}
+
+type E2EEKeyBundleModelInterface interface {
+	// Set returns the bundle replaced, nil if the device had none
+	Set(ctx context.Context, bundle *E2EEKeyBundleModel) (*E2EEKeyBundleModel, error)
+	// Find deviceIDs empty returns all devices of the user
+	Find(ctx context.Context, userID string, deviceIDs []string) ([]*E2EEKeyBundleModel, error)
+	Delete(ctx context.Context, userID string, deviceID string) error
+	// DeleteUser deletes all devices of the user, returns the number deleted
+	DeleteUser(ctx context.Context, userID string) (int64, error)
+}
+
+type E2EEOneTimePreKeyModelInterface interface {
+	// Create keys already uploaded are ignored
+	Create(ctx context.Context, preKeys []*E2EEOneTimePreKeyModel) error
+	// Take removes and returns the oldest key of the device, nil if there is none
+	Take(ctx context.Context, userID string, deviceID string) (*E2EEOneTimePreKeyModel, error)
+	Count(ctx context.Context, userID string, deviceID string) (int64, error)
+	DeleteDevice(ctx context.Context, userID string, deviceID string) error
+	DeleteUser(ctx context.Context, userID string) (int64, error)
+}
+
+type E2EESenderKeyModelInterface interface {
+	// Set adds recipients to the distribution, a new distributionID replaces the old recipients
+	Set(ctx context.Context, senderKey *E2EESenderKeyModel) error
+	// Find userIDs empty returns all senders of the group
+	Find(ctx context.Context, groupID string, userIDs []string) ([]*E2EESenderKeyModel, error)
+	DeleteDevice(ctx context.Context, userID string, deviceID string) error
+	// DeleteUser deletes the sender keys of the user and removes the user's devices from the recipients of others
+	DeleteUser(ctx context.Context, userID string) (int64, error)
+}
@@ -0,0 +1,42 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	GroupAnnouncement = "group_announcement"
+)
+
+// GroupAnnouncementModel 群公告历史, GroupModel.Notification只保存最新的一条.
+type GroupAnnouncementModel struct {
+	GroupID      string    `bson:"group_id"`
+	Notification string    `bson:"notification"`
+	UserID       string    `bson:"user_id"`
+	CreateTime   time.Time `bson:"create_time"`
+}
+
+func (GroupAnnouncementModel) TableName() string {
+	return GroupAnnouncement
+}
+
+type GroupAnnouncementModelInterface interface {
+	Create(ctx context.Context, announcement *GroupAnnouncementModel) error
+	// Page 按发布时间倒序
+	Page(ctx context.Context, groupID string, pageNumber, showNumber int32) (total int64, announcements []*GroupAnnouncementModel, err error)
+}
@@ -0,0 +1,82 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	GroupAuditLog = "group_audit_log"
+)
+
+// group audit log actions.
+const (
+	GroupAuditSetInfo           = "set_info"
+	GroupAuditMuteGroup         = "mute_group"
+	GroupAuditCancelMuteGroup   = "cancel_mute_group"
+	GroupAuditMuteMember        = "mute_member"
+	GroupAuditCancelMuteMember  = "cancel_mute_member"
+	GroupAuditKickMember        = "kick_member"
+	GroupAuditSetMemberInfo     = "set_member_info"
+	GroupAuditSetMemberRole     = "set_member_role"
+	GroupAuditTransferOwner     = "transfer_owner"
+	GroupAuditDismiss           = "dismiss"
+	GroupAuditAcceptApplication = "accept_application"
+	GroupAuditRefuseApplication = "refuse_application"
+	GroupAuditBanMember         = "ban_member"
+	GroupAuditUnbanMember       = "unban_member"
+	GroupAuditSetSlowMode       = "set_slow_mode"
+	GroupAuditSetJoinPolicy     = "set_join_policy"
+	GroupAuditCreateInviteLink  = "create_invite_link"
+	GroupAuditRevokeInviteLinks = "revoke_invite_links"
+	GroupAuditCreateRole        = "create_role"
+	GroupAuditSetRole           = "set_role"
+	GroupAuditDeleteRole        = "delete_role"
+	GroupAuditSetMembersRoleID  = "set_members_role_id"
+	GroupAuditSetChannelPublic  = "set_channel_public"
+	GroupAuditSucceedOwner      = "succeed_owner" // 群主继任, 由系统或app管理员强制转让
+)
+
+// GroupAuditLogModel 群管理操作记录, Before/After为变更前后的值(json).
+type GroupAuditLogModel struct {
+	GroupID        string    `bson:"group_id"`
+	Action         string    `bson:"action"`
+	OperatorUserID string    `bson:"operator_user_id"`
+	TargetUserIDs  []string  `bson:"target_user_ids"`
+	Before         string    `bson:"before"`
+	After          string    `bson:"after"`
+	CreateTime     time.Time `bson:"create_time"`
+}
+
+func (GroupAuditLogModel) TableName() string {
+	return GroupAuditLog
+}
+
+// GroupAuditLogFilter 为空的字段不参与过滤.
+type GroupAuditLogFilter struct {
+	GroupID        string
+	OperatorUserID string
+	TargetUserID   string
+	Actions        []string
+	StartTime      time.Time
+	EndTime        time.Time
+}
+
+type GroupAuditLogModelInterface interface {
+	Create(ctx context.Context, logs []*GroupAuditLogModel) error
+	Page(ctx context.Context, filter *GroupAuditLogFilter, pageNumber, showNumber int32) (total int64, logs []*GroupAuditLogModel, err error)
+}
@@ -0,0 +1,95 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	GroupJoinPolicy = "group_join_policy"
+	GroupJoinAnswer = "group_join_answer"
+)
+
+// 入群规则类型.
+const (
+	GroupJoinRuleFriendOfAdmin = "friendOfAdmin" // 申请人是群主或管理员的好友
+	GroupJoinRuleExMatch       = "exMatch"       // 申请人用户信息Ex(json)中Key的值等于Value
+	GroupJoinRuleAnswerCorrect = "answerCorrect" // 所有入群问题回答正确
+	GroupJoinRuleAnswerWrong   = "answerWrong"   // 存在回答错误的入群问题
+)
+
+type GroupEntryQuestionModel struct {
+	QuestionID string `bson:"question_id"`
+	Question   string `bson:"question"`
+	// 忽略大小写和首尾空格后等于其中一个即回答正确, 为空时任何回答都正确
+	Answers []string `bson:"answers"`
+}
+
+type GroupJoinRuleModel struct {
+	Type string `bson:"type"`
+	// constant.GroupResponseAgree or constant.GroupResponseRefuse
+	Action int32  `bson:"action"`
+	Key    string `bson:"key"`
+	Value  string `bson:"value"`
+}
+
+// GroupJoinPolicyModel 入群申请按顺序匹配Rules, 第一个命中的规则自动通过或拒绝, 都不命中时由管理员审批.
+type GroupJoinPolicyModel struct {
+	GroupID   string                     `bson:"group_id"`
+	Questions []*GroupEntryQuestionModel `bson:"questions"`
+	Rules     []*GroupJoinRuleModel      `bson:"rules"`
+	// 未处理的入群申请超过该秒数后被清理, 0 不过期
+	RequestExpire int64     `bson:"request_expire"`
+	OpUserID      string    `bson:"op_user_id"`
+	UpdateTime    time.Time `bson:"update_time"`
+}
+
+func (GroupJoinPolicyModel) TableName() string {
+	return GroupJoinPolicy
+}
+
+type GroupEntryAnswerModel struct {
+	QuestionID string `bson:"question_id"`
+	Answer     string `bson:"answer"`
+}
+
+// GroupJoinAnswerModel 入群申请中对入群问题的回答.
+type GroupJoinAnswerModel struct {
+	GroupID    string                   `bson:"group_id"`
+	UserID     string                   `bson:"user_id"`
+	Answers    []*GroupEntryAnswerModel `bson:"answers"`
+	CreateTime time.Time                `bson:"create_time"`
+}
+
+func (GroupJoinAnswerModel) TableName() string {
+	return GroupJoinAnswer
+}
+
+type GroupJoinPolicyModelInterface interface {
+	Set(ctx context.Context, policy *GroupJoinPolicyModel) error
+	// Take 未设置时返回nil
+	Take(ctx context.Context, groupID string) (*GroupJoinPolicyModel, error)
+	Delete(ctx context.Context, groupID string) error
+	FindRequestExpire(ctx context.Context) ([]*GroupJoinPolicyModel, error)
+}
+
+type GroupJoinAnswerModelInterface interface {
+	Set(ctx context.Context, answer *GroupJoinAnswerModel) error
+	Find(ctx context.Context, groupID string, userIDs []string) ([]*GroupJoinAnswerModel, error)
+	Delete(ctx context.Context, groupID string, userIDs []string) error
+	DeleteUser(ctx context.Context, userID string) error
+}
@@ -0,0 +1,51 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	MsgMention = "msg_mention"
+)
+
+// MentionModel one @ in a group message. @all is stored once with an empty UserID and AtAll set.
+type MentionModel struct {
+	UserID         string    `bson:"user_id"`
+	ConversationID string    `bson:"conversation_id"`
+	GroupID        string    `bson:"group_id"`
+	Seq            int64     `bson:"seq"`
+	SendID         string    `bson:"send_id"`
+	ClientMsgID    string    `bson:"client_msg_id"`
+	SendTime       int64     `bson:"send_time"`
+	AtAll          bool      `bson:"at_all"`
+	CreateTime     time.Time `bson:"create_time"`
+}
+
+func (MentionModel) TableName() string {
+	return MsgMention
+}
+
+type MentionModelInterface interface {
+	Create(ctx context.Context, mentions []*MentionModel) error
+	// FindUserMentions the mentions of userID in conversationIDs, newest first.
+	// minSeqs are exclusive lower bounds by conversation id, conversations absent from minSeqs have no bound.
+	// @all sent before joinTimes (milliseconds) of the conversation are excluded.
+	FindUserMentions(ctx context.Context, userID string, conversationIDs []string, minSeqs map[string]int64, joinTimes map[string]int64, pageNumber, showNumber int32) (total int64, mentions []*MentionModel, err error)
+	// DeleteUser deletes the mentions of userID, returns the number deleted
+	DeleteUser(ctx context.Context, userID string) (int64, error)
+}
@@ -130,6 +130,8 @@ type MsgDocModelInterface interface {
 		showNumber int32,
 	) (msgCount int64, userCount int64, groups []*GroupCount, dateCount map[string]int64, err error)
 	ConvertMsgsDocLen(ctx context.Context, conversationIDs []string)
+	// AnonymizeUserMsgs clears the sender nickname and face url of the msgs sent by userID
+	AnonymizeUserMsgs(ctx context.Context, userID string) (int64, error)
 }
 
 func (MsgDocModel) TableName() string {
@@ -0,0 +1,49 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	MsgPin = "msg_pin"
+)
+
+// MsgPinModel a pinned message of a conversation, single and group chats share the conversation id of both sides.
+type MsgPinModel struct {
+	ConversationID string    `bson:"conversation_id"`
+	Seq            int64     `bson:"seq"`
+	PinUserID      string    `bson:"pin_user_id"`
+	PinTime        time.Time `bson:"pin_time"`
+}
+
+func (MsgPinModel) TableName() string {
+	return MsgPin
+}
+
+type MsgPinModelInterface interface {
+	// Create returns false when the message is already pinned
+	Create(ctx context.Context, pin *MsgPinModel) (bool, error)
+	Delete(ctx context.Context, conversationID string, seqs []int64) (int64, error)
+	Count(ctx context.Context, conversationID string) (int64, error)
+	// Rank the number of pins of the conversation created no later than the pin of seq, 0 if seq is not pinned
+	Rank(ctx context.Context, conversationID string, seq int64) (int64, error)
+	// Find the pins of a conversation, newest first
+	Find(ctx context.Context, conversationID string) ([]*MsgPinModel, error)
+	// DeletePinUser deletes the pins made by userID in all conversations, returns the number deleted
+	DeletePinUser(ctx context.Context, userID string) (int64, error)
+}
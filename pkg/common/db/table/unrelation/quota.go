@@ -0,0 +1,52 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	Quota = "quota"
+)
+
+const (
+	QuotaKindUserMsg      = "userMsg"      // messages sent by a user
+	QuotaKindUserFileSize = "userFileSize" // bytes uploaded by a user
+	QuotaKindGroupMsg     = "groupMsg"     // messages sent in a group
+	// friend requests sent by a user
+	QuotaKindUserFriendRequest = "userFriendRequest"
+)
+
+// QuotaModel overrides the configured limit of one kind for a user or a group.
+type QuotaModel struct {
+	Kind     string `bson:"kind"`
+	TargetID string `bson:"target_id"`
+	// 0 means unlimited
+	Limit      int64     `bson:"limit"`
+	OpUserID   string    `bson:"op_user_id"`
+	UpdateTime time.Time `bson:"update_time"`
+}
+
+func (QuotaModel) TableName() string {
+	return Quota
+}
+
+type QuotaModelInterface interface {
+	Set(ctx context.Context, quota *QuotaModel) error
+	Delete(ctx context.Context, kind string, targetID string) error
+	Find(ctx context.Context, targetID string) ([]*QuotaModel, error)
+}
@@ -43,4 +43,6 @@ type UserModelInterface interface {
 	GetAllSubscribeList(ctx context.Context, id string) (userIDList []string, err error)
 	// GetSubscribedList Get the user subscribed by those users
 	GetSubscribedList(ctx context.Context, id string) (userIDList []string, err error)
+	// DeleteUser Delete the lists of the user and remove the user from the other lists, returns the changed lists
+	DeleteUser(ctx context.Context, userID string) (int64, error)
 }
@@ -0,0 +1,36 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	UserActivity = "user_activity"
+)
+
+// UserActivityModel 用户最近一次登录或发送消息的时间.
+type UserActivityModel struct {
+	UserID         string    `bson:"user_id"          json:"userID"`
+	LastActiveTime time.Time `bson:"last_active_time" json:"lastActiveTime"`
+}
+
+type UserActivityModelInterface interface {
+	// Touch 将用户的最近活跃时间推进到activeTime, 已记录的时间更晚时不变
+	Touch(ctx context.Context, userIDs []string, activeTime time.Time) error
+	Find(ctx context.Context, userIDs []string) ([]*UserActivityModel, error)
+}
@@ -0,0 +1,121 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	UserEraseJob = "user_erase_job"
+)
+
+// user erase job status.
+const (
+	UserEraseStatusPending  = 0
+	UserEraseStatusRunning  = 1
+	UserEraseStatusFinished = 2
+	UserEraseStatusFailed   = 3
+)
+
+// user erase step status.
+const (
+	UserEraseStepPending  = 0
+	UserEraseStepFinished = 1
+	UserEraseStepFailed   = 2
+	UserEraseStepSkipped  = 3
+)
+
+// user erase steps, run in this order.
+const (
+	UserEraseStepDeactivate    = "deactivate"     // deactivate the account, revoke tokens and kick connections
+	UserEraseStepGroups        = "groups"         // hand owned groups over, dismiss the groups without successor, quit the others
+	UserEraseStepChannels      = "channels"       // channel subscriptions
+	UserEraseStepGroupRequests = "group_requests" // group join requests and their answers
+	UserEraseStepFriends       = "friends"        // friendships in both directions and friend requests
+	UserEraseStepLabels        = "labels"         // friend labels of the user and the user in the labels of others
+	UserEraseStepBlacks        = "blacks"         // blacklists in both directions
+	UserEraseStepConversations = "conversations"  // conversation records of the user
+	UserEraseStepSubscriptions = "subscriptions"  // presence subscription lists
+	UserEraseStepMentions      = "mentions"       // mentions of the user
+	UserEraseStepPins          = "pins"           // messages pinned by the user
+	UserEraseStepE2EE          = "e2ee"           // keys of all devices and the devices in sender key distributions
+	UserEraseStepObjects       = "objects"        // uploaded objects
+	UserEraseStepMessages      = "messages"       // clear sender nickname and face url of the sent messages, optional
+	UserEraseStepUser          = "user"           // the user record and version logs
+)
+
+// UserEraseSteps the steps of a job in running order.
+var UserEraseSteps = []string{
+	UserEraseStepDeactivate,
+	UserEraseStepGroups,
+	UserEraseStepChannels,
+	UserEraseStepGroupRequests,
+	UserEraseStepFriends,
+	UserEraseStepLabels,
+	UserEraseStepBlacks,
+	UserEraseStepConversations,
+	UserEraseStepSubscriptions,
+	UserEraseStepMentions,
+	UserEraseStepPins,
+	UserEraseStepE2EE,
+	UserEraseStepObjects,
+	UserEraseStepMessages,
+	UserEraseStepUser,
+}
+
+type UserEraseStepModel struct {
+	Name   string `bson:"name"`
+	Status int32  `bson:"status"`
+	// records removed or changed by the step
+	Count      int64     `bson:"count"`
+	ErrMsg     string    `bson:"err_msg"`
+	FinishTime time.Time `bson:"finish_time"`
+}
+
+type UserEraseJobModel struct {
+	JobID         string                `bson:"job_id"`
+	UserID        string                `bson:"user_id"`
+	OpUserID      string                `bson:"op_user_id"`
+	AnonymizeMsgs bool                  `bson:"anonymize_msgs"`
+	Status        int32                 `bson:"status"`
+	Steps         []*UserEraseStepModel `bson:"steps"`
+	ErrMsg        string                `bson:"err_msg"`
+	CreateTime    time.Time             `bson:"create_time"`
+	StartTime     time.Time             `bson:"start_time"`
+	UpdateTime    time.Time             `bson:"update_time"`
+	FinishTime    time.Time             `bson:"finish_time"`
+}
+
+func (UserEraseJobModel) TableName() string {
+	return UserEraseJob
+}
+
+type UserEraseJobModelInterface interface {
+	Create(ctx context.Context, job *UserEraseJobModel) error
+	Take(ctx context.Context, jobID string) (*UserEraseJobModel, error)
+	// TakeUnfinished returns the pending or running job of the user, nil when there is none
+	TakeUnfinished(ctx context.Context, userID string) (*UserEraseJobModel, error)
+	// userID empty matches all users, status empty matches all jobs
+	Page(ctx context.Context, userID string, status []int32, pageNumber, showNumber int32) (total int64, jobs []*UserEraseJobModel, err error)
+	// Claim marks the oldest pending job, or a running job not updated since staleTime, as running.
+	// returns nil when there is no job to run
+	Claim(ctx context.Context, staleTime time.Time) (*UserEraseJobModel, error)
+	// UpdateStep only updates running jobs, false means the job is no longer running
+	UpdateStep(ctx context.Context, jobID string, index int, step *UserEraseStepModel) (bool, error)
+	// Finish sets the final status of a running job
+	Finish(ctx context.Context, jobID string, status int32, errMsg string) error
+}
@@ -0,0 +1,73 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	UserExportJob = "user_export_job"
+)
+
+// user export job status.
+const (
+	UserExportStatusPending  = 0
+	UserExportStatusRunning  = 1
+	UserExportStatusFinished = 2
+	UserExportStatusFailed   = 3
+)
+
+// where the archive of a user export job is written.
+const (
+	UserExportStorageLocal  = "local"  // a directory of json files on the host running the job
+	UserExportStorageObject = "object" // a zip uploaded to the object storage
+)
+
+type UserExportJobModel struct {
+	JobID    string `bson:"job_id"`
+	UserID   string `bson:"user_id"`
+	OpUserID string `bson:"op_user_id"`
+	Status   int32  `bson:"status"`
+	Storage  string `bson:"storage"`
+	// the directory for local storage, the object name for object storage
+	Location string `bson:"location"`
+	// records exported of each part, e.g. friends, messages
+	Counts     map[string]int64 `bson:"counts"`
+	ErrMsg     string           `bson:"err_msg"`
+	CreateTime time.Time        `bson:"create_time"`
+	StartTime  time.Time        `bson:"start_time"`
+	UpdateTime time.Time        `bson:"update_time"`
+	FinishTime time.Time        `bson:"finish_time"`
+}
+
+func (UserExportJobModel) TableName() string {
+	return UserExportJob
+}
+
+type UserExportJobModelInterface interface {
+	Create(ctx context.Context, job *UserExportJobModel) error
+	Take(ctx context.Context, jobID string) (*UserExportJobModel, error)
+	// userID empty matches all users, status empty matches all jobs
+	Page(ctx context.Context, userID string, status []int32, pageNumber, showNumber int32) (total int64, jobs []*UserExportJobModel, err error)
+	// Claim marks the oldest pending job, or a running job not updated since staleTime, as running.
+	// returns nil when there is no job to run
+	Claim(ctx context.Context, staleTime time.Time) (*UserExportJobModel, error)
+	// Heartbeat updates the update time of a running job, false means the job is no longer running
+	Heartbeat(ctx context.Context, jobID string) (bool, error)
+	// Finish sets the final status of a running job
+	Finish(ctx context.Context, jobID string, status int32, location string, counts map[string]int64, errMsg string) error
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	UserSuspension = "user_suspension"
+)
+
+// user suspension scopes.
+const (
+	UserSuspensionScopeLogin       = "login"       // get token, call api and connect to the gateway
+	UserSuspensionScopeSendMsg     = "sendMsg"     // send messages except notifications
+	UserSuspensionScopeCreateGroup = "createGroup" // create groups
+)
+
+// user suspension status, records are never deleted and make up the audit trail.
+const (
+	UserSuspensionStatusActive  = 0
+	UserSuspensionStatusLifted  = 1 // lifted by an admin before the end time
+	UserSuspensionStatusExpired = 2 // lifted automatically at the end time
+)
+
+type UserSuspensionModel struct {
+	SuspensionID string    `bson:"suspension_id" json:"suspensionID"`
+	UserID       string    `bson:"user_id"       json:"userID"`
+	Scopes       []string  `bson:"scopes"        json:"scopes"`
+	Reason       string    `bson:"reason"        json:"reason"`
+	OpUserID     string    `bson:"op_user_id"    json:"opUserID"`
+	StartTime    time.Time `bson:"start_time"    json:"startTime"`
+	// zero means the suspension has no end time
+	EndTime      time.Time `bson:"end_time"        json:"endTime"`
+	Status       int32     `bson:"status"          json:"status"`
+	LiftOpUserID string    `bson:"lift_op_user_id" json:"liftOpUserID"`
+	LiftReason   string    `bson:"lift_reason"     json:"liftReason"`
+	LiftTime     time.Time `bson:"lift_time"       json:"liftTime"`
+	CreateTime   time.Time `bson:"create_time"     json:"createTime"`
+}
+
+// IsActive reports whether the suspension is in force at now.
+func (s *UserSuspensionModel) IsActive(now time.Time) bool {
+	if s.Status != UserSuspensionStatusActive {
+		return false
+	}
+	return s.EndTime.IsZero() || now.Before(s.EndTime)
+}
+
+func (s *UserSuspensionModel) HasScope(scope string) bool {
+	for _, v := range s.Scopes {
+		if v == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type UserSuspensionModelInterface interface {
+	Create(ctx context.Context, suspension *UserSuspensionModel) error
+	Take(ctx context.Context, suspensionID string) (*UserSuspensionModel, error)
+	// FindActive returns the suspensions of the user in active status, including the ones past the end time not expired yet
+	FindActive(ctx context.Context, userID string) ([]*UserSuspensionModel, error)
+	// userID empty matches all users, status empty matches all suspensions
+	Page(ctx context.Context, userID string, status []int32, pageNumber, showNumber int32) (total int64, suspensions []*UserSuspensionModel, err error)
+	// Lift sets the status of an active suspension, false means the suspension is not active
+	Lift(ctx context.Context, suspensionID string, status int32, opUserID string, reason string, liftTime time.Time) (bool, error)
+	// FindExpired returns the active suspensions whose end time is before now
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*UserSuspensionModel, error)
+}
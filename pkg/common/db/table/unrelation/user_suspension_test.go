@@ -0,0 +1,64 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UserSuspensionIsActive(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		suspension UserSuspensionModel
+		expected   bool
+	}{
+		{"no end time", UserSuspensionModel{Status: UserSuspensionStatusActive}, true},
+		{"before end time", UserSuspensionModel{Status: UserSuspensionStatusActive, EndTime: now.Add(time.Second)}, true},
+		{"at end time", UserSuspensionModel{Status: UserSuspensionStatusActive, EndTime: now}, false},
+		{"past end time not expired yet", UserSuspensionModel{Status: UserSuspensionStatusActive, EndTime: now.Add(-time.Hour)}, false},
+		{"lifted", UserSuspensionModel{Status: UserSuspensionStatusLifted}, false},
+		{"expired", UserSuspensionModel{Status: UserSuspensionStatusExpired, EndTime: now.Add(time.Hour)}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.suspension.IsActive(now))
+		})
+	}
+}
+
+func Test_UserSuspensionHasScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []string
+		scope    string
+		expected bool
+	}{
+		{"no scopes", nil, UserSuspensionScopeLogin, false},
+		{"single scope", []string{UserSuspensionScopeSendMsg}, UserSuspensionScopeSendMsg, true},
+		{"other scope", []string{UserSuspensionScopeSendMsg}, UserSuspensionScopeLogin, false},
+		{"multiple scopes", []string{UserSuspensionScopeLogin, UserSuspensionScopeCreateGroup}, UserSuspensionScopeCreateGroup, true},
+		{"case sensitive", []string{"sendmsg"}, UserSuspensionScopeSendMsg, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			suspension := UserSuspensionModel{Scopes: test.scopes}
+			assert.Equal(t, test.expected, suspension.HasScope(test.scope))
+		})
+	}
+}
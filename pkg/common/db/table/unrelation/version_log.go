@@ -0,0 +1,105 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	Version    = "version"
+	VersionLog = "version_log"
+)
+
+// version domains, the did of a domain is the id owning the list.
+const (
+	VersionDomainFriend       = "friend"       // did: owner user id, eid: friend user id
+	VersionDomainJoinedGroup  = "joinedGroup"  // did: user id, eid: group id
+	VersionDomainGroupMember  = "groupMember"  // did: group id, eid: member user id
+	VersionDomainConversation = "conversation" // did: owner user id, eid: conversation id
+)
+
+// states of an element in the version log.
+const (
+	VersionStateInsert = 1
+	VersionStateUpdate = 2
+	VersionStateDelete = 3
+)
+
+// VersionModel the current version of a list, increased by one on every change.
+type VersionModel struct {
+	Domain  string `bson:"domain"`
+	DID     string `bson:"did"`
+	Version int64  `bson:"version"`
+	// deletions not after this version have been truncated, or the changes of this version were lost
+	TruncatedVersion int64 `bson:"truncated_version"`
+	// the versions reserved but whose logs are not written yet
+	Pending []*VersionPending `bson:"pending"`
+}
+
+func (VersionModel) TableName() string {
+	return Version
+}
+
+// VisibleVersion the logs of all versions not after it have been written.
+func (v *VersionModel) VisibleVersion() int64 {
+	version := v.Version
+	for _, pending := range v.Pending {
+		if pending.Version <= version {
+			version = pending.Version - 1
+		}
+	}
+	return version
+}
+
+// VersionPending a version being written by IncrVersion.
+type VersionPending struct {
+	Token     string    `bson:"token"`
+	Version   int64     `bson:"version"`
+	StartTime time.Time `bson:"start_time"`
+}
+
+// VersionLogModel the latest change of an element in a list.
+type VersionLogModel struct {
+	Domain     string    `bson:"domain"`
+	DID        string    `bson:"did"`
+	EID        string    `bson:"eid"`
+	State      int32     `bson:"state"`
+	Version    int64     `bson:"version"`
+	UpdateTime time.Time `bson:"update_time"`
+}
+
+func (VersionLogModel) TableName() string {
+	return VersionLog
+}
+
+type VersionLogModelInterface interface {
+	// IncrVersion increases the version of each did by one and records the state of eids at the new version,
+	// the new version stays pending until its logs are written, if the logs can not be written the version is truncated
+	IncrVersion(ctx context.Context, domain string, dids []string, eids []string, state int32) error
+	// FindVersions the dids never changed are not returned
+	FindVersions(ctx context.Context, domain string, dids []string) ([]*VersionModel, error)
+	// FindLogsAfter the changes after version and not after until in version order, at most limit
+	FindLogsAfter(ctx context.Context, domain string, did string, version int64, until int64, limit int) ([]*VersionLogModel, error)
+	// FindLogsAt the changes at version
+	FindLogsAt(ctx context.Context, domain string, did string, version int64) ([]*VersionLogModel, error)
+	// ExpirePending truncates the pending versions started before, their writers are gone
+	ExpirePending(ctx context.Context, domain string, did string, before time.Time) error
+	// TruncateDeleted deletes the deletions before and marks the truncated version of their lists
+	TruncateDeleted(ctx context.Context, before time.Time) (int64, error)
+	// DeleteDID deletes the version and the logs of the list owned by did
+	DeleteDID(ctx context.Context, domain string, did string) error
+}
@@ -0,0 +1,141 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewBroadcastMongoDriver(database *mongo.Database) unrelation.BroadcastJobModelInterface {
+	return &BroadcastMongoDriver{
+		jobCollection: database.Collection(unrelation.BroadcastJob),
+	}
+}
+
+type BroadcastMongoDriver struct {
+	jobCollection *mongo.Collection
+}
+
+func (b *BroadcastMongoDriver) Create(ctx context.Context, job *unrelation.BroadcastJobModel) error {
+	_, err := b.jobCollection.InsertOne(ctx, job)
+	return errs.Wrap(err)
+}
+
+func (b *BroadcastMongoDriver) Take(ctx context.Context, jobID string) (*unrelation.BroadcastJobModel, error) {
+	var job unrelation.BroadcastJobModel
+	if err := b.jobCollection.FindOne(ctx, bson.M{"job_id": jobID}).Decode(&job); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &job, nil
+}
+
+func (b *BroadcastMongoDriver) Page(ctx context.Context, status []int32, pageNumber, showNumber int32) (int64, []*unrelation.BroadcastJobModel, error) {
+	filter := bson.M{}
+	if len(status) > 0 {
+		filter["status"] = bson.M{"$in": status}
+	}
+	total, err := b.jobCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().
+		SetSort(bson.M{"create_time": -1}).
+		SetSkip(int64(pageNumber-1) * int64(showNumber)).
+		SetLimit(int64(showNumber)).
+		// the recipients list may be huge, it is not needed in the list
+		SetProjection(bson.M{"segment.user_ids": 0, "msg_data": 0})
+	cur, err := b.jobCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var jobs []*unrelation.BroadcastJobModel
+	if err := cur.All(ctx, &jobs); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return total, jobs, nil
+}
+
+func (b *BroadcastMongoDriver) Claim(ctx context.Context, staleTime time.Time, leaseID string) (*unrelation.BroadcastJobModel, error) {
+	now := time.Now()
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": unrelation.BroadcastStatusPending},
+		bson.M{"status": unrelation.BroadcastStatusRunning, "update_time": bson.M{"$lt": staleTime}},
+	}}
+	update := bson.M{"$set": bson.M{"status": unrelation.BroadcastStatusRunning, "lease_id": leaseID, "update_time": now}}
+	opts := options.FindOneAndUpdate().SetSort(bson.M{"create_time": 1}).SetReturnDocument(options.After)
+	var job unrelation.BroadcastJobModel
+	if err := b.jobCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	if job.StartTime.IsZero() {
+		if _, err := b.UpdateByMap(ctx, job.JobID, leaseID, map[string]any{"start_time": now}); err != nil {
+			return nil, err
+		}
+		job.StartTime = now
+	}
+	return &job, nil
+}
+
+func (b *BroadcastMongoDriver) UpdateByMap(ctx context.Context, jobID string, leaseID string, args map[string]any) (bool, error) {
+	set := bson.M{"update_time": time.Now()}
+	for k, v := range args {
+		set[k] = v
+	}
+	filter := bson.M{"job_id": jobID, "status": unrelation.BroadcastStatusRunning, "lease_id": leaseID}
+	res, err := b.jobCollection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (b *BroadcastMongoDriver) AddProgress(ctx context.Context, jobID string, leaseID string, cursor string, successCount, failedCount int64, failedUserIDs []string) (bool, error) {
+	update := bson.M{
+		"$set": bson.M{"cursor": cursor, "update_time": time.Now()},
+		"$inc": bson.M{"success_count": successCount, "failed_count": failedCount},
+	}
+	if len(failedUserIDs) > 0 {
+		update["$push"] = bson.M{"failed_user_ids": bson.M{"$each": failedUserIDs, "$slice": unrelation.MaxBroadcastFailedUserIDs}}
+	}
+	filter := bson.M{"job_id": jobID, "status": unrelation.BroadcastStatusRunning, "lease_id": leaseID}
+	res, err := b.jobCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (b *BroadcastMongoDriver) Cancel(ctx context.Context, jobID string) (bool, error) {
+	now := time.Now()
+	filter := bson.M{"job_id": jobID, "status": bson.M{"$in": []int32{unrelation.BroadcastStatusPending, unrelation.BroadcastStatusRunning}}}
+	update := bson.M{"$set": bson.M{"status": unrelation.BroadcastStatusCancelled, "update_time": now, "finish_time": now}}
+	res, err := b.jobCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.MatchedCount > 0, nil
+}
@@ -0,0 +1,208 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewE2EEKeyBundleMongoDriver(database *mongo.Database) unrelation.E2EEKeyBundleModelInterface {
+	return &E2EEKeyBundleMongoDriver{
+		bundleCollection: database.Collection(unrelation.E2EEKeyBundle),
+	}
+}
+
+type E2EEKeyBundleMongoDriver struct {
+	bundleCollection *mongo.Collection
+}
+
+func (e *E2EEKeyBundleMongoDriver) Set(ctx context.Context, bundle *unrelation.E2EEKeyBundleModel) (*unrelation.E2EEKeyBundleModel, error) {
+	filter := bson.M{"user_id": bundle.UserID, "device_id": bundle.DeviceID}
+	update := bson.M{
+		"$set": bson.M{
+			"registration_id": bundle.RegistrationID,
+			"identity_key":    bundle.IdentityKey,
+			"signed_pre_key":  bundle.SignedPreKey,
+			"update_time":     bundle.UpdateTime,
+		},
+		"$setOnInsert": bson.M{"create_time": bundle.CreateTime},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	var old unrelation.E2EEKeyBundleModel
+	if err := e.bundleCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&old); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	return &old, nil
+}
+
+func (e *E2EEKeyBundleMongoDriver) Find(ctx context.Context, userID string, deviceIDs []string) ([]*unrelation.E2EEKeyBundleModel, error) {
+	filter := bson.M{"user_id": userID}
+	if len(deviceIDs) > 0 {
+		filter["device_id"] = bson.M{"$in": deviceIDs}
+	}
+	cur, err := e.bundleCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var bundles []*unrelation.E2EEKeyBundleModel
+	if err := cur.All(ctx, &bundles); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return bundles, nil
+}
+
+func (e *E2EEKeyBundleMongoDriver) Delete(ctx context.Context, userID string, deviceID string) error {
+	_, err := e.bundleCollection.DeleteOne(ctx, bson.M{"user_id": userID, "device_id": deviceID})
+	return errs.Wrap(err)
+}
+
+func (e *E2EEKeyBundleMongoDriver) DeleteUser(ctx context.Context, userID string) (int64, error) {
+	res, err := e.bundleCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return res.DeletedCount, nil
+}
+
+func NewE2EEOneTimePreKeyMongoDriver(database *mongo.Database) unrelation.E2EEOneTimePreKeyModelInterface {
+	return &E2EEOneTimePreKeyMongoDriver{
+		preKeyCollection: database.Collection(unrelation.E2EEOneTimePreKey),
+	}
+}
+
+type E2EEOneTimePreKeyMongoDriver struct {
+	preKeyCollection *mongo.Collection
+}
+
+func (e *E2EEOneTimePreKeyMongoDriver) Create(ctx context.Context, preKeys []*unrelation.E2EEOneTimePreKeyModel) error {
+	if len(preKeys) == 0 {
+		return nil
+	}
+	docs := utils.Slice(preKeys, func(e *unrelation.E2EEOneTimePreKeyModel) any { return e })
+	_, err := e.preKeyCollection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+func (e *E2EEOneTimePreKeyMongoDriver) Take(ctx context.Context, userID string, deviceID string) (*unrelation.E2EEOneTimePreKeyModel, error) {
+	filter := bson.M{"user_id": userID, "device_id": deviceID}
+	opts := options.FindOneAndDelete().SetSort(bson.D{{Key: "key_id", Value: 1}})
+	var preKey unrelation.E2EEOneTimePreKeyModel
+	if err := e.preKeyCollection.FindOneAndDelete(ctx, filter, opts).Decode(&preKey); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	return &preKey, nil
+}
+
+func (e *E2EEOneTimePreKeyMongoDriver) Count(ctx context.Context, userID string, deviceID string) (int64, error) {
+	count, err := e.preKeyCollection.CountDocuments(ctx, bson.M{"user_id": userID, "device_id": deviceID})
+	return count, errs.Wrap(err)
+}
+
+func (e *E2EEOneTimePreKeyMongoDriver) DeleteDevice(ctx context.Context, userID string, deviceID string) error {
+	_, err := e.preKeyCollection.DeleteMany(ctx, bson.M{"user_id": userID, "device_id": deviceID})
+	return errs.Wrap(err)
+}
+
+func (e *E2EEOneTimePreKeyMongoDriver) DeleteUser(ctx context.Context, userID string) (int64, error) {
+	res, err := e.preKeyCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return res.DeletedCount, nil
+}
+
+func NewE2EESenderKeyMongoDriver(database *mongo.Database) unrelation.E2EESenderKeyModelInterface {
+	return &E2EESenderKeyMongoDriver{
+		senderKeyCollection: database.Collection(unrelation.E2EESenderKey),
+	}
+}
+
+type E2EESenderKeyMongoDriver struct {
+	senderKeyCollection *mongo.Collection
+}
+
+// Set the recipients are merged while the distribution is the same, and reset when the device has rotated its sender key.
+// it is a single upsert, so concurrent first writes of a device do not race.
+func (e *E2EESenderKeyMongoDriver) Set(ctx context.Context, senderKey *unrelation.E2EESenderKeyModel) error {
+	recipients := senderKey.Recipients
+	if recipients == nil {
+		recipients = []string{}
+	}
+	filter := bson.M{"group_id": senderKey.GroupID, "user_id": senderKey.UserID, "device_id": senderKey.DeviceID}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"recipients": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$distribution_id", senderKey.DistributionID}},
+				bson.M{"$setUnion": bson.A{bson.M{"$ifNull": bson.A{"$recipients", bson.A{}}}, recipients}},
+				bson.M{"$setUnion": bson.A{recipients}},
+			}},
+			"distribution_id": senderKey.DistributionID,
+			"update_time":     senderKey.UpdateTime,
+		}}},
+	}
+	_, err := e.senderKeyCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return errs.Wrap(err)
+}
+
+func (e *E2EESenderKeyMongoDriver) Find(ctx context.Context, groupID string, userIDs []string) ([]*unrelation.E2EESenderKeyModel, error) {
+	filter := bson.M{"group_id": groupID}
+	if len(userIDs) > 0 {
+		filter["user_id"] = bson.M{"$in": userIDs}
+	}
+	cur, err := e.senderKeyCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var senderKeys []*unrelation.E2EESenderKeyModel
+	if err := cur.All(ctx, &senderKeys); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return senderKeys, nil
+}
+
+func (e *E2EESenderKeyMongoDriver) DeleteDevice(ctx context.Context, userID string, deviceID string) error {
+	_, err := e.senderKeyCollection.DeleteMany(ctx, bson.M{"user_id": userID, "device_id": deviceID})
+	return errs.Wrap(err)
+}
+
+func (e *E2EESenderKeyMongoDriver) DeleteUser(ctx context.Context, userID string) (int64, error) {
+	res, err := e.senderKeyCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	// recipients are userID/deviceID
+	recipient := bson.M{"$regex": "^" + regexp.QuoteMeta(userID+"/")}
+	_, err = e.senderKeyCollection.UpdateMany(ctx, bson.M{"recipients": recipient}, bson.M{"$pull": bson.M{"recipients": recipient}})
+	return res.DeletedCount, errs.Wrap(err)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewGroupAnnouncementMongoDriver(database *mongo.Database) unrelation.GroupAnnouncementModelInterface {
+	return &GroupAnnouncementMongoDriver{
+		announcementCollection: database.Collection(unrelation.GroupAnnouncement),
+	}
+}
+
+type GroupAnnouncementMongoDriver struct {
+	announcementCollection *mongo.Collection
+}
+
+func (g *GroupAnnouncementMongoDriver) Create(ctx context.Context, announcement *unrelation.GroupAnnouncementModel) error {
+	_, err := g.announcementCollection.InsertOne(ctx, announcement)
+	return errs.Wrap(err)
+}
+
+func (g *GroupAnnouncementMongoDriver) Page(
+	ctx context.Context,
+	groupID string,
+	pageNumber, showNumber int32,
+) (int64, []*unrelation.GroupAnnouncementModel, error) {
+	filter := bson.M{"group_id": groupID}
+	total, err := g.announcementCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().
+		SetSort(bson.M{"create_time": -1}).
+		SetSkip(int64(pageNumber-1) * int64(showNumber)).
+		SetLimit(int64(showNumber))
+	cur, err := g.announcementCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var announcements []*unrelation.GroupAnnouncementModel
+	if err := cur.All(ctx, &announcements); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return total, announcements, nil
+}
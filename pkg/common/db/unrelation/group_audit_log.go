@@ -0,0 +1,93 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewGroupAuditLogMongoDriver(database *mongo.Database) unrelation.GroupAuditLogModelInterface {
+	return &GroupAuditLogMongoDriver{
+		logCollection: database.Collection(unrelation.GroupAuditLog),
+	}
+}
+
+type GroupAuditLogMongoDriver struct {
+	logCollection *mongo.Collection
+}
+
+func (g *GroupAuditLogMongoDriver) Create(ctx context.Context, logs []*unrelation.GroupAuditLogModel) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	_, err := g.logCollection.InsertMany(ctx, utils.Slice(logs, func(e *unrelation.GroupAuditLogModel) any { return e }))
+	return errs.Wrap(err)
+}
+
+func (g *GroupAuditLogMongoDriver) Page(
+	ctx context.Context,
+	filter *unrelation.GroupAuditLogFilter,
+	pageNumber, showNumber int32,
+) (int64, []*unrelation.GroupAuditLogModel, error) {
+	query := bson.M{}
+	if filter.GroupID != "" {
+		query["group_id"] = filter.GroupID
+	}
+	if filter.OperatorUserID != "" {
+		query["operator_user_id"] = filter.OperatorUserID
+	}
+	if filter.TargetUserID != "" {
+		query["target_user_ids"] = filter.TargetUserID
+	}
+	if len(filter.Actions) > 0 {
+		query["action"] = bson.M{"$in": filter.Actions}
+	}
+	createTime := bson.M{}
+	if !filter.StartTime.IsZero() {
+		createTime["$gte"] = filter.StartTime
+	}
+	if !filter.EndTime.IsZero() {
+		createTime["$lt"] = filter.EndTime
+	}
+	if len(createTime) > 0 {
+		query["create_time"] = createTime
+	}
+	total, err := g.logCollection.CountDocuments(ctx, query)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().
+		SetSort(bson.M{"create_time": -1}).
+		SetSkip(int64(pageNumber-1) * int64(showNumber)).
+		SetLimit(int64(showNumber))
+	cur, err := g.logCollection.Find(ctx, query, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var logs []*unrelation.GroupAuditLogModel
+	if err := cur.All(ctx, &logs); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return total, logs, nil
+}
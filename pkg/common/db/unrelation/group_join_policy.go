@@ -0,0 +1,112 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewGroupJoinPolicyMongoDriver(database *mongo.Database) unrelation.GroupJoinPolicyModelInterface {
+	return &GroupJoinPolicyMongoDriver{
+		policyCollection: database.Collection(unrelation.GroupJoinPolicy),
+	}
+}
+
+type GroupJoinPolicyMongoDriver struct {
+	policyCollection *mongo.Collection
+}
+
+func (g *GroupJoinPolicyMongoDriver) Set(ctx context.Context, policy *unrelation.GroupJoinPolicyModel) error {
+	_, err := g.policyCollection.ReplaceOne(ctx, bson.M{"group_id": policy.GroupID}, policy, options.Replace().SetUpsert(true))
+	return errs.Wrap(err)
+}
+
+func (g *GroupJoinPolicyMongoDriver) Take(ctx context.Context, groupID string) (*unrelation.GroupJoinPolicyModel, error) {
+	var policy unrelation.GroupJoinPolicyModel
+	if err := g.policyCollection.FindOne(ctx, bson.M{"group_id": groupID}).Decode(&policy); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	return &policy, nil
+}
+
+func (g *GroupJoinPolicyMongoDriver) Delete(ctx context.Context, groupID string) error {
+	_, err := g.policyCollection.DeleteOne(ctx, bson.M{"group_id": groupID})
+	return errs.Wrap(err)
+}
+
+func (g *GroupJoinPolicyMongoDriver) FindRequestExpire(ctx context.Context) ([]*unrelation.GroupJoinPolicyModel, error) {
+	cur, err := g.policyCollection.Find(ctx, bson.M{"request_expire": bson.M{"$gt": 0}})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var policies []*unrelation.GroupJoinPolicyModel
+	if err := cur.All(ctx, &policies); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return policies, nil
+}
+
+func NewGroupJoinAnswerMongoDriver(database *mongo.Database) unrelation.GroupJoinAnswerModelInterface {
+	return &GroupJoinAnswerMongoDriver{
+		answerCollection: database.Collection(unrelation.GroupJoinAnswer),
+	}
+}
+
+type GroupJoinAnswerMongoDriver struct {
+	answerCollection *mongo.Collection
+}
+
+func (g *GroupJoinAnswerMongoDriver) Set(ctx context.Context, answer *unrelation.GroupJoinAnswerModel) error {
+	filter := bson.M{"group_id": answer.GroupID, "user_id": answer.UserID}
+	_, err := g.answerCollection.ReplaceOne(ctx, filter, answer, options.Replace().SetUpsert(true))
+	return errs.Wrap(err)
+}
+
+func (g *GroupJoinAnswerMongoDriver) Find(
+	ctx context.Context,
+	groupID string,
+	userIDs []string,
+) ([]*unrelation.GroupJoinAnswerModel, error) {
+	cur, err := g.answerCollection.Find(ctx, bson.M{"group_id": groupID, "user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var answers []*unrelation.GroupJoinAnswerModel
+	if err := cur.All(ctx, &answers); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return answers, nil
+}
+
+func (g *GroupJoinAnswerMongoDriver) Delete(ctx context.Context, groupID string, userIDs []string) error {
+	_, err := g.answerCollection.DeleteMany(ctx, bson.M{"group_id": groupID, "user_id": bson.M{"$in": userIDs}})
+	return errs.Wrap(err)
+}
+
+func (g *GroupJoinAnswerMongoDriver) DeleteUser(ctx context.Context, userID string) error {
+	_, err := g.answerCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return errs.Wrap(err)
+}
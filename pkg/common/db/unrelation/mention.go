@@ -0,0 +1,93 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewMentionMongoDriver(database *mongo.Database) unrelation.MentionModelInterface {
+	return &MentionMongoDriver{
+		mentionCollection: database.Collection(unrelation.MsgMention),
+	}
+}
+
+type MentionMongoDriver struct {
+	mentionCollection *mongo.Collection
+}
+
+func (m *MentionMongoDriver) Create(ctx context.Context, mentions []*unrelation.MentionModel) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+	_, err := m.mentionCollection.InsertMany(ctx, utils.Slice(mentions, func(e *unrelation.MentionModel) any { return e }))
+	return errs.Wrap(err)
+}
+
+func (m *MentionMongoDriver) FindUserMentions(ctx context.Context, userID string, conversationIDs []string, minSeqs map[string]int64, joinTimes map[string]int64, pageNumber, showNumber int32) (int64, []*unrelation.MentionModel, error) {
+	if len(conversationIDs) == 0 {
+		return 0, nil, nil
+	}
+	conversations := make(bson.A, 0, len(conversationIDs))
+	for _, conversationID := range conversationIDs {
+		atAll := bson.M{"at_all": true, "send_id": bson.M{"$ne": userID}}
+		if joinTime, ok := joinTimes[conversationID]; ok {
+			atAll["send_time"] = bson.M{"$gte": joinTime}
+		}
+		filter := bson.M{
+			"conversation_id": conversationID,
+			"$or":             bson.A{bson.M{"user_id": userID}, atAll},
+		}
+		if minSeq, ok := minSeqs[conversationID]; ok {
+			filter["seq"] = bson.M{"$gt": minSeq}
+		}
+		conversations = append(conversations, filter)
+	}
+	filter := bson.M{"$or": conversations}
+	total, err := m.mentionCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "send_time", Value: -1}}).
+		SetSkip(int64(pageNumber-1) * int64(showNumber)).
+		SetLimit(int64(showNumber))
+	cur, err := m.mentionCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var mentions []*unrelation.MentionModel
+	if err := cur.All(ctx, &mentions); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return total, mentions, nil
+}
+
+func (m *MentionMongoDriver) DeleteUser(ctx context.Context, userID string) (int64, error) {
+	res, err := m.mentionCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return res.DeletedCount, nil
+}
@@ -109,6 +109,183 @@ func (m *Mongo) CreateSuperGroupIndex() error {
 	return nil
 }
 
+func (m *Mongo) CreateBroadcastIndex() error {
+	if err := m.createMongoIndex(unrelation.BroadcastJob, true, "job_id"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.BroadcastJob, false, "status", "create_time"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Mongo) CreateUserEraseIndex() error {
+	if err := m.createMongoIndex(unrelation.UserEraseJob, true, "job_id"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.UserEraseJob, false, "status", "create_time"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.UserEraseJob, false, "user_id", "create_time"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Mongo) CreateUserExportIndex() error {
+	if err := m.createMongoIndex(unrelation.UserExportJob, true, "job_id"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.UserExportJob, false, "status", "create_time"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.UserExportJob, false, "user_id", "create_time"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Mongo) CreateUserSuspensionIndex() error {
+	if err := m.createMongoIndex(unrelation.UserSuspension, true, "suspension_id"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.UserSuspension, false, "user_id", "create_time"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.UserSuspension, false, "status", "end_time"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Mongo) CreateUserActivityIndex() error {
+	return m.createMongoIndex(unrelation.UserActivity, true, "user_id")
+}
+
+func (m *Mongo) CreateQuotaIndex() error {
+	return m.createMongoIndex(unrelation.Quota, true, "target_id", "kind")
+}
+
+func (m *Mongo) CreateE2EEIndex() error {
+	if err := m.createMongoIndex(unrelation.E2EEKeyBundle, true, "user_id", "device_id"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.E2EEOneTimePreKey, true, "user_id", "device_id", "key_id"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.E2EESenderKey, true, "group_id", "user_id", "device_id"); err != nil {
+		return err
+	}
+	return m.createMongoIndex(unrelation.E2EESenderKey, false, "user_id", "device_id")
+}
+
+func (m *Mongo) CreateMentionIndex() error {
+	if err := m.createMongoIndex(unrelation.MsgMention, false, "user_id", "conversation_id", "-seq"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.MsgMention, false, "conversation_id", "at_all", "-seq"); err != nil {
+		return err
+	}
+	// mentions are kept as long as the chat records
+	return m.syncMongoTTLIndex(unrelation.MsgMention, "create_time", time.Duration(config.Config.RetainChatRecords)*24*time.Hour)
+}
+
+func (m *Mongo) CreateGroupJoinPolicyIndex() error {
+	if err := m.createMongoIndex(unrelation.GroupJoinPolicy, true, "group_id"); err != nil {
+		return err
+	}
+	return m.createMongoIndex(unrelation.GroupJoinAnswer, true, "group_id", "user_id")
+}
+
+func (m *Mongo) CreateGroupAuditLogIndex() error {
+	if err := m.createMongoIndex(unrelation.GroupAuditLog, false, "group_id", "-create_time"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.GroupAuditLog, false, "operator_user_id", "-create_time"); err != nil {
+		return err
+	}
+	// RetainDays为0时删除TTL索引, 日志永久保留
+	return m.syncMongoTTLIndex(unrelation.GroupAuditLog, "create_time", time.Duration(config.Config.GroupAuditLog.RetainDays)*24*time.Hour)
+}
+
+func (m *Mongo) CreateGroupAnnouncementIndex() error {
+	return m.createMongoIndex(unrelation.GroupAnnouncement, false, "group_id", "-create_time")
+}
+
+func (m *Mongo) CreateMsgPinIndex() error {
+	return m.createMongoIndex(unrelation.MsgPin, true, "conversation_id", "seq")
+}
+
+func (m *Mongo) CreateVersionLogIndex() error {
+	if err := m.createMongoIndex(unrelation.Version, true, "domain", "did"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.VersionLog, true, "domain", "did", "eid"); err != nil {
+		return err
+	}
+	if err := m.createMongoIndex(unrelation.VersionLog, false, "domain", "did", "version"); err != nil {
+		return err
+	}
+	return m.createMongoIndex(unrelation.VersionLog, false, "state", "update_time")
+}
+
+// syncMongoTTLIndex 使key上的TTL索引与expire一致: expire<=0时删除索引, 索引已存在时通过collMod修改过期时间, 不存在时创建.
+func (m *Mongo) syncMongoTTLIndex(collection string, key string, expire time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	database := m.db.Database(config.Config.Mongo.Database)
+	indexView := database.Collection(collection).Indexes()
+	name := key + "_1"
+	cursor, err := indexView.List(ctx)
+	if err != nil {
+		return utils.Wrap(err, "")
+	}
+	var indexes []struct {
+		Name               string `bson:"name"`
+		ExpireAfterSeconds *int32 `bson:"expireAfterSeconds"`
+	}
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return utils.Wrap(err, "")
+	}
+	var (
+		exist   bool
+		current *int32
+	)
+	for _, index := range indexes {
+		if index.Name == name {
+			exist, current = true, index.ExpireAfterSeconds
+			break
+		}
+	}
+	seconds := int32(expire / time.Second)
+	switch {
+	case seconds <= 0:
+		if !exist {
+			return nil
+		}
+		_, err := indexView.DropOne(ctx, name)
+		return utils.Wrap(err, "")
+	case !exist:
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: key, Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(seconds),
+		}
+		result, err := indexView.CreateOne(ctx, index)
+		if err != nil {
+			return utils.Wrap(err, result)
+		}
+		return nil
+	case current != nil && *current == seconds:
+		return nil
+	default:
+		cmd := bson.D{
+			{Key: "collMod", Value: collection},
+			{Key: "index", Value: bson.D{{Key: "name", Value: name}, {Key: "expireAfterSeconds", Value: seconds}}},
+		}
+		return utils.Wrap(database.RunCommand(ctx, cmd).Err(), "")
+	}
+}
+
 func (m *Mongo) createMongoIndex(collection string, isUnique bool, keys ...string) error {
 	db := m.db.Database(config.Config.Mongo.Database).Collection(collection)
 	opts := options.CreateIndexes().SetMaxTime(10 * time.Second)
@@ -1214,3 +1214,21 @@ func (m *MsgMongoDriver) searchMessage(ctx context.Context, req *msg.SearchMessa
 	}
 	return n, msgs, nil
 }
+
+func (m *MsgMongoDriver) AnonymizeUserMsgs(ctx context.Context, userID string) (int64, error) {
+	filter := bson.M{"msgs.msg.send_id": userID}
+	update := bson.M{
+		"$set": bson.M{
+			"msgs.$[m].msg.sender_nickname": "",
+			"msgs.$[m].msg.sender_face_url": "",
+		},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []any{bson.M{"m.msg.send_id": userID}},
+	})
+	res, err := m.MsgCollection.UpdateMany(ctx, filter, update, opts)
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return res.ModifiedCount, nil
+}
@@ -0,0 +1,95 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewMsgPinMongoDriver(database *mongo.Database) unrelation.MsgPinModelInterface {
+	return &MsgPinMongoDriver{
+		pinCollection: database.Collection(unrelation.MsgPin),
+	}
+}
+
+type MsgPinMongoDriver struct {
+	pinCollection *mongo.Collection
+}
+
+func (m *MsgPinMongoDriver) Create(ctx context.Context, pin *unrelation.MsgPinModel) (bool, error) {
+	filter := bson.M{"conversation_id": pin.ConversationID, "seq": pin.Seq}
+	res, err := m.pinCollection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": pin}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+func (m *MsgPinMongoDriver) Delete(ctx context.Context, conversationID string, seqs []int64) (int64, error) {
+	res, err := m.pinCollection.DeleteMany(ctx, bson.M{"conversation_id": conversationID, "seq": bson.M{"$in": seqs}})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return res.DeletedCount, nil
+}
+
+func (m *MsgPinMongoDriver) Count(ctx context.Context, conversationID string) (int64, error) {
+	count, err := m.pinCollection.CountDocuments(ctx, bson.M{"conversation_id": conversationID})
+	return count, errs.Wrap(err)
+}
+
+func (m *MsgPinMongoDriver) Rank(ctx context.Context, conversationID string, seq int64) (int64, error) {
+	var pin struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	err := m.pinCollection.FindOne(ctx, bson.M{"conversation_id": conversationID, "seq": seq}, options.FindOne().SetProjection(bson.M{"_id": 1})).Decode(&pin)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		return 0, errs.Wrap(err)
+	}
+	count, err := m.pinCollection.CountDocuments(ctx, bson.M{"conversation_id": conversationID, "_id": bson.M{"$lte": pin.ID}})
+	return count, errs.Wrap(err)
+}
+
+func (m *MsgPinMongoDriver) Find(ctx context.Context, conversationID string) ([]*unrelation.MsgPinModel, error) {
+	cur, err := m.pinCollection.Find(ctx, bson.M{"conversation_id": conversationID}, options.Find().SetSort(bson.M{"pin_time": -1}))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var pins []*unrelation.MsgPinModel
+	if err := cur.All(ctx, &pins); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return pins, nil
+}
+
+func (m *MsgPinMongoDriver) DeletePinUser(ctx context.Context, userID string) (int64, error) {
+	res, err := m.pinCollection.DeleteMany(ctx, bson.M{"pin_user_id": userID})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return res.DeletedCount, nil
+}
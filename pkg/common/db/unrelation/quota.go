@@ -0,0 +1,60 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewQuotaMongoDriver(database *mongo.Database) unrelation.QuotaModelInterface {
+	return &QuotaMongoDriver{
+		quotaCollection: database.Collection(unrelation.Quota),
+	}
+}
+
+type QuotaMongoDriver struct {
+	quotaCollection *mongo.Collection
+}
+
+func (q *QuotaMongoDriver) Set(ctx context.Context, quota *unrelation.QuotaModel) error {
+	filter := bson.M{"kind": quota.Kind, "target_id": quota.TargetID}
+	_, err := q.quotaCollection.ReplaceOne(ctx, filter, quota, options.Replace().SetUpsert(true))
+	return errs.Wrap(err)
+}
+
+func (q *QuotaMongoDriver) Delete(ctx context.Context, kind string, targetID string) error {
+	_, err := q.quotaCollection.DeleteOne(ctx, bson.M{"kind": kind, "target_id": targetID})
+	return errs.Wrap(err)
+}
+
+func (q *QuotaMongoDriver) Find(ctx context.Context, targetID string) ([]*unrelation.QuotaModel, error) {
+	cur, err := q.quotaCollection.Find(ctx, bson.M{"target_id": targetID})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var quotas []*unrelation.QuotaModel
+	if err := cur.All(ctx, &quotas); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return quotas, nil
+}
@@ -180,3 +180,23 @@ func (u *UserMongoDriver) GetSubscribedList(ctx context.Context, userID string)
 	}
 	return user.UserIDList, nil
 }
+
+// DeleteUser Delete the lists of the user and remove the user from the other lists
+func (u *UserMongoDriver) DeleteUser(ctx context.Context, userID string) (int64, error) {
+	res, err := u.userCollection.UpdateMany(
+		ctx,
+		bson.M{"user_id_list": userID},
+		bson.M{"$pull": bson.M{"user_id_list": userID}},
+	)
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	deleted, err := u.userCollection.DeleteMany(
+		ctx,
+		bson.M{"user_id": bson.M{"$in": []string{SubscriptionPrefix + userID, SubscribedPrefix + userID}}},
+	)
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return res.ModifiedCount + deleted.DeletedCount, nil
+}
@@ -0,0 +1,68 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewUserActivityMongoDriver(database *mongo.Database) unrelation.UserActivityModelInterface {
+	return &UserActivityMongoDriver{
+		activityCollection: database.Collection(unrelation.UserActivity),
+	}
+}
+
+type UserActivityMongoDriver struct {
+	activityCollection *mongo.Collection
+}
+
+func (u *UserActivityMongoDriver) Touch(ctx context.Context, userIDs []string, activeTime time.Time) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(userIDs))
+	for _, userID := range userIDs {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"user_id": userID}).
+			SetUpdate(bson.M{"$max": bson.M{"last_active_time": activeTime}}).
+			SetUpsert(true))
+	}
+	_, err := u.activityCollection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return errs.Wrap(err)
+}
+
+func (u *UserActivityMongoDriver) Find(ctx context.Context, userIDs []string) ([]*unrelation.UserActivityModel, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	cur, err := u.activityCollection.Find(ctx, bson.M{"user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var activities []*unrelation.UserActivityModel
+	if err := cur.All(ctx, &activities); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return activities, nil
+}
@@ -0,0 +1,139 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewUserEraseMongoDriver(database *mongo.Database) unrelation.UserEraseJobModelInterface {
+	return &UserEraseMongoDriver{
+		jobCollection: database.Collection(unrelation.UserEraseJob),
+	}
+}
+
+type UserEraseMongoDriver struct {
+	jobCollection *mongo.Collection
+}
+
+func (u *UserEraseMongoDriver) Create(ctx context.Context, job *unrelation.UserEraseJobModel) error {
+	_, err := u.jobCollection.InsertOne(ctx, job)
+	return errs.Wrap(err)
+}
+
+func (u *UserEraseMongoDriver) Take(ctx context.Context, jobID string) (*unrelation.UserEraseJobModel, error) {
+	var job unrelation.UserEraseJobModel
+	if err := u.jobCollection.FindOne(ctx, bson.M{"job_id": jobID}).Decode(&job); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &job, nil
+}
+
+func (u *UserEraseMongoDriver) TakeUnfinished(ctx context.Context, userID string) (*unrelation.UserEraseJobModel, error) {
+	filter := bson.M{"user_id": userID, "status": bson.M{"$in": []int32{unrelation.UserEraseStatusPending, unrelation.UserEraseStatusRunning}}}
+	var job unrelation.UserEraseJobModel
+	if err := u.jobCollection.FindOne(ctx, filter).Decode(&job); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	return &job, nil
+}
+
+func (u *UserEraseMongoDriver) Page(
+	ctx context.Context,
+	userID string,
+	status []int32,
+	pageNumber, showNumber int32,
+) (int64, []*unrelation.UserEraseJobModel, error) {
+	filter := bson.M{}
+	if userID != "" {
+		filter["user_id"] = userID
+	}
+	if len(status) > 0 {
+		filter["status"] = bson.M{"$in": status}
+	}
+	total, err := u.jobCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().
+		SetSort(bson.M{"create_time": -1}).
+		SetSkip(int64(pageNumber-1) * int64(showNumber)).
+		SetLimit(int64(showNumber))
+	cur, err := u.jobCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var jobs []*unrelation.UserEraseJobModel
+	if err := cur.All(ctx, &jobs); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return total, jobs, nil
+}
+
+func (u *UserEraseMongoDriver) Claim(ctx context.Context, staleTime time.Time) (*unrelation.UserEraseJobModel, error) {
+	now := time.Now()
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": unrelation.UserEraseStatusPending},
+		bson.M{"status": unrelation.UserEraseStatusRunning, "update_time": bson.M{"$lt": staleTime}},
+	}}
+	update := bson.M{"$set": bson.M{"status": unrelation.UserEraseStatusRunning, "update_time": now}}
+	opts := options.FindOneAndUpdate().SetSort(bson.M{"create_time": 1}).SetReturnDocument(options.After)
+	var job unrelation.UserEraseJobModel
+	if err := u.jobCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	if job.StartTime.IsZero() {
+		filter := bson.M{"job_id": job.JobID, "status": unrelation.UserEraseStatusRunning}
+		if _, err := u.jobCollection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"start_time": now}}); err != nil {
+			return nil, errs.Wrap(err)
+		}
+		job.StartTime = now
+	}
+	return &job, nil
+}
+
+func (u *UserEraseMongoDriver) UpdateStep(ctx context.Context, jobID string, index int, step *unrelation.UserEraseStepModel) (bool, error) {
+	filter := bson.M{"job_id": jobID, "status": unrelation.UserEraseStatusRunning}
+	update := bson.M{"$set": bson.M{"steps." + strconv.Itoa(index): step, "update_time": time.Now()}}
+	res, err := u.jobCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (u *UserEraseMongoDriver) Finish(ctx context.Context, jobID string, status int32, errMsg string) error {
+	now := time.Now()
+	filter := bson.M{"job_id": jobID, "status": unrelation.UserEraseStatusRunning}
+	update := bson.M{"$set": bson.M{"status": status, "err_msg": errMsg, "update_time": now, "finish_time": now}}
+	_, err := u.jobCollection.UpdateOne(ctx, filter, update)
+	return errs.Wrap(err)
+}
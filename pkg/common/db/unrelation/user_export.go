@@ -0,0 +1,132 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewUserExportMongoDriver(database *mongo.Database) unrelation.UserExportJobModelInterface {
+	return &UserExportMongoDriver{
+		jobCollection: database.Collection(unrelation.UserExportJob),
+	}
+}
+
+type UserExportMongoDriver struct {
+	jobCollection *mongo.Collection
+}
+
+func (u *UserExportMongoDriver) Create(ctx context.Context, job *unrelation.UserExportJobModel) error {
+	_, err := u.jobCollection.InsertOne(ctx, job)
+	return errs.Wrap(err)
+}
+
+func (u *UserExportMongoDriver) Take(ctx context.Context, jobID string) (*unrelation.UserExportJobModel, error) {
+	var job unrelation.UserExportJobModel
+	if err := u.jobCollection.FindOne(ctx, bson.M{"job_id": jobID}).Decode(&job); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &job, nil
+}
+
+func (u *UserExportMongoDriver) Page(
+	ctx context.Context,
+	userID string,
+	status []int32,
+	pageNumber, showNumber int32,
+) (int64, []*unrelation.UserExportJobModel, error) {
+	filter := bson.M{}
+	if userID != "" {
+		filter["user_id"] = userID
+	}
+	if len(status) > 0 {
+		filter["status"] = bson.M{"$in": status}
+	}
+	total, err := u.jobCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().
+		SetSort(bson.M{"create_time": -1}).
+		SetSkip(int64(pageNumber-1) * int64(showNumber)).
+		SetLimit(int64(showNumber))
+	cur, err := u.jobCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	var jobs []*unrelation.UserExportJobModel
+	if err := cur.All(ctx, &jobs); err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return total, jobs, nil
+}
+
+func (u *UserExportMongoDriver) Claim(ctx context.Context, staleTime time.Time) (*unrelation.UserExportJobModel, error) {
+	now := time.Now()
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": unrelation.UserExportStatusPending},
+		bson.M{"status": unrelation.UserExportStatusRunning, "update_time": bson.M{"$lt": staleTime}},
+	}}
+	update := bson.M{"$set": bson.M{"status": unrelation.UserExportStatusRunning, "update_time": now, "start_time": now}}
+	opts := options.FindOneAndUpdate().SetSort(bson.M{"create_time": 1}).SetReturnDocument(options.After)
+	var job unrelation.UserExportJobModel
+	if err := u.jobCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	return &job, nil
+}
+
+func (u *UserExportMongoDriver) Heartbeat(ctx context.Context, jobID string) (bool, error) {
+	filter := bson.M{"job_id": jobID, "status": unrelation.UserExportStatusRunning}
+	res, err := u.jobCollection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"update_time": time.Now()}})
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (u *UserExportMongoDriver) Finish(
+	ctx context.Context,
+	jobID string,
+	status int32,
+	location string,
+	counts map[string]int64,
+	errMsg string,
+) error {
+	now := time.Now()
+	filter := bson.M{"job_id": jobID, "status": unrelation.UserExportStatusRunning}
+	update := bson.M{"$set": bson.M{
+		"status":      status,
+		"location":    location,
+		"counts":      counts,
+		"err_msg":     errMsg,
+		"update_time": now,
+		"finish_time": now,
+	}}
+	_, err := u.jobCollection.UpdateOne(ctx, filter, update)
+	return errs.Wrap(err)
+}
@@ -0,0 +1,126 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewUserSuspensionMongoDriver(database *mongo.Database) unrelation.UserSuspensionModelInterface {
+	return &UserSuspensionMongoDriver{
+		suspensionCollection: database.Collection(unrelation.UserSuspension),
+	}
+}
+
+type UserSuspensionMongoDriver struct {
+	suspensionCollection *mongo.Collection
+}
+
+func (u *UserSuspensionMongoDriver) Create(ctx context.Context, suspension *unrelation.UserSuspensionModel) error {
+	_, err := u.suspensionCollection.InsertOne(ctx, suspension)
+	return errs.Wrap(err)
+}
+
+func (u *UserSuspensionMongoDriver) Take(ctx context.Context, suspensionID string) (*unrelation.UserSuspensionModel, error) {
+	var suspension unrelation.UserSuspensionModel
+	if err := u.suspensionCollection.FindOne(ctx, bson.M{"suspension_id": suspensionID}).Decode(&suspension); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &suspension, nil
+}
+
+func (u *UserSuspensionMongoDriver) find(ctx context.Context, filter any, opts ...*options.FindOptions) ([]*unrelation.UserSuspensionModel, error) {
+	cur, err := u.suspensionCollection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var suspensions []*unrelation.UserSuspensionModel
+	if err := cur.All(ctx, &suspensions); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return suspensions, nil
+}
+
+func (u *UserSuspensionMongoDriver) FindActive(ctx context.Context, userID string) ([]*unrelation.UserSuspensionModel, error) {
+	filter := bson.M{"user_id": userID, "status": unrelation.UserSuspensionStatusActive}
+	return u.find(ctx, filter, options.Find().SetSort(bson.M{"create_time": 1}))
+}
+
+func (u *UserSuspensionMongoDriver) Page(
+	ctx context.Context,
+	userID string,
+	status []int32,
+	pageNumber, showNumber int32,
+) (int64, []*unrelation.UserSuspensionModel, error) {
+	filter := bson.M{}
+	if userID != "" {
+		filter["user_id"] = userID
+	}
+	if len(status) > 0 {
+		filter["status"] = bson.M{"$in": status}
+	}
+	total, err := u.suspensionCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	opts := options.Find().
+		SetSort(bson.M{"create_time": -1}).
+		SetSkip(int64(pageNumber-1) * int64(showNumber)).
+		SetLimit(int64(showNumber))
+	suspensions, err := u.find(ctx, filter, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return total, suspensions, nil
+}
+
+func (u *UserSuspensionMongoDriver) Lift(
+	ctx context.Context,
+	suspensionID string,
+	status int32,
+	opUserID string,
+	reason string,
+	liftTime time.Time,
+) (bool, error) {
+	filter := bson.M{"suspension_id": suspensionID, "status": unrelation.UserSuspensionStatusActive}
+	update := bson.M{"$set": bson.M{
+		"status":          status,
+		"lift_op_user_id": opUserID,
+		"lift_reason":     reason,
+		"lift_time":       liftTime,
+	}}
+	res, err := u.suspensionCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (u *UserSuspensionMongoDriver) FindExpired(ctx context.Context, now time.Time, limit int) ([]*unrelation.UserSuspensionModel, error) {
+	filter := bson.M{
+		"status":   unrelation.UserSuspensionStatusActive,
+		"end_time": bson.M{"$gt": time.Time{}, "$lte": now},
+	}
+	return u.find(ctx, filter, options.Find().SetSort(bson.M{"end_time": 1}).SetLimit(int64(limit)))
+}
@@ -0,0 +1,236 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unrelation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/log"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+func NewVersionLogMongoDriver(database *mongo.Database) unrelation.VersionLogModelInterface {
+	return &VersionLogMongoDriver{
+		versionCollection: database.Collection(unrelation.Version),
+		logCollection:     database.Collection(unrelation.VersionLog),
+	}
+}
+
+type VersionLogMongoDriver struct {
+	versionCollection *mongo.Collection
+	logCollection     *mongo.Collection
+}
+
+func (v *VersionLogMongoDriver) IncrVersion(ctx context.Context, domain string, dids []string, eids []string, state int32) error {
+	if len(dids) == 0 || len(eids) == 0 {
+		return nil
+	}
+	now := time.Now()
+	token := uuid.New().String()
+	filter := bson.M{"domain": domain, "did": bson.M{"$in": dids}}
+	if len(dids) == 1 {
+		filter = bson.M{"domain": domain, "did": dids[0]}
+	} else if err := v.createVersions(ctx, domain, dids); err != nil {
+		return v.truncatePending(ctx, domain, dids, token, err)
+	}
+	// the version and its pending mark are written in one update, readers never see the version before its logs
+	reserve := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"version": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}}}}},
+		{{Key: "$set", Value: bson.M{"pending": bson.M{"$concatArrays": bson.A{
+			bson.M{"$ifNull": bson.A{"$pending", bson.A{}}},
+			bson.A{bson.M{"token": token, "version": "$version", "start_time": now}},
+		}}}}},
+	}
+	if _, err := v.versionCollection.UpdateMany(ctx, filter, reserve, options.Update().SetUpsert(len(dids) == 1)); err != nil {
+		return v.truncatePending(ctx, domain, dids, token, errs.Wrap(err))
+	}
+	versions, err := v.findPending(ctx, domain, dids, token)
+	if err != nil {
+		return v.truncatePending(ctx, domain, dids, token, err)
+	}
+	models := make([]mongo.WriteModel, 0, len(versions)*len(eids))
+	for _, version := range versions {
+		for _, eid := range eids {
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"domain": domain, "did": version.DID, "eid": eid}).
+				SetUpdate(bson.M{"$set": bson.M{"state": state, "version": version.Pending[0].Version, "update_time": now}}).
+				SetUpsert(true))
+		}
+	}
+	if _, err := v.logCollection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return v.truncatePending(ctx, domain, dids, token, errs.Wrap(err))
+	}
+	// a pending mark left by a failure here expires and the version is truncated by the reader
+	_, err = v.versionCollection.UpdateMany(ctx, filter, bson.M{"$pull": bson.M{"pending": bson.M{"token": token}}})
+	return errs.Wrap(err)
+}
+
+// createVersions 创建不存在的版本号, 之后的版本号增加可以一次UpdateMany完成.
+func (v *VersionLogMongoDriver) createVersions(ctx context.Context, domain string, dids []string) error {
+	models := make([]mongo.WriteModel, 0, len(dids))
+	for _, did := range dids {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"domain": domain, "did": did}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{"version": int64(0)}}).
+			SetUpsert(true))
+	}
+	_, err := v.versionCollection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return errs.Wrap(err)
+}
+
+// findPending 获取token预留的各did版本号, Pending只包含该token.
+func (v *VersionLogMongoDriver) findPending(ctx context.Context, domain string, dids []string, token string) ([]*unrelation.VersionModel, error) {
+	filter := bson.M{"domain": domain, "did": bson.M{"$in": dids}, "pending.token": token}
+	opts := options.Find().SetProjection(bson.M{"domain": 1, "did": 1, "pending": bson.M{"$elemMatch": bson.M{"token": token}}})
+	cur, err := v.versionCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var versions []*unrelation.VersionModel
+	if err := cur.All(ctx, &versions); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return versions, nil
+}
+
+// truncatePending 写入失败时版本号加一并截断到新版本, 之前的版本同步时均为FullSync.
+func (v *VersionLogMongoDriver) truncatePending(ctx context.Context, domain string, dids []string, token string, cause error) error {
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"version": bson.M{"$add": bson.A{"$version", 1}}}}},
+		{{Key: "$set", Value: bson.M{
+			"truncated_version": "$version",
+			"pending": bson.M{"$filter": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$pending", bson.A{}}},
+				"cond":  bson.M{"$ne": bson.A{"$$this.token", token}},
+			}},
+		}}},
+	}
+	if _, err := v.versionCollection.UpdateMany(ctx, bson.M{"domain": domain, "did": bson.M{"$in": dids}}, update); err != nil {
+		log.ZError(ctx, "truncate pending version failed", err, "domain", domain, "token", token)
+	}
+	return cause
+}
+
+func (v *VersionLogMongoDriver) ExpirePending(ctx context.Context, domain string, did string, before time.Time) error {
+	stale := bson.M{"$filter": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$pending", bson.A{}}},
+		"cond":  bson.M{"$lt": bson.A{"$$this.start_time", before}},
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"truncated_version": bson.M{"$max": bson.A{"$truncated_version", bson.M{"$max": bson.M{"$map": bson.M{"input": stale, "in": "$$this.version"}}}}}}}},
+		{{Key: "$set", Value: bson.M{"pending": bson.M{"$filter": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$pending", bson.A{}}},
+			"cond":  bson.M{"$gte": bson.A{"$$this.start_time", before}},
+		}}}}},
+	}
+	_, err := v.versionCollection.UpdateOne(ctx, bson.M{"domain": domain, "did": did}, update)
+	return errs.Wrap(err)
+}
+
+func (v *VersionLogMongoDriver) FindVersions(ctx context.Context, domain string, dids []string) ([]*unrelation.VersionModel, error) {
+	cur, err := v.versionCollection.Find(ctx, bson.M{"domain": domain, "did": bson.M{"$in": dids}})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var versions []*unrelation.VersionModel
+	if err := cur.All(ctx, &versions); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return versions, nil
+}
+
+func (v *VersionLogMongoDriver) findLogs(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*unrelation.VersionLogModel, error) {
+	cur, err := v.logCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var logs []*unrelation.VersionLogModel
+	if err := cur.All(ctx, &logs); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return logs, nil
+}
+
+func (v *VersionLogMongoDriver) FindLogsAfter(
+	ctx context.Context,
+	domain string,
+	did string,
+	version int64,
+	until int64,
+	limit int,
+) ([]*unrelation.VersionLogModel, error) {
+	filter := bson.M{"domain": domain, "did": did, "version": bson.M{"$gt": version, "$lte": until}}
+	return v.findLogs(ctx, filter, options.Find().SetSort(bson.M{"version": 1}).SetLimit(int64(limit)))
+}
+
+func (v *VersionLogMongoDriver) FindLogsAt(ctx context.Context, domain string, did string, version int64) ([]*unrelation.VersionLogModel, error) {
+	return v.findLogs(ctx, bson.M{"domain": domain, "did": did, "version": version}, nil)
+}
+
+func (v *VersionLogMongoDriver) TruncateDeleted(ctx context.Context, before time.Time) (int64, error) {
+	match := bson.M{"state": unrelation.VersionStateDelete, "update_time": bson.M{"$lt": before}}
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$group": bson.M{"_id": bson.M{"domain": "$domain", "did": "$did"}, "version": bson.M{"$max": "$version"}}},
+	}
+	cur, err := v.logCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	var groups []struct {
+		ID struct {
+			Domain string `bson:"domain"`
+			DID    string `bson:"did"`
+		} `bson:"_id"`
+		Version int64 `bson:"version"`
+	}
+	if err := cur.All(ctx, &groups); err != nil {
+		return 0, errs.Wrap(err)
+	}
+	var count int64
+	for _, group := range groups {
+		filter := bson.M{"domain": group.ID.Domain, "did": group.ID.DID}
+		// mark first, a client never misses a deletion which is gone
+		if _, err := v.versionCollection.UpdateOne(ctx, filter, bson.M{"$max": bson.M{"truncated_version": group.Version}}); err != nil {
+			return count, errs.Wrap(err)
+		}
+		filter["state"] = unrelation.VersionStateDelete
+		filter["update_time"] = bson.M{"$lt": before}
+		filter["version"] = bson.M{"$lte": group.Version}
+		res, err := v.logCollection.DeleteMany(ctx, filter)
+		if err != nil {
+			return count, errs.Wrap(err)
+		}
+		count += res.DeletedCount
+	}
+	return count, nil
+}
+
+func (v *VersionLogMongoDriver) DeleteDID(ctx context.Context, domain string, did string) error {
+	filter := bson.M{"domain": domain, "did": did}
+	if _, err := v.logCollection.DeleteMany(ctx, filter); err != nil {
+		return errs.Wrap(err)
+	}
+	_, err := v.versionCollection.DeleteOne(ctx, filter)
+	return errs.Wrap(err)
+}
@@ -0,0 +1,81 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errcode error codes of the server that are not defined by github.com/OpenIMSDK/tools/errs.
+package errcode
+
+import "github.com/OpenIMSDK/tools/errs"
+
+// 配额错误码.
+const (
+	QuotaExceededError = 1801 // 超出配额
+)
+
+// 群邀请链接错误码.
+const (
+	GroupInviteLinkInvalidError = 1802 // 邀请链接不存在、已撤销、已过期或次数已用完
+)
+
+// 入群规则错误码.
+const (
+	GroupJoinRejectedError = 1803 // 入群申请被规则自动拒绝
+)
+
+// 群慢速模式错误码.
+const (
+	GroupSlowModeError = 1804 // 慢速模式冷却中
+)
+
+// 群封禁错误码.
+const (
+	GroupMemberBannedError = 1805 // 用户已被群封禁
+)
+
+// 置顶消息错误码.
+const (
+	PinnedMsgLimitError = 1806 // 会话置顶消息数量已达上限
+)
+
+// 好友申请错误码.
+const (
+	FriendRequestCooldownError = 1807 // 被拒绝后冷却中, 不能再次申请
+	AddFriendNotAllowedError   = 1808 // 对方的加好友权限不允许
+	FriendRequestExpiredError  = 1809 // 好友申请已过期
+)
+
+// 账号状态错误码.
+const (
+	UserDeactivatedError = 1810 // 账号已停用
+	UserSuspendedError   = 1811 // 账号被封禁, 不能进行封禁范围内的操作
+)
+
+// 入群申请错误码.
+const (
+	GroupRequestExpiredError = 1812 // 入群申请已过期
+)
+
+var (
+	ErrQuotaExceeded          = errs.NewCodeError(QuotaExceededError, "QuotaExceededError")
+	ErrGroupInviteLinkInvalid = errs.NewCodeError(GroupInviteLinkInvalidError, "GroupInviteLinkInvalidError")
+	ErrGroupJoinRejected      = errs.NewCodeError(GroupJoinRejectedError, "GroupJoinRejectedError")
+	ErrGroupSlowMode          = errs.NewCodeError(GroupSlowModeError, "GroupSlowModeError")
+	ErrGroupMemberBanned      = errs.NewCodeError(GroupMemberBannedError, "GroupMemberBannedError")
+	ErrPinnedMsgLimit         = errs.NewCodeError(PinnedMsgLimitError, "PinnedMsgLimitError")
+	ErrFriendRequestCooldown  = errs.NewCodeError(FriendRequestCooldownError, "FriendRequestCooldownError")
+	ErrAddFriendNotAllowed    = errs.NewCodeError(AddFriendNotAllowedError, "AddFriendNotAllowedError")
+	ErrFriendRequestExpired   = errs.NewCodeError(FriendRequestExpiredError, "FriendRequestExpiredError")
+	ErrUserDeactivated        = errs.NewCodeError(UserDeactivatedError, "UserDeactivatedError")
+	ErrUserSuspended          = errs.NewCodeError(UserSuspendedError, "UserSuspendedError")
+	ErrGroupRequestExpired    = errs.NewCodeError(GroupRequestExpiredError, "GroupRequestExpiredError")
+)
@@ -0,0 +1,52 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgprocessor
+
+import (
+	"encoding/json"
+
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+)
+
+// AttachedInfoElem is the part of MsgData.AttachedInfo the server understands,
+// unknown fields written by the sdk are ignored.
+type AttachedInfoElem struct {
+	IsPrivateChat bool `json:"isPrivateChat"`
+	// seconds after the recipient reads the message before it is destroyed
+	BurnDuration int32 `json:"burnDuration"`
+	// seconds after the message is sent before it is destroyed
+	DestructDuration int32 `json:"destructDuration"`
+}
+
+func ParseAttachedInfo(attachedInfo string) *AttachedInfoElem {
+	var elem AttachedInfoElem
+	if attachedInfo == "" {
+		return &elem
+	}
+	if err := json.Unmarshal([]byte(attachedInfo), &elem); err != nil {
+		return &AttachedInfoElem{}
+	}
+	return &elem
+}
+
+// IsBurnAfterRead burn after reading only applies to private single chats.
+func (a *AttachedInfoElem) IsBurnAfterRead(msg *sdkws.MsgData) bool {
+	return a.IsPrivateChat && a.BurnDuration > 0 && msg.SessionType == constant.SingleChatType
+}
+
+func (a *AttachedInfoElem) IsDestructAfterSend() bool {
+	return a.DestructDuration > 0
+}
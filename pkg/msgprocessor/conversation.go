@@ -142,6 +142,10 @@ func IsNotification(conversationID string) bool {
 	return strings.HasPrefix(conversationID, "n_")
 }
 
+func IsSuperGroupConversationID(conversationID string) bool {
+	return strings.HasPrefix(conversationID, "sg_")
+}
+
 func IsNotificationByMsg(msg *sdkws.MsgData) bool {
 	return !Options(msg.Options).IsNotNotification()
 }
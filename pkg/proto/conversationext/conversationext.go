@@ -0,0 +1,105 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversationext
+
+import (
+	"github.com/OpenIMSDK/protocol/conversation"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+// GetIncrementalConversationsReq 获取version之后会话列表的变更, version为0时返回fullSync.
+type GetIncrementalConversationsReq struct {
+	OwnerUserID string `json:"ownerUserID"`
+	Version     int64  `json:"version"`
+}
+
+// GetIncrementalConversationsResp fullSync为true时客户端需要重新拉取全部会话, more为true时以version继续获取.
+type GetIncrementalConversationsResp struct {
+	Version  int64                        `json:"version"`
+	FullSync bool                         `json:"fullSync"`
+	More     bool                         `json:"more"`
+	Insert   []*conversation.Conversation `json:"insert"`
+	Update   []*conversation.Conversation `json:"update"`
+	Delete   []string                     `json:"delete"`
+}
+
+// SetConversationMuteUntilReq 会话静音到指定时间, 期间仍在线投递但不做离线推送, muteUntil为毫秒时间戳, 0表示取消.
+type SetConversationMuteUntilReq struct {
+	OwnerUserID    string `json:"ownerUserID"`
+	ConversationID string `json:"conversationID"`
+	MuteUntil      int64  `json:"muteUntil"`
+}
+
+type SetConversationMuteUntilResp struct{}
+
+type GetConversationsMuteUntilReq struct {
+	OwnerUserID     string   `json:"ownerUserID"`
+	ConversationIDs []string `json:"conversationIDs"`
+}
+
+// GetConversationsMuteUntilResp 只返回仍在静音中的会话.
+type GetConversationsMuteUntilResp struct {
+	MuteUntil map[string]int64 `json:"muteUntil"`
+}
+
+// GetMutedUserIDsReq 获取userIDs中当前处于会话静音的用户, 供push使用.
+type GetMutedUserIDsReq struct {
+	ConversationID string   `json:"conversationID"`
+	UserIDs        []string `json:"userIDs"`
+}
+
+type GetMutedUserIDsResp struct {
+	UserIDs []string `json:"userIDs"`
+}
+
+func (x *GetIncrementalConversationsReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.Version < 0 {
+		return errs.ErrArgs.Wrap("version is invalid")
+	}
+	return nil
+}
+
+func (x *SetConversationMuteUntilReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.ConversationID == "" {
+		return errs.ErrArgs.Wrap("conversationID is empty")
+	}
+	if x.MuteUntil < 0 {
+		return errs.ErrArgs.Wrap("muteUntil is invalid")
+	}
+	return nil
+}
+
+func (x *GetConversationsMuteUntilReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if len(x.ConversationIDs) == 0 {
+		return errs.ErrArgs.Wrap("conversationIDs is empty")
+	}
+	return nil
+}
+
+func (x *GetMutedUserIDsReq) Check() error {
+	if x.ConversationID == "" {
+		return errs.ErrArgs.Wrap("conversationID is empty")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversationext
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/jsonrpc"
+)
+
+const ServiceName = "OpenIMServer.conversationext.conversationExt"
+
+type ConversationExtClient interface {
+	GetIncrementalConversations(ctx context.Context, in *GetIncrementalConversationsReq, opts ...grpc.CallOption) (*GetIncrementalConversationsResp, error)
+	SetConversationMuteUntil(ctx context.Context, in *SetConversationMuteUntilReq, opts ...grpc.CallOption) (*SetConversationMuteUntilResp, error)
+	GetConversationsMuteUntil(ctx context.Context, in *GetConversationsMuteUntilReq, opts ...grpc.CallOption) (*GetConversationsMuteUntilResp, error)
+	GetMutedUserIDs(ctx context.Context, in *GetMutedUserIDsReq, opts ...grpc.CallOption) (*GetMutedUserIDsResp, error)
+}
+
+type conversationExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConversationExtClient(cc grpc.ClientConnInterface) ConversationExtClient {
+	return &conversationExtClient{cc}
+}
+
+func (c *conversationExtClient) GetIncrementalConversations(ctx context.Context, in *GetIncrementalConversationsReq, opts ...grpc.CallOption) (*GetIncrementalConversationsResp, error) {
+	return jsonrpc.Invoke[GetIncrementalConversationsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetIncrementalConversations"), in, opts...)
+}
+
+func (c *conversationExtClient) SetConversationMuteUntil(ctx context.Context, in *SetConversationMuteUntilReq, opts ...grpc.CallOption) (*SetConversationMuteUntilResp, error) {
+	return jsonrpc.Invoke[SetConversationMuteUntilResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetConversationMuteUntil"), in, opts...)
+}
+
+func (c *conversationExtClient) GetConversationsMuteUntil(ctx context.Context, in *GetConversationsMuteUntilReq, opts ...grpc.CallOption) (*GetConversationsMuteUntilResp, error) {
+	return jsonrpc.Invoke[GetConversationsMuteUntilResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetConversationsMuteUntil"), in, opts...)
+}
+
+func (c *conversationExtClient) GetMutedUserIDs(ctx context.Context, in *GetMutedUserIDsReq, opts ...grpc.CallOption) (*GetMutedUserIDsResp, error) {
+	return jsonrpc.Invoke[GetMutedUserIDsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetMutedUserIDs"), in, opts...)
+}
+
+type ConversationExtServer interface {
+	GetIncrementalConversations(context.Context, *GetIncrementalConversationsReq) (*GetIncrementalConversationsResp, error)
+	SetConversationMuteUntil(context.Context, *SetConversationMuteUntilReq) (*SetConversationMuteUntilResp, error)
+	GetConversationsMuteUntil(context.Context, *GetConversationsMuteUntilReq) (*GetConversationsMuteUntilResp, error)
+	GetMutedUserIDs(context.Context, *GetMutedUserIDsReq) (*GetMutedUserIDsResp, error)
+}
+
+func RegisterConversationExtServer(s grpc.ServiceRegistrar, srv ConversationExtServer) {
+	s.RegisterService(&ConversationExt_ServiceDesc, srv)
+}
+
+var ConversationExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*ConversationExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		jsonrpc.MethodDesc(ServiceName, "GetIncrementalConversations", ConversationExtServer.GetIncrementalConversations),
+		jsonrpc.MethodDesc(ServiceName, "SetConversationMuteUntil", ConversationExtServer.SetConversationMuteUntil),
+		jsonrpc.MethodDesc(ServiceName, "GetConversationsMuteUntil", ConversationExtServer.GetConversationsMuteUntil),
+		jsonrpc.MethodDesc(ServiceName, "GetMutedUserIDs", ConversationExtServer.GetMutedUserIDs),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "conversationext",
+}
@@ -0,0 +1,348 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friendext
+
+import (
+	"unicode/utf8"
+
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+)
+
+const (
+	// MaxFriendLabels labels a user can create.
+	MaxFriendLabels = 100
+	// MaxFriendLabelNameLen characters of a label name.
+	MaxFriendLabelNameLen = 128
+)
+
+type FriendLabel struct {
+	LabelID       string   `json:"labelID"`
+	Name          string   `json:"name"`
+	Sort          int32    `json:"sort"`
+	CreateTime    int64    `json:"createTime"`
+	FriendUserIDs []string `json:"friendUserIDs"`
+}
+
+type CreateFriendLabelReq struct {
+	OwnerUserID   string   `json:"ownerUserID"`
+	Name          string   `json:"name"`
+	FriendUserIDs []string `json:"friendUserIDs"`
+}
+
+type CreateFriendLabelResp struct {
+	Label *FriendLabel `json:"label"`
+}
+
+type UpdateFriendLabelReq struct {
+	OwnerUserID string `json:"ownerUserID"`
+	LabelID     string `json:"labelID"`
+	Name        string `json:"name"`
+}
+
+type UpdateFriendLabelResp struct{}
+
+type DeleteFriendLabelReq struct {
+	OwnerUserID string `json:"ownerUserID"`
+	LabelID     string `json:"labelID"`
+}
+
+type DeleteFriendLabelResp struct{}
+
+// SortFriendLabelsReq labelIDs为排序后的全部分组.
+type SortFriendLabelsReq struct {
+	OwnerUserID string   `json:"ownerUserID"`
+	LabelIDs    []string `json:"labelIDs"`
+}
+
+type SortFriendLabelsResp struct{}
+
+type GetFriendLabelsReq struct {
+	OwnerUserID string `json:"ownerUserID"`
+}
+
+type GetFriendLabelsResp struct {
+	Labels []*FriendLabel `json:"labels"`
+}
+
+type AddFriendLabelMembersReq struct {
+	OwnerUserID   string   `json:"ownerUserID"`
+	LabelID       string   `json:"labelID"`
+	FriendUserIDs []string `json:"friendUserIDs"`
+}
+
+type AddFriendLabelMembersResp struct{}
+
+type RemoveFriendLabelMembersReq struct {
+	OwnerUserID   string   `json:"ownerUserID"`
+	LabelID       string   `json:"labelID"`
+	FriendUserIDs []string `json:"friendUserIDs"`
+}
+
+type RemoveFriendLabelMembersResp struct{}
+
+// SetFriendLabelsReq 覆盖好友所在的分组, labelIDs为空把好友移出所有分组.
+type SetFriendLabelsReq struct {
+	OwnerUserID  string   `json:"ownerUserID"`
+	FriendUserID string   `json:"friendUserID"`
+	LabelIDs     []string `json:"labelIDs"`
+}
+
+type SetFriendLabelsResp struct{}
+
+type GetLabelFriendsReq struct {
+	OwnerUserID string                   `json:"ownerUserID"`
+	LabelID     string                   `json:"labelID"`
+	Pagination  *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetLabelFriendsResp struct {
+	Total   int64               `json:"total"`
+	Friends []*sdkws.FriendInfo `json:"friends"`
+}
+
+// FriendLabelsUpdatedTips content of rpcclient.FriendLabelsUpdatedNotification, sent to all devices of the owner.
+type FriendLabelsUpdatedTips struct {
+	OwnerUserID string   `json:"ownerUserID"`
+	LabelIDs    []string `json:"labelIDs"`
+}
+
+type FriendSuggestion struct {
+	User              *sdkws.PublicUserInfo `json:"user"`
+	MutualFriendCount int32                 `json:"mutualFriendCount"`
+	SharedGroupCount  int32                 `json:"sharedGroupCount"`
+}
+
+// GetFriendSuggestionsReq 可能认识的人, 按共同好友数和共同群数排序.
+type GetFriendSuggestionsReq struct {
+	UserID     string                   `json:"userID"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetFriendSuggestionsResp struct {
+	Total       int64               `json:"total"`
+	Suggestions []*FriendSuggestion `json:"suggestions"`
+}
+
+// RefreshFriendSuggestionsReq 重新计算并保存用户的好友推荐, 由定时任务调用.
+type RefreshFriendSuggestionsReq struct {
+	UserIDs []string `json:"userIDs"`
+}
+
+type RefreshFriendSuggestionsResp struct{}
+
+// SetAddFriendPolicyReq 设置谁可以加我为好友, relation.AddFriendPolicyAnyone等.
+type SetAddFriendPolicyReq struct {
+	UserID string `json:"userID"`
+	Policy int32  `json:"policy"`
+}
+
+type SetAddFriendPolicyResp struct{}
+
+type GetAddFriendPolicyReq struct {
+	UserID string `json:"userID"`
+}
+
+type GetAddFriendPolicyResp struct {
+	Policy int32 `json:"policy"`
+}
+
+// GetIncrementalFriendsReq 获取version之后好友列表的变更, version为0时返回fullSync.
+type GetIncrementalFriendsReq struct {
+	UserID  string `json:"userID"`
+	Version int64  `json:"version"`
+}
+
+// GetIncrementalFriendsResp fullSync为true时客户端需要重新拉取全部好友, more为true时以version继续获取.
+type GetIncrementalFriendsResp struct {
+	Version  int64               `json:"version"`
+	FullSync bool                `json:"fullSync"`
+	More     bool                `json:"more"`
+	Insert   []*sdkws.FriendInfo `json:"insert"`
+	Update   []*sdkws.FriendInfo `json:"update"`
+	Delete   []string            `json:"delete"`
+}
+
+func checkLabelName(name string) error {
+	if name == "" {
+		return errs.ErrArgs.Wrap("name is empty")
+	}
+	if utf8.RuneCountInString(name) > MaxFriendLabelNameLen {
+		return errs.ErrArgs.Wrap("name is too long")
+	}
+	return nil
+}
+
+func checkFriendUserIDs(friendUserIDs []string) error {
+	if len(friendUserIDs) == 0 {
+		return errs.ErrArgs.Wrap("friendUserIDs is empty")
+	}
+	if utils.Duplicate(friendUserIDs) {
+		return errs.ErrArgs.Wrap("friendUserIDs repeated")
+	}
+	return nil
+}
+
+func (x *CreateFriendLabelReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if utils.Duplicate(x.FriendUserIDs) {
+		return errs.ErrArgs.Wrap("friendUserIDs repeated")
+	}
+	return checkLabelName(x.Name)
+}
+
+func (x *UpdateFriendLabelReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.LabelID == "" {
+		return errs.ErrArgs.Wrap("labelID is empty")
+	}
+	return checkLabelName(x.Name)
+}
+
+func (x *DeleteFriendLabelReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.LabelID == "" {
+		return errs.ErrArgs.Wrap("labelID is empty")
+	}
+	return nil
+}
+
+func (x *SortFriendLabelsReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if len(x.LabelIDs) == 0 {
+		return errs.ErrArgs.Wrap("labelIDs is empty")
+	}
+	if utils.Duplicate(x.LabelIDs) {
+		return errs.ErrArgs.Wrap("labelIDs repeated")
+	}
+	return nil
+}
+
+func (x *GetFriendLabelsReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	return nil
+}
+
+func (x *AddFriendLabelMembersReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.LabelID == "" {
+		return errs.ErrArgs.Wrap("labelID is empty")
+	}
+	return checkFriendUserIDs(x.FriendUserIDs)
+}
+
+func (x *RemoveFriendLabelMembersReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.LabelID == "" {
+		return errs.ErrArgs.Wrap("labelID is empty")
+	}
+	return checkFriendUserIDs(x.FriendUserIDs)
+}
+
+func (x *SetFriendLabelsReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.FriendUserID == "" {
+		return errs.ErrArgs.Wrap("friendUserID is empty")
+	}
+	if utils.Duplicate(x.LabelIDs) {
+		return errs.ErrArgs.Wrap("labelIDs repeated")
+	}
+	return nil
+}
+
+func (x *GetLabelFriendsReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.LabelID == "" {
+		return errs.ErrArgs.Wrap("labelID is empty")
+	}
+	if x.Pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	return nil
+}
+
+func (x *GetFriendSuggestionsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.Pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	return nil
+}
+
+func (x *RefreshFriendSuggestionsReq) Check() error {
+	if len(x.UserIDs) == 0 {
+		return errs.ErrArgs.Wrap("userIDs is empty")
+	}
+	return nil
+}
+
+func (x *SetAddFriendPolicyReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	switch x.Policy {
+	case relation.AddFriendPolicyAnyone, relation.AddFriendPolicyFriendsOfFriends,
+		relation.AddFriendPolicyGroupCoMembers, relation.AddFriendPolicyNobody:
+	default:
+		return errs.ErrArgs.Wrap("policy is invalid")
+	}
+	return nil
+}
+
+func (x *GetAddFriendPolicyReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetIncrementalFriendsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.Version < 0 {
+		return errs.ErrArgs.Wrap("version is invalid")
+	}
+	return nil
+}
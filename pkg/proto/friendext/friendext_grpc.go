@@ -0,0 +1,150 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friendext
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/jsonrpc"
+)
+
+const ServiceName = "OpenIMServer.friendext.friendExt"
+
+type FriendExtClient interface {
+	CreateFriendLabel(ctx context.Context, in *CreateFriendLabelReq, opts ...grpc.CallOption) (*CreateFriendLabelResp, error)
+	UpdateFriendLabel(ctx context.Context, in *UpdateFriendLabelReq, opts ...grpc.CallOption) (*UpdateFriendLabelResp, error)
+	DeleteFriendLabel(ctx context.Context, in *DeleteFriendLabelReq, opts ...grpc.CallOption) (*DeleteFriendLabelResp, error)
+	SortFriendLabels(ctx context.Context, in *SortFriendLabelsReq, opts ...grpc.CallOption) (*SortFriendLabelsResp, error)
+	GetFriendLabels(ctx context.Context, in *GetFriendLabelsReq, opts ...grpc.CallOption) (*GetFriendLabelsResp, error)
+	AddFriendLabelMembers(ctx context.Context, in *AddFriendLabelMembersReq, opts ...grpc.CallOption) (*AddFriendLabelMembersResp, error)
+	RemoveFriendLabelMembers(ctx context.Context, in *RemoveFriendLabelMembersReq, opts ...grpc.CallOption) (*RemoveFriendLabelMembersResp, error)
+	SetFriendLabels(ctx context.Context, in *SetFriendLabelsReq, opts ...grpc.CallOption) (*SetFriendLabelsResp, error)
+	GetLabelFriends(ctx context.Context, in *GetLabelFriendsReq, opts ...grpc.CallOption) (*GetLabelFriendsResp, error)
+	GetFriendSuggestions(ctx context.Context, in *GetFriendSuggestionsReq, opts ...grpc.CallOption) (*GetFriendSuggestionsResp, error)
+	RefreshFriendSuggestions(ctx context.Context, in *RefreshFriendSuggestionsReq, opts ...grpc.CallOption) (*RefreshFriendSuggestionsResp, error)
+	SetAddFriendPolicy(ctx context.Context, in *SetAddFriendPolicyReq, opts ...grpc.CallOption) (*SetAddFriendPolicyResp, error)
+	GetAddFriendPolicy(ctx context.Context, in *GetAddFriendPolicyReq, opts ...grpc.CallOption) (*GetAddFriendPolicyResp, error)
+	GetIncrementalFriends(ctx context.Context, in *GetIncrementalFriendsReq, opts ...grpc.CallOption) (*GetIncrementalFriendsResp, error)
+}
+
+type friendExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFriendExtClient(cc grpc.ClientConnInterface) FriendExtClient {
+	return &friendExtClient{cc}
+}
+
+func (c *friendExtClient) CreateFriendLabel(ctx context.Context, in *CreateFriendLabelReq, opts ...grpc.CallOption) (*CreateFriendLabelResp, error) {
+	return jsonrpc.Invoke[CreateFriendLabelResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "CreateFriendLabel"), in, opts...)
+}
+
+func (c *friendExtClient) UpdateFriendLabel(ctx context.Context, in *UpdateFriendLabelReq, opts ...grpc.CallOption) (*UpdateFriendLabelResp, error) {
+	return jsonrpc.Invoke[UpdateFriendLabelResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "UpdateFriendLabel"), in, opts...)
+}
+
+func (c *friendExtClient) DeleteFriendLabel(ctx context.Context, in *DeleteFriendLabelReq, opts ...grpc.CallOption) (*DeleteFriendLabelResp, error) {
+	return jsonrpc.Invoke[DeleteFriendLabelResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "DeleteFriendLabel"), in, opts...)
+}
+
+func (c *friendExtClient) SortFriendLabels(ctx context.Context, in *SortFriendLabelsReq, opts ...grpc.CallOption) (*SortFriendLabelsResp, error) {
+	return jsonrpc.Invoke[SortFriendLabelsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SortFriendLabels"), in, opts...)
+}
+
+func (c *friendExtClient) GetFriendLabels(ctx context.Context, in *GetFriendLabelsReq, opts ...grpc.CallOption) (*GetFriendLabelsResp, error) {
+	return jsonrpc.Invoke[GetFriendLabelsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetFriendLabels"), in, opts...)
+}
+
+func (c *friendExtClient) AddFriendLabelMembers(ctx context.Context, in *AddFriendLabelMembersReq, opts ...grpc.CallOption) (*AddFriendLabelMembersResp, error) {
+	return jsonrpc.Invoke[AddFriendLabelMembersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "AddFriendLabelMembers"), in, opts...)
+}
+
+func (c *friendExtClient) RemoveFriendLabelMembers(ctx context.Context, in *RemoveFriendLabelMembersReq, opts ...grpc.CallOption) (*RemoveFriendLabelMembersResp, error) {
+	return jsonrpc.Invoke[RemoveFriendLabelMembersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "RemoveFriendLabelMembers"), in, opts...)
+}
+
+func (c *friendExtClient) SetFriendLabels(ctx context.Context, in *SetFriendLabelsReq, opts ...grpc.CallOption) (*SetFriendLabelsResp, error) {
+	return jsonrpc.Invoke[SetFriendLabelsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetFriendLabels"), in, opts...)
+}
+
+func (c *friendExtClient) GetLabelFriends(ctx context.Context, in *GetLabelFriendsReq, opts ...grpc.CallOption) (*GetLabelFriendsResp, error) {
+	return jsonrpc.Invoke[GetLabelFriendsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetLabelFriends"), in, opts...)
+}
+
+func (c *friendExtClient) GetFriendSuggestions(ctx context.Context, in *GetFriendSuggestionsReq, opts ...grpc.CallOption) (*GetFriendSuggestionsResp, error) {
+	return jsonrpc.Invoke[GetFriendSuggestionsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetFriendSuggestions"), in, opts...)
+}
+
+func (c *friendExtClient) RefreshFriendSuggestions(ctx context.Context, in *RefreshFriendSuggestionsReq, opts ...grpc.CallOption) (*RefreshFriendSuggestionsResp, error) {
+	return jsonrpc.Invoke[RefreshFriendSuggestionsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "RefreshFriendSuggestions"), in, opts...)
+}
+
+func (c *friendExtClient) SetAddFriendPolicy(ctx context.Context, in *SetAddFriendPolicyReq, opts ...grpc.CallOption) (*SetAddFriendPolicyResp, error) {
+	return jsonrpc.Invoke[SetAddFriendPolicyResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetAddFriendPolicy"), in, opts...)
+}
+
+func (c *friendExtClient) GetAddFriendPolicy(ctx context.Context, in *GetAddFriendPolicyReq, opts ...grpc.CallOption) (*GetAddFriendPolicyResp, error) {
+	return jsonrpc.Invoke[GetAddFriendPolicyResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetAddFriendPolicy"), in, opts...)
+}
+
+func (c *friendExtClient) GetIncrementalFriends(ctx context.Context, in *GetIncrementalFriendsReq, opts ...grpc.CallOption) (*GetIncrementalFriendsResp, error) {
+	return jsonrpc.Invoke[GetIncrementalFriendsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetIncrementalFriends"), in, opts...)
+}
+
+type FriendExtServer interface {
+	CreateFriendLabel(context.Context, *CreateFriendLabelReq) (*CreateFriendLabelResp, error)
+	UpdateFriendLabel(context.Context, *UpdateFriendLabelReq) (*UpdateFriendLabelResp, error)
+	DeleteFriendLabel(context.Context, *DeleteFriendLabelReq) (*DeleteFriendLabelResp, error)
+	SortFriendLabels(context.Context, *SortFriendLabelsReq) (*SortFriendLabelsResp, error)
+	GetFriendLabels(context.Context, *GetFriendLabelsReq) (*GetFriendLabelsResp, error)
+	AddFriendLabelMembers(context.Context, *AddFriendLabelMembersReq) (*AddFriendLabelMembersResp, error)
+	RemoveFriendLabelMembers(context.Context, *RemoveFriendLabelMembersReq) (*RemoveFriendLabelMembersResp, error)
+	SetFriendLabels(context.Context, *SetFriendLabelsReq) (*SetFriendLabelsResp, error)
+	GetLabelFriends(context.Context, *GetLabelFriendsReq) (*GetLabelFriendsResp, error)
+	GetFriendSuggestions(context.Context, *GetFriendSuggestionsReq) (*GetFriendSuggestionsResp, error)
+	RefreshFriendSuggestions(context.Context, *RefreshFriendSuggestionsReq) (*RefreshFriendSuggestionsResp, error)
+	SetAddFriendPolicy(context.Context, *SetAddFriendPolicyReq) (*SetAddFriendPolicyResp, error)
+	GetAddFriendPolicy(context.Context, *GetAddFriendPolicyReq) (*GetAddFriendPolicyResp, error)
+	GetIncrementalFriends(context.Context, *GetIncrementalFriendsReq) (*GetIncrementalFriendsResp, error)
+}
+
+func RegisterFriendExtServer(s grpc.ServiceRegistrar, srv FriendExtServer) {
+	s.RegisterService(&FriendExt_ServiceDesc, srv)
+}
+
+var FriendExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*FriendExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		jsonrpc.MethodDesc(ServiceName, "CreateFriendLabel", FriendExtServer.CreateFriendLabel),
+		jsonrpc.MethodDesc(ServiceName, "UpdateFriendLabel", FriendExtServer.UpdateFriendLabel),
+		jsonrpc.MethodDesc(ServiceName, "DeleteFriendLabel", FriendExtServer.DeleteFriendLabel),
+		jsonrpc.MethodDesc(ServiceName, "SortFriendLabels", FriendExtServer.SortFriendLabels),
+		jsonrpc.MethodDesc(ServiceName, "GetFriendLabels", FriendExtServer.GetFriendLabels),
+		jsonrpc.MethodDesc(ServiceName, "AddFriendLabelMembers", FriendExtServer.AddFriendLabelMembers),
+		jsonrpc.MethodDesc(ServiceName, "RemoveFriendLabelMembers", FriendExtServer.RemoveFriendLabelMembers),
+		jsonrpc.MethodDesc(ServiceName, "SetFriendLabels", FriendExtServer.SetFriendLabels),
+		jsonrpc.MethodDesc(ServiceName, "GetLabelFriends", FriendExtServer.GetLabelFriends),
+		jsonrpc.MethodDesc(ServiceName, "GetFriendSuggestions", FriendExtServer.GetFriendSuggestions),
+		jsonrpc.MethodDesc(ServiceName, "RefreshFriendSuggestions", FriendExtServer.RefreshFriendSuggestions),
+		jsonrpc.MethodDesc(ServiceName, "SetAddFriendPolicy", FriendExtServer.SetAddFriendPolicy),
+		jsonrpc.MethodDesc(ServiceName, "GetAddFriendPolicy", FriendExtServer.GetAddFriendPolicy),
+		jsonrpc.MethodDesc(ServiceName, "GetIncrementalFriends", FriendExtServer.GetIncrementalFriends),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "friendext",
+}
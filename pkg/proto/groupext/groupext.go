@@ -0,0 +1,1141 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupext
+
+import (
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+	"github.com/OpenIMSDK/tools/utils"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type GroupRole struct {
+	GroupID string `json:"groupID"`
+	RoleID  string `json:"roleID"`
+	Name    string `json:"name"`
+	// bitset of relation.GroupPermissionSendMsg ... GroupPermissionApproveJoin
+	Permissions int64  `json:"permissions"`
+	CreateTime  int64  `json:"createTime"`
+	Ex          string `json:"ex"`
+}
+
+// GroupExtInfo 群信息及sdkws.GroupInfo中没有的扩展字段.
+type GroupExtInfo struct {
+	*sdkws.GroupInfo
+	// 慢速模式间隔秒数, 0为关闭
+	SlowMode int32 `json:"slowMode"`
+}
+
+type GroupMemberInfo struct {
+	*sdkws.GroupMemberFullInfo
+	RoleID string `json:"roleID"`
+	// effective permissions of the member
+	Permissions int64 `json:"permissions"`
+}
+
+type CreateGroupRoleReq struct {
+	GroupID     string `json:"groupID"`
+	Name        string `json:"name"`
+	Permissions int64  `json:"permissions"`
+	Ex          string `json:"ex"`
+}
+
+type CreateGroupRoleResp struct {
+	RoleID string `json:"roleID"`
+}
+
+type SetGroupRoleReq struct {
+	GroupID     string  `json:"groupID"`
+	RoleID      string  `json:"roleID"`
+	Name        *string `json:"name"`
+	Permissions *int64  `json:"permissions"`
+	Ex          *string `json:"ex"`
+}
+
+type SetGroupRoleResp struct{}
+
+type DeleteGroupRoleReq struct {
+	GroupID string `json:"groupID"`
+	RoleID  string `json:"roleID"`
+}
+
+type DeleteGroupRoleResp struct{}
+
+type GetGroupRolesReq struct {
+	GroupID string `json:"groupID"`
+}
+
+type GetGroupRolesResp struct {
+	Roles []*GroupRole `json:"roles"`
+}
+
+type SetGroupMembersRoleReq struct {
+	GroupID string   `json:"groupID"`
+	UserIDs []string `json:"userIDs"`
+	// empty removes the custom role
+	RoleID string `json:"roleID"`
+}
+
+type SetGroupMembersRoleResp struct{}
+
+// GetGroupExtInfoCacheReq 从缓存获取群信息, 供其他服务内部调用.
+type GetGroupExtInfoCacheReq struct {
+	GroupID string `json:"groupID"`
+}
+
+type GetGroupExtInfoCacheResp struct {
+	Group *GroupExtInfo `json:"group"`
+}
+
+type GetGroupMembersExtInfoReq struct {
+	GroupID string   `json:"groupID"`
+	UserIDs []string `json:"userIDs"`
+}
+
+type GetGroupMembersExtInfoResp struct {
+	Members []*GroupMemberInfo `json:"members"`
+}
+
+type GroupInviteLink struct {
+	Code          string `json:"code"`
+	GroupID       string `json:"groupID"`
+	CreatorUserID string `json:"creatorUserID"`
+	// 0 不限次数
+	MaxUses   int32 `json:"maxUses"`
+	UseCount  int32 `json:"useCount"`
+	JoinCount int32 `json:"joinCount"`
+	// 毫秒时间戳, 0 永不过期
+	ExpireTime  int64  `json:"expireTime"`
+	AutoApprove bool   `json:"autoApprove"`
+	RoleID      string `json:"roleID"`
+	Revoked     bool   `json:"revoked"`
+	CreateTime  int64  `json:"createTime"`
+	Ex          string `json:"ex"`
+}
+
+type CreateGroupInviteLinkReq struct {
+	GroupID     string `json:"groupID"`
+	MaxUses     int32  `json:"maxUses"`
+	ExpireTime  int64  `json:"expireTime"`
+	AutoApprove bool   `json:"autoApprove"`
+	RoleID      string `json:"roleID"`
+	Ex          string `json:"ex"`
+}
+
+type CreateGroupInviteLinkResp struct {
+	Link *GroupInviteLink `json:"link"`
+}
+
+type GetGroupInviteLinksReq struct {
+	GroupID        string `json:"groupID"`
+	IncludeRevoked bool   `json:"includeRevoked"`
+}
+
+type GetGroupInviteLinksResp struct {
+	Links []*GroupInviteLink `json:"links"`
+	// 所有链接的入群人数
+	TotalJoinCount int64 `json:"totalJoinCount"`
+}
+
+type RevokeGroupInviteLinksReq struct {
+	GroupID string   `json:"groupID"`
+	Codes   []string `json:"codes"`
+}
+
+type RevokeGroupInviteLinksResp struct{}
+
+type JoinGroupByInviteLinkReq struct {
+	Code       string `json:"code"`
+	ReqMessage string `json:"reqMessage"`
+}
+
+type JoinGroupByInviteLinkResp struct {
+	GroupID string `json:"groupID"`
+	// false 表示已提交入群申请, 等待审批
+	Joined bool `json:"joined"`
+}
+
+type GroupEntryQuestion struct {
+	QuestionID string `json:"questionID"`
+	Question   string `json:"question"`
+	// 期望的答案, 只返回给有审批权限的成员
+	Answers []string `json:"answers"`
+}
+
+type GroupJoinRule struct {
+	// friendOfAdmin, exMatch, answerCorrect, answerWrong
+	Type string `json:"type"`
+	// 1 自动通过, -1 自动拒绝
+	Action int32  `json:"action"`
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+}
+
+type GroupJoinPolicy struct {
+	GroupID   string                `json:"groupID"`
+	Questions []*GroupEntryQuestion `json:"questions"`
+	// 只返回给有审批权限的成员
+	Rules []*GroupJoinRule `json:"rules"`
+	// 秒, 0 不过期
+	RequestExpire int64  `json:"requestExpire"`
+	OpUserID      string `json:"opUserID"`
+	UpdateTime    int64  `json:"updateTime"`
+}
+
+type GroupEntryAnswer struct {
+	QuestionID string `json:"questionID"`
+	Answer     string `json:"answer"`
+}
+
+type GroupJoinAnswer struct {
+	UserID     string              `json:"userID"`
+	Answers    []*GroupEntryAnswer `json:"answers"`
+	CreateTime int64               `json:"createTime"`
+}
+
+type SetGroupJoinPolicyReq struct {
+	GroupID       string                `json:"groupID"`
+	Questions     []*GroupEntryQuestion `json:"questions"`
+	Rules         []*GroupJoinRule      `json:"rules"`
+	RequestExpire int64                 `json:"requestExpire"`
+}
+
+type SetGroupJoinPolicyResp struct{}
+
+type GetGroupJoinPolicyReq struct {
+	GroupID string `json:"groupID"`
+}
+
+type GetGroupJoinPolicyResp struct {
+	// 未设置时为空
+	Policy *GroupJoinPolicy `json:"policy"`
+}
+
+type JoinGroupWithAnswersReq struct {
+	GroupID    string              `json:"groupID"`
+	ReqMessage string              `json:"reqMessage"`
+	JoinSource int32               `json:"joinSource"`
+	Answers    []*GroupEntryAnswer `json:"answers"`
+}
+
+type JoinGroupWithAnswersResp struct {
+	// false 表示已提交入群申请, 等待审批
+	Joined bool `json:"joined"`
+}
+
+type GetGroupJoinAnswersReq struct {
+	GroupID string   `json:"groupID"`
+	UserIDs []string `json:"userIDs"`
+}
+
+type GetGroupJoinAnswersResp struct {
+	Answers []*GroupJoinAnswer `json:"answers"`
+}
+
+type SearchGroupMembersReq struct {
+	GroupID string `json:"groupID"`
+	// 匹配群昵称、用户昵称或等于userID
+	Keyword       string  `json:"keyword"`
+	KeywordPrefix bool    `json:"keywordPrefix"`
+	RoleLevels    []int32 `json:"roleLevels"`
+	Muted         *bool   `json:"muted"`
+	// 毫秒时间戳, 0 不过滤
+	JoinTimeBegin int64   `json:"joinTimeBegin"`
+	JoinTimeEnd   int64   `json:"joinTimeEnd"`
+	JoinSources   []int32 `json:"joinSources"`
+	// joinTimeAsc(默认), joinTimeDesc, roleLevel
+	Sort string `json:"sort"`
+	// 上一页返回的nextCursor, 为空从第一页开始
+	Cursor string `json:"cursor"`
+	Count  int32  `json:"count"`
+}
+
+type SearchGroupMembersResp struct {
+	Total   int64              `json:"total"`
+	Members []*GroupMemberInfo `json:"members"`
+	// 为空表示没有更多
+	NextCursor string `json:"nextCursor"`
+}
+
+type ChannelInfo struct {
+	*sdkws.GroupInfo
+	IsPublic        bool  `json:"isPublic"`
+	SubscriberCount int64 `json:"subscriberCount"`
+	// 当前用户是否已订阅
+	Subscribed bool `json:"subscribed"`
+}
+
+type CreateChannelReq struct {
+	GroupID      string   `json:"groupID"`
+	GroupName    string   `json:"groupName"`
+	Introduction string   `json:"introduction"`
+	FaceURL      string   `json:"faceURL"`
+	Ex           string   `json:"ex"`
+	OwnerUserID  string   `json:"ownerUserID"`
+	AdminUserIDs []string `json:"adminUserIDs"`
+	IsPublic     bool     `json:"isPublic"`
+}
+
+type CreateChannelResp struct {
+	Channel *ChannelInfo `json:"channel"`
+}
+
+type SetChannelPublicReq struct {
+	GroupID  string `json:"groupID"`
+	IsPublic bool   `json:"isPublic"`
+}
+
+type SetChannelPublicResp struct{}
+
+type GetChannelsInfoReq struct {
+	GroupIDs []string `json:"groupIDs"`
+}
+
+type GetChannelsInfoResp struct {
+	Channels []*ChannelInfo `json:"channels"`
+}
+
+type SearchChannelsReq struct {
+	Keyword    string                   `json:"keyword"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type SearchChannelsResp struct {
+	Total    int64          `json:"total"`
+	Channels []*ChannelInfo `json:"channels"`
+}
+
+type SubscribeChannelReq struct {
+	GroupID string `json:"groupID"`
+	UserID  string `json:"userID"`
+}
+
+type SubscribeChannelResp struct{}
+
+type UnsubscribeChannelReq struct {
+	GroupID string `json:"groupID"`
+	UserID  string `json:"userID"`
+}
+
+type UnsubscribeChannelResp struct{}
+
+// OpenChannelReq 订阅者首次打开频道时创建会话.
+type OpenChannelReq struct {
+	GroupID string `json:"groupID"`
+	UserID  string `json:"userID"`
+}
+
+type OpenChannelResp struct{}
+
+type GetSubscribedChannelsReq struct {
+	UserID     string                   `json:"userID"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetSubscribedChannelsResp struct {
+	Total    int64          `json:"total"`
+	Channels []*ChannelInfo `json:"channels"`
+}
+
+type IsChannelSubscriberReq struct {
+	GroupID string `json:"groupID"`
+	UserID  string `json:"userID"`
+}
+
+type IsChannelSubscriberResp struct {
+	Subscribed bool `json:"subscribed"`
+}
+
+// GetChannelSubscriberIDsReq 按userID顺序分批获取订阅者, Cursor为上一批最后一个userID.
+type GetChannelSubscriberIDsReq struct {
+	GroupID string `json:"groupID"`
+	Cursor  string `json:"cursor"`
+	Count   int32  `json:"count"`
+}
+
+type GetChannelSubscriberIDsResp struct {
+	UserIDs []string `json:"userIDs"`
+}
+
+type SetGroupSlowModeReq struct {
+	GroupID string `json:"groupID"`
+	// 两次发言的最小间隔秒数, 0为关闭
+	Seconds int32 `json:"seconds"`
+}
+
+type SetGroupSlowModeResp struct{}
+
+type GetGroupSlowModeReq struct {
+	GroupID string `json:"groupID"`
+}
+
+type GetGroupSlowModeResp struct {
+	Seconds int32 `json:"seconds"`
+}
+
+// GroupSlowModeSetTips rpcclient.GroupSlowModeSetNotification的内容.
+type GroupSlowModeSetTips struct {
+	Group         *sdkws.GroupInfo           `json:"group"`
+	OpUser        *sdkws.GroupMemberFullInfo `json:"opUser"`
+	Seconds       int32                      `json:"seconds"`
+	OperationTime int64                      `json:"operationTime"`
+}
+
+type GroupBan struct {
+	GroupID        string `json:"groupID"`
+	UserID         string `json:"userID"`
+	Reason         string `json:"reason"`
+	OperatorUserID string `json:"operatorUserID"`
+	// 0为永久封禁
+	ExpireTime int64 `json:"expireTime"`
+	CreateTime int64 `json:"createTime"`
+}
+
+type BanGroupMembersReq struct {
+	GroupID string   `json:"groupID"`
+	UserIDs []string `json:"userIDs"`
+	Reason  string   `json:"reason"`
+	// 封禁秒数, 0为永久封禁
+	Duration int64 `json:"duration"`
+	// 同时将群成员踢出群
+	Kick bool `json:"kick"`
+	// 同时删除最近多少秒内发送的群消息, 0不删除
+	DeleteMsgsDuration int64 `json:"deleteMsgsDuration"`
+}
+
+// 封禁生效后的步骤, 失败时不影响封禁, 可用相同参数重试.
+const (
+	BanStepDeleteMsgs  = "deleteMsgs"
+	BanStepKick        = "kick"
+	BanStepUnsubscribe = "unsubscribe"
+)
+
+type BanGroupMemberFailure struct {
+	UserID string `json:"userID"`
+	Step   string `json:"step"`
+	ErrMsg string `json:"errMsg"`
+}
+
+type BanGroupMembersResp struct {
+	// 封禁已生效但后续步骤失败的用户
+	Failures []*BanGroupMemberFailure `json:"failures"`
+}
+
+type UnbanGroupMembersReq struct {
+	GroupID string   `json:"groupID"`
+	UserIDs []string `json:"userIDs"`
+}
+
+type UnbanGroupMembersResp struct{}
+
+type GetGroupBansReq struct {
+	GroupID    string                   `json:"groupID"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetGroupBansResp struct {
+	Total int64       `json:"total"`
+	Bans  []*GroupBan `json:"bans"`
+}
+
+// GroupMemberBannedTips rpcclient.GroupMemberBannedNotification的内容.
+type GroupMemberBannedTips struct {
+	Group         *sdkws.GroupInfo           `json:"group"`
+	OpUser        *sdkws.GroupMemberFullInfo `json:"opUser"`
+	BannedUserIDs []string                   `json:"bannedUserIDs"`
+	Reason        string                     `json:"reason"`
+	ExpireTime    int64                      `json:"expireTime"`
+	OperationTime int64                      `json:"operationTime"`
+}
+
+type GroupAuditLog struct {
+	GroupID        string   `json:"groupID"`
+	Action         string   `json:"action"`
+	OperatorUserID string   `json:"operatorUserID"`
+	TargetUserIDs  []string `json:"targetUserIDs"`
+	// json, 变更前后的值
+	Before     string `json:"before"`
+	After      string `json:"after"`
+	CreateTime int64  `json:"createTime"`
+}
+
+// GetGroupAuditLogsReq groupID为空时仅app管理员可查询, 其余过滤条件为空不生效.
+type GetGroupAuditLogsReq struct {
+	GroupID        string                   `json:"groupID"`
+	OperatorUserID string                   `json:"operatorUserID"`
+	TargetUserID   string                   `json:"targetUserID"`
+	Actions        []string                 `json:"actions"`
+	StartTime      int64                    `json:"startTime"`
+	EndTime        int64                    `json:"endTime"`
+	Pagination     *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetGroupAuditLogsResp struct {
+	Total int64            `json:"total"`
+	Logs  []*GroupAuditLog `json:"logs"`
+}
+
+type CommunityInfo struct {
+	GroupInfo *sdkws.GroupInfo `json:"groupInfo"`
+	// 社区默认公告频道
+	AnnouncementGroupID string `json:"announcementGroupID"`
+}
+
+type CommunityGroup struct {
+	GroupInfo      *sdkws.GroupInfo `json:"groupInfo"`
+	IsPublic       bool             `json:"isPublic"`
+	IsAnnouncement bool             `json:"isAnnouncement"`
+}
+
+// CreateCommunityReq 创建社区并创建默认公告频道, 群主和管理员同时是公告频道的群主和管理员.
+type CreateCommunityReq struct {
+	GroupID          string   `json:"groupID"`
+	GroupName        string   `json:"groupName"`
+	Introduction     string   `json:"introduction"`
+	FaceURL          string   `json:"faceURL"`
+	Ex               string   `json:"ex"`
+	NeedVerification int32    `json:"needVerification"`
+	OwnerUserID      string   `json:"ownerUserID"`
+	AdminUserIDs     []string `json:"adminUserIDs"`
+	MemberUserIDs    []string `json:"memberUserIDs"`
+}
+
+type CreateCommunityResp struct {
+	Community *CommunityInfo `json:"community"`
+}
+
+// AttachCommunityGroupsReq 操作者需要是群的群主.
+type AttachCommunityGroupsReq struct {
+	CommunityID string   `json:"communityID"`
+	GroupIDs    []string `json:"groupIDs"`
+	IsPublic    bool     `json:"isPublic"`
+}
+
+type AttachCommunityGroupsResp struct{}
+
+type DetachCommunityGroupsReq struct {
+	CommunityID string   `json:"communityID"`
+	GroupIDs    []string `json:"groupIDs"`
+}
+
+type DetachCommunityGroupsResp struct{}
+
+type GetCommunityGroupsReq struct {
+	CommunityID string `json:"communityID"`
+}
+
+// GetCommunityGroupsResp 普通成员只能看到公开的群和已加入的群.
+type GetCommunityGroupsResp struct {
+	Groups []*CommunityGroup `json:"groups"`
+}
+
+type GetJoinedCommunitiesReq struct {
+	UserID     string                   `json:"userID"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetJoinedCommunitiesResp struct {
+	Total       int64            `json:"total"`
+	Communities []*CommunityInfo `json:"communities"`
+}
+
+type GroupAnnouncement struct {
+	GroupID      string `json:"groupID"`
+	Notification string `json:"notification"`
+	UserID       string `json:"userID"`
+	CreateTime   int64  `json:"createTime"`
+}
+
+type GetGroupAnnouncementsReq struct {
+	GroupID    string                   `json:"groupID"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetGroupAnnouncementsResp struct {
+	Total         int64                `json:"total"`
+	Announcements []*GroupAnnouncement `json:"announcements"`
+}
+
+// ReassignGroupOwnerReq newOwnerUserID为空时按继任策略选择新群主.
+type ReassignGroupOwnerReq struct {
+	GroupID        string `json:"groupID"`
+	NewOwnerUserID string `json:"newOwnerUserID"`
+}
+
+type ReassignGroupOwnerResp struct {
+	NewOwnerUserID string `json:"newOwnerUserID"`
+}
+
+// SucceedUserOwnedGroupsReq 用户注销时为其作为群主的群选择继任者.
+type SucceedUserOwnedGroupsReq struct {
+	UserID string `json:"userID"`
+}
+
+type SucceedUserOwnedGroupsResp struct {
+	// 没有继任者的群, 群主保持不变
+	NoSuccessorGroupIDs []string `json:"noSuccessorGroupIDs"`
+}
+
+// GetSharedGroupCountsReq 统计和userID同在一个群的用户及共同群数, 成员数超过maxMemberCount的群不统计.
+type GetSharedGroupCountsReq struct {
+	UserID         string `json:"userID"`
+	MaxMemberCount int32  `json:"maxMemberCount"`
+}
+
+type GetSharedGroupCountsResp struct {
+	// userID -> 共同群数
+	Counts map[string]int32 `json:"counts"`
+}
+
+// GetSharedGroupIDsReq 获取userID和otherUserID共同所在的未解散群.
+type GetSharedGroupIDsReq struct {
+	UserID      string `json:"userID"`
+	OtherUserID string `json:"otherUserID"`
+}
+
+type GetSharedGroupIDsResp struct {
+	GroupIDs []string `json:"groupIDs"`
+}
+
+// GetIncrementalJoinGroupsReq 获取version之后已加入群列表的变更, version为0时返回fullSync.
+type GetIncrementalJoinGroupsReq struct {
+	UserID  string `json:"userID"`
+	Version int64  `json:"version"`
+}
+
+// GetIncrementalJoinGroupsResp fullSync为true时客户端需要重新拉取全部群, more为true时以version继续获取.
+type GetIncrementalJoinGroupsResp struct {
+	Version  int64              `json:"version"`
+	FullSync bool               `json:"fullSync"`
+	More     bool               `json:"more"`
+	Insert   []*sdkws.GroupInfo `json:"insert"`
+	Update   []*sdkws.GroupInfo `json:"update"`
+	Delete   []string           `json:"delete"`
+}
+
+// MaxIncrementalGroups groups of one GetIncrementalGroupMembersReq.
+const MaxIncrementalGroups = 1000
+
+type GroupVersion struct {
+	GroupID string `json:"groupID"`
+	Version int64  `json:"version"`
+}
+
+// GetIncrementalGroupMembersReq 批量获取多个群version之后成员列表的变更.
+type GetIncrementalGroupMembersReq struct {
+	UserID string          `json:"userID"`
+	Groups []*GroupVersion `json:"groups"`
+}
+
+type GroupMemberChanges struct {
+	GroupID  string                       `json:"groupID"`
+	Version  int64                        `json:"version"`
+	FullSync bool                         `json:"fullSync"`
+	More     bool                         `json:"more"`
+	Insert   []*sdkws.GroupMemberFullInfo `json:"insert"`
+	Update   []*sdkws.GroupMemberFullInfo `json:"update"`
+	Delete   []string                     `json:"delete"`
+}
+
+type GetIncrementalGroupMembersResp struct {
+	// 只返回有变更的群, 用户已不在其中的群不返回
+	Groups []*GroupMemberChanges `json:"groups"`
+}
+
+func checkPermissions(permissions int64) error {
+	if permissions&^relation.GroupPermissionAll != 0 {
+		return errs.ErrArgs.Wrap("permissions is invalid")
+	}
+	return nil
+}
+
+func (x *CreateGroupRoleReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.Name == "" {
+		return errs.ErrArgs.Wrap("name is empty")
+	}
+	return checkPermissions(x.Permissions)
+}
+
+func (x *SetGroupRoleReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.RoleID == "" {
+		return errs.ErrArgs.Wrap("roleID is empty")
+	}
+	if x.Name != nil && *x.Name == "" {
+		return errs.ErrArgs.Wrap("name is empty")
+	}
+	if x.Permissions != nil {
+		return checkPermissions(*x.Permissions)
+	}
+	return nil
+}
+
+func (x *DeleteGroupRoleReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.RoleID == "" {
+		return errs.ErrArgs.Wrap("roleID is empty")
+	}
+	return nil
+}
+
+func (x *GetGroupRolesReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return nil
+}
+
+func (x *SetGroupMembersRoleReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if len(x.UserIDs) == 0 {
+		return errs.ErrArgs.Wrap("userIDs is empty")
+	}
+	return nil
+}
+
+func (x *GetGroupExtInfoCacheReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return nil
+}
+
+func (x *GetGroupMembersExtInfoReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if len(x.UserIDs) == 0 {
+		return errs.ErrArgs.Wrap("userIDs is empty")
+	}
+	return nil
+}
+
+func (x *CreateGroupInviteLinkReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.MaxUses < 0 {
+		return errs.ErrArgs.Wrap("maxUses is invalid")
+	}
+	if x.ExpireTime < 0 {
+		return errs.ErrArgs.Wrap("expireTime is invalid")
+	}
+	return nil
+}
+
+func (x *GetGroupInviteLinksReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return nil
+}
+
+func (x *RevokeGroupInviteLinksReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if len(x.Codes) == 0 {
+		return errs.ErrArgs.Wrap("codes is empty")
+	}
+	return nil
+}
+
+func (x *JoinGroupByInviteLinkReq) Check() error {
+	if x.Code == "" {
+		return errs.ErrArgs.Wrap("code is empty")
+	}
+	return nil
+}
+
+func (x *SetGroupJoinPolicyReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if len(x.Questions) > 20 {
+		return errs.ErrArgs.Wrap("too many questions")
+	}
+	if len(x.Rules) > 20 {
+		return errs.ErrArgs.Wrap("too many rules")
+	}
+	if x.RequestExpire < 0 {
+		return errs.ErrArgs.Wrap("requestExpire is invalid")
+	}
+	questionIDs := make(map[string]struct{})
+	for _, question := range x.Questions {
+		if question == nil || question.QuestionID == "" || question.Question == "" {
+			return errs.ErrArgs.Wrap("question is invalid")
+		}
+		if _, ok := questionIDs[question.QuestionID]; ok {
+			return errs.ErrArgs.Wrap("questionID duplicate " + question.QuestionID)
+		}
+		questionIDs[question.QuestionID] = struct{}{}
+	}
+	for _, rule := range x.Rules {
+		if rule == nil {
+			return errs.ErrArgs.Wrap("rule is nil")
+		}
+		switch rule.Type {
+		case unrelation.GroupJoinRuleFriendOfAdmin, unrelation.GroupJoinRuleAnswerCorrect, unrelation.GroupJoinRuleAnswerWrong:
+		case unrelation.GroupJoinRuleExMatch:
+			if rule.Key == "" {
+				return errs.ErrArgs.Wrap("rule key is empty")
+			}
+		default:
+			return errs.ErrArgs.Wrap("rule type unknown " + rule.Type)
+		}
+		if rule.Action != constant.GroupResponseAgree && rule.Action != constant.GroupResponseRefuse {
+			return errs.ErrArgs.Wrap("rule action unknown")
+		}
+	}
+	return nil
+}
+
+func (x *GetGroupJoinPolicyReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return nil
+}
+
+func (x *JoinGroupWithAnswersReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	for _, answer := range x.Answers {
+		if answer == nil || answer.QuestionID == "" {
+			return errs.ErrArgs.Wrap("answer is invalid")
+		}
+	}
+	return nil
+}
+
+func (x *GetGroupJoinAnswersReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if len(x.UserIDs) == 0 {
+		return errs.ErrArgs.Wrap("userIDs is empty")
+	}
+	return nil
+}
+
+func (x *SearchGroupMembersReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.Count <= 0 || x.Count > 1000 {
+		return errs.ErrArgs.Wrap("count is invalid")
+	}
+	switch x.Sort {
+	case "", relation.GroupMemberSortJoinTimeAsc, relation.GroupMemberSortJoinTimeDesc, relation.GroupMemberSortRoleLevel:
+	default:
+		return errs.ErrArgs.Wrap("sort is invalid")
+	}
+	if x.JoinTimeBegin < 0 || x.JoinTimeEnd < 0 {
+		return errs.ErrArgs.Wrap("joinTime is invalid")
+	}
+	return nil
+}
+
+func checkPagination(pagination *sdkws.RequestPagination) error {
+	if pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	return nil
+}
+
+func (x *CreateChannelReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.GroupName == "" {
+		return errs.ErrArgs.Wrap("groupName is empty")
+	}
+	return nil
+}
+
+func (x *SetChannelPublicReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return nil
+}
+
+func (x *GetChannelsInfoReq) Check() error {
+	if len(x.GroupIDs) == 0 {
+		return errs.ErrArgs.Wrap("groupIDs is empty")
+	}
+	return nil
+}
+
+func (x *SearchChannelsReq) Check() error {
+	return checkPagination(x.Pagination)
+}
+
+func (x *SubscribeChannelReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *UnsubscribeChannelReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *OpenChannelReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetSubscribedChannelsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return checkPagination(x.Pagination)
+}
+
+func (x *IsChannelSubscriberReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetChannelSubscriberIDsReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.Count <= 0 || x.Count > 10000 {
+		return errs.ErrArgs.Wrap("count is invalid")
+	}
+	return nil
+}
+
+func (x *SetGroupSlowModeReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.Seconds < 0 || x.Seconds > 86400 {
+		return errs.ErrArgs.Wrap("seconds is invalid")
+	}
+	return nil
+}
+
+func (x *GetGroupSlowModeReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return nil
+}
+
+func (x *BanGroupMembersReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if len(x.UserIDs) == 0 {
+		return errs.ErrArgs.Wrap("userIDs is empty")
+	}
+	if utils.Duplicate(x.UserIDs) {
+		return errs.ErrArgs.Wrap("userIDs is duplicate")
+	}
+	if x.Duration < 0 || x.DeleteMsgsDuration < 0 {
+		return errs.ErrArgs.Wrap("duration is invalid")
+	}
+	return nil
+}
+
+func (x *UnbanGroupMembersReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if len(x.UserIDs) == 0 {
+		return errs.ErrArgs.Wrap("userIDs is empty")
+	}
+	return nil
+}
+
+func (x *GetGroupBansReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return checkPagination(x.Pagination)
+}
+
+func (x *GetGroupAuditLogsReq) Check() error {
+	if x.StartTime < 0 || x.EndTime < 0 {
+		return errs.ErrArgs.Wrap("invalid time range")
+	}
+	if x.EndTime > 0 && x.StartTime >= x.EndTime {
+		return errs.ErrArgs.Wrap("startTime must be before endTime")
+	}
+	return checkPagination(x.Pagination)
+}
+
+func (x *CreateCommunityReq) Check() error {
+	if x.OwnerUserID == "" {
+		return errs.ErrArgs.Wrap("ownerUserID is empty")
+	}
+	if x.GroupName == "" {
+		return errs.ErrArgs.Wrap("groupName is empty")
+	}
+	return nil
+}
+
+func (x *AttachCommunityGroupsReq) Check() error {
+	if x.CommunityID == "" {
+		return errs.ErrArgs.Wrap("communityID is empty")
+	}
+	if len(x.GroupIDs) == 0 {
+		return errs.ErrArgs.Wrap("groupIDs is empty")
+	}
+	if utils.Duplicate(x.GroupIDs) {
+		return errs.ErrArgs.Wrap("groupIDs duplicate")
+	}
+	if utils.Contain(x.CommunityID, x.GroupIDs...) {
+		return errs.ErrArgs.Wrap("can not attach community to itself")
+	}
+	return nil
+}
+
+func (x *DetachCommunityGroupsReq) Check() error {
+	if x.CommunityID == "" {
+		return errs.ErrArgs.Wrap("communityID is empty")
+	}
+	if len(x.GroupIDs) == 0 {
+		return errs.ErrArgs.Wrap("groupIDs is empty")
+	}
+	return nil
+}
+
+func (x *GetCommunityGroupsReq) Check() error {
+	if x.CommunityID == "" {
+		return errs.ErrArgs.Wrap("communityID is empty")
+	}
+	return nil
+}
+
+func (x *GetJoinedCommunitiesReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return checkPagination(x.Pagination)
+}
+
+func (x *GetGroupAnnouncementsReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return checkPagination(x.Pagination)
+}
+
+func (x *ReassignGroupOwnerReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return nil
+}
+
+func (x *SucceedUserOwnedGroupsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetSharedGroupIDsReq) Check() error {
+	if x.UserID == "" || x.OtherUserID == "" {
+		return errs.ErrArgs.Wrap("userID or otherUserID is empty")
+	}
+	return nil
+}
+
+func (x *GetSharedGroupCountsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.MaxMemberCount < 0 {
+		return errs.ErrArgs.Wrap("maxMemberCount is invalid")
+	}
+	return nil
+}
+
+func (x *GetIncrementalJoinGroupsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.Version < 0 {
+		return errs.ErrArgs.Wrap("version is invalid")
+	}
+	return nil
+}
+
+func (x *GetIncrementalGroupMembersReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if len(x.Groups) == 0 {
+		return errs.ErrArgs.Wrap("groups is empty")
+	}
+	if len(x.Groups) > MaxIncrementalGroups {
+		return errs.ErrArgs.Wrap("too many groups")
+	}
+	groupIDs := make([]string, 0, len(x.Groups))
+	for _, group := range x.Groups {
+		if group == nil || group.GroupID == "" {
+			return errs.ErrArgs.Wrap("groupID is empty")
+		}
+		if group.Version < 0 {
+			return errs.ErrArgs.Wrap("version is invalid")
+		}
+		groupIDs = append(groupIDs, group.GroupID)
+	}
+	if utils.Duplicate(groupIDs) {
+		return errs.ErrArgs.Wrap("groupID duplicate")
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupext
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/jsonrpc"
+)
+
+const ServiceName = "OpenIMServer.groupext.groupExt"
+
+type GroupExtClient interface {
+	CreateGroupRole(ctx context.Context, in *CreateGroupRoleReq, opts ...grpc.CallOption) (*CreateGroupRoleResp, error)
+	SetGroupRole(ctx context.Context, in *SetGroupRoleReq, opts ...grpc.CallOption) (*SetGroupRoleResp, error)
+	DeleteGroupRole(ctx context.Context, in *DeleteGroupRoleReq, opts ...grpc.CallOption) (*DeleteGroupRoleResp, error)
+	GetGroupRoles(ctx context.Context, in *GetGroupRolesReq, opts ...grpc.CallOption) (*GetGroupRolesResp, error)
+	SetGroupMembersRole(ctx context.Context, in *SetGroupMembersRoleReq, opts ...grpc.CallOption) (*SetGroupMembersRoleResp, error)
+	GetGroupMembersExtInfo(ctx context.Context, in *GetGroupMembersExtInfoReq, opts ...grpc.CallOption) (*GetGroupMembersExtInfoResp, error)
+	CreateGroupInviteLink(ctx context.Context, in *CreateGroupInviteLinkReq, opts ...grpc.CallOption) (*CreateGroupInviteLinkResp, error)
+	GetGroupInviteLinks(ctx context.Context, in *GetGroupInviteLinksReq, opts ...grpc.CallOption) (*GetGroupInviteLinksResp, error)
+	RevokeGroupInviteLinks(ctx context.Context, in *RevokeGroupInviteLinksReq, opts ...grpc.CallOption) (*RevokeGroupInviteLinksResp, error)
+	JoinGroupByInviteLink(ctx context.Context, in *JoinGroupByInviteLinkReq, opts ...grpc.CallOption) (*JoinGroupByInviteLinkResp, error)
+	SetGroupJoinPolicy(ctx context.Context, in *SetGroupJoinPolicyReq, opts ...grpc.CallOption) (*SetGroupJoinPolicyResp, error)
+	GetGroupJoinPolicy(ctx context.Context, in *GetGroupJoinPolicyReq, opts ...grpc.CallOption) (*GetGroupJoinPolicyResp, error)
+	JoinGroupWithAnswers(ctx context.Context, in *JoinGroupWithAnswersReq, opts ...grpc.CallOption) (*JoinGroupWithAnswersResp, error)
+	GetGroupJoinAnswers(ctx context.Context, in *GetGroupJoinAnswersReq, opts ...grpc.CallOption) (*GetGroupJoinAnswersResp, error)
+	SearchGroupMembers(ctx context.Context, in *SearchGroupMembersReq, opts ...grpc.CallOption) (*SearchGroupMembersResp, error)
+	CreateChannel(ctx context.Context, in *CreateChannelReq, opts ...grpc.CallOption) (*CreateChannelResp, error)
+	SetChannelPublic(ctx context.Context, in *SetChannelPublicReq, opts ...grpc.CallOption) (*SetChannelPublicResp, error)
+	GetChannelsInfo(ctx context.Context, in *GetChannelsInfoReq, opts ...grpc.CallOption) (*GetChannelsInfoResp, error)
+	SearchChannels(ctx context.Context, in *SearchChannelsReq, opts ...grpc.CallOption) (*SearchChannelsResp, error)
+	SubscribeChannel(ctx context.Context, in *SubscribeChannelReq, opts ...grpc.CallOption) (*SubscribeChannelResp, error)
+	UnsubscribeChannel(ctx context.Context, in *UnsubscribeChannelReq, opts ...grpc.CallOption) (*UnsubscribeChannelResp, error)
+	OpenChannel(ctx context.Context, in *OpenChannelReq, opts ...grpc.CallOption) (*OpenChannelResp, error)
+	GetSubscribedChannels(ctx context.Context, in *GetSubscribedChannelsReq, opts ...grpc.CallOption) (*GetSubscribedChannelsResp, error)
+	IsChannelSubscriber(ctx context.Context, in *IsChannelSubscriberReq, opts ...grpc.CallOption) (*IsChannelSubscriberResp, error)
+	GetChannelSubscriberIDs(ctx context.Context, in *GetChannelSubscriberIDsReq, opts ...grpc.CallOption) (*GetChannelSubscriberIDsResp, error)
+	SetGroupSlowMode(ctx context.Context, in *SetGroupSlowModeReq, opts ...grpc.CallOption) (*SetGroupSlowModeResp, error)
+	GetGroupSlowMode(ctx context.Context, in *GetGroupSlowModeReq, opts ...grpc.CallOption) (*GetGroupSlowModeResp, error)
+	BanGroupMembers(ctx context.Context, in *BanGroupMembersReq, opts ...grpc.CallOption) (*BanGroupMembersResp, error)
+	UnbanGroupMembers(ctx context.Context, in *UnbanGroupMembersReq, opts ...grpc.CallOption) (*UnbanGroupMembersResp, error)
+	GetGroupBans(ctx context.Context, in *GetGroupBansReq, opts ...grpc.CallOption) (*GetGroupBansResp, error)
+	GetGroupAuditLogs(ctx context.Context, in *GetGroupAuditLogsReq, opts ...grpc.CallOption) (*GetGroupAuditLogsResp, error)
+	CreateCommunity(ctx context.Context, in *CreateCommunityReq, opts ...grpc.CallOption) (*CreateCommunityResp, error)
+	AttachCommunityGroups(ctx context.Context, in *AttachCommunityGroupsReq, opts ...grpc.CallOption) (*AttachCommunityGroupsResp, error)
+	DetachCommunityGroups(ctx context.Context, in *DetachCommunityGroupsReq, opts ...grpc.CallOption) (*DetachCommunityGroupsResp, error)
+	GetCommunityGroups(ctx context.Context, in *GetCommunityGroupsReq, opts ...grpc.CallOption) (*GetCommunityGroupsResp, error)
+	GetJoinedCommunities(ctx context.Context, in *GetJoinedCommunitiesReq, opts ...grpc.CallOption) (*GetJoinedCommunitiesResp, error)
+	GetGroupAnnouncements(ctx context.Context, in *GetGroupAnnouncementsReq, opts ...grpc.CallOption) (*GetGroupAnnouncementsResp, error)
+	ReassignGroupOwner(ctx context.Context, in *ReassignGroupOwnerReq, opts ...grpc.CallOption) (*ReassignGroupOwnerResp, error)
+	SucceedUserOwnedGroups(ctx context.Context, in *SucceedUserOwnedGroupsReq, opts ...grpc.CallOption) (*SucceedUserOwnedGroupsResp, error)
+	GetSharedGroupCounts(ctx context.Context, in *GetSharedGroupCountsReq, opts ...grpc.CallOption) (*GetSharedGroupCountsResp, error)
+	GetIncrementalJoinGroups(ctx context.Context, in *GetIncrementalJoinGroupsReq, opts ...grpc.CallOption) (*GetIncrementalJoinGroupsResp, error)
+	GetIncrementalGroupMembers(ctx context.Context, in *GetIncrementalGroupMembersReq, opts ...grpc.CallOption) (*GetIncrementalGroupMembersResp, error)
+	GetSharedGroupIDs(ctx context.Context, in *GetSharedGroupIDsReq, opts ...grpc.CallOption) (*GetSharedGroupIDsResp, error)
+	GetGroupExtInfoCache(ctx context.Context, in *GetGroupExtInfoCacheReq, opts ...grpc.CallOption) (*GetGroupExtInfoCacheResp, error)
+}
+
+type groupExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupExtClient(cc grpc.ClientConnInterface) GroupExtClient {
+	return &groupExtClient{cc}
+}
+
+func (c *groupExtClient) CreateGroupRole(ctx context.Context, in *CreateGroupRoleReq, opts ...grpc.CallOption) (*CreateGroupRoleResp, error) {
+	return jsonrpc.Invoke[CreateGroupRoleResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "CreateGroupRole"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupRole(ctx context.Context, in *SetGroupRoleReq, opts ...grpc.CallOption) (*SetGroupRoleResp, error) {
+	return jsonrpc.Invoke[SetGroupRoleResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetGroupRole"), in, opts...)
+}
+
+func (c *groupExtClient) DeleteGroupRole(ctx context.Context, in *DeleteGroupRoleReq, opts ...grpc.CallOption) (*DeleteGroupRoleResp, error) {
+	return jsonrpc.Invoke[DeleteGroupRoleResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "DeleteGroupRole"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupRoles(ctx context.Context, in *GetGroupRolesReq, opts ...grpc.CallOption) (*GetGroupRolesResp, error) {
+	return jsonrpc.Invoke[GetGroupRolesResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupRoles"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupMembersRole(ctx context.Context, in *SetGroupMembersRoleReq, opts ...grpc.CallOption) (*SetGroupMembersRoleResp, error) {
+	return jsonrpc.Invoke[SetGroupMembersRoleResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetGroupMembersRole"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupMembersExtInfo(ctx context.Context, in *GetGroupMembersExtInfoReq, opts ...grpc.CallOption) (*GetGroupMembersExtInfoResp, error) {
+	return jsonrpc.Invoke[GetGroupMembersExtInfoResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupMembersExtInfo"), in, opts...)
+}
+
+func (c *groupExtClient) CreateGroupInviteLink(ctx context.Context, in *CreateGroupInviteLinkReq, opts ...grpc.CallOption) (*CreateGroupInviteLinkResp, error) {
+	return jsonrpc.Invoke[CreateGroupInviteLinkResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "CreateGroupInviteLink"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupInviteLinks(ctx context.Context, in *GetGroupInviteLinksReq, opts ...grpc.CallOption) (*GetGroupInviteLinksResp, error) {
+	return jsonrpc.Invoke[GetGroupInviteLinksResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupInviteLinks"), in, opts...)
+}
+
+func (c *groupExtClient) RevokeGroupInviteLinks(ctx context.Context, in *RevokeGroupInviteLinksReq, opts ...grpc.CallOption) (*RevokeGroupInviteLinksResp, error) {
+	return jsonrpc.Invoke[RevokeGroupInviteLinksResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "RevokeGroupInviteLinks"), in, opts...)
+}
+
+func (c *groupExtClient) JoinGroupByInviteLink(ctx context.Context, in *JoinGroupByInviteLinkReq, opts ...grpc.CallOption) (*JoinGroupByInviteLinkResp, error) {
+	return jsonrpc.Invoke[JoinGroupByInviteLinkResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "JoinGroupByInviteLink"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupJoinPolicy(ctx context.Context, in *SetGroupJoinPolicyReq, opts ...grpc.CallOption) (*SetGroupJoinPolicyResp, error) {
+	return jsonrpc.Invoke[SetGroupJoinPolicyResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetGroupJoinPolicy"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupJoinPolicy(ctx context.Context, in *GetGroupJoinPolicyReq, opts ...grpc.CallOption) (*GetGroupJoinPolicyResp, error) {
+	return jsonrpc.Invoke[GetGroupJoinPolicyResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupJoinPolicy"), in, opts...)
+}
+
+func (c *groupExtClient) JoinGroupWithAnswers(ctx context.Context, in *JoinGroupWithAnswersReq, opts ...grpc.CallOption) (*JoinGroupWithAnswersResp, error) {
+	return jsonrpc.Invoke[JoinGroupWithAnswersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "JoinGroupWithAnswers"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupJoinAnswers(ctx context.Context, in *GetGroupJoinAnswersReq, opts ...grpc.CallOption) (*GetGroupJoinAnswersResp, error) {
+	return jsonrpc.Invoke[GetGroupJoinAnswersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupJoinAnswers"), in, opts...)
+}
+
+func (c *groupExtClient) SearchGroupMembers(ctx context.Context, in *SearchGroupMembersReq, opts ...grpc.CallOption) (*SearchGroupMembersResp, error) {
+	return jsonrpc.Invoke[SearchGroupMembersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SearchGroupMembers"), in, opts...)
+}
+
+func (c *groupExtClient) CreateChannel(ctx context.Context, in *CreateChannelReq, opts ...grpc.CallOption) (*CreateChannelResp, error) {
+	return jsonrpc.Invoke[CreateChannelResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "CreateChannel"), in, opts...)
+}
+
+func (c *groupExtClient) SetChannelPublic(ctx context.Context, in *SetChannelPublicReq, opts ...grpc.CallOption) (*SetChannelPublicResp, error) {
+	return jsonrpc.Invoke[SetChannelPublicResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetChannelPublic"), in, opts...)
+}
+
+func (c *groupExtClient) GetChannelsInfo(ctx context.Context, in *GetChannelsInfoReq, opts ...grpc.CallOption) (*GetChannelsInfoResp, error) {
+	return jsonrpc.Invoke[GetChannelsInfoResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetChannelsInfo"), in, opts...)
+}
+
+func (c *groupExtClient) SearchChannels(ctx context.Context, in *SearchChannelsReq, opts ...grpc.CallOption) (*SearchChannelsResp, error) {
+	return jsonrpc.Invoke[SearchChannelsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SearchChannels"), in, opts...)
+}
+
+func (c *groupExtClient) SubscribeChannel(ctx context.Context, in *SubscribeChannelReq, opts ...grpc.CallOption) (*SubscribeChannelResp, error) {
+	return jsonrpc.Invoke[SubscribeChannelResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SubscribeChannel"), in, opts...)
+}
+
+func (c *groupExtClient) UnsubscribeChannel(ctx context.Context, in *UnsubscribeChannelReq, opts ...grpc.CallOption) (*UnsubscribeChannelResp, error) {
+	return jsonrpc.Invoke[UnsubscribeChannelResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "UnsubscribeChannel"), in, opts...)
+}
+
+func (c *groupExtClient) OpenChannel(ctx context.Context, in *OpenChannelReq, opts ...grpc.CallOption) (*OpenChannelResp, error) {
+	return jsonrpc.Invoke[OpenChannelResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "OpenChannel"), in, opts...)
+}
+
+func (c *groupExtClient) GetSubscribedChannels(ctx context.Context, in *GetSubscribedChannelsReq, opts ...grpc.CallOption) (*GetSubscribedChannelsResp, error) {
+	return jsonrpc.Invoke[GetSubscribedChannelsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetSubscribedChannels"), in, opts...)
+}
+
+func (c *groupExtClient) IsChannelSubscriber(ctx context.Context, in *IsChannelSubscriberReq, opts ...grpc.CallOption) (*IsChannelSubscriberResp, error) {
+	return jsonrpc.Invoke[IsChannelSubscriberResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "IsChannelSubscriber"), in, opts...)
+}
+
+func (c *groupExtClient) GetChannelSubscriberIDs(ctx context.Context, in *GetChannelSubscriberIDsReq, opts ...grpc.CallOption) (*GetChannelSubscriberIDsResp, error) {
+	return jsonrpc.Invoke[GetChannelSubscriberIDsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetChannelSubscriberIDs"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupSlowMode(ctx context.Context, in *SetGroupSlowModeReq, opts ...grpc.CallOption) (*SetGroupSlowModeResp, error) {
+	return jsonrpc.Invoke[SetGroupSlowModeResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetGroupSlowMode"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupSlowMode(ctx context.Context, in *GetGroupSlowModeReq, opts ...grpc.CallOption) (*GetGroupSlowModeResp, error) {
+	return jsonrpc.Invoke[GetGroupSlowModeResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupSlowMode"), in, opts...)
+}
+
+func (c *groupExtClient) BanGroupMembers(ctx context.Context, in *BanGroupMembersReq, opts ...grpc.CallOption) (*BanGroupMembersResp, error) {
+	return jsonrpc.Invoke[BanGroupMembersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "BanGroupMembers"), in, opts...)
+}
+
+func (c *groupExtClient) UnbanGroupMembers(ctx context.Context, in *UnbanGroupMembersReq, opts ...grpc.CallOption) (*UnbanGroupMembersResp, error) {
+	return jsonrpc.Invoke[UnbanGroupMembersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "UnbanGroupMembers"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupBans(ctx context.Context, in *GetGroupBansReq, opts ...grpc.CallOption) (*GetGroupBansResp, error) {
+	return jsonrpc.Invoke[GetGroupBansResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupBans"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupAuditLogs(ctx context.Context, in *GetGroupAuditLogsReq, opts ...grpc.CallOption) (*GetGroupAuditLogsResp, error) {
+	return jsonrpc.Invoke[GetGroupAuditLogsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupAuditLogs"), in, opts...)
+}
+
+func (c *groupExtClient) CreateCommunity(ctx context.Context, in *CreateCommunityReq, opts ...grpc.CallOption) (*CreateCommunityResp, error) {
+	return jsonrpc.Invoke[CreateCommunityResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "CreateCommunity"), in, opts...)
+}
+
+func (c *groupExtClient) AttachCommunityGroups(ctx context.Context, in *AttachCommunityGroupsReq, opts ...grpc.CallOption) (*AttachCommunityGroupsResp, error) {
+	return jsonrpc.Invoke[AttachCommunityGroupsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "AttachCommunityGroups"), in, opts...)
+}
+
+func (c *groupExtClient) DetachCommunityGroups(ctx context.Context, in *DetachCommunityGroupsReq, opts ...grpc.CallOption) (*DetachCommunityGroupsResp, error) {
+	return jsonrpc.Invoke[DetachCommunityGroupsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "DetachCommunityGroups"), in, opts...)
+}
+
+func (c *groupExtClient) GetCommunityGroups(ctx context.Context, in *GetCommunityGroupsReq, opts ...grpc.CallOption) (*GetCommunityGroupsResp, error) {
+	return jsonrpc.Invoke[GetCommunityGroupsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetCommunityGroups"), in, opts...)
+}
+
+func (c *groupExtClient) GetJoinedCommunities(ctx context.Context, in *GetJoinedCommunitiesReq, opts ...grpc.CallOption) (*GetJoinedCommunitiesResp, error) {
+	return jsonrpc.Invoke[GetJoinedCommunitiesResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetJoinedCommunities"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupAnnouncements(ctx context.Context, in *GetGroupAnnouncementsReq, opts ...grpc.CallOption) (*GetGroupAnnouncementsResp, error) {
+	return jsonrpc.Invoke[GetGroupAnnouncementsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupAnnouncements"), in, opts...)
+}
+
+func (c *groupExtClient) ReassignGroupOwner(ctx context.Context, in *ReassignGroupOwnerReq, opts ...grpc.CallOption) (*ReassignGroupOwnerResp, error) {
+	return jsonrpc.Invoke[ReassignGroupOwnerResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "ReassignGroupOwner"), in, opts...)
+}
+
+func (c *groupExtClient) SucceedUserOwnedGroups(ctx context.Context, in *SucceedUserOwnedGroupsReq, opts ...grpc.CallOption) (*SucceedUserOwnedGroupsResp, error) {
+	return jsonrpc.Invoke[SucceedUserOwnedGroupsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SucceedUserOwnedGroups"), in, opts...)
+}
+
+func (c *groupExtClient) GetSharedGroupCounts(ctx context.Context, in *GetSharedGroupCountsReq, opts ...grpc.CallOption) (*GetSharedGroupCountsResp, error) {
+	return jsonrpc.Invoke[GetSharedGroupCountsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetSharedGroupCounts"), in, opts...)
+}
+
+func (c *groupExtClient) GetIncrementalJoinGroups(ctx context.Context, in *GetIncrementalJoinGroupsReq, opts ...grpc.CallOption) (*GetIncrementalJoinGroupsResp, error) {
+	return jsonrpc.Invoke[GetIncrementalJoinGroupsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetIncrementalJoinGroups"), in, opts...)
+}
+
+func (c *groupExtClient) GetIncrementalGroupMembers(ctx context.Context, in *GetIncrementalGroupMembersReq, opts ...grpc.CallOption) (*GetIncrementalGroupMembersResp, error) {
+	return jsonrpc.Invoke[GetIncrementalGroupMembersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetIncrementalGroupMembers"), in, opts...)
+}
+
+func (c *groupExtClient) GetSharedGroupIDs(ctx context.Context, in *GetSharedGroupIDsReq, opts ...grpc.CallOption) (*GetSharedGroupIDsResp, error) {
+	return jsonrpc.Invoke[GetSharedGroupIDsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetSharedGroupIDs"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupExtInfoCache(ctx context.Context, in *GetGroupExtInfoCacheReq, opts ...grpc.CallOption) (*GetGroupExtInfoCacheResp, error) {
+	return jsonrpc.Invoke[GetGroupExtInfoCacheResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetGroupExtInfoCache"), in, opts...)
+}
+
+type GroupExtServer interface {
+	CreateGroupRole(context.Context, *CreateGroupRoleReq) (*CreateGroupRoleResp, error)
+	SetGroupRole(context.Context, *SetGroupRoleReq) (*SetGroupRoleResp, error)
+	DeleteGroupRole(context.Context, *DeleteGroupRoleReq) (*DeleteGroupRoleResp, error)
+	GetGroupRoles(context.Context, *GetGroupRolesReq) (*GetGroupRolesResp, error)
+	SetGroupMembersRole(context.Context, *SetGroupMembersRoleReq) (*SetGroupMembersRoleResp, error)
+	GetGroupMembersExtInfo(context.Context, *GetGroupMembersExtInfoReq) (*GetGroupMembersExtInfoResp, error)
+	CreateGroupInviteLink(context.Context, *CreateGroupInviteLinkReq) (*CreateGroupInviteLinkResp, error)
+	GetGroupInviteLinks(context.Context, *GetGroupInviteLinksReq) (*GetGroupInviteLinksResp, error)
+	RevokeGroupInviteLinks(context.Context, *RevokeGroupInviteLinksReq) (*RevokeGroupInviteLinksResp, error)
+	JoinGroupByInviteLink(context.Context, *JoinGroupByInviteLinkReq) (*JoinGroupByInviteLinkResp, error)
+	SetGroupJoinPolicy(context.Context, *SetGroupJoinPolicyReq) (*SetGroupJoinPolicyResp, error)
+	GetGroupJoinPolicy(context.Context, *GetGroupJoinPolicyReq) (*GetGroupJoinPolicyResp, error)
+	JoinGroupWithAnswers(context.Context, *JoinGroupWithAnswersReq) (*JoinGroupWithAnswersResp, error)
+	GetGroupJoinAnswers(context.Context, *GetGroupJoinAnswersReq) (*GetGroupJoinAnswersResp, error)
+	SearchGroupMembers(context.Context, *SearchGroupMembersReq) (*SearchGroupMembersResp, error)
+	CreateChannel(context.Context, *CreateChannelReq) (*CreateChannelResp, error)
+	SetChannelPublic(context.Context, *SetChannelPublicReq) (*SetChannelPublicResp, error)
+	GetChannelsInfo(context.Context, *GetChannelsInfoReq) (*GetChannelsInfoResp, error)
+	SearchChannels(context.Context, *SearchChannelsReq) (*SearchChannelsResp, error)
+	SubscribeChannel(context.Context, *SubscribeChannelReq) (*SubscribeChannelResp, error)
+	UnsubscribeChannel(context.Context, *UnsubscribeChannelReq) (*UnsubscribeChannelResp, error)
+	OpenChannel(context.Context, *OpenChannelReq) (*OpenChannelResp, error)
+	GetSubscribedChannels(context.Context, *GetSubscribedChannelsReq) (*GetSubscribedChannelsResp, error)
+	IsChannelSubscriber(context.Context, *IsChannelSubscriberReq) (*IsChannelSubscriberResp, error)
+	GetChannelSubscriberIDs(context.Context, *GetChannelSubscriberIDsReq) (*GetChannelSubscriberIDsResp, error)
+	SetGroupSlowMode(context.Context, *SetGroupSlowModeReq) (*SetGroupSlowModeResp, error)
+	GetGroupSlowMode(context.Context, *GetGroupSlowModeReq) (*GetGroupSlowModeResp, error)
+	BanGroupMembers(context.Context, *BanGroupMembersReq) (*BanGroupMembersResp, error)
+	UnbanGroupMembers(context.Context, *UnbanGroupMembersReq) (*UnbanGroupMembersResp, error)
+	GetGroupBans(context.Context, *GetGroupBansReq) (*GetGroupBansResp, error)
+	GetGroupAuditLogs(context.Context, *GetGroupAuditLogsReq) (*GetGroupAuditLogsResp, error)
+	CreateCommunity(context.Context, *CreateCommunityReq) (*CreateCommunityResp, error)
+	AttachCommunityGroups(context.Context, *AttachCommunityGroupsReq) (*AttachCommunityGroupsResp, error)
+	DetachCommunityGroups(context.Context, *DetachCommunityGroupsReq) (*DetachCommunityGroupsResp, error)
+	GetCommunityGroups(context.Context, *GetCommunityGroupsReq) (*GetCommunityGroupsResp, error)
+	GetJoinedCommunities(context.Context, *GetJoinedCommunitiesReq) (*GetJoinedCommunitiesResp, error)
+	GetGroupAnnouncements(context.Context, *GetGroupAnnouncementsReq) (*GetGroupAnnouncementsResp, error)
+	ReassignGroupOwner(context.Context, *ReassignGroupOwnerReq) (*ReassignGroupOwnerResp, error)
+	SucceedUserOwnedGroups(context.Context, *SucceedUserOwnedGroupsReq) (*SucceedUserOwnedGroupsResp, error)
+	GetSharedGroupCounts(context.Context, *GetSharedGroupCountsReq) (*GetSharedGroupCountsResp, error)
+	GetIncrementalJoinGroups(context.Context, *GetIncrementalJoinGroupsReq) (*GetIncrementalJoinGroupsResp, error)
+	GetIncrementalGroupMembers(context.Context, *GetIncrementalGroupMembersReq) (*GetIncrementalGroupMembersResp, error)
+	GetSharedGroupIDs(context.Context, *GetSharedGroupIDsReq) (*GetSharedGroupIDsResp, error)
+	GetGroupExtInfoCache(context.Context, *GetGroupExtInfoCacheReq) (*GetGroupExtInfoCacheResp, error)
+}
+
+func RegisterGroupExtServer(s grpc.ServiceRegistrar, srv GroupExtServer) {
+	s.RegisterService(&GroupExt_ServiceDesc, srv)
+}
+
+var GroupExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*GroupExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		jsonrpc.MethodDesc(ServiceName, "CreateGroupRole", GroupExtServer.CreateGroupRole),
+		jsonrpc.MethodDesc(ServiceName, "SetGroupRole", GroupExtServer.SetGroupRole),
+		jsonrpc.MethodDesc(ServiceName, "DeleteGroupRole", GroupExtServer.DeleteGroupRole),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupRoles", GroupExtServer.GetGroupRoles),
+		jsonrpc.MethodDesc(ServiceName, "SetGroupMembersRole", GroupExtServer.SetGroupMembersRole),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupMembersExtInfo", GroupExtServer.GetGroupMembersExtInfo),
+		jsonrpc.MethodDesc(ServiceName, "CreateGroupInviteLink", GroupExtServer.CreateGroupInviteLink),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupInviteLinks", GroupExtServer.GetGroupInviteLinks),
+		jsonrpc.MethodDesc(ServiceName, "RevokeGroupInviteLinks", GroupExtServer.RevokeGroupInviteLinks),
+		jsonrpc.MethodDesc(ServiceName, "JoinGroupByInviteLink", GroupExtServer.JoinGroupByInviteLink),
+		jsonrpc.MethodDesc(ServiceName, "SetGroupJoinPolicy", GroupExtServer.SetGroupJoinPolicy),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupJoinPolicy", GroupExtServer.GetGroupJoinPolicy),
+		jsonrpc.MethodDesc(ServiceName, "JoinGroupWithAnswers", GroupExtServer.JoinGroupWithAnswers),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupJoinAnswers", GroupExtServer.GetGroupJoinAnswers),
+		jsonrpc.MethodDesc(ServiceName, "SearchGroupMembers", GroupExtServer.SearchGroupMembers),
+		jsonrpc.MethodDesc(ServiceName, "CreateChannel", GroupExtServer.CreateChannel),
+		jsonrpc.MethodDesc(ServiceName, "SetChannelPublic", GroupExtServer.SetChannelPublic),
+		jsonrpc.MethodDesc(ServiceName, "GetChannelsInfo", GroupExtServer.GetChannelsInfo),
+		jsonrpc.MethodDesc(ServiceName, "SearchChannels", GroupExtServer.SearchChannels),
+		jsonrpc.MethodDesc(ServiceName, "SubscribeChannel", GroupExtServer.SubscribeChannel),
+		jsonrpc.MethodDesc(ServiceName, "UnsubscribeChannel", GroupExtServer.UnsubscribeChannel),
+		jsonrpc.MethodDesc(ServiceName, "OpenChannel", GroupExtServer.OpenChannel),
+		jsonrpc.MethodDesc(ServiceName, "GetSubscribedChannels", GroupExtServer.GetSubscribedChannels),
+		jsonrpc.MethodDesc(ServiceName, "IsChannelSubscriber", GroupExtServer.IsChannelSubscriber),
+		jsonrpc.MethodDesc(ServiceName, "GetChannelSubscriberIDs", GroupExtServer.GetChannelSubscriberIDs),
+		jsonrpc.MethodDesc(ServiceName, "SetGroupSlowMode", GroupExtServer.SetGroupSlowMode),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupSlowMode", GroupExtServer.GetGroupSlowMode),
+		jsonrpc.MethodDesc(ServiceName, "BanGroupMembers", GroupExtServer.BanGroupMembers),
+		jsonrpc.MethodDesc(ServiceName, "UnbanGroupMembers", GroupExtServer.UnbanGroupMembers),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupBans", GroupExtServer.GetGroupBans),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupAuditLogs", GroupExtServer.GetGroupAuditLogs),
+		jsonrpc.MethodDesc(ServiceName, "CreateCommunity", GroupExtServer.CreateCommunity),
+		jsonrpc.MethodDesc(ServiceName, "AttachCommunityGroups", GroupExtServer.AttachCommunityGroups),
+		jsonrpc.MethodDesc(ServiceName, "DetachCommunityGroups", GroupExtServer.DetachCommunityGroups),
+		jsonrpc.MethodDesc(ServiceName, "GetCommunityGroups", GroupExtServer.GetCommunityGroups),
+		jsonrpc.MethodDesc(ServiceName, "GetJoinedCommunities", GroupExtServer.GetJoinedCommunities),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupAnnouncements", GroupExtServer.GetGroupAnnouncements),
+		jsonrpc.MethodDesc(ServiceName, "ReassignGroupOwner", GroupExtServer.ReassignGroupOwner),
+		jsonrpc.MethodDesc(ServiceName, "SucceedUserOwnedGroups", GroupExtServer.SucceedUserOwnedGroups),
+		jsonrpc.MethodDesc(ServiceName, "GetSharedGroupCounts", GroupExtServer.GetSharedGroupCounts),
+		jsonrpc.MethodDesc(ServiceName, "GetIncrementalJoinGroups", GroupExtServer.GetIncrementalJoinGroups),
+		jsonrpc.MethodDesc(ServiceName, "GetIncrementalGroupMembers", GroupExtServer.GetIncrementalGroupMembers),
+		jsonrpc.MethodDesc(ServiceName, "GetSharedGroupIDs", GroupExtServer.GetSharedGroupIDs),
+		jsonrpc.MethodDesc(ServiceName, "GetGroupExtInfoCache", GroupExtServer.GetGroupExtInfoCache),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "groupext",
+}
@@ -0,0 +1,92 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonrpc lets in-repo services be served over the same grpc servers as the
+// protocol services, encoding messages as json so no generated protobuf code is needed.
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is registered as grpc content-subtype.
+const Name = "json"
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
+
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (codec) Name() string {
+	return Name
+}
+
+// Invoke calls fullMethod on cc with the json codec.
+func Invoke[B any](ctx context.Context, cc grpc.ClientConnInterface, fullMethod string, in any, opts ...grpc.CallOption) (*B, error) {
+	out := new(B)
+	if err := cc.Invoke(ctx, fullMethod, in, out, append(opts, grpc.CallContentSubtype(Name))...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MethodHandler has the same underlying type as the handler of grpc.MethodDesc.
+type MethodHandler = func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error)
+
+// UnaryHandler adapts a server method expression, e.g. MsgExtServer.CreateBroadcast, to a MethodHandler.
+func UnaryHandler[S, A, B any](fullMethod string, fn func(S, context.Context, *A) (*B, error)) MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := new(A)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return fn(srv.(S), ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: fullMethod,
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return fn(srv.(S), ctx, req.(*A))
+		}
+		return interceptor(ctx, in, info, handler)
+	}
+}
+
+// MethodDesc builds the grpc.MethodDesc of one unary method of serviceName.
+func MethodDesc[S, A, B any](serviceName, methodName string, fn func(S, context.Context, *A) (*B, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: methodName,
+		Handler:    UnaryHandler("/"+serviceName+"/"+methodName, fn),
+	}
+}
+
+// FullMethod returns the method path used by clients.
+func FullMethod(serviceName, methodName string) string {
+	return "/" + serviceName + "/" + methodName
+}
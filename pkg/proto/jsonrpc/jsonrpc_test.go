@@ -0,0 +1,72 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type echoReq struct {
+	Text string `json:"text"`
+}
+
+type echoResp struct {
+	Text string `json:"text"`
+}
+
+type echoServer interface {
+	Echo(context.Context, *echoReq) (*echoResp, error)
+}
+
+type echo struct{}
+
+func (echo) Echo(_ context.Context, req *echoReq) (*echoResp, error) {
+	return &echoResp{Text: req.Text}, nil
+}
+
+func TestInvoke(t *testing.T) {
+	const serviceName = "jsonrpc.test"
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*echoServer)(nil),
+		Methods:     []grpc.MethodDesc{MethodDesc(serviceName, "Echo", echoServer.Echo)},
+	}, echo{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	resp, err := Invoke[echoResp](context.Background(), conn, FullMethod(serviceName, "Echo"), &echoReq{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Text != "hello" {
+		t.Fatalf("got %q, want %q", resp.Text, "hello")
+	}
+}
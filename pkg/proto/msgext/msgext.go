@@ -0,0 +1,350 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgext
+
+import (
+	"github.com/OpenIMSDK/protocol/constant"
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/unrelation"
+)
+
+type BroadcastSegment struct {
+	// unrelation.BroadcastSegmentAll, BroadcastSegmentUserIDs, BroadcastSegmentUserAttr, BroadcastSegmentGroups
+	Type            int32    `json:"type"`
+	UserIDs         []string `json:"userIDs,omitempty"`
+	Ex              *string  `json:"ex,omitempty"`
+	AppMangerLevels []int32  `json:"appMangerLevels,omitempty"`
+	GroupIDs        []string `json:"groupIDs,omitempty"`
+}
+
+type BroadcastJob struct {
+	JobID         string            `json:"jobID"`
+	OpUserID      string            `json:"opUserID"`
+	Segment       *BroadcastSegment `json:"segment"`
+	Rate          int32             `json:"rate"`
+	Status        int32             `json:"status"`
+	Total         int64             `json:"total"`
+	SuccessCount  int64             `json:"successCount"`
+	FailedCount   int64             `json:"failedCount"`
+	FailedUserIDs []string          `json:"failedUserIDs"`
+	ErrMsg        string            `json:"errMsg"`
+	CreateTime    int64             `json:"createTime"`
+	StartTime     int64             `json:"startTime"`
+	FinishTime    int64             `json:"finishTime"`
+}
+
+type CreateBroadcastReq struct {
+	// recvID is filled for every target user
+	MsgData *sdkws.MsgData    `json:"msgData"`
+	Segment *BroadcastSegment `json:"segment"`
+	// messages sent per second, 0 uses the config
+	Rate int32 `json:"rate"`
+}
+
+type CreateBroadcastResp struct {
+	JobID string `json:"jobID"`
+}
+
+type GetBroadcastReq struct {
+	JobID string `json:"jobID"`
+}
+
+type GetBroadcastResp struct {
+	Job *BroadcastJob `json:"job"`
+}
+
+type GetBroadcastsReq struct {
+	Status     []int32                  `json:"status"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetBroadcastsResp struct {
+	Total int64           `json:"total"`
+	Jobs  []*BroadcastJob `json:"jobs"`
+}
+
+type CancelBroadcastReq struct {
+	JobID string `json:"jobID"`
+}
+
+type CancelBroadcastResp struct{}
+
+type Mention struct {
+	ConversationID string `json:"conversationID"`
+	GroupID        string `json:"groupID"`
+	Seq            int64  `json:"seq"`
+	SendID         string `json:"sendID"`
+	ClientMsgID    string `json:"clientMsgID"`
+	SendTime       int64  `json:"sendTime"`
+	AtAll          bool   `json:"atAll"`
+	// seq <= hasReadSeq of the conversation
+	IsRead bool `json:"isRead"`
+}
+
+type GetMentionsReq struct {
+	UserID string `json:"userID"`
+	// only return mentions after the hasReadSeq of each conversation
+	UnreadOnly bool `json:"unreadOnly"`
+	// empty returns mentions in all group conversations of the user
+	ConversationIDs []string                 `json:"conversationIDs"`
+	Pagination      *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetMentionsResp struct {
+	Total    int64      `json:"total"`
+	Mentions []*Mention `json:"mentions"`
+}
+
+type Quota struct {
+	// unrelation.QuotaKindUserMsg, QuotaKindUserFileSize, QuotaKindGroupMsg, QuotaKindUserFriendRequest
+	Kind     string `json:"kind"`
+	TargetID string `json:"targetID"`
+	// 0 means unlimited
+	Limit int64 `json:"limit"`
+	// seconds
+	Window int64 `json:"window"`
+	// usage of the current window, only counted while the quota is limited
+	Used     int64 `json:"used"`
+	Override bool  `json:"override"`
+}
+
+type GetQuotasReq struct {
+	UserID  string `json:"userID"`
+	GroupID string `json:"groupID"`
+}
+
+type GetQuotasResp struct {
+	Quotas []*Quota `json:"quotas"`
+}
+
+type SetQuotaReq struct {
+	Kind     string `json:"kind"`
+	TargetID string `json:"targetID"`
+	Limit    int64  `json:"limit"`
+	// remove the override and use the config again
+	Reset bool `json:"reset"`
+}
+
+type SetQuotaResp struct{}
+
+// DeleteUserGroupMsgsReq 物理删除用户Since(毫秒时间戳)之后在群里发送的消息.
+type DeleteUserGroupMsgsReq struct {
+	GroupID string `json:"groupID"`
+	UserID  string `json:"userID"`
+	Since   int64  `json:"since"`
+}
+
+type DeleteUserGroupMsgsResp struct {
+	Seqs []int64 `json:"seqs"`
+}
+
+type PinMsgReq struct {
+	UserID         string `json:"userID"`
+	ConversationID string `json:"conversationID"`
+	Seq            int64  `json:"seq"`
+}
+
+type PinMsgResp struct{}
+
+type UnpinMsgReq struct {
+	UserID         string `json:"userID"`
+	ConversationID string `json:"conversationID"`
+	Seq            int64  `json:"seq"`
+}
+
+type UnpinMsgResp struct{}
+
+type PinnedMsg struct {
+	Seq       int64  `json:"seq"`
+	PinUserID string `json:"pinUserID"`
+	PinTime   int64  `json:"pinTime"`
+	// nil when the message has been deleted
+	Msg *sdkws.MsgData `json:"msg"`
+}
+
+type GetPinnedMsgsReq struct {
+	UserID         string `json:"userID"`
+	ConversationID string `json:"conversationID"`
+}
+
+type GetPinnedMsgsResp struct {
+	Pins []*PinnedMsg `json:"pins"`
+}
+
+// MsgPinTips content of rpcclient.MsgPinnedNotification and rpcclient.MsgUnpinnedNotification.
+type MsgPinTips struct {
+	ConversationID string `json:"conversationID"`
+	Seq            int64  `json:"seq"`
+	ClientMsgID    string `json:"clientMsgID"`
+	OpUserID       string `json:"opUserID"`
+	OperationTime  int64  `json:"operationTime"`
+}
+
+// MsgDestructTips content of rpcclient.MsgDestructedNotification, the msgs have been deleted physically
+// by the server when their destruct time came, not by a user.
+type MsgDestructTips struct {
+	ConversationID string  `json:"conversationID"`
+	Seqs           []int64 `json:"seqs"`
+}
+
+func (x *BroadcastSegment) Check() error {
+	switch x.Type {
+	case unrelation.BroadcastSegmentAll:
+	case unrelation.BroadcastSegmentUserIDs:
+		if len(x.UserIDs) == 0 {
+			return errs.ErrArgs.Wrap("userIDs is empty")
+		}
+	case unrelation.BroadcastSegmentUserAttr:
+		if x.Ex == nil && len(x.AppMangerLevels) == 0 {
+			return errs.ErrArgs.Wrap("ex and appMangerLevels are both empty")
+		}
+	case unrelation.BroadcastSegmentGroups:
+		if len(x.GroupIDs) == 0 {
+			return errs.ErrArgs.Wrap("groupIDs is empty")
+		}
+	default:
+		return errs.ErrArgs.Wrap("segment type is invalid")
+	}
+	return nil
+}
+
+func (x *CreateBroadcastReq) Check() error {
+	if x.MsgData == nil {
+		return errs.ErrArgs.Wrap("msgData is empty")
+	}
+	if x.MsgData.SendID == "" {
+		return errs.ErrArgs.Wrap("sendID is empty")
+	}
+	if x.MsgData.SessionType != constant.SingleChatType && x.MsgData.SessionType != constant.NotificationChatType {
+		return errs.ErrArgs.Wrap("sessionType must be single chat or notification chat")
+	}
+	if x.Segment == nil {
+		return errs.ErrArgs.Wrap("segment is empty")
+	}
+	if x.Rate < 0 {
+		return errs.ErrArgs.Wrap("rate is invalid")
+	}
+	return x.Segment.Check()
+}
+
+func (x *GetBroadcastReq) Check() error {
+	if x.JobID == "" {
+		return errs.ErrArgs.Wrap("jobID is empty")
+	}
+	return nil
+}
+
+func (x *GetBroadcastsReq) Check() error {
+	if x.Pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	return nil
+}
+
+func (x *CancelBroadcastReq) Check() error {
+	if x.JobID == "" {
+		return errs.ErrArgs.Wrap("jobID is empty")
+	}
+	return nil
+}
+
+func (x *GetMentionsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.Pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	return nil
+}
+
+func (x *GetQuotasReq) Check() error {
+	if x.UserID == "" && x.GroupID == "" {
+		return errs.ErrArgs.Wrap("userID and groupID are both empty")
+	}
+	return nil
+}
+
+func (x *SetQuotaReq) Check() error {
+	switch x.Kind {
+	case unrelation.QuotaKindUserMsg, unrelation.QuotaKindUserFileSize, unrelation.QuotaKindGroupMsg, unrelation.QuotaKindUserFriendRequest:
+	default:
+		return errs.ErrArgs.Wrap("kind is invalid")
+	}
+	if x.TargetID == "" {
+		return errs.ErrArgs.Wrap("targetID is empty")
+	}
+	if x.Limit < 0 {
+		return errs.ErrArgs.Wrap("limit is invalid")
+	}
+	return nil
+}
+
+func (x *DeleteUserGroupMsgsReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.Since <= 0 {
+		return errs.ErrArgs.Wrap("since is invalid")
+	}
+	return nil
+}
+
+func (x *PinMsgReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.ConversationID == "" {
+		return errs.ErrArgs.Wrap("conversationID is empty")
+	}
+	if x.Seq <= 0 {
+		return errs.ErrArgs.Wrap("seq is invalid")
+	}
+	return nil
+}
+
+func (x *UnpinMsgReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.ConversationID == "" {
+		return errs.ErrArgs.Wrap("conversationID is empty")
+	}
+	if x.Seq <= 0 {
+		return errs.ErrArgs.Wrap("seq is invalid")
+	}
+	return nil
+}
+
+func (x *GetPinnedMsgsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.ConversationID == "" {
+		return errs.ErrArgs.Wrap("conversationID is empty")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgext
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/jsonrpc"
+)
+
+const ServiceName = "OpenIMServer.msgext.msgExt"
+
+type MsgExtClient interface {
+	CreateBroadcast(ctx context.Context, in *CreateBroadcastReq, opts ...grpc.CallOption) (*CreateBroadcastResp, error)
+	GetBroadcast(ctx context.Context, in *GetBroadcastReq, opts ...grpc.CallOption) (*GetBroadcastResp, error)
+	GetBroadcasts(ctx context.Context, in *GetBroadcastsReq, opts ...grpc.CallOption) (*GetBroadcastsResp, error)
+	CancelBroadcast(ctx context.Context, in *CancelBroadcastReq, opts ...grpc.CallOption) (*CancelBroadcastResp, error)
+	GetMentions(ctx context.Context, in *GetMentionsReq, opts ...grpc.CallOption) (*GetMentionsResp, error)
+	GetQuotas(ctx context.Context, in *GetQuotasReq, opts ...grpc.CallOption) (*GetQuotasResp, error)
+	SetQuota(ctx context.Context, in *SetQuotaReq, opts ...grpc.CallOption) (*SetQuotaResp, error)
+	DeleteUserGroupMsgs(ctx context.Context, in *DeleteUserGroupMsgsReq, opts ...grpc.CallOption) (*DeleteUserGroupMsgsResp, error)
+	PinMsg(ctx context.Context, in *PinMsgReq, opts ...grpc.CallOption) (*PinMsgResp, error)
+	UnpinMsg(ctx context.Context, in *UnpinMsgReq, opts ...grpc.CallOption) (*UnpinMsgResp, error)
+	GetPinnedMsgs(ctx context.Context, in *GetPinnedMsgsReq, opts ...grpc.CallOption) (*GetPinnedMsgsResp, error)
+}
+
+type msgExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMsgExtClient(cc grpc.ClientConnInterface) MsgExtClient {
+	return &msgExtClient{cc}
+}
+
+func (c *msgExtClient) CreateBroadcast(ctx context.Context, in *CreateBroadcastReq, opts ...grpc.CallOption) (*CreateBroadcastResp, error) {
+	return jsonrpc.Invoke[CreateBroadcastResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "CreateBroadcast"), in, opts...)
+}
+
+func (c *msgExtClient) GetBroadcast(ctx context.Context, in *GetBroadcastReq, opts ...grpc.CallOption) (*GetBroadcastResp, error) {
+	return jsonrpc.Invoke[GetBroadcastResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetBroadcast"), in, opts...)
+}
+
+func (c *msgExtClient) GetBroadcasts(ctx context.Context, in *GetBroadcastsReq, opts ...grpc.CallOption) (*GetBroadcastsResp, error) {
+	return jsonrpc.Invoke[GetBroadcastsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetBroadcasts"), in, opts...)
+}
+
+func (c *msgExtClient) CancelBroadcast(ctx context.Context, in *CancelBroadcastReq, opts ...grpc.CallOption) (*CancelBroadcastResp, error) {
+	return jsonrpc.Invoke[CancelBroadcastResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "CancelBroadcast"), in, opts...)
+}
+
+func (c *msgExtClient) GetMentions(ctx context.Context, in *GetMentionsReq, opts ...grpc.CallOption) (*GetMentionsResp, error) {
+	return jsonrpc.Invoke[GetMentionsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetMentions"), in, opts...)
+}
+
+func (c *msgExtClient) GetQuotas(ctx context.Context, in *GetQuotasReq, opts ...grpc.CallOption) (*GetQuotasResp, error) {
+	return jsonrpc.Invoke[GetQuotasResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetQuotas"), in, opts...)
+}
+
+func (c *msgExtClient) SetQuota(ctx context.Context, in *SetQuotaReq, opts ...grpc.CallOption) (*SetQuotaResp, error) {
+	return jsonrpc.Invoke[SetQuotaResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetQuota"), in, opts...)
+}
+
+func (c *msgExtClient) DeleteUserGroupMsgs(ctx context.Context, in *DeleteUserGroupMsgsReq, opts ...grpc.CallOption) (*DeleteUserGroupMsgsResp, error) {
+	return jsonrpc.Invoke[DeleteUserGroupMsgsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "DeleteUserGroupMsgs"), in, opts...)
+}
+
+func (c *msgExtClient) PinMsg(ctx context.Context, in *PinMsgReq, opts ...grpc.CallOption) (*PinMsgResp, error) {
+	return jsonrpc.Invoke[PinMsgResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "PinMsg"), in, opts...)
+}
+
+func (c *msgExtClient) UnpinMsg(ctx context.Context, in *UnpinMsgReq, opts ...grpc.CallOption) (*UnpinMsgResp, error) {
+	return jsonrpc.Invoke[UnpinMsgResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "UnpinMsg"), in, opts...)
+}
+
+func (c *msgExtClient) GetPinnedMsgs(ctx context.Context, in *GetPinnedMsgsReq, opts ...grpc.CallOption) (*GetPinnedMsgsResp, error) {
+	return jsonrpc.Invoke[GetPinnedMsgsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetPinnedMsgs"), in, opts...)
+}
+
+type MsgExtServer interface {
+	CreateBroadcast(context.Context, *CreateBroadcastReq) (*CreateBroadcastResp, error)
+	GetBroadcast(context.Context, *GetBroadcastReq) (*GetBroadcastResp, error)
+	GetBroadcasts(context.Context, *GetBroadcastsReq) (*GetBroadcastsResp, error)
+	CancelBroadcast(context.Context, *CancelBroadcastReq) (*CancelBroadcastResp, error)
+	GetMentions(context.Context, *GetMentionsReq) (*GetMentionsResp, error)
+	GetQuotas(context.Context, *GetQuotasReq) (*GetQuotasResp, error)
+	SetQuota(context.Context, *SetQuotaReq) (*SetQuotaResp, error)
+	DeleteUserGroupMsgs(context.Context, *DeleteUserGroupMsgsReq) (*DeleteUserGroupMsgsResp, error)
+	PinMsg(context.Context, *PinMsgReq) (*PinMsgResp, error)
+	UnpinMsg(context.Context, *UnpinMsgReq) (*UnpinMsgResp, error)
+	GetPinnedMsgs(context.Context, *GetPinnedMsgsReq) (*GetPinnedMsgsResp, error)
+}
+
+func RegisterMsgExtServer(s grpc.ServiceRegistrar, srv MsgExtServer) {
+	s.RegisterService(&MsgExt_ServiceDesc, srv)
+}
+
+var MsgExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*MsgExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		jsonrpc.MethodDesc(ServiceName, "CreateBroadcast", MsgExtServer.CreateBroadcast),
+		jsonrpc.MethodDesc(ServiceName, "GetBroadcast", MsgExtServer.GetBroadcast),
+		jsonrpc.MethodDesc(ServiceName, "GetBroadcasts", MsgExtServer.GetBroadcasts),
+		jsonrpc.MethodDesc(ServiceName, "CancelBroadcast", MsgExtServer.CancelBroadcast),
+		jsonrpc.MethodDesc(ServiceName, "GetMentions", MsgExtServer.GetMentions),
+		jsonrpc.MethodDesc(ServiceName, "GetQuotas", MsgExtServer.GetQuotas),
+		jsonrpc.MethodDesc(ServiceName, "SetQuota", MsgExtServer.SetQuota),
+		jsonrpc.MethodDesc(ServiceName, "DeleteUserGroupMsgs", MsgExtServer.DeleteUserGroupMsgs),
+		jsonrpc.MethodDesc(ServiceName, "PinMsg", MsgExtServer.PinMsg),
+		jsonrpc.MethodDesc(ServiceName, "UnpinMsg", MsgExtServer.UnpinMsg),
+		jsonrpc.MethodDesc(ServiceName, "GetPinnedMsgs", MsgExtServer.GetPinnedMsgs),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "msgext",
+}
@@ -0,0 +1,326 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thirdext
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+const (
+	// MaxPublicKeySize public keys are base64 of at most 128 bytes.
+	MaxPublicKeySize = 128
+	// MaxOneTimePreKeys one-time prekeys uploaded at a time.
+	MaxOneTimePreKeys = 100
+)
+
+// Keys are base64 encoded public keys, private keys must never be uploaded.
+
+type SignedPreKey struct {
+	KeyID     int64  `json:"keyID"`
+	PublicKey string `json:"publicKey"`
+	Signature string `json:"signature"`
+}
+
+type OneTimePreKey struct {
+	KeyID     int64  `json:"keyID"`
+	PublicKey string `json:"publicKey"`
+}
+
+type KeyBundle struct {
+	UserID         string        `json:"userID"`
+	DeviceID       string        `json:"deviceID"`
+	RegistrationID int32         `json:"registrationID"`
+	IdentityKey    string        `json:"identityKey"`
+	SignedPreKey   *SignedPreKey `json:"signedPreKey"`
+	// nil when the device has run out of one-time prekeys
+	OneTimePreKey *OneTimePreKey `json:"oneTimePreKey"`
+	UpdateTime    int64          `json:"updateTime"`
+}
+
+type SenderKeyDistribution struct {
+	GroupID        string `json:"groupID"`
+	UserID         string `json:"userID"`
+	DeviceID       string `json:"deviceID"`
+	DistributionID string `json:"distributionID"`
+	// userID/deviceID of the devices which have received the sender key
+	Recipients []string `json:"recipients"`
+	UpdateTime int64    `json:"updateTime"`
+}
+
+type PublishKeyBundleReq struct {
+	UserID         string           `json:"userID"`
+	DeviceID       string           `json:"deviceID"`
+	RegistrationID int32            `json:"registrationID"`
+	IdentityKey    string           `json:"identityKey"`
+	SignedPreKey   *SignedPreKey    `json:"signedPreKey"`
+	OneTimePreKeys []*OneTimePreKey `json:"oneTimePreKeys"`
+}
+
+type PublishKeyBundleResp struct {
+	// the friends have been notified
+	IdentityKeyChanged bool  `json:"identityKeyChanged"`
+	OneTimePreKeyCount int64 `json:"oneTimePreKeyCount"`
+}
+
+type UploadOneTimePreKeysReq struct {
+	UserID         string           `json:"userID"`
+	DeviceID       string           `json:"deviceID"`
+	OneTimePreKeys []*OneTimePreKey `json:"oneTimePreKeys"`
+}
+
+type UploadOneTimePreKeysResp struct {
+	OneTimePreKeyCount int64 `json:"oneTimePreKeyCount"`
+}
+
+type GetOneTimePreKeyCountReq struct {
+	UserID   string `json:"userID"`
+	DeviceID string `json:"deviceID"`
+}
+
+type GetOneTimePreKeyCountResp struct {
+	OneTimePreKeyCount int64 `json:"oneTimePreKeyCount"`
+}
+
+type FetchKeyBundlesReq struct {
+	UserID string `json:"userID"`
+	// empty fetches all devices of the user
+	DeviceIDs []string `json:"deviceIDs"`
+}
+
+type FetchKeyBundlesResp struct {
+	KeyBundles []*KeyBundle `json:"keyBundles"`
+}
+
+type DeleteKeyBundleReq struct {
+	UserID   string `json:"userID"`
+	DeviceID string `json:"deviceID"`
+}
+
+type DeleteKeyBundleResp struct{}
+
+type SetSenderKeyDistributionReq struct {
+	GroupID        string   `json:"groupID"`
+	UserID         string   `json:"userID"`
+	DeviceID       string   `json:"deviceID"`
+	DistributionID string   `json:"distributionID"`
+	Recipients     []string `json:"recipients"`
+}
+
+type SetSenderKeyDistributionResp struct{}
+
+type GetSenderKeyDistributionsReq struct {
+	GroupID string `json:"groupID"`
+	// empty returns all senders of the group
+	UserIDs []string `json:"userIDs"`
+}
+
+type GetSenderKeyDistributionsResp struct {
+	Distributions []*SenderKeyDistribution `json:"distributions"`
+}
+
+type DeleteUserObjectsReq struct {
+	UserID string `json:"userID"`
+}
+
+type DeleteUserObjectsResp struct {
+	Count int64 `json:"count"`
+}
+
+type ObjectInfo struct {
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"contentType"`
+	Cause       string `json:"cause"`
+	CreateTime  int64  `json:"createTime"`
+}
+
+type GetUserObjectsReq struct {
+	UserID string `json:"userID"`
+}
+
+type GetUserObjectsResp struct {
+	Objects []*ObjectInfo `json:"objects"`
+}
+
+// InitiateExportUpload 导出文件上传到 export/{userID}/{jobID}.zip, 通过url直接PUT上传.
+type InitiateExportUploadReq struct {
+	UserID string `json:"userID"`
+	JobID  string `json:"jobID"`
+}
+
+type InitiateExportUploadResp struct {
+	Name       string `json:"name"`
+	Url        string `json:"url"`
+	ExpireTime int64  `json:"expireTime"`
+}
+
+// CompleteExportUpload 记录上传完成的导出文件, 之后可通过AccessURL下载, 属于被导出的用户.
+type CompleteExportUploadReq struct {
+	UserID string `json:"userID"`
+	JobID  string `json:"jobID"`
+}
+
+type CompleteExportUploadResp struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+func checkPublicKey(name string, key string) error {
+	if key == "" {
+		return errs.ErrArgs.Wrap(name + " is empty")
+	}
+	data, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return errs.ErrArgs.Wrap(name + " is not base64")
+	}
+	if len(data) > MaxPublicKeySize {
+		return errs.ErrArgs.Wrap(name + " is too long")
+	}
+	return nil
+}
+
+func checkOneTimePreKeys(preKeys []*OneTimePreKey) error {
+	if len(preKeys) > MaxOneTimePreKeys {
+		return errs.ErrArgs.Wrap("too many oneTimePreKeys")
+	}
+	for _, preKey := range preKeys {
+		if preKey == nil {
+			return errs.ErrArgs.Wrap("oneTimePreKey is nil")
+		}
+		if err := checkPublicKey("oneTimePreKey", preKey.PublicKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkDevice(userID string, deviceID string) error {
+	if userID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if deviceID == "" {
+		return errs.ErrArgs.Wrap("deviceID is empty")
+	}
+	if strings.Contains(deviceID, "/") {
+		return errs.ErrArgs.Wrap("deviceID contains /")
+	}
+	return nil
+}
+
+func (x *PublishKeyBundleReq) Check() error {
+	if err := checkDevice(x.UserID, x.DeviceID); err != nil {
+		return err
+	}
+	if err := checkPublicKey("identityKey", x.IdentityKey); err != nil {
+		return err
+	}
+	if x.SignedPreKey == nil {
+		return errs.ErrArgs.Wrap("signedPreKey is empty")
+	}
+	if err := checkPublicKey("signedPreKey", x.SignedPreKey.PublicKey); err != nil {
+		return err
+	}
+	if x.SignedPreKey.Signature == "" {
+		return errs.ErrArgs.Wrap("signedPreKey signature is empty")
+	}
+	return checkOneTimePreKeys(x.OneTimePreKeys)
+}
+
+func (x *UploadOneTimePreKeysReq) Check() error {
+	if err := checkDevice(x.UserID, x.DeviceID); err != nil {
+		return err
+	}
+	if len(x.OneTimePreKeys) == 0 {
+		return errs.ErrArgs.Wrap("oneTimePreKeys is empty")
+	}
+	return checkOneTimePreKeys(x.OneTimePreKeys)
+}
+
+func (x *GetOneTimePreKeyCountReq) Check() error {
+	return checkDevice(x.UserID, x.DeviceID)
+}
+
+func (x *FetchKeyBundlesReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *DeleteKeyBundleReq) Check() error {
+	return checkDevice(x.UserID, x.DeviceID)
+}
+
+func (x *SetSenderKeyDistributionReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	if err := checkDevice(x.UserID, x.DeviceID); err != nil {
+		return err
+	}
+	if x.DistributionID == "" {
+		return errs.ErrArgs.Wrap("distributionID is empty")
+	}
+	for _, recipient := range x.Recipients {
+		if strings.Count(recipient, "/") != 1 {
+			return errs.ErrArgs.Wrap("recipient must be userID/deviceID")
+		}
+	}
+	return nil
+}
+
+func (x *GetSenderKeyDistributionsReq) Check() error {
+	if x.GroupID == "" {
+		return errs.ErrArgs.Wrap("groupID is empty")
+	}
+	return nil
+}
+
+func (x *DeleteUserObjectsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetUserObjectsReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *InitiateExportUploadReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.JobID == "" {
+		return errs.ErrArgs.Wrap("jobID is empty")
+	}
+	return nil
+}
+
+func (x *CompleteExportUploadReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.JobID == "" {
+		return errs.ErrArgs.Wrap("jobID is empty")
+	}
+	return nil
+}
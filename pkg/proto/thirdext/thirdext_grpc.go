@@ -0,0 +1,129 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thirdext
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/jsonrpc"
+)
+
+const ServiceName = "OpenIMServer.thirdext.thirdExt"
+
+type ThirdExtClient interface {
+	PublishKeyBundle(ctx context.Context, in *PublishKeyBundleReq, opts ...grpc.CallOption) (*PublishKeyBundleResp, error)
+	UploadOneTimePreKeys(ctx context.Context, in *UploadOneTimePreKeysReq, opts ...grpc.CallOption) (*UploadOneTimePreKeysResp, error)
+	GetOneTimePreKeyCount(ctx context.Context, in *GetOneTimePreKeyCountReq, opts ...grpc.CallOption) (*GetOneTimePreKeyCountResp, error)
+	FetchKeyBundles(ctx context.Context, in *FetchKeyBundlesReq, opts ...grpc.CallOption) (*FetchKeyBundlesResp, error)
+	DeleteKeyBundle(ctx context.Context, in *DeleteKeyBundleReq, opts ...grpc.CallOption) (*DeleteKeyBundleResp, error)
+	SetSenderKeyDistribution(ctx context.Context, in *SetSenderKeyDistributionReq, opts ...grpc.CallOption) (*SetSenderKeyDistributionResp, error)
+	GetSenderKeyDistributions(ctx context.Context, in *GetSenderKeyDistributionsReq, opts ...grpc.CallOption) (*GetSenderKeyDistributionsResp, error)
+	DeleteUserObjects(ctx context.Context, in *DeleteUserObjectsReq, opts ...grpc.CallOption) (*DeleteUserObjectsResp, error)
+	GetUserObjects(ctx context.Context, in *GetUserObjectsReq, opts ...grpc.CallOption) (*GetUserObjectsResp, error)
+	InitiateExportUpload(ctx context.Context, in *InitiateExportUploadReq, opts ...grpc.CallOption) (*InitiateExportUploadResp, error)
+	CompleteExportUpload(ctx context.Context, in *CompleteExportUploadReq, opts ...grpc.CallOption) (*CompleteExportUploadResp, error)
+}
+
+type thirdExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewThirdExtClient(cc grpc.ClientConnInterface) ThirdExtClient {
+	return &thirdExtClient{cc}
+}
+
+func (c *thirdExtClient) PublishKeyBundle(ctx context.Context, in *PublishKeyBundleReq, opts ...grpc.CallOption) (*PublishKeyBundleResp, error) {
+	return jsonrpc.Invoke[PublishKeyBundleResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "PublishKeyBundle"), in, opts...)
+}
+
+func (c *thirdExtClient) UploadOneTimePreKeys(ctx context.Context, in *UploadOneTimePreKeysReq, opts ...grpc.CallOption) (*UploadOneTimePreKeysResp, error) {
+	return jsonrpc.Invoke[UploadOneTimePreKeysResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "UploadOneTimePreKeys"), in, opts...)
+}
+
+func (c *thirdExtClient) GetOneTimePreKeyCount(ctx context.Context, in *GetOneTimePreKeyCountReq, opts ...grpc.CallOption) (*GetOneTimePreKeyCountResp, error) {
+	return jsonrpc.Invoke[GetOneTimePreKeyCountResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetOneTimePreKeyCount"), in, opts...)
+}
+
+func (c *thirdExtClient) FetchKeyBundles(ctx context.Context, in *FetchKeyBundlesReq, opts ...grpc.CallOption) (*FetchKeyBundlesResp, error) {
+	return jsonrpc.Invoke[FetchKeyBundlesResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "FetchKeyBundles"), in, opts...)
+}
+
+func (c *thirdExtClient) DeleteKeyBundle(ctx context.Context, in *DeleteKeyBundleReq, opts ...grpc.CallOption) (*DeleteKeyBundleResp, error) {
+	return jsonrpc.Invoke[DeleteKeyBundleResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "DeleteKeyBundle"), in, opts...)
+}
+
+func (c *thirdExtClient) SetSenderKeyDistribution(ctx context.Context, in *SetSenderKeyDistributionReq, opts ...grpc.CallOption) (*SetSenderKeyDistributionResp, error) {
+	return jsonrpc.Invoke[SetSenderKeyDistributionResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetSenderKeyDistribution"), in, opts...)
+}
+
+func (c *thirdExtClient) GetSenderKeyDistributions(ctx context.Context, in *GetSenderKeyDistributionsReq, opts ...grpc.CallOption) (*GetSenderKeyDistributionsResp, error) {
+	return jsonrpc.Invoke[GetSenderKeyDistributionsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetSenderKeyDistributions"), in, opts...)
+}
+
+func (c *thirdExtClient) DeleteUserObjects(ctx context.Context, in *DeleteUserObjectsReq, opts ...grpc.CallOption) (*DeleteUserObjectsResp, error) {
+	return jsonrpc.Invoke[DeleteUserObjectsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "DeleteUserObjects"), in, opts...)
+}
+
+func (c *thirdExtClient) GetUserObjects(ctx context.Context, in *GetUserObjectsReq, opts ...grpc.CallOption) (*GetUserObjectsResp, error) {
+	return jsonrpc.Invoke[GetUserObjectsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetUserObjects"), in, opts...)
+}
+
+func (c *thirdExtClient) InitiateExportUpload(ctx context.Context, in *InitiateExportUploadReq, opts ...grpc.CallOption) (*InitiateExportUploadResp, error) {
+	return jsonrpc.Invoke[InitiateExportUploadResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "InitiateExportUpload"), in, opts...)
+}
+
+func (c *thirdExtClient) CompleteExportUpload(ctx context.Context, in *CompleteExportUploadReq, opts ...grpc.CallOption) (*CompleteExportUploadResp, error) {
+	return jsonrpc.Invoke[CompleteExportUploadResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "CompleteExportUpload"), in, opts...)
+}
+
+type ThirdExtServer interface {
+	PublishKeyBundle(context.Context, *PublishKeyBundleReq) (*PublishKeyBundleResp, error)
+	UploadOneTimePreKeys(context.Context, *UploadOneTimePreKeysReq) (*UploadOneTimePreKeysResp, error)
+	GetOneTimePreKeyCount(context.Context, *GetOneTimePreKeyCountReq) (*GetOneTimePreKeyCountResp, error)
+	FetchKeyBundles(context.Context, *FetchKeyBundlesReq) (*FetchKeyBundlesResp, error)
+	DeleteKeyBundle(context.Context, *DeleteKeyBundleReq) (*DeleteKeyBundleResp, error)
+	SetSenderKeyDistribution(context.Context, *SetSenderKeyDistributionReq) (*SetSenderKeyDistributionResp, error)
+	GetSenderKeyDistributions(context.Context, *GetSenderKeyDistributionsReq) (*GetSenderKeyDistributionsResp, error)
+	DeleteUserObjects(context.Context, *DeleteUserObjectsReq) (*DeleteUserObjectsResp, error)
+	GetUserObjects(context.Context, *GetUserObjectsReq) (*GetUserObjectsResp, error)
+	InitiateExportUpload(context.Context, *InitiateExportUploadReq) (*InitiateExportUploadResp, error)
+	CompleteExportUpload(context.Context, *CompleteExportUploadReq) (*CompleteExportUploadResp, error)
+}
+
+func RegisterThirdExtServer(s grpc.ServiceRegistrar, srv ThirdExtServer) {
+	s.RegisterService(&ThirdExt_ServiceDesc, srv)
+}
+
+var ThirdExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*ThirdExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		jsonrpc.MethodDesc(ServiceName, "PublishKeyBundle", ThirdExtServer.PublishKeyBundle),
+		jsonrpc.MethodDesc(ServiceName, "UploadOneTimePreKeys", ThirdExtServer.UploadOneTimePreKeys),
+		jsonrpc.MethodDesc(ServiceName, "GetOneTimePreKeyCount", ThirdExtServer.GetOneTimePreKeyCount),
+		jsonrpc.MethodDesc(ServiceName, "FetchKeyBundles", ThirdExtServer.FetchKeyBundles),
+		jsonrpc.MethodDesc(ServiceName, "DeleteKeyBundle", ThirdExtServer.DeleteKeyBundle),
+		jsonrpc.MethodDesc(ServiceName, "SetSenderKeyDistribution", ThirdExtServer.SetSenderKeyDistribution),
+		jsonrpc.MethodDesc(ServiceName, "GetSenderKeyDistributions", ThirdExtServer.GetSenderKeyDistributions),
+		jsonrpc.MethodDesc(ServiceName, "DeleteUserObjects", ThirdExtServer.DeleteUserObjects),
+		jsonrpc.MethodDesc(ServiceName, "GetUserObjects", ThirdExtServer.GetUserObjects),
+		jsonrpc.MethodDesc(ServiceName, "InitiateExportUpload", ThirdExtServer.InitiateExportUpload),
+		jsonrpc.MethodDesc(ServiceName, "CompleteExportUpload", ThirdExtServer.CompleteExportUpload),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "thirdext",
+}
@@ -0,0 +1,468 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userext
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/OpenIMSDK/protocol/sdkws"
+	"github.com/OpenIMSDK/tools/errs"
+)
+
+// SearchUsersMaxShowNumber 搜索用户单页最多返回的数量.
+const SearchUsersMaxShowNumber = 100
+
+// account status.
+const (
+	AccountStatusNormal      = 0
+	AccountStatusDeactivated = 1
+)
+
+type AccountStatus struct {
+	UserID string `json:"userID"`
+	Status int32  `json:"status"`
+}
+
+type UserEraseStep struct {
+	Name string `json:"name"`
+	// 0 pending, 1 finished, 2 failed, 3 skipped
+	Status     int32  `json:"status"`
+	Count      int64  `json:"count"`
+	ErrMsg     string `json:"errMsg"`
+	FinishTime int64  `json:"finishTime"`
+}
+
+type UserEraseJob struct {
+	JobID         string `json:"jobID"`
+	UserID        string `json:"userID"`
+	OpUserID      string `json:"opUserID"`
+	AnonymizeMsgs bool   `json:"anonymizeMsgs"`
+	// 0 pending, 1 running, 2 finished, 3 failed
+	Status     int32            `json:"status"`
+	Steps      []*UserEraseStep `json:"steps"`
+	ErrMsg     string           `json:"errMsg"`
+	CreateTime int64            `json:"createTime"`
+	StartTime  int64            `json:"startTime"`
+	FinishTime int64            `json:"finishTime"`
+}
+
+type UserExportJob struct {
+	JobID    string `json:"jobID"`
+	UserID   string `json:"userID"`
+	OpUserID string `json:"opUserID"`
+	// 0 pending, 1 running, 2 finished, 3 failed
+	Status int32 `json:"status"`
+	// local: location is a directory on the host of the job, object: location is the object name
+	Storage  string `json:"storage"`
+	Location string `json:"location"`
+	// records exported of each part
+	Counts     map[string]int64 `json:"counts"`
+	ErrMsg     string           `json:"errMsg"`
+	CreateTime int64            `json:"createTime"`
+	StartTime  int64            `json:"startTime"`
+	FinishTime int64            `json:"finishTime"`
+	// download url of a finished object storage job, only returned by GetUserExportJob
+	Url           string `json:"url"`
+	UrlExpireTime int64  `json:"urlExpireTime"`
+}
+
+type UserSuspension struct {
+	SuspensionID string `json:"suspensionID"`
+	UserID       string `json:"userID"`
+	// login, sendMsg, createGroup
+	Scopes    []string `json:"scopes"`
+	Reason    string   `json:"reason"`
+	OpUserID  string   `json:"opUserID"`
+	StartTime int64    `json:"startTime"`
+	// 0 means no end time
+	EndTime int64 `json:"endTime"`
+	// 0 active, 1 lifted, 2 expired
+	Status       int32  `json:"status"`
+	LiftOpUserID string `json:"liftOpUserID"`
+	LiftReason   string `json:"liftReason"`
+	LiftTime     int64  `json:"liftTime"`
+	CreateTime   int64  `json:"createTime"`
+}
+
+// DoNotDisturbSchedule 免打扰时段, startMinute/endMinute为timezone下当天的分钟数(0-1439), 开始大于结束表示跨零点.
+type DoNotDisturbSchedule struct {
+	Enable      bool   `json:"enable"`
+	StartMinute int32  `json:"startMinute"`
+	EndMinute   int32  `json:"endMinute"`
+	Timezone    string `json:"timezone"`
+}
+
+// DeactivateUserReq 停用账号, 吊销token并踢下线, 资料对其他用户隐藏.
+type DeactivateUserReq struct {
+	UserID string `json:"userID"`
+}
+
+type DeactivateUserResp struct{}
+
+// ReactivateUserReq 恢复已停用的账号.
+type ReactivateUserReq struct {
+	UserID string `json:"userID"`
+}
+
+type ReactivateUserResp struct{}
+
+// GetUsersAccountStatusReq 不存在的用户不返回.
+type GetUsersAccountStatusReq struct {
+	UserIDs []string `json:"userIDs"`
+}
+
+type GetUsersAccountStatusResp struct {
+	Statuses []*AccountStatus `json:"statuses"`
+}
+
+// EraseUserReq 创建删除用户数据的任务, 用户已有未完成的任务时返回该任务.
+type EraseUserReq struct {
+	UserID string `json:"userID"`
+	// 清除已发送消息中的发送者昵称及头像
+	AnonymizeMsgs bool `json:"anonymizeMsgs"`
+}
+
+type EraseUserResp struct {
+	Job *UserEraseJob `json:"job"`
+}
+
+type GetUserEraseJobReq struct {
+	JobID string `json:"jobID"`
+}
+
+type GetUserEraseJobResp struct {
+	Job *UserEraseJob `json:"job"`
+}
+
+// GetUserEraseJobsReq userID为空时查询所有用户, status为空时查询所有状态.
+type GetUserEraseJobsReq struct {
+	UserID     string                   `json:"userID"`
+	Status     []int32                  `json:"status"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetUserEraseJobsResp struct {
+	Total int64           `json:"total"`
+	Jobs  []*UserEraseJob `json:"jobs"`
+}
+
+// ExportUserReq 创建导出用户数据的任务, 导出位置由配置userExport.storage决定.
+type ExportUserReq struct {
+	UserID string `json:"userID"`
+}
+
+type ExportUserResp struct {
+	Job *UserExportJob `json:"job"`
+}
+
+// GetUserExportJobReq 已完成的对象存储任务返回有时效的下载地址.
+type GetUserExportJobReq struct {
+	JobID string `json:"jobID"`
+}
+
+type GetUserExportJobResp struct {
+	Job *UserExportJob `json:"job"`
+}
+
+// GetUserExportJobsReq userID为空时查询所有用户, status为空时查询所有状态.
+type GetUserExportJobsReq struct {
+	UserID     string                   `json:"userID"`
+	Status     []int32                  `json:"status"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetUserExportJobsResp struct {
+	Total int64            `json:"total"`
+	Jobs  []*UserExportJob `json:"jobs"`
+}
+
+// SearchUsersReq 搜索用户, 空字段不作为条件, 结果按注册时间倒序.
+// 非管理员必须指定userIDPrefix或nickname, 不能按注册时间及管理员等级过滤, 且不返回设置了不可搜索及已停用的用户.
+type SearchUsersReq struct {
+	UserIDPrefix string `json:"userIDPrefix"`
+	// 昵称包含即匹配, 不区分大小写
+	Nickname string `json:"nickname"`
+	// 毫秒时间戳, 注册时间在[createTimeBegin, createTimeEnd)内
+	CreateTimeBegin int64                    `json:"createTimeBegin"`
+	CreateTimeEnd   int64                    `json:"createTimeEnd"`
+	AppMangerLevels []int32                  `json:"appMangerLevels"`
+	Pagination      *sdkws.RequestPagination `json:"pagination"`
+}
+
+// SearchUsersResp 管理员搜索返回users, 普通用户只返回publicUsers.
+type SearchUsersResp struct {
+	Total       int64                   `json:"total"`
+	Users       []*sdkws.UserInfo       `json:"users"`
+	PublicUsers []*sdkws.PublicUserInfo `json:"publicUsers"`
+}
+
+// SetUserSearchHiddenReq 设置用户是否出现在其他用户的搜索结果中, 管理员搜索不受影响.
+type SetUserSearchHiddenReq struct {
+	UserID string `json:"userID"`
+	Hidden bool   `json:"hidden"`
+}
+
+type SetUserSearchHiddenResp struct{}
+
+type GetUserSearchHiddenReq struct {
+	UserID string `json:"userID"`
+}
+
+type GetUserSearchHiddenResp struct {
+	Hidden bool `json:"hidden"`
+}
+
+// SuspendUserReq 封禁用户的登录、发消息或建群, 封禁登录时吊销token并踢下线.
+type SuspendUserReq struct {
+	UserID string   `json:"userID"`
+	Scopes []string `json:"scopes"`
+	Reason string   `json:"reason"`
+	// 毫秒时间戳, 到期自动解除, 0表示不自动解除
+	EndTime int64 `json:"endTime"`
+}
+
+type SuspendUserResp struct {
+	Suspension *UserSuspension `json:"suspension"`
+}
+
+// LiftUserSuspensionReq 提前解除生效中的封禁.
+type LiftUserSuspensionReq struct {
+	SuspensionID string `json:"suspensionID"`
+	Reason       string `json:"reason"`
+}
+
+type LiftUserSuspensionResp struct{}
+
+// GetUserSuspensionsReq 查询封禁记录, userID为空时查询所有用户, status为空时查询所有状态.
+type GetUserSuspensionsReq struct {
+	UserID     string                   `json:"userID"`
+	Status     []int32                  `json:"status"`
+	Pagination *sdkws.RequestPagination `json:"pagination"`
+}
+
+type GetUserSuspensionsResp struct {
+	Total       int64             `json:"total"`
+	Suspensions []*UserSuspension `json:"suspensions"`
+}
+
+// LiftExpiredUserSuspensionsReq 解除已到结束时间的封禁, 由定时任务调用.
+type LiftExpiredUserSuspensionsReq struct{}
+
+type LiftExpiredUserSuspensionsResp struct {
+	Count int64 `json:"count"`
+}
+
+// SetDoNotDisturbScheduleReq 设置用户免打扰时段, 时段内消息仍在线投递, 只是不做离线推送.
+type SetDoNotDisturbScheduleReq struct {
+	UserID   string                `json:"userID"`
+	Schedule *DoNotDisturbSchedule `json:"schedule"`
+}
+
+type SetDoNotDisturbScheduleResp struct{}
+
+type GetDoNotDisturbScheduleReq struct {
+	UserID string `json:"userID"`
+}
+
+type GetDoNotDisturbScheduleResp struct {
+	Schedule *DoNotDisturbSchedule `json:"schedule"`
+	// 当前是否处于免打扰时段
+	InDoNotDisturb bool `json:"inDoNotDisturb"`
+}
+
+// GetDoNotDisturbUserIDsReq 获取userIDs中当前处于免打扰时段的用户, 供push使用.
+type GetDoNotDisturbUserIDsReq struct {
+	UserIDs []string `json:"userIDs"`
+}
+
+type GetDoNotDisturbUserIDsResp struct {
+	UserIDs []string `json:"userIDs"`
+}
+
+func (x *DeactivateUserReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *ReactivateUserReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetUsersAccountStatusReq) Check() error {
+	if len(x.UserIDs) == 0 {
+		return errs.ErrArgs.Wrap("userIDs is empty")
+	}
+	return nil
+}
+
+func (x *EraseUserReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetUserEraseJobReq) Check() error {
+	if x.JobID == "" {
+		return errs.ErrArgs.Wrap("jobID is empty")
+	}
+	return nil
+}
+
+func (x *GetUserEraseJobsReq) Check() error {
+	if x.Pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	return nil
+}
+
+func (x *ExportUserReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetUserExportJobReq) Check() error {
+	if x.JobID == "" {
+		return errs.ErrArgs.Wrap("jobID is empty")
+	}
+	return nil
+}
+
+func (x *GetUserExportJobsReq) Check() error {
+	if x.Pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	return nil
+}
+
+func (x *SearchUsersReq) Check() error {
+	if x.Pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	if x.Pagination.ShowNumber < 1 || x.Pagination.ShowNumber > SearchUsersMaxShowNumber {
+		return errs.ErrArgs.Wrap(fmt.Sprintf("showNumber must be in [1, %d]", SearchUsersMaxShowNumber))
+	}
+	if x.CreateTimeBegin < 0 || x.CreateTimeEnd < 0 {
+		return errs.ErrArgs.Wrap("createTime is invalid")
+	}
+	if x.CreateTimeEnd > 0 && x.CreateTimeBegin >= x.CreateTimeEnd {
+		return errs.ErrArgs.Wrap("createTimeBegin must be before createTimeEnd")
+	}
+	return nil
+}
+
+func (x *SetUserSearchHiddenReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetUserSearchHiddenReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *SuspendUserReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if len(x.Scopes) == 0 {
+		return errs.ErrArgs.Wrap("scopes is empty")
+	}
+	if x.Reason == "" {
+		return errs.ErrArgs.Wrap("reason is empty")
+	}
+	if x.EndTime < 0 {
+		return errs.ErrArgs.Wrap("endTime is invalid")
+	}
+	return nil
+}
+
+func (x *LiftUserSuspensionReq) Check() error {
+	if x.SuspensionID == "" {
+		return errs.ErrArgs.Wrap("suspensionID is empty")
+	}
+	return nil
+}
+
+func (x *GetUserSuspensionsReq) Check() error {
+	if x.Pagination == nil {
+		return errs.ErrArgs.Wrap("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errs.ErrArgs.Wrap("pageNumber is invalid")
+	}
+	return nil
+}
+
+func (x *LiftExpiredUserSuspensionsReq) Check() error {
+	return nil
+}
+
+func (x *SetDoNotDisturbScheduleReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	if x.Schedule == nil {
+		return errs.ErrArgs.Wrap("schedule is empty")
+	}
+	if !x.Schedule.Enable {
+		return nil
+	}
+	if x.Schedule.StartMinute < 0 || x.Schedule.StartMinute >= 24*60 || x.Schedule.EndMinute < 0 || x.Schedule.EndMinute >= 24*60 {
+		return errs.ErrArgs.Wrap("startMinute and endMinute must be in [0, 1440)")
+	}
+	if x.Schedule.StartMinute == x.Schedule.EndMinute {
+		return errs.ErrArgs.Wrap("startMinute equals endMinute")
+	}
+	if _, err := time.LoadLocation(x.Schedule.Timezone); err != nil {
+		return errs.ErrArgs.Wrap("timezone is invalid")
+	}
+	return nil
+}
+
+func (x *GetDoNotDisturbScheduleReq) Check() error {
+	if x.UserID == "" {
+		return errs.ErrArgs.Wrap("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetDoNotDisturbUserIDsReq) Check() error {
+	if len(x.UserIDs) == 0 {
+		return errs.ErrArgs.Wrap("userIDs is empty")
+	}
+	return nil
+}
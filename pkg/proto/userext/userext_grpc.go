@@ -0,0 +1,185 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userext
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/jsonrpc"
+)
+
+const ServiceName = "OpenIMServer.userext.userExt"
+
+type UserExtClient interface {
+	DeactivateUser(ctx context.Context, in *DeactivateUserReq, opts ...grpc.CallOption) (*DeactivateUserResp, error)
+	ReactivateUser(ctx context.Context, in *ReactivateUserReq, opts ...grpc.CallOption) (*ReactivateUserResp, error)
+	GetUsersAccountStatus(ctx context.Context, in *GetUsersAccountStatusReq, opts ...grpc.CallOption) (*GetUsersAccountStatusResp, error)
+	EraseUser(ctx context.Context, in *EraseUserReq, opts ...grpc.CallOption) (*EraseUserResp, error)
+	GetUserEraseJob(ctx context.Context, in *GetUserEraseJobReq, opts ...grpc.CallOption) (*GetUserEraseJobResp, error)
+	GetUserEraseJobs(ctx context.Context, in *GetUserEraseJobsReq, opts ...grpc.CallOption) (*GetUserEraseJobsResp, error)
+	ExportUser(ctx context.Context, in *ExportUserReq, opts ...grpc.CallOption) (*ExportUserResp, error)
+	GetUserExportJob(ctx context.Context, in *GetUserExportJobReq, opts ...grpc.CallOption) (*GetUserExportJobResp, error)
+	GetUserExportJobs(ctx context.Context, in *GetUserExportJobsReq, opts ...grpc.CallOption) (*GetUserExportJobsResp, error)
+	SearchUsers(ctx context.Context, in *SearchUsersReq, opts ...grpc.CallOption) (*SearchUsersResp, error)
+	SetUserSearchHidden(ctx context.Context, in *SetUserSearchHiddenReq, opts ...grpc.CallOption) (*SetUserSearchHiddenResp, error)
+	GetUserSearchHidden(ctx context.Context, in *GetUserSearchHiddenReq, opts ...grpc.CallOption) (*GetUserSearchHiddenResp, error)
+	SuspendUser(ctx context.Context, in *SuspendUserReq, opts ...grpc.CallOption) (*SuspendUserResp, error)
+	LiftUserSuspension(ctx context.Context, in *LiftUserSuspensionReq, opts ...grpc.CallOption) (*LiftUserSuspensionResp, error)
+	GetUserSuspensions(ctx context.Context, in *GetUserSuspensionsReq, opts ...grpc.CallOption) (*GetUserSuspensionsResp, error)
+	LiftExpiredUserSuspensions(ctx context.Context, in *LiftExpiredUserSuspensionsReq, opts ...grpc.CallOption) (*LiftExpiredUserSuspensionsResp, error)
+	SetDoNotDisturbSchedule(ctx context.Context, in *SetDoNotDisturbScheduleReq, opts ...grpc.CallOption) (*SetDoNotDisturbScheduleResp, error)
+	GetDoNotDisturbSchedule(ctx context.Context, in *GetDoNotDisturbScheduleReq, opts ...grpc.CallOption) (*GetDoNotDisturbScheduleResp, error)
+	GetDoNotDisturbUserIDs(ctx context.Context, in *GetDoNotDisturbUserIDsReq, opts ...grpc.CallOption) (*GetDoNotDisturbUserIDsResp, error)
+}
+
+type userExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserExtClient(cc grpc.ClientConnInterface) UserExtClient {
+	return &userExtClient{cc}
+}
+
+func (c *userExtClient) DeactivateUser(ctx context.Context, in *DeactivateUserReq, opts ...grpc.CallOption) (*DeactivateUserResp, error) {
+	return jsonrpc.Invoke[DeactivateUserResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "DeactivateUser"), in, opts...)
+}
+
+func (c *userExtClient) ReactivateUser(ctx context.Context, in *ReactivateUserReq, opts ...grpc.CallOption) (*ReactivateUserResp, error) {
+	return jsonrpc.Invoke[ReactivateUserResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "ReactivateUser"), in, opts...)
+}
+
+func (c *userExtClient) GetUsersAccountStatus(ctx context.Context, in *GetUsersAccountStatusReq, opts ...grpc.CallOption) (*GetUsersAccountStatusResp, error) {
+	return jsonrpc.Invoke[GetUsersAccountStatusResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetUsersAccountStatus"), in, opts...)
+}
+
+func (c *userExtClient) EraseUser(ctx context.Context, in *EraseUserReq, opts ...grpc.CallOption) (*EraseUserResp, error) {
+	return jsonrpc.Invoke[EraseUserResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "EraseUser"), in, opts...)
+}
+
+func (c *userExtClient) GetUserEraseJob(ctx context.Context, in *GetUserEraseJobReq, opts ...grpc.CallOption) (*GetUserEraseJobResp, error) {
+	return jsonrpc.Invoke[GetUserEraseJobResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetUserEraseJob"), in, opts...)
+}
+
+func (c *userExtClient) GetUserEraseJobs(ctx context.Context, in *GetUserEraseJobsReq, opts ...grpc.CallOption) (*GetUserEraseJobsResp, error) {
+	return jsonrpc.Invoke[GetUserEraseJobsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetUserEraseJobs"), in, opts...)
+}
+
+func (c *userExtClient) ExportUser(ctx context.Context, in *ExportUserReq, opts ...grpc.CallOption) (*ExportUserResp, error) {
+	return jsonrpc.Invoke[ExportUserResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "ExportUser"), in, opts...)
+}
+
+func (c *userExtClient) GetUserExportJob(ctx context.Context, in *GetUserExportJobReq, opts ...grpc.CallOption) (*GetUserExportJobResp, error) {
+	return jsonrpc.Invoke[GetUserExportJobResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetUserExportJob"), in, opts...)
+}
+
+func (c *userExtClient) GetUserExportJobs(ctx context.Context, in *GetUserExportJobsReq, opts ...grpc.CallOption) (*GetUserExportJobsResp, error) {
+	return jsonrpc.Invoke[GetUserExportJobsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetUserExportJobs"), in, opts...)
+}
+
+func (c *userExtClient) SearchUsers(ctx context.Context, in *SearchUsersReq, opts ...grpc.CallOption) (*SearchUsersResp, error) {
+	return jsonrpc.Invoke[SearchUsersResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SearchUsers"), in, opts...)
+}
+
+func (c *userExtClient) SetUserSearchHidden(ctx context.Context, in *SetUserSearchHiddenReq, opts ...grpc.CallOption) (*SetUserSearchHiddenResp, error) {
+	return jsonrpc.Invoke[SetUserSearchHiddenResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetUserSearchHidden"), in, opts...)
+}
+
+func (c *userExtClient) GetUserSearchHidden(ctx context.Context, in *GetUserSearchHiddenReq, opts ...grpc.CallOption) (*GetUserSearchHiddenResp, error) {
+	return jsonrpc.Invoke[GetUserSearchHiddenResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetUserSearchHidden"), in, opts...)
+}
+
+func (c *userExtClient) SuspendUser(ctx context.Context, in *SuspendUserReq, opts ...grpc.CallOption) (*SuspendUserResp, error) {
+	return jsonrpc.Invoke[SuspendUserResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SuspendUser"), in, opts...)
+}
+
+func (c *userExtClient) LiftUserSuspension(ctx context.Context, in *LiftUserSuspensionReq, opts ...grpc.CallOption) (*LiftUserSuspensionResp, error) {
+	return jsonrpc.Invoke[LiftUserSuspensionResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "LiftUserSuspension"), in, opts...)
+}
+
+func (c *userExtClient) GetUserSuspensions(ctx context.Context, in *GetUserSuspensionsReq, opts ...grpc.CallOption) (*GetUserSuspensionsResp, error) {
+	return jsonrpc.Invoke[GetUserSuspensionsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetUserSuspensions"), in, opts...)
+}
+
+func (c *userExtClient) LiftExpiredUserSuspensions(ctx context.Context, in *LiftExpiredUserSuspensionsReq, opts ...grpc.CallOption) (*LiftExpiredUserSuspensionsResp, error) {
+	return jsonrpc.Invoke[LiftExpiredUserSuspensionsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "LiftExpiredUserSuspensions"), in, opts...)
+}
+
+func (c *userExtClient) SetDoNotDisturbSchedule(ctx context.Context, in *SetDoNotDisturbScheduleReq, opts ...grpc.CallOption) (*SetDoNotDisturbScheduleResp, error) {
+	return jsonrpc.Invoke[SetDoNotDisturbScheduleResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "SetDoNotDisturbSchedule"), in, opts...)
+}
+
+func (c *userExtClient) GetDoNotDisturbSchedule(ctx context.Context, in *GetDoNotDisturbScheduleReq, opts ...grpc.CallOption) (*GetDoNotDisturbScheduleResp, error) {
+	return jsonrpc.Invoke[GetDoNotDisturbScheduleResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetDoNotDisturbSchedule"), in, opts...)
+}
+
+func (c *userExtClient) GetDoNotDisturbUserIDs(ctx context.Context, in *GetDoNotDisturbUserIDsReq, opts ...grpc.CallOption) (*GetDoNotDisturbUserIDsResp, error) {
+	return jsonrpc.Invoke[GetDoNotDisturbUserIDsResp](ctx, c.cc, jsonrpc.FullMethod(ServiceName, "GetDoNotDisturbUserIDs"), in, opts...)
+}
+
+type UserExtServer interface {
+	DeactivateUser(context.Context, *DeactivateUserReq) (*DeactivateUserResp, error)
+	ReactivateUser(context.Context, *ReactivateUserReq) (*ReactivateUserResp, error)
+	GetUsersAccountStatus(context.Context, *GetUsersAccountStatusReq) (*GetUsersAccountStatusResp, error)
+	EraseUser(context.Context, *EraseUserReq) (*EraseUserResp, error)
+	GetUserEraseJob(context.Context, *GetUserEraseJobReq) (*GetUserEraseJobResp, error)
+	GetUserEraseJobs(context.Context, *GetUserEraseJobsReq) (*GetUserEraseJobsResp, error)
+	ExportUser(context.Context, *ExportUserReq) (*ExportUserResp, error)
+	GetUserExportJob(context.Context, *GetUserExportJobReq) (*GetUserExportJobResp, error)
+	GetUserExportJobs(context.Context, *GetUserExportJobsReq) (*GetUserExportJobsResp, error)
+	SearchUsers(context.Context, *SearchUsersReq) (*SearchUsersResp, error)
+	SetUserSearchHidden(context.Context, *SetUserSearchHiddenReq) (*SetUserSearchHiddenResp, error)
+	GetUserSearchHidden(context.Context, *GetUserSearchHiddenReq) (*GetUserSearchHiddenResp, error)
+	SuspendUser(context.Context, *SuspendUserReq) (*SuspendUserResp, error)
+	LiftUserSuspension(context.Context, *LiftUserSuspensionReq) (*LiftUserSuspensionResp, error)
+	GetUserSuspensions(context.Context, *GetUserSuspensionsReq) (*GetUserSuspensionsResp, error)
+	LiftExpiredUserSuspensions(context.Context, *LiftExpiredUserSuspensionsReq) (*LiftExpiredUserSuspensionsResp, error)
+	SetDoNotDisturbSchedule(context.Context, *SetDoNotDisturbScheduleReq) (*SetDoNotDisturbScheduleResp, error)
+	GetDoNotDisturbSchedule(context.Context, *GetDoNotDisturbScheduleReq) (*GetDoNotDisturbScheduleResp, error)
+	GetDoNotDisturbUserIDs(context.Context, *GetDoNotDisturbUserIDsReq) (*GetDoNotDisturbUserIDsResp, error)
+}
+
+func RegisterUserExtServer(s grpc.ServiceRegistrar, srv UserExtServer) {
+	s.RegisterService(&UserExt_ServiceDesc, srv)
+}
+
+var UserExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*UserExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		jsonrpc.MethodDesc(ServiceName, "DeactivateUser", UserExtServer.DeactivateUser),
+		jsonrpc.MethodDesc(ServiceName, "ReactivateUser", UserExtServer.ReactivateUser),
+		jsonrpc.MethodDesc(ServiceName, "GetUsersAccountStatus", UserExtServer.GetUsersAccountStatus),
+		jsonrpc.MethodDesc(ServiceName, "EraseUser", UserExtServer.EraseUser),
+		jsonrpc.MethodDesc(ServiceName, "GetUserEraseJob", UserExtServer.GetUserEraseJob),
+		jsonrpc.MethodDesc(ServiceName, "GetUserEraseJobs", UserExtServer.GetUserEraseJobs),
+		jsonrpc.MethodDesc(ServiceName, "ExportUser", UserExtServer.ExportUser),
+		jsonrpc.MethodDesc(ServiceName, "GetUserExportJob", UserExtServer.GetUserExportJob),
+		jsonrpc.MethodDesc(ServiceName, "GetUserExportJobs", UserExtServer.GetUserExportJobs),
+		jsonrpc.MethodDesc(ServiceName, "SearchUsers", UserExtServer.SearchUsers),
+		jsonrpc.MethodDesc(ServiceName, "SetUserSearchHidden", UserExtServer.SetUserSearchHidden),
+		jsonrpc.MethodDesc(ServiceName, "GetUserSearchHidden", UserExtServer.GetUserSearchHidden),
+		jsonrpc.MethodDesc(ServiceName, "SuspendUser", UserExtServer.SuspendUser),
+		jsonrpc.MethodDesc(ServiceName, "LiftUserSuspension", UserExtServer.LiftUserSuspension),
+		jsonrpc.MethodDesc(ServiceName, "GetUserSuspensions", UserExtServer.GetUserSuspensions),
+		jsonrpc.MethodDesc(ServiceName, "LiftExpiredUserSuspensions", UserExtServer.LiftExpiredUserSuspensions),
+		jsonrpc.MethodDesc(ServiceName, "SetDoNotDisturbSchedule", UserExtServer.SetDoNotDisturbSchedule),
+		jsonrpc.MethodDesc(ServiceName, "GetDoNotDisturbSchedule", UserExtServer.GetDoNotDisturbSchedule),
+		jsonrpc.MethodDesc(ServiceName, "GetDoNotDisturbUserIDs", UserExtServer.GetDoNotDisturbUserIDs),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "userext",
+}
@@ -25,12 +25,14 @@ import (
 	"github.com/OpenIMSDK/tools/errs"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/conversationext"
 )
 
 type Conversation struct {
-	Client pbConversation.ConversationClient
-	conn   grpc.ClientConnInterface
-	discov discoveryregistry.SvcDiscoveryRegistry
+	Client    pbConversation.ConversationClient
+	ExtClient conversationext.ConversationExtClient
+	conn      grpc.ClientConnInterface
+	discov    discoveryregistry.SvcDiscoveryRegistry
 }
 
 func NewConversation(discov discoveryregistry.SvcDiscoveryRegistry) *Conversation {
@@ -39,7 +41,7 @@ func NewConversation(discov discoveryregistry.SvcDiscoveryRegistry) *Conversatio
 		panic(err)
 	}
 	client := pbConversation.NewConversationClient(conn)
-	return &Conversation{discov: discov, conn: conn, Client: client}
+	return &Conversation{discov: discov, conn: conn, Client: client, ExtClient: conversationext.NewConversationExtClient(conn)}
 }
 
 type ConversationRpcClient Conversation
@@ -87,6 +89,15 @@ func (c *ConversationRpcClient) GetConversationIDs(ctx context.Context, ownerUse
 	return resp.ConversationIDs, nil
 }
 
+// GetMutedUserIDs 获取userIDs中当前处于会话静音的用户.
+func (c *ConversationRpcClient) GetMutedUserIDs(ctx context.Context, conversationID string, userIDs []string) ([]string, error) {
+	resp, err := c.ExtClient.GetMutedUserIDs(ctx, &conversationext.GetMutedUserIDsReq{ConversationID: conversationID, UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	return resp.UserIDs, nil
+}
+
 func (c *ConversationRpcClient) GetConversation(ctx context.Context, ownerUserID, conversationID string) (*pbConversation.Conversation, error) {
 	resp, err := c.Client.GetConversation(ctx, &pbConversation.GetConversationReq{OwnerUserID: ownerUserID, ConversationID: conversationID})
 	if err != nil {
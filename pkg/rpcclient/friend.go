@@ -24,12 +24,14 @@ import (
 	"github.com/OpenIMSDK/tools/discoveryregistry"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
 )
 
 type Friend struct {
-	conn   grpc.ClientConnInterface
-	Client friend.FriendClient
-	discov discoveryregistry.SvcDiscoveryRegistry
+	conn      grpc.ClientConnInterface
+	Client    friend.FriendClient
+	ExtClient friendext.FriendExtClient
+	discov    discoveryregistry.SvcDiscoveryRegistry
 }
 
 func NewFriend(discov discoveryregistry.SvcDiscoveryRegistry) *Friend {
@@ -38,7 +40,7 @@ func NewFriend(discov discoveryregistry.SvcDiscoveryRegistry) *Friend {
 		panic(err)
 	}
 	client := friend.NewFriendClient(conn)
-	return &Friend{discov: discov, conn: conn, Client: client}
+	return &Friend{discov: discov, conn: conn, Client: client, ExtClient: friendext.NewFriendExtClient(conn)}
 }
 
 type FriendRpcClient Friend
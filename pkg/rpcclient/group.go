@@ -28,12 +28,14 @@ import (
 	"github.com/OpenIMSDK/tools/utils"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
 )
 
 type Group struct {
-	conn   grpc.ClientConnInterface
-	Client group.GroupClient
-	discov discoveryregistry.SvcDiscoveryRegistry
+	conn      grpc.ClientConnInterface
+	Client    group.GroupClient
+	ExtClient groupext.GroupExtClient
+	discov    discoveryregistry.SvcDiscoveryRegistry
 }
 
 func NewGroup(discov discoveryregistry.SvcDiscoveryRegistry) *Group {
@@ -42,7 +44,7 @@ func NewGroup(discov discoveryregistry.SvcDiscoveryRegistry) *Group {
 		panic(err)
 	}
 	client := group.NewGroupClient(conn)
-	return &Group{discov: discov, conn: conn, Client: client}
+	return &Group{discov: discov, conn: conn, Client: client, ExtClient: groupext.NewGroupExtClient(conn)}
 }
 
 type GroupRpcClient Group
@@ -129,6 +131,22 @@ func (g *GroupRpcClient) GetGroupMemberInfo(
 	return members[0], nil
 }
 
+// GetUserInGroupMembers 用户在groupIDs中作为成员的信息, 不在的群不返回.
+func (g *GroupRpcClient) GetUserInGroupMembers(
+	ctx context.Context,
+	userID string,
+	groupIDs []string,
+) ([]*sdkws.GroupMemberFullInfo, error) {
+	resp, err := g.Client.GetUserInGroupMembers(ctx, &group.GetUserInGroupMembersReq{
+		UserID:   userID,
+		GroupIDs: groupIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Members, nil
+}
+
 func (g *GroupRpcClient) GetGroupMemberInfoMap(
 	ctx context.Context,
 	groupID string,
@@ -208,3 +226,79 @@ func (g *GroupRpcClient) DismissGroup(ctx context.Context, groupID string) error
 	})
 	return err
 }
+
+// GetGroupMemberExtInfo 获取群成员信息及其生效的权限.
+func (g *GroupRpcClient) GetGroupMemberExtInfo(
+	ctx context.Context,
+	groupID string,
+	userID string,
+) (*groupext.GroupMemberInfo, error) {
+	resp, err := g.ExtClient.GetGroupMembersExtInfo(ctx, &groupext.GetGroupMembersExtInfoReq{
+		GroupID: groupID,
+		UserIDs: []string{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Members) == 0 {
+		return nil, errs.ErrNotInGroupYet.Wrap(userID)
+	}
+	return resp.Members[0], nil
+}
+
+// IsChannelSubscriber 判断用户是否订阅了频道.
+func (g *GroupRpcClient) IsChannelSubscriber(ctx context.Context, groupID string, userID string) (bool, error) {
+	resp, err := g.ExtClient.IsChannelSubscriber(ctx, &groupext.IsChannelSubscriberReq{
+		GroupID: groupID,
+		UserID:  userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Subscribed, nil
+}
+
+// GetChannelSubscriberIDs 按userID顺序分批获取频道订阅者, cursor为上一批最后一个userID.
+func (g *GroupRpcClient) GetChannelSubscriberIDs(
+	ctx context.Context,
+	groupID string,
+	cursor string,
+	count int32,
+) ([]string, error) {
+	resp, err := g.ExtClient.GetChannelSubscriberIDs(ctx, &groupext.GetChannelSubscriberIDsReq{
+		GroupID: groupID,
+		Cursor:  cursor,
+		Count:   count,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.UserIDs, nil
+}
+
+// GetGroupExtInfoCache 获取群信息及慢速模式等扩展字段.
+func (g *GroupRpcClient) GetGroupExtInfoCache(ctx context.Context, groupID string) (*groupext.GroupExtInfo, error) {
+	resp, err := g.ExtClient.GetGroupExtInfoCache(ctx, &groupext.GetGroupExtInfoCacheReq{GroupID: groupID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Group, nil
+}
+
+// GetSharedGroupCounts 获取和userID同在一个群的用户及共同群数, 成员数超过maxMemberCount的群不统计.
+func (g *GroupRpcClient) GetSharedGroupCounts(ctx context.Context, userID string, maxMemberCount int32) (map[string]int32, error) {
+	resp, err := g.ExtClient.GetSharedGroupCounts(ctx, &groupext.GetSharedGroupCountsReq{UserID: userID, MaxMemberCount: maxMemberCount})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Counts, nil
+}
+
+// GetSharedGroupIDs 获取userID和otherUserID共同所在的未解散群.
+func (g *GroupRpcClient) GetSharedGroupIDs(ctx context.Context, userID string, otherUserID string) ([]string, error) {
+	resp, err := g.ExtClient.GetSharedGroupIDs(ctx, &groupext.GetSharedGroupIDsReq{UserID: userID, OtherUserID: otherUserID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GroupIDs, nil
+}
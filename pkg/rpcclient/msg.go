@@ -29,9 +29,20 @@ import (
 	"github.com/OpenIMSDK/tools/utils"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 	// "google.golang.org/protobuf/proto".
 )
 
+// 服务端扩展的通知类型, 取值不与constant中已定义的冲突.
+const (
+	GroupSlowModeSetNotification    = 1521
+	GroupMemberBannedNotification   = 1522
+	MsgPinnedNotification           = 1523
+	MsgUnpinnedNotification         = 1524
+	FriendLabelsUpdatedNotification = 1525
+	MsgDestructedNotification       = 1526
+)
+
 func newContentTypeConf() map[int32]config.NotificationConf {
 	return map[int32]config.NotificationConf{
 		// group
@@ -55,6 +66,8 @@ func newContentTypeConf() map[int32]config.NotificationConf {
 		constant.GroupMemberSetToOrdinaryUserNotification: config.Config.Notification.GroupMemberSetToOrdinary,
 		constant.GroupInfoSetAnnouncementNotification:     config.Config.Notification.GroupInfoSetAnnouncement,
 		constant.GroupInfoSetNameNotification:             config.Config.Notification.GroupInfoSetName,
+		GroupSlowModeSetNotification:                      config.Config.Notification.GroupSlowModeSet,
+		GroupMemberBannedNotification:                     config.Config.Notification.GroupMemberBanned,
 		// user
 		constant.UserInfoUpdatedNotification: config.Config.Notification.UserInfoUpdated,
 		// friend
@@ -67,6 +80,7 @@ func newContentTypeConf() map[int32]config.NotificationConf {
 		constant.BlackAddedNotification:                config.Config.Notification.BlackAdded,
 		constant.BlackDeletedNotification:              config.Config.Notification.BlackDeleted,
 		constant.FriendInfoUpdatedNotification:         config.Config.Notification.FriendInfoUpdated,
+		FriendLabelsUpdatedNotification:                config.Config.Notification.FriendLabelsUpdated,
 		// conversation
 		constant.ConversationChangeNotification:      config.Config.Notification.ConversationChanged,
 		constant.ConversationUnreadNotification:      config.Config.Notification.ConversationChanged,
@@ -75,6 +89,9 @@ func newContentTypeConf() map[int32]config.NotificationConf {
 		constant.MsgRevokeNotification:  {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
 		constant.HasReadReceipt:         {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
 		constant.DeleteMsgsNotification: {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		MsgDestructedNotification:       {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		MsgPinnedNotification:           config.Config.Notification.MsgPinned,
+		MsgUnpinnedNotification:         config.Config.Notification.MsgUnpinned,
 	}
 }
 
@@ -101,6 +118,8 @@ func newSessionTypeConf() map[int32]int32 {
 		constant.GroupMemberSetToOrdinaryUserNotification: constant.SuperGroupChatType,
 		constant.GroupInfoSetAnnouncementNotification:     constant.SuperGroupChatType,
 		constant.GroupInfoSetNameNotification:             constant.SuperGroupChatType,
+		GroupSlowModeSetNotification:                      constant.SuperGroupChatType,
+		GroupMemberBannedNotification:                     constant.SuperGroupChatType,
 		// user
 		constant.UserInfoUpdatedNotification: constant.SingleChatType,
 		// friend
@@ -113,6 +132,7 @@ func newSessionTypeConf() map[int32]int32 {
 		constant.BlackAddedNotification:                constant.SingleChatType,
 		constant.BlackDeletedNotification:              constant.SingleChatType,
 		constant.FriendInfoUpdatedNotification:         constant.SingleChatType,
+		FriendLabelsUpdatedNotification:                constant.SingleChatType,
 		// conversation
 		constant.ConversationChangeNotification:      constant.SingleChatType,
 		constant.ConversationUnreadNotification:      constant.SingleChatType,
@@ -123,9 +143,10 @@ func newSessionTypeConf() map[int32]int32 {
 }
 
 type Message struct {
-	conn   grpc.ClientConnInterface
-	Client msg.MsgClient
-	discov discoveryregistry.SvcDiscoveryRegistry
+	conn      grpc.ClientConnInterface
+	Client    msg.MsgClient
+	ExtClient msgext.MsgExtClient
+	discov    discoveryregistry.SvcDiscoveryRegistry
 }
 
 func NewMessage(discov discoveryregistry.SvcDiscoveryRegistry) *Message {
@@ -134,7 +155,7 @@ func NewMessage(discov discoveryregistry.SvcDiscoveryRegistry) *Message {
 		panic(err)
 	}
 	client := msg.NewMsgClient(conn)
-	return &Message{discov: discov, conn: conn, Client: client}
+	return &Message{discov: discov, conn: conn, Client: client, ExtClient: msgext.NewMsgExtClient(conn)}
 }
 
 type MessageRpcClient Message
@@ -214,10 +235,34 @@ func WithRpcGetUserName() NotificationOptions {
 }
 
 func (s *NotificationSender) NotificationWithSesstionType(ctx context.Context, sendID, recvID string, contentType, sesstionType int32, m proto.Message, opts ...NotificationOptions) (err error) {
-	n := sdkws.NotificationElem{Detail: utils.StructToJsonString(m)}
+	return s.notification(ctx, sendID, recvID, contentType, sesstionType, utils.StructToJsonString(m), s.contentTypeConf[contentType], opts...)
+}
+
+// JsonNotification sends a notification whose tips are not defined in sdkws, data is marshaled to json.
+func (s *NotificationSender) JsonNotification(ctx context.Context, sendID, recvID string, contentType int32, data any, opts ...NotificationOptions) error {
+	return s.notification(ctx, sendID, recvID, contentType, s.sessionTypeConf[contentType], utils.StructToJsonString(data), s.contentTypeConf[contentType], opts...)
+}
+
+// JsonNotificationWithSesstionType is JsonNotification for the content types sent to both single and group chats.
+func (s *NotificationSender) JsonNotificationWithSesstionType(ctx context.Context, sendID, recvID string, contentType, sesstionType int32, data any, opts ...NotificationOptions) error {
+	return s.notification(ctx, sendID, recvID, contentType, sesstionType, utils.StructToJsonString(data), s.contentTypeConf[contentType], opts...)
+}
+
+// BusinessNotification sends a reliable constant.BusinessNotification, clients tell business notifications apart by key.
+func (s *NotificationSender) BusinessNotification(ctx context.Context, sendID, recvID string, sesstionType int32, key string, data any) error {
+	detail := utils.StructToJsonString(&struct {
+		Key  string `json:"key"`
+		Data string `json:"data"`
+	}{Key: key, Data: utils.StructToJsonString(data)})
+	conf := config.NotificationConf{ReliabilityLevel: constant.ReliableNotificationNoMsg}
+	return s.notification(ctx, sendID, recvID, constant.BusinessNotification, sesstionType, detail, conf)
+}
+
+func (s *NotificationSender) notification(ctx context.Context, sendID, recvID string, contentType, sesstionType int32, detail string, conf config.NotificationConf, opts ...NotificationOptions) (err error) {
+	n := sdkws.NotificationElem{Detail: detail}
 	content, err := json.Marshal(&n)
 	if err != nil {
-		log.ZError(ctx, "MsgClient Notification json.Marshal failed", err, "sendID", sendID, "recvID", recvID, "contentType", contentType, "msg", m)
+		log.ZError(ctx, "MsgClient Notification json.Marshal failed", err, "sendID", sendID, "recvID", recvID, "contentType", contentType, "detail", detail)
 		return err
 	}
 	notificationOpt := &notificationOpt{}
@@ -248,7 +293,7 @@ func (s *NotificationSender) NotificationWithSesstionType(ctx context.Context, s
 	}
 	msg.CreateTime = utils.GetCurrentTimestampByMill()
 	msg.ClientMsgID = utils.GetMsgID(sendID)
-	options := config.GetOptionsByNotification(s.contentTypeConf[contentType])
+	options := config.GetOptionsByNotification(conf)
 	msg.Options = options
 	offlineInfo.Title = title
 	offlineInfo.Desc = desc
@@ -17,6 +17,7 @@ package notification
 import (
 	"context"
 
+	"github.com/OpenIMSDK/tools/log"
 	"github.com/OpenIMSDK/tools/mcontext"
 
 	"github.com/OpenIMSDK/protocol/constant"
@@ -26,6 +27,7 @@ import (
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/convert"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	relationTb "github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/friendext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -220,3 +222,11 @@ func (c *FriendNotificationSender) FriendInfoUpdatedNotification(
 	tips := sdkws.UserInfoUpdatedTips{UserID: changedUserID}
 	c.Notification(ctx, mcontext.GetOpUserID(ctx), needNotifiedUserID, constant.FriendInfoUpdatedNotification, &tips)
 }
+
+// FriendLabelsUpdatedNotification 好友分组变更, 同步到自己的所有设备.
+func (c *FriendNotificationSender) FriendLabelsUpdatedNotification(ctx context.Context, ownerUserID string, labelIDs []string) {
+	tips := &friendext.FriendLabelsUpdatedTips{OwnerUserID: ownerUserID, LabelIDs: labelIDs}
+	if err := c.JsonNotification(ctx, ownerUserID, ownerUserID, rpcclient.FriendLabelsUpdatedNotification, tips); err != nil {
+		log.ZError(ctx, "FriendLabelsUpdatedNotification failed", err, "ownerUserID", ownerUserID)
+	}
+}
@@ -17,6 +17,7 @@ package notification
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/OpenIMSDK/protocol/constant"
 	pbGroup "github.com/OpenIMSDK/protocol/group"
@@ -26,8 +27,10 @@ import (
 	"github.com/OpenIMSDK/tools/mcontext"
 	"github.com/OpenIMSDK/tools/utils"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/controller"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/db/table/relation"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/groupext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -304,6 +307,54 @@ func (g *GroupNotificationSender) GroupInfoSetNameNotification(ctx context.Conte
 	return g.Notification(ctx, mcontext.GetOpUserID(ctx), tips.Group.GroupID, constant.GroupInfoSetNameNotification, tips)
 }
 
+func (g *GroupNotificationSender) GroupSlowModeSetNotification(ctx context.Context, groupID string, seconds int32) (err error) {
+	defer log.ZDebug(ctx, "return")
+	defer func() {
+		if err != nil {
+			log.ZError(ctx, utils.GetFuncName(1)+" failed", err)
+		}
+	}()
+	group, err := g.getGroupInfo(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	tips := &groupext.GroupSlowModeSetTips{Group: group, Seconds: seconds, OperationTime: time.Now().UnixMilli()}
+	if err := g.fillOpUser(ctx, &tips.OpUser, groupID); err != nil {
+		return err
+	}
+	return g.JsonNotification(ctx, mcontext.GetOpUserID(ctx), groupID, rpcclient.GroupSlowModeSetNotification, tips)
+}
+
+func (g *GroupNotificationSender) GroupMemberBannedNotification(
+	ctx context.Context,
+	groupID string,
+	bannedUserIDs []string,
+	reason string,
+	expireTime int64,
+) (err error) {
+	defer log.ZDebug(ctx, "return")
+	defer func() {
+		if err != nil {
+			log.ZError(ctx, utils.GetFuncName(1)+" failed", err)
+		}
+	}()
+	group, err := g.getGroupInfo(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	tips := &groupext.GroupMemberBannedTips{
+		Group:         group,
+		BannedUserIDs: bannedUserIDs,
+		Reason:        reason,
+		ExpireTime:    expireTime,
+		OperationTime: time.Now().UnixMilli(),
+	}
+	if err := g.fillOpUser(ctx, &tips.OpUser, groupID); err != nil {
+		return err
+	}
+	return g.JsonNotification(ctx, mcontext.GetOpUserID(ctx), groupID, rpcclient.GroupMemberBannedNotification, tips)
+}
+
 func (g *GroupNotificationSender) GroupInfoSetAnnouncementNotification(ctx context.Context, tips *sdkws.GroupInfoSetAnnouncementTips) (err error) {
 	defer log.ZDebug(ctx, "return")
 	defer func() {
@@ -441,6 +492,39 @@ func (g *GroupNotificationSender) GroupOwnerTransferredNotification(ctx context.
 	return g.Notification(ctx, mcontext.GetOpUserID(ctx), group.GroupID, constant.GroupOwnerTransferredNotification, tips)
 }
 
+// GroupOwnerSucceededNotification 群主注销或不活跃时由系统选择继任者, 操作者为系统管理员.
+func (g *GroupNotificationSender) GroupOwnerSucceededNotification(ctx context.Context, groupID string, newOwnerUserID string) (err error) {
+	defer log.ZDebug(ctx, "return")
+	defer func() {
+		if err != nil {
+			log.ZError(ctx, utils.GetFuncName(1)+" failed", err)
+		}
+	}()
+	if len(config.Config.Manager.UserID) == 0 {
+		return errs.ErrInternalServer.Wrap("manager userID is not configured")
+	}
+	group, err := g.getGroupInfo(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	newOwner, err := g.getGroupMember(ctx, groupID, newOwnerUserID)
+	if err != nil {
+		return err
+	}
+	systemUserID := config.Config.Manager.UserID[0]
+	opUser := &sdkws.GroupMemberFullInfo{
+		GroupID:        groupID,
+		UserID:         systemUserID,
+		AppMangerLevel: constant.AppAdmin,
+		OperatorUserID: systemUserID,
+	}
+	if len(config.Config.Manager.Nickname) > 0 {
+		opUser.Nickname = config.Config.Manager.Nickname[0]
+	}
+	tips := &sdkws.GroupOwnerTransferredTips{Group: group, OpUser: opUser, NewGroupOwner: newOwner}
+	return g.Notification(ctx, systemUserID, group.GroupID, constant.GroupOwnerTransferredNotification, tips)
+}
+
 func (g *GroupNotificationSender) MemberKickedNotification(ctx context.Context, tips *sdkws.MemberKickedTips) (err error) {
 	defer log.ZDebug(ctx, "return")
 	defer func() {
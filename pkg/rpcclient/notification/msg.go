@@ -20,6 +20,7 @@ import (
 	"github.com/OpenIMSDK/protocol/constant"
 	"github.com/OpenIMSDK/protocol/sdkws"
 
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/msgext"
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/rpcclient"
 )
 
@@ -40,6 +41,15 @@ func (m *MsgNotificationSender) UserDeleteMsgsNotification(ctx context.Context,
 	return m.Notification(ctx, userID, userID, constant.DeleteMsgsNotification, &tips)
 }
 
+// DestructMsgsNotification tells every device in the conversation the msgs have been deleted physically.
+func (m *MsgNotificationSender) DestructMsgsNotification(ctx context.Context, sendID, recvID string, sesstionType int32, conversationID string, seqs []int64) error {
+	tips := &msgext.MsgDestructTips{
+		ConversationID: conversationID,
+		Seqs:           seqs,
+	}
+	return m.JsonNotificationWithSesstionType(ctx, sendID, recvID, rpcclient.MsgDestructedNotification, sesstionType, tips)
+}
+
 func (m *MsgNotificationSender) MarkAsReadNotification(ctx context.Context, conversationID string, sesstionType int32, sendID, recvID string, seqs []int64, hasReadSeq int64) error {
 	tips := &sdkws.MarkAsReadTips{
 		MarkAsReadUserID: sendID,
@@ -27,11 +27,13 @@ import (
 	"github.com/OpenIMSDK/tools/discoveryregistry"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/thirdext"
 )
 
 type Third struct {
 	conn        grpc.ClientConnInterface
 	Client      third.ThirdClient
+	ExtClient   thirdext.ThirdExtClient
 	discov      discoveryregistry.SvcDiscoveryRegistry
 	MinioClient *minio.Client
 }
@@ -43,7 +45,7 @@ func NewThird(discov discoveryregistry.SvcDiscoveryRegistry) *Third {
 	}
 	client := third.NewThirdClient(conn)
 	minioClient, err := minioInit()
-	return &Third{discov: discov, Client: client, conn: conn, MinioClient: minioClient}
+	return &Third{discov: discov, Client: client, ExtClient: thirdext.NewThirdExtClient(conn), conn: conn, MinioClient: minioClient}
 }
 
 func minioInit() (*minio.Client, error) {
@@ -29,12 +29,15 @@ import (
 	"github.com/OpenIMSDK/tools/utils"
 
 	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/config"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/common/errcode"
+	"github.com/OpenIMSDK/Open-IM-Server/pkg/proto/userext"
 )
 
 type User struct {
-	conn   grpc.ClientConnInterface
-	Client user.UserClient
-	Discov discoveryregistry.SvcDiscoveryRegistry
+	conn      grpc.ClientConnInterface
+	Client    user.UserClient
+	ExtClient userext.UserExtClient
+	Discov    discoveryregistry.SvcDiscoveryRegistry
 }
 
 func NewUser(discov discoveryregistry.SvcDiscoveryRegistry) *User {
@@ -43,7 +46,7 @@ func NewUser(discov discoveryregistry.SvcDiscoveryRegistry) *User {
 		panic(err)
 	}
 	client := user.NewUserClient(conn)
-	return &User{Discov: discov, Client: client, conn: conn}
+	return &User{Discov: discov, Client: client, ExtClient: userext.NewUserExtClient(conn), conn: conn}
 }
 
 type UserRpcClient User
@@ -72,6 +75,30 @@ func (u *UserRpcClient) GetUsersInfo(ctx context.Context, userIDs []string) ([]*
 	return resp.UsersInfo, nil
 }
 
+// CheckUserActive 用户不存在或已停用时返回错误.
+func (u *UserRpcClient) CheckUserActive(ctx context.Context, userID string) error {
+	resp, err := u.ExtClient.GetUsersAccountStatus(ctx, &userext.GetUsersAccountStatusReq{UserIDs: []string{userID}})
+	if err != nil {
+		return err
+	}
+	if len(resp.Statuses) == 0 {
+		return errs.ErrUserIDNotFound.Wrap(userID)
+	}
+	if resp.Statuses[0].Status == userext.AccountStatusDeactivated {
+		return errcode.ErrUserDeactivated.Wrap(userID)
+	}
+	return nil
+}
+
+// GetDoNotDisturbUserIDs 获取userIDs中当前处于免打扰时段的用户.
+func (u *UserRpcClient) GetDoNotDisturbUserIDs(ctx context.Context, userIDs []string) ([]string, error) {
+	resp, err := u.ExtClient.GetDoNotDisturbUserIDs(ctx, &userext.GetDoNotDisturbUserIDsReq{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	return resp.UserIDs, nil
+}
+
 func (u *UserRpcClient) GetUserInfo(ctx context.Context, userID string) (*sdkws.UserInfo, error) {
 	users, err := u.GetUsersInfo(ctx, []string{userID})
 	if err != nil {